@@ -11,6 +11,7 @@ import (
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/server"
+	"github.com/mattermost/focalboard/server/services/i18n"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions/mmpermissions"
 	"github.com/mattermost/focalboard/server/services/store"
@@ -84,7 +85,8 @@ func NewBoardsApp(api model.ServicesAPI) (*BoardsApp, error) {
 		NewMutexFn: func(name string) (*cluster.Mutex, error) {
 			return cluster.NewMutex(&mutexAPIAdapter{api: api}, name)
 		},
-		ServicesAPI: api,
+		ServicesAPI:       api,
+		MaxBlockTreeDepth: cfg.MaxBlockTreeDepth,
 	}
 
 	var db store.Store
@@ -93,7 +95,7 @@ func NewBoardsApp(api model.ServicesAPI) (*BoardsApp, error) {
 		return nil, fmt.Errorf("error initializing the DB: %w", err)
 	}
 	if cfg.AuthMode == server.MattermostAuthMod {
-		layeredStore, err2 := mattermostauthlayer.New(cfg.DBType, sqlDB, db, logger, api, storeParams.TablePrefix)
+		layeredStore, err2 := mattermostauthlayer.New(cfg.DBType, sqlDB, db, logger, api, storeParams.TablePrefix, cfg.EnableChannelBoardAccess)
 		if err2 != nil {
 			return nil, fmt.Errorf("error initializing the DB: %w", err2)
 		}
@@ -104,10 +106,16 @@ func NewBoardsApp(api model.ServicesAPI) (*BoardsApp, error) {
 
 	wsPluginAdapter := ws.NewPluginAdapter(api, auth.New(cfg, db, permissionsService), db, logger)
 
+	bundle, err := i18n.NewBundle(cfg.DefaultLocale)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the translations: %w", err)
+	}
+
 	backendParams := notifyBackendParams{
 		cfg:         cfg,
 		servicesAPI: api,
 		appAPI:      &appAPI{store: db},
+		bundle:      bundle,
 		permissions: permissionsService,
 		serverRoot:  baseURL + "/boards",
 		logger:      logger,
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/i18n"
 
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
 )
@@ -70,7 +71,14 @@ func createBoardsConfig(mmconfig mm_model.Config, baseURL string, serverID strin
 
 	featureFlags := parseFeatureFlags(mmconfig.FeatureFlags.ToMap())
 
-	return &config.Configuration{
+	// the notifications use the default locale of the server if the
+	// boards have translations for it
+	defaultLocale := i18n.DefaultLocale
+	if mmconfig.LocalizationSettings.DefaultServerLocale != nil && i18n.IsSupportedLocale(*mmconfig.LocalizationSettings.DefaultServerLocale) {
+		defaultLocale = *mmconfig.LocalizationSettings.DefaultServerLocale
+	}
+
+	cfg := &config.Configuration{
 		ServerRoot:               baseURL + "/plugins/focalboard",
 		Port:                     -1,
 		DBType:                   *mmconfig.SqlSettings.DriverName,
@@ -99,7 +107,15 @@ func createBoardsConfig(mmconfig mm_model.Config, baseURL string, serverID strin
 		EnableDataRetention:      enableBoardsDeletion,
 		DataRetentionDays:        *mmconfig.DataRetentionSettings.BoardsRetentionDays,
 		TeammateNameDisplay:      *mmconfig.TeamSettings.TeammateNameDisplay,
+		EnableChannelBoardAccess: true,
+		RunMigrations:            true, // the plugin migrations are serialized with a cluster mutex
+		DefaultLocale:            defaultLocale,
 	}
+
+	// the plugin follows the files settings of the server, so an
+	// unreachable storage is checked periodically instead of failing
+	cfg.FilesBackendCheckInterval = 60
+	return cfg
 }
 
 func getPluginSetting(mmConfig mm_model.Config, key string) (interface{}, bool) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/i18n"
 	"github.com/mattermost/focalboard/server/services/notify/notifymentions"
 	"github.com/mattermost/focalboard/server/services/notify/notifysubscriptions"
 	"github.com/mattermost/focalboard/server/services/notify/plugindelivery"
@@ -20,12 +21,13 @@ type notifyBackendParams struct {
 	servicesAPI model.ServicesAPI
 	permissions permissions.PermissionsService
 	appAPI      *appAPI
+	bundle      *i18n.Bundle
 	serverRoot  string
 	logger      mlog.LoggerIFace
 }
 
 func createMentionsNotifyBackend(params notifyBackendParams) (*notifymentions.Backend, error) {
-	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot, params.bundle)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +45,7 @@ func createMentionsNotifyBackend(params notifyBackendParams) (*notifymentions.Ba
 }
 
 func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscriptions.Backend, error) {
-	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot, params.bundle)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +55,7 @@ func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscr
 		AppAPI:                 params.appAPI,
 		Permissions:            params.permissions,
 		Delivery:               delivery,
+		Bundle:                 params.bundle,
 		Logger:                 params.logger,
 		NotifyFreqCardSeconds:  params.cfg.NotifyFreqCardSeconds,
 		NotifyFreqBoardSeconds: params.cfg.NotifyFreqBoardSeconds,
@@ -62,7 +65,7 @@ func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscr
 	return backend, nil
 }
 
-func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*plugindelivery.PluginDelivery, error) {
+func createDelivery(servicesAPI model.ServicesAPI, serverRoot string, bundle *i18n.Bundle) (*plugindelivery.PluginDelivery, error) {
 	bot := model.FocalboardBot
 
 	botID, err := servicesAPI.EnsureBot(bot)
@@ -70,7 +73,7 @@ func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*pluginde
 		return nil, fmt.Errorf("failed to ensure %s bot: %w", bot.DisplayName, err)
 	}
 
-	return plugindelivery.New(botID, serverRoot, servicesAPI), nil
+	return plugindelivery.New(botID, serverRoot, servicesAPI, bundle), nil
 }
 
 type appIface interface {
@@ -1,14 +1,20 @@
 package api
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -49,8 +55,808 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.recordAdminAction(r, model.AdminActionSetPassword, username)
+
 	a.logger.Debug("AdminSetPassword, username: %s", mlog.String("username", username))
 
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
+
+// AdminImpersonateResponse is the session created to impersonate a user.
+type AdminImpersonateResponse struct {
+	Token     string `json:"token"`
+	UserID    string `json:"userId"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+func (a *API) handleAdminImpersonateUser(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminImpersonateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+
+	session, err := a.app.ImpersonateUser(adminActorLocal, username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.recordAdminAction(r, model.AdminActionImpersonate, username)
+
+	a.logger.Info("AdminImpersonateUser",
+		mlog.String("username", username),
+		mlog.String("userID", session.UserID),
+		mlog.String("sessionID", session.ID),
+	)
+
+	data, err := json.Marshal(AdminImpersonateResponse{
+		Token:     session.Token,
+		UserID:    session.UserID,
+		ExpiresAt: session.ExpiresAt(),
+	})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("userID", session.UserID)
+	auditRec.AddMeta("sessionID", session.ID)
+	auditRec.Success()
+}
+
+type AdminSetReadOnlyModeData struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (a *API) handleAdminSetReadOnlyMode(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData AdminSetReadOnlyModeData
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminSetReadOnlyMode", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("enabled", requestData.Enabled)
+
+	a.app.SetReadOnlyMode(requestData.Enabled)
+	a.recordAdminAction(r, model.AdminActionSetReadOnlyMode, strconv.FormatBool(requestData.Enabled))
+
+	a.logger.Debug("AdminSetReadOnlyMode", mlog.Bool("enabled", requestData.Enabled))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetActiveUsersStats(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminGetActiveUsersStats", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	stats, err := a.app.GetActiveUsersStats()
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetActiveUsersStats", mlog.Int64("updateAt", stats.UpdateAt))
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["teamID"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetFeatureFlags", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	flags, err := a.app.GetFeatureFlags(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetFeatureFlags", mlog.String("teamID", teamID))
+
+	data, err := json.Marshal(flags)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+type AdminSetFeatureFlagData struct {
+	Value string `json:"value"`
+}
+
+func (a *API) handleAdminSetFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	name := vars["name"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData AdminSetFeatureFlagData
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminSetFeatureFlag", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("name", name)
+	auditRec.AddMeta("value", requestData.Value)
+
+	if err = a.app.SetFeatureFlag(teamID, name, requestData.Value); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionSetFeatureFlag, teamID+"/"+name+"="+requestData.Value)
+
+	a.logger.Debug("AdminSetFeatureFlag",
+		mlog.String("teamID", teamID),
+		mlog.String("name", name),
+		mlog.String("value", requestData.Value),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminDeleteFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	name := vars["name"]
+
+	auditRec := a.makeAuditRecord(r, "adminDeleteFeatureFlag", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("name", name)
+
+	if err := a.app.DeleteFeatureFlag(teamID, name); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionDeleteFeatureFlag, teamID+"/"+name)
+
+	a.logger.Debug("AdminDeleteFeatureFlag",
+		mlog.String("teamID", teamID),
+		mlog.String("name", name),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// handleAdminGetInvites lists the invites of a team, including the ones
+// that expired or were used up.
+func (a *API) handleAdminGetInvites(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["teamID"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetInvites", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	invites, err := a.app.GetInvitesForTeam(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetInvites",
+		mlog.String("teamID", teamID),
+		mlog.Int("invite_count", len(invites)),
+	)
+
+	data, err := json.Marshal(invites)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminCreateInvite(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["teamID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData model.CreateInviteRequest
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if requestData.TTLSeconds < 0 || requestData.MaxUses < 0 {
+		a.errorResponse(w, r, model.NewErrBadRequest("ttlSeconds and maxUses cannot be negative"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminCreateInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("role", requestData.Role)
+
+	ttl := time.Duration(requestData.TTLSeconds) * time.Second
+	invite, err := a.app.CreateInvite(teamID, requestData.Role, ttl, requestData.MaxUses)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionCreateInvite, teamID+"/"+string(invite.Role))
+
+	a.logger.Debug("AdminCreateInvite",
+		mlog.String("teamID", teamID),
+		mlog.String("role", string(invite.Role)),
+		mlog.Int("maxUses", invite.MaxUses),
+	)
+
+	data, err := json.Marshal(invite)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminRevokeInvite(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	auditRec := a.makeAuditRecord(r, "adminRevokeInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	if err := a.app.RevokeInvite(token); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionRevokeInvite, "invite")
+
+	a.logger.Debug("AdminRevokeInvite")
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetTeamWebhooks(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["teamID"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetTeamWebhooks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	webhooks, err := a.app.GetTeamWebhooks(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetTeamWebhooks",
+		mlog.String("teamID", teamID),
+		mlog.Int("webhook_count", len(webhooks)),
+	)
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminCreateTeamWebhook(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["teamID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData model.TeamWebhookRequest
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminCreateTeamWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("url", requestData.URL)
+
+	webhook, err := a.app.CreateTeamWebhook(teamID, requestData.URL, requestData.EventTypes)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionCreateTeamWebhook, teamID+"/"+webhook.ID)
+
+	a.logger.Debug("AdminCreateTeamWebhook",
+		mlog.String("teamID", teamID),
+		mlog.String("webhookID", webhook.ID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminUpdateTeamWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID := mux.Vars(r)["webhookID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData model.TeamWebhookRequest
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminUpdateTeamWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("webhookID", webhookID)
+	auditRec.AddMeta("url", requestData.URL)
+
+	webhook, err := a.app.UpdateTeamWebhook(webhookID, requestData.URL, requestData.EventTypes)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionUpdateTeamWebhook, webhook.TeamID+"/"+webhook.ID)
+
+	a.logger.Debug("AdminUpdateTeamWebhook",
+		mlog.String("teamID", webhook.TeamID),
+		mlog.String("webhookID", webhook.ID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminDeleteTeamWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID := mux.Vars(r)["webhookID"]
+
+	auditRec := a.makeAuditRecord(r, "adminDeleteTeamWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err := a.app.DeleteTeamWebhook(webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionDeleteTeamWebhook, webhookID)
+
+	a.logger.Debug("AdminDeleteTeamWebhook", mlog.String("webhookID", webhookID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// handleAdminSendNotice broadcasts a notice to the connected clients of a
+// team, or to all of them if the notice has no team.
+func (a *API) handleAdminSendNotice(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var notice model.AdminNotice
+	err = json.Unmarshal(requestBody, &notice)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminSendNotice", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", notice.TeamID)
+	auditRec.AddMeta("severity", notice.Severity)
+
+	if err = a.app.SendAdminNotice(&notice); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.recordAdminAction(r, model.AdminActionSendNotice, notice.TeamID)
+
+	a.logger.Debug("AdminSendNotice",
+		mlog.String("teamID", notice.TeamID),
+		mlog.String("severity", notice.Severity),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// handleAdminCheckBlockIntegrity reports the integrity problems of the
+// blocks of a board, or of all the boards of a team. The POST requests
+// also repair them, with the orphans parameter choosing whether the
+// orphaned blocks are reparented, the default, or deleted.
+func (a *API) handleAdminCheckBlockIntegrity(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	teamID := vars["teamID"]
+
+	repair := ""
+	if r.Method == http.MethodPost {
+		repair = r.URL.Query().Get("orphans")
+		if repair == "" {
+			repair = model.OrphanRepairReparent
+		}
+		if !model.IsValidOrphanRepair(repair) {
+			a.errorResponse(w, r, model.NewErrInvalidField("orphans", "must be reparent or delete"))
+			return
+		}
+	}
+
+	auditLevel := audit.LevelRead
+	if repair != "" {
+		auditLevel = audit.LevelModify
+	}
+	auditRec := a.makeAuditRecord(r, "adminCheckBlockIntegrity", audit.Fail)
+	defer a.audit.LogRecord(auditLevel, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("repair", repair)
+
+	var report *model.BlockIntegrityReport
+	var err error
+	if boardID != "" {
+		report, err = a.app.CheckBoardBlockIntegrity(boardID, repair)
+	} else {
+		report, err = a.app.CheckTeamBlockIntegrity(teamID, repair)
+	}
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if repair != "" {
+		target := boardID
+		if target == "" {
+			target = teamID
+		}
+		a.recordAdminAction(r, model.AdminActionRepairBlocks, target)
+	}
+
+	a.logger.Debug("AdminCheckBlockIntegrity",
+		mlog.String("boardID", boardID),
+		mlog.String("teamID", teamID),
+		mlog.String("repair", repair),
+		mlog.Int("board_count", report.BoardCount),
+		mlog.Int("issue_count", len(report.Issues)),
+	)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+// adminActorLocal identifies the actions done through the local socket,
+// where the requests don't carry a user session.
+const adminActorLocal = "local"
+
+// recordAdminAction adds the action to the admin audit log. It is
+// called once the action succeeded, so a failure to record it is
+// logged instead of failing the request.
+func (a *API) recordAdminAction(r *http.Request, action, target string) {
+	actor := getUserID(r)
+	if actor == "" {
+		actor = adminActorLocal
+	}
+
+	if err := a.app.RecordAdminAction(actor, action, target); err != nil {
+		a.logger.Error("Cannot record admin action",
+			mlog.String("actor", actor),
+			mlog.String("action", action),
+			mlog.String("target", target),
+			mlog.Err(err),
+		)
+	}
+}
+
+const (
+	defaultAdminAuditLimit = 100
+	maxAdminAuditLimit     = 1000
+)
+
+// adminAuditQueryOptions reads the actor, action and time range filters
+// of the audit log endpoints.
+func adminAuditQueryOptions(r *http.Request) (model.QueryAdminAuditOptions, error) {
+	query := r.URL.Query()
+
+	opts := model.QueryAdminAuditOptions{
+		Actor:  query.Get("actor"),
+		Action: query.Get("action"),
+	}
+
+	var err error
+	if since := query.Get("since"); since != "" {
+		if opts.AfterCreateAt, err = strconv.ParseInt(since, 10, 64); err != nil {
+			return opts, model.NewErrInvalidField("since", "must be a timestamp in milliseconds")
+		}
+	}
+
+	if until := query.Get("until"); until != "" {
+		if opts.BeforeCreateAt, err = strconv.ParseInt(until, 10, 64); err != nil {
+			return opts, model.NewErrInvalidField("until", "must be a timestamp in milliseconds")
+		}
+	}
+
+	return opts, nil
+}
+
+func (a *API) handleAdminGetAuditEntries(w http.ResponseWriter, r *http.Request) {
+	opts, err := adminAuditQueryOptions(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	opts.Limit = defaultAdminAuditLimit
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if opts.Limit, err = strconv.ParseUint(limit, 10, 64); err != nil || opts.Limit == 0 || opts.Limit > maxAdminAuditLimit {
+			a.errorResponse(w, r, model.NewErrInvalidField("limit", fmt.Sprintf("must be between 1 and %d", maxAdminAuditLimit)))
+			return
+		}
+	}
+
+	entries, err := a.app.GetAdminAuditEntries(opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetAuditEntries", mlog.Int("entry_count", len(entries)))
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+const (
+	adminAuditExportNDJSON   = "ndjson"
+	adminAuditExportCSV      = "csv"
+	adminAuditExportPageSize = 500
+)
+
+// adminAuditEntryWriter writes audit entries in one of the export formats.
+type adminAuditEntryWriter interface {
+	Write(entry *model.AdminAuditEntry) error
+	Flush() error
+}
+
+type ndjsonAuditEntryWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonAuditEntryWriter) Write(entry *model.AdminAuditEntry) error {
+	// Encode terminates every entry with a newline
+	return w.encoder.Encode(entry)
+}
+
+func (w *ndjsonAuditEntryWriter) Flush() error {
+	return nil
+}
+
+type csvAuditEntryWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvAuditEntryWriter) Write(entry *model.AdminAuditEntry) error {
+	return w.writer.Write([]string{
+		entry.ID,
+		entry.Actor,
+		entry.Action,
+		entry.Target,
+		strconv.FormatInt(entry.CreateAt, 10),
+	})
+}
+
+func (w *csvAuditEntryWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (a *API) handleAdminExportAuditEntries(w http.ResponseWriter, r *http.Request) {
+	opts, err := adminAuditQueryOptions(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = adminAuditExportNDJSON
+	}
+
+	var contentType string
+	switch format {
+	case adminAuditExportNDJSON:
+		contentType = "application/x-ndjson"
+	case adminAuditExportCSV:
+		contentType = "text/csv"
+	default:
+		a.errorResponse(w, r, model.NewErrInvalidField("format", "must be ndjson or csv"))
+		return
+	}
+
+	// entries inserted while the export runs are left out, so the pages
+	// are read from a fixed set and no entry is skipped or repeated
+	if opts.BeforeCreateAt == 0 {
+		opts.BeforeCreateAt = utils.GetMillis() + 1
+	}
+	opts.Ascending = true
+	opts.Limit = adminAuditExportPageSize
+
+	// the entries are streamed one page at a time so the whole log
+	// doesn't need to be held in memory. Once the first byte is written
+	// the status can't change anymore, so errors past that point close
+	// the response early and are logged.
+	setResponseHeader(w, "Content-Type", contentType)
+	setResponseHeader(w, "Content-Disposition", fmt.Sprintf("attachment; filename=\"audit.%s\"", format))
+	w.WriteHeader(http.StatusOK)
+
+	var writer adminAuditEntryWriter
+	if format == adminAuditExportCSV {
+		csvWriter := csv.NewWriter(w)
+		_ = csvWriter.Write([]string{"id", "actor", "action", "target", "createAt"})
+		writer = &csvAuditEntryWriter{writer: csvWriter}
+	} else {
+		writer = &ndjsonAuditEntryWriter{encoder: json.NewEncoder(w)}
+	}
+
+	entryCount := 0
+	for {
+		var entries []*model.AdminAuditEntry
+		entries, err = a.app.GetAdminAuditEntries(opts)
+		if err != nil {
+			a.logger.Error("AdminExportAuditEntries ERROR fetching entries", mlog.Err(err))
+			return
+		}
+
+		for _, entry := range entries {
+			if err = writer.Write(entry); err != nil {
+				a.logger.Error("AdminExportAuditEntries ERROR writing entry", mlog.String("entryID", entry.ID), mlog.Err(err))
+				return
+			}
+		}
+
+		if err = writer.Flush(); err != nil {
+			a.logger.Error("AdminExportAuditEntries ERROR flushing entries", mlog.Err(err))
+			return
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		entryCount += len(entries)
+		if len(entries) < adminAuditExportPageSize {
+			break
+		}
+		opts.Offset += adminAuditExportPageSize
+	}
+
+	a.logger.Debug("AdminExportAuditEntries",
+		mlog.String("format", format),
+		mlog.Int("entry_count", entryCount),
+	)
+}
+
+// AdminRoute describes an endpoint available through the local socket.
+type AdminRoute struct {
+	Path    string   `json:"path"`
+	Methods []string `json:"methods"`
+}
+
+// adminRoutes lists the routes registered in the admin router, so the
+// tools that use the local socket can discover them instead of
+// hardcoding their paths.
+func adminRoutes(router *mux.Router) ([]AdminRoute, error) {
+	routes := []AdminRoute{}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			// routes without a path, like subrouters, are skipped
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{}
+		}
+
+		routes = append(routes, AdminRoute{Path: path, Methods: methods})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Path < routes[j].Path
+	})
+	return routes, nil
+}
+
+func (a *API) handleAdminGetRoutes(router *mux.Router) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routes, err := adminRoutes(router)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+
+		a.logger.Debug("AdminGetRoutes", mlog.Int("route_count", len(routes)))
+
+		data, err := json.Marshal(routes)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+
+		jsonBytesResponse(w, http.StatusOK, data)
+	}
+}
@@ -1,17 +1,20 @@
 package api
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -19,17 +22,63 @@ import (
 const (
 	HeaderRequestedWith    = "X-Requested-With"
 	HeaderRequestedWithXML = "XMLHttpRequest"
+	HeaderRequestID        = "X-Request-ID"
 	UploadFormFileKey      = "file"
 	True                   = "true"
 
 	ErrorNoTeamCode    = 1000
 	ErrorNoTeamMessage = "No team"
+
+	maxRequestIDLength = 64
 )
 
 var (
 	ErrHandlerPanic = errors.New("http handler panic")
 )
 
+// readOnlyAllowedRoutes contains the non GET routes that don't modify
+// any data, and thus are still allowed while in read-only mode.
+var readOnlyAllowedRoutes = map[string]bool{
+	"/api/v2/login":        true,
+	"/api/v2/logout":       true,
+	"/api/v2/users":        true,
+	"/api/v2/blocks/batch": true,
+	"/api/v2/teams/{teamID}/archive/import/validate": true,
+}
+
+// longLivedRoutes contains the routes that stream their response or
+// receive large bodies, and thus aren't bounded by the request timeout.
+// The websocket isn't served by the API router, so it's never bounded.
+var longLivedRoutes = map[string]bool{
+	"/api/v2/blocks/batch":                              true,
+	"/api/v2/boards/{boardID}/archive/export":           true,
+	"/api/v2/teams/{teamID}/archive/export":             true,
+	"/api/v2/teams/{teamID}/archive/import":             true,
+	"/api/v2/teams/{teamID}/archive/import/validate":    true,
+	"/api/v2/teams/{teamID}/{boardID}/files":            true,
+	"/api/v2/files/teams/{teamID}/{boardID}/{filename}": true,
+}
+
+// boardAPIKeyRoutes contains the routes that can be used with a board
+// API key, and their methods. The keys only give access to the contents
+// of their board, so the routes that manage the board itself, its
+// members or its keys are left out.
+var boardAPIKeyRoutes = map[string][]string{
+	"/api/v2/boards/{boardID}":                            {http.MethodGet},
+	"/api/v2/boards/{boardID}/blocks":                     {http.MethodGet, http.MethodPost, http.MethodPatch},
+	"/api/v2/boards/{boardID}/blocks/sync":                {http.MethodPost},
+	"/api/v2/boards/{boardID}/blocks/{blockID}":           {http.MethodPatch, http.MethodDelete},
+	"/api/v2/boards/{boardID}/blocks/{blockID}/undelete":  {http.MethodPost},
+	"/api/v2/boards/{boardID}/blocks/{blockID}/duplicate": {http.MethodPost},
+	"/api/v2/boards/{boardID}/manifest":                   {http.MethodGet},
+	"/api/v2/boards/{boardID}/trash":                      {http.MethodGet},
+	"/api/v2/boards/{boardID}/cards":                      {http.MethodGet, http.MethodPost},
+	"/api/v2/boards/{boardID}/cards/assign":               {http.MethodPost},
+	"/api/v2/boards/{boardID}/cards/from-template":        {http.MethodPost},
+	"/api/v2/boards/{boardID}/cards/update-property":      {http.MethodPost},
+	"/api/v2/boards/{boardID}/cards/{cardID}/move":        {http.MethodPost},
+}
+
 // ----------------------------------------------------------------------------------------------------
 // REST APIs
 
@@ -66,8 +115,12 @@ func NewAPI(
 
 func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2 := r.PathPrefix("/api/v2").Subrouter()
+	apiv2.Use(a.requestIDHandler)
 	apiv2.Use(a.panicHandler)
+	apiv2.Use(a.requestTimeoutHandler)
+	apiv2.Use(a.requestContextHandler)
 	apiv2.Use(a.requireCSRFToken)
+	apiv2.Use(a.requireWritable)
 
 	/* ToDo:
 	apiv3 := r.PathPrefix("/api/v3").Subrouter()
@@ -98,6 +151,19 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 	// V3 routes
 	a.registerCardsRoutes(apiv2)
+	a.registerLabelsRoutes(apiv2)
+	a.registerRollupRoutes(apiv2)
+	a.registerBoardAPIKeysRoutes(apiv2)
+	a.registerUserBoardViewsRoutes(apiv2)
+	a.registerPropertyOptionsRoutes(apiv2)
+	a.registerPropertyVisibilityRoutes(apiv2)
+	a.registerUserBoardsRoutes(apiv2)
+	a.registerBoardFavoritesRoutes(apiv2)
+	a.registerBoardStatsRoutes(apiv2)
+	a.registerRecentBoardsRoutes(apiv2)
+	a.registerBoardUnreadRoutes(apiv2)
+	a.registerBoardSlugsRoutes(apiv2)
+	a.registerSubtasksRoutes(apiv2)
 
 	// System routes are outside the /api/v2 path
 	a.registerSystemRoutes(r)
@@ -105,6 +171,25 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 func (a *API) RegisterAdminRoutes(r *mux.Router) {
 	r.HandleFunc("/api/v2/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/users/{username}/impersonate", a.adminRequired(a.handleAdminImpersonateUser)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/readonly", a.adminRequired(a.handleAdminSetReadOnlyMode)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/stats/active-users", a.adminRequired(a.handleAdminGetActiveUsersStats)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/featureflags", a.adminRequired(a.handleAdminGetFeatureFlags)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/featureflags/{name}", a.adminRequired(a.handleAdminSetFeatureFlag)).Methods("PUT")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/featureflags/{name}", a.adminRequired(a.handleAdminDeleteFeatureFlag)).Methods("DELETE")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/invites", a.adminRequired(a.handleAdminGetInvites)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/invites", a.adminRequired(a.handleAdminCreateInvite)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/invites/{token}", a.adminRequired(a.handleAdminRevokeInvite)).Methods("DELETE")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/webhooks", a.adminRequired(a.handleAdminGetTeamWebhooks)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/webhooks", a.adminRequired(a.handleAdminCreateTeamWebhook)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/webhooks/{webhookID}", a.adminRequired(a.handleAdminUpdateTeamWebhook)).Methods("PUT")
+	r.HandleFunc("/api/v2/admin/webhooks/{webhookID}", a.adminRequired(a.handleAdminDeleteTeamWebhook)).Methods("DELETE")
+	r.HandleFunc("/api/v2/admin/boards/{boardID}/integrity", a.adminRequired(a.handleAdminCheckBlockIntegrity)).Methods("GET", "POST")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/integrity", a.adminRequired(a.handleAdminCheckBlockIntegrity)).Methods("GET", "POST")
+	r.HandleFunc("/api/v2/admin/notices", a.adminRequired(a.handleAdminSendNotice)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/audit", a.adminRequired(a.handleAdminGetAuditEntries)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/audit/export", a.adminRequired(a.handleAdminExportAuditEntries)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/routes", a.adminRequired(a.handleAdminGetRoutes(r))).Methods("GET")
 }
 
 func getUserID(r *http.Request) string {
@@ -133,6 +218,158 @@ func (a *API) panicHandler(next http.Handler) http.Handler {
 	})
 }
 
+// requestTimeoutHandler responds with a 503 when a handler takes longer
+// than the configured request timeout, so a stuck dependency can't hold
+// a connection indefinitely. The long-lived routes are bounded by
+// neither the request timeout nor the web server timeouts.
+func (a *API) requestTimeoutHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isLongLivedRoute(r) {
+			clearConnDeadlines(r)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		timeout := time.Duration(a.app.GetConfig().RequestTimeout) * time.Second
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		a.serveWithTimeout(next, timeout, w, r)
+	})
+}
+
+// serveWithTimeout serves the request with http.TimeoutHandler. The
+// handler keeps running until it returns, but its response is discarded
+// once the timeout is reached.
+func (a *API) serveWithTimeout(next http.Handler, timeout time.Duration, w http.ResponseWriter, r *http.Request) {
+	data, err := json.Marshal(model.ErrorResponse{
+		Error:     "the request timed out",
+		ErrorCode: http.StatusServiceUnavailable,
+		ErrorID:   model.ErrorIDServiceUnavailable,
+		RequestID: getRequestID(r),
+	})
+	if err != nil {
+		data = []byte("{}")
+	}
+
+	tw := &timeoutResponseWriter{ResponseWriter: w}
+	http.TimeoutHandler(next, timeout, string(data)).ServeHTTP(tw, r)
+	if tw.timedOut {
+		a.logger.Warn("Request timed out",
+			mlog.String("api", r.URL.Path),
+			mlog.String("method", r.Method),
+			mlog.String("request_id", getRequestID(r)),
+			mlog.Duration("timeout", timeout),
+		)
+	}
+}
+
+// clearConnDeadlines removes the read and write deadlines that the web
+// server set on the connection of the request, so a long export or
+// upload isn't cut off. The server sets them again for the next request
+// on the connection. It does nothing when the connection isn't stored
+// in the request context, as in plugin mode.
+func clearConnDeadlines(r *http.Request) {
+	conn := GetContextConn(r)
+	if conn == nil {
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+	_ = conn.SetWriteDeadline(time.Time{})
+}
+
+func isLongLivedRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	tpl, err := route.GetPathTemplate()
+	return err == nil && longLivedRoutes[tpl]
+}
+
+// isBoardAPIKeyRoute checks whether a request can be authenticated with a
+// board API key.
+func isBoardAPIKeyRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	tpl, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+	for _, method := range boardAPIKeyRoutes[tpl] {
+		if method == r.Method {
+			return true
+		}
+	}
+	return false
+}
+
+// timeoutResponseWriter sets the content type of the timeout responses.
+// http.TimeoutHandler only copies the headers of the handler when it
+// finishes in time, so a 503 without content type is a timeout.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+	timedOut bool
+}
+
+func (w *timeoutResponseWriter) WriteHeader(code int) {
+	if code == http.StatusServiceUnavailable && w.Header().Get("Content-Type") == "" {
+		w.timedOut = true
+		setResponseHeader(w, "Content-Type", "application/json")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// requestContextHandler registers the request context for the goroutine
+// running the handler, so the slow query log can tell which request a
+// query ran for. It runs after requestTimeoutHandler, which serves the
+// request from a new goroutine.
+func (a *API) requestContextHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.app.GetConfig().SlowQueryThreshold <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		utils.RunWithGoroutineContext(r.Context(), func() {
+			next.ServeHTTP(w, r)
+		})
+	})
+}
+
+// requestIDHandler assigns an ID to every request so the errors
+// returned to the clients can be matched with the server logs. A
+// well-formed ID sent by a proxy in the X-Request-ID header is kept.
+func (a *API) requestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(HeaderRequestID)
+		if !isValidRequestID(requestID) {
+			requestID = utils.NewID(utils.IDTypeNone)
+		}
+
+		setResponseHeader(w, HeaderRequestID, requestID)
+		ctx := utils.ContextWithRequestID(r.Context(), requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
 func (a *API) requireCSRFToken(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !a.checkCSRFToken(r) {
@@ -145,6 +382,32 @@ func (a *API) requireCSRFToken(next http.Handler) http.Handler {
 	})
 }
 
+// requireWritable rejects the requests that modify data while the
+// server is in read-only maintenance mode.
+func (a *API) requireWritable(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.app.IsReadOnlyMode() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if route := mux.CurrentRoute(r); route != nil {
+			if tpl, err := route.GetPathTemplate(); err == nil && readOnlyAllowedRoutes[tpl] {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		a.errorResponse(w, r, model.NewErrServiceUnavailable("server is in maintenance mode, changes are temporarily disabled"))
+	})
+}
+
 func (a *API) checkCSRFToken(r *http.Request) bool {
 	token := r.Header.Get(HeaderRequestedWith)
 	return token == HeaderRequestedWithXML
@@ -167,6 +430,13 @@ func (a *API) hasValidReadTokenForBoard(r *http.Request, boardID string) bool {
 	return isValid
 }
 
+// isSharedView returns true if the request can only access the board
+// through its shared link, in which case the card properties that are
+// not visible through it have to be filtered out.
+func (a *API) isSharedView(userID, boardID string, hasValidReadToken bool) bool {
+	return hasValidReadToken && !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard)
+}
+
 func (a *API) userIsGuest(userID string) (bool, error) {
 	if a.singleUserToken != "" {
 		return false, nil
@@ -177,29 +447,67 @@ func (a *API) userIsGuest(userID string) (bool, error) {
 // Response helpers
 
 func (a *API) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	errorResponse := model.ErrorResponse{Error: err.Error()}
+	errorResponse := model.ErrorResponse{
+		Error:     err.Error(),
+		RequestID: getRequestID(r),
+	}
+
+	var invalidField *model.ErrInvalidField
+	var blockConflict *model.ErrBlockConflict
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
 
 	switch {
+	case errors.As(err, &invalidField):
+		errorResponse.ErrorCode = http.StatusBadRequest
+		errorResponse.ErrorID = model.ErrorIDInvalidField
+		errorResponse.Details = map[string]string{invalidField.Field: invalidField.Reason()}
+	case errors.As(err, &blockConflict):
+		errorResponse.ErrorCode = http.StatusConflict
+		errorResponse.ErrorID = model.ErrorIDConflict
+		errorResponse.ServerBlock = blockConflict.Block
+	case errors.As(err, &typeErr):
+		errorResponse.ErrorCode = http.StatusBadRequest
+		errorResponse.ErrorID = model.ErrorIDInvalidField
+		errorResponse.Details = map[string]string{typeErr.Field: "must be of type " + typeErr.Type.String()}
+	case errors.As(err, &syntaxErr):
+		errorResponse.ErrorCode = http.StatusBadRequest
+		errorResponse.ErrorID = model.ErrorIDBadRequest
+		errorResponse.Error = "invalid JSON body: " + err.Error()
 	case model.IsErrBadRequest(err):
 		errorResponse.ErrorCode = http.StatusBadRequest
+		errorResponse.ErrorID = model.ErrorIDBadRequest
 	case model.IsErrUnauthorized(err):
 		errorResponse.ErrorCode = http.StatusUnauthorized
+		errorResponse.ErrorID = model.ErrorIDUnauthorized
 	case model.IsErrForbidden(err):
 		errorResponse.ErrorCode = http.StatusForbidden
+		errorResponse.ErrorID = model.ErrorIDForbidden
 	case model.IsErrNotFound(err):
 		errorResponse.ErrorCode = http.StatusNotFound
+		errorResponse.ErrorID = model.ErrorIDNotFound
 	case model.IsErrRequestEntityTooLarge(err):
 		errorResponse.ErrorCode = http.StatusRequestEntityTooLarge
+		errorResponse.ErrorID = model.ErrorIDRequestEntityTooLarge
 	case model.IsErrNotImplemented(err):
 		errorResponse.ErrorCode = http.StatusNotImplemented
+		errorResponse.ErrorID = model.ErrorIDNotImplemented
+	case model.IsErrServiceUnavailable(err):
+		errorResponse.ErrorCode = http.StatusServiceUnavailable
+		errorResponse.ErrorID = model.ErrorIDServiceUnavailable
+	case model.IsErrTooManyRequests(err):
+		errorResponse.ErrorCode = http.StatusTooManyRequests
+		errorResponse.ErrorID = model.ErrorIDTooManyRequests
 	default:
 		a.logger.Error("API ERROR",
 			mlog.Int("code", http.StatusInternalServerError),
 			mlog.Err(err),
 			mlog.String("api", r.URL.Path),
+			mlog.String("request_id", errorResponse.RequestID),
 		)
 		errorResponse.Error = "internal server error"
 		errorResponse.ErrorCode = http.StatusInternalServerError
+		errorResponse.ErrorID = model.ErrorIDInternal
 	}
 
 	setResponseHeader(w, "Content-Type", "application/json")
@@ -229,6 +537,20 @@ func jsonBytesResponse(w http.ResponseWriter, code int, json []byte) {
 	_, _ = w.Write(json)
 }
 
+// jsonBytesResponseWithETag writes a 200 response with an ETag computed
+// from its content, or a 304 without content if the request already
+// has the current version.
+func jsonBytesResponseWithETag(w http.ResponseWriter, r *http.Request, json []byte) {
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(json))
+	setResponseHeader(w, "ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, json)
+}
+
 func setResponseHeader(w http.ResponseWriter, key string, value string) {
 	header := w.Header()
 	if header == nil {
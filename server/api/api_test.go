@@ -2,13 +2,17 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 	"github.com/stretchr/testify/require"
 
@@ -31,6 +35,8 @@ func TestErrorResponse(t *testing.T) {
 		{"ErrInvalidCategory", model.NewErrInvalidCategory("open"), http.StatusBadRequest, "open"},
 		{"ErrBoardMemberIsLastAdmin", model.ErrBoardMemberIsLastAdmin, http.StatusBadRequest, "no admins"},
 		{"ErrBoardIDMismatch", model.ErrBoardIDMismatch, http.StatusBadRequest, "Board IDs do not match"},
+		{"ErrInvalidField", model.NewErrInvalidField("title", "too long"), http.StatusBadRequest, `"details":{"title":"too long"}`},
+		{"json.SyntaxError", json.Unmarshal([]byte("{"), &struct{}{}), http.StatusBadRequest, "invalid JSON body"},
 
 		// unauthorized
 		{"ErrUnauthorized", model.NewErrUnauthorized("not enough permissions"), http.StatusUnauthorized, "not enough permissions"},
@@ -55,6 +61,15 @@ func TestErrorResponse(t *testing.T) {
 		{"ErrNotFound", model.ErrInsufficientLicense, http.StatusNotImplemented, "appropriate license required"},
 		{"ErrNotImplemented", model.NewErrNotImplemented("not implemented in plugin mode"), http.StatusNotImplemented, "plugin mode"},
 
+		// conflict
+		{"ErrBlockConflict", model.NewErrBlockConflict(1, &model.Block{ID: "block-id", UpdateAt: 2}), http.StatusConflict, `"serverBlock":{"id":"block-id"`},
+
+		// too many requests
+		{"ErrTooManyRequests", model.NewErrTooManyRequests("too many comments"), http.StatusTooManyRequests, "too many comments"},
+
+		// service unavailable
+		{"ErrServiceUnavailable", model.NewErrServiceUnavailable("maintenance mode"), http.StatusServiceUnavailable, "maintenance mode"},
+
 		// internal server error
 		{"Any other error", ErrHandlerPanic, http.StatusInternalServerError, "internal server error"},
 	}
@@ -76,3 +91,194 @@ func TestErrorResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorResponseFields(t *testing.T) {
+	testAPI := API{logger: mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)}
+
+	decodeResponse := func(t *testing.T, err error) model.ErrorResponse {
+		r := httptest.NewRequest(http.MethodGet, "/test", nil)
+		r = r.WithContext(utils.ContextWithRequestID(r.Context(), "request-id"))
+		w := httptest.NewRecorder()
+
+		testAPI.errorResponse(w, r, err)
+
+		var errorResponse model.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+		require.Equal(t, w.Code, errorResponse.ErrorCode)
+		require.Equal(t, "request-id", errorResponse.RequestID)
+		return errorResponse
+	}
+
+	t.Run("error ids", func(t *testing.T) {
+		require.Equal(t, model.ErrorIDBadRequest, decodeResponse(t, model.NewErrBadRequest("bad")).ErrorID)
+		require.Equal(t, model.ErrorIDNotFound, decodeResponse(t, model.NewErrNotFound("board")).ErrorID)
+		require.Equal(t, model.ErrorIDInternal, decodeResponse(t, ErrHandlerPanic).ErrorID)
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		errorResponse := decodeResponse(t, model.NewErrInvalidField("title", "too long"))
+		require.Equal(t, model.ErrorIDInvalidField, errorResponse.ErrorID)
+		require.Equal(t, map[string]string{"title": "too long"}, errorResponse.Details)
+	})
+
+	t.Run("json type error", func(t *testing.T) {
+		var body struct {
+			Title string `json:"title"`
+		}
+		err := json.Unmarshal([]byte(`{"title": 1}`), &body)
+
+		errorResponse := decodeResponse(t, err)
+		require.Equal(t, http.StatusBadRequest, errorResponse.ErrorCode)
+		require.Equal(t, model.ErrorIDInvalidField, errorResponse.ErrorID)
+		require.Contains(t, errorResponse.Details, "title")
+	})
+}
+
+func TestRequestIDHandler(t *testing.T) {
+	testAPI := API{logger: mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)}
+
+	var requestID string
+	handler := testAPI.requestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = getRequestID(r)
+	}))
+
+	t.Run("generates an id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.NotEmpty(t, requestID)
+		require.Equal(t, requestID, w.Header().Get(HeaderRequestID))
+	})
+
+	t.Run("keeps a valid incoming id", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/test", nil)
+		r.Header.Set(HeaderRequestID, "proxy-id_123")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, "proxy-id_123", requestID)
+		require.Equal(t, "proxy-id_123", w.Header().Get(HeaderRequestID))
+	})
+
+	t.Run("replaces an invalid incoming id", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/test", nil)
+		r.Header.Set(HeaderRequestID, "bad id\nwith newline")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.NotEqual(t, "bad id\nwith newline", requestID)
+		require.NotEmpty(t, requestID)
+	})
+}
+
+func TestAdminRoutes(t *testing.T) {
+	testAPI := API{logger: mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)}
+
+	router := mux.NewRouter()
+	testAPI.RegisterAdminRoutes(router)
+
+	routes, err := adminRoutes(router)
+	require.NoError(t, err)
+
+	require.Contains(t, routes, AdminRoute{Path: "/api/v2/admin/routes", Methods: []string{"GET"}})
+	require.Contains(t, routes, AdminRoute{Path: "/api/v2/admin/users/{username}/password", Methods: []string{"POST"}})
+
+	for i := 1; i < len(routes); i++ {
+		require.LessOrEqual(t, routes[i-1].Path, routes[i].Path)
+	}
+
+	t.Run("requests without the local socket are rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/admin/routes", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestServeWithTimeout(t *testing.T) {
+	testAPI := API{logger: mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)}
+
+	t.Run("handlers within the timeout respond normally", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			jsonStringResponse(w, http.StatusCreated, `{"ok":true}`)
+		})
+
+		w := httptest.NewRecorder()
+		testAPI.serveWithTimeout(handler, time.Second, w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, `{"ok":true}`, w.Body.String())
+	})
+
+	t.Run("handlers returning a 503 are not timeouts", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			testAPI.errorResponse(w, r, model.NewErrServiceUnavailable("maintenance"))
+		})
+
+		w := httptest.NewRecorder()
+		testAPI.serveWithTimeout(handler, time.Second, w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		var errorResponse model.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+		require.Equal(t, "maintenance", errorResponse.Error)
+	})
+
+	t.Run("slow handlers get a 503", func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+		})
+
+		w := httptest.NewRecorder()
+		testAPI.serveWithTimeout(handler, 10*time.Millisecond, w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var errorResponse model.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+		require.Equal(t, model.ErrorIDServiceUnavailable, errorResponse.ErrorID)
+	})
+}
+
+func TestIsLongLivedRoute(t *testing.T) {
+	var longLived bool
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		longLived = isLongLivedRoute(r)
+	}
+
+	router := mux.NewRouter()
+	apiv2 := router.PathPrefix("/api/v2").Subrouter()
+	apiv2.HandleFunc("/teams/{teamID}/archive/export", handler)
+	apiv2.HandleFunc("/boards/{boardID}", handler)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v2/teams/team-id/archive/export", nil))
+	require.True(t, longLived)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v2/boards/board-id", nil))
+	require.False(t, longLived)
+}
+
+func TestClearConnDeadlines(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clearConnDeadlines(r)
+		time.Sleep(200 * time.Millisecond)
+		jsonStringResponse(w, http.StatusOK, `{"ok":true}`)
+	})
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Config.ConnContext = SetContextConn
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `{"ok":true}`, string(body))
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -13,13 +14,20 @@ import (
 )
 
 const (
-	archiveExtension = ".boardarchive"
+	archiveExtension  = ".boardarchive"
+	markdownExtension = ".md"
 )
 
+// exportFormatMarkdown is the format of the board export as a Markdown
+// document.
+const exportFormatMarkdown = "markdown"
+
 func (a *API) registerAchivesRoutes(r *mux.Router) {
 	// Archive APIs
 	r.HandleFunc("/boards/{boardID}/archive/export", a.sessionRequired(a.handleArchiveExportBoard)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/export", a.sessionRequired(a.handleExportBoard)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/archive/import", a.sessionRequired(a.handleArchiveImport)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/archive/import/validate", a.sessionRequired(a.handleArchiveValidate)).Methods("POST")
 	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeam)).Methods("GET")
 }
 
@@ -75,15 +83,90 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 		BoardIDs: []string{board.ID},
 	}
 
-	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
+	filename := fmt.Sprintf("archive-%s%s", time.Now().In(a.app.ServerLocation()).Format("2006-01-02"), archiveExtension)
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
+	// the archive is streamed, so once the export starts the status
+	// can't change anymore and the errors are only logged
+	if err := a.app.ExportArchive(r.Context(), w, opts); err != nil {
+		a.logger.Error("ArchiveExportBoard ERROR exporting archive", mlog.String("boardID", boardID), mlog.Err(err))
+		return
+	}
+
+	auditRec.Success()
+}
+
+func (a *API) handleExportBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/export exportBoard
+	//
+	// Exports a board as a single document.
+	//
+	// ---
+	// produces:
+	// - text/markdown
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Id of board to export
+	//   required: true
+	//   type: string
+	// - name: format
+	//   in: query
+	//   description: Format of the document, only "markdown" is supported
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     content:
+	//       text/markdown:
+	//         type: string
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	userID := getUserID(r)
+	format := r.URL.Query().Get("format")
+
+	if format != exportFormatMarkdown {
+		a.errorResponse(w, r, model.NewErrBadRequest(fmt.Sprintf("unsupported export format %q, only %q is supported", format, exportFormatMarkdown)))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("BoardID", boardID)
+	auditRec.AddMeta("format", format)
+
+	document, err := a.app.ExportBoardMarkdown(boardID)
+	if err != nil {
 		a.errorResponse(w, r, err)
+		return
 	}
 
+	a.logger.Debug("ExportBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("format", format),
+	)
+
+	filename := fmt.Sprintf("board-%s%s", time.Now().In(a.app.ServerLocation()).Format("2006-01-02"), markdownExtension)
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(document))
+
 	auditRec.Success()
 }
 
@@ -108,6 +191,11 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	//   description: archive file to import
 	//   required: true
 	//   type: file
+	// - name: idStrategy
+	//   in: query
+	//   description: Strategy used to generate the new IDs, either "random" (default) or "deterministic"
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -152,9 +240,17 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("filename", handle.Filename)
 	auditRec.AddMeta("size", handle.Size)
 
+	idStrategy := r.URL.Query().Get("idStrategy")
+	if !model.IsValidIDStrategy(idStrategy) {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid idStrategy: "+idStrategy))
+		return
+	}
+	auditRec.AddMeta("idStrategy", idStrategy)
+
 	opt := model.ImportArchiveOptions{
 		TeamID:     teamID,
 		ModifiedBy: userID,
+		IDStrategy: idStrategy,
 	}
 
 	if err := a.app.ImportArchive(file, opt); err != nil {
@@ -170,6 +266,94 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleArchiveValidate(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/archive/import/validate archiveValidate
+	//
+	// Checks an archive of boards without importing it, returning a
+	// summary of its content and the issues found.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - multipart/form-data
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: file
+	//   in: formData
+	//   description: archive file to validate
+	//   required: true
+	//   type: file
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ArchiveValidation"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board"))
+		return
+	}
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if isGuest {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board"))
+		return
+	}
+
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	defer file.Close()
+
+	auditRec := a.makeAuditRecord(r, "validateImport", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("filename", handle.Filename)
+	auditRec.AddMeta("size", handle.Size)
+
+	opt := model.ImportArchiveOptions{
+		TeamID:     teamID,
+		ModifiedBy: userID,
+	}
+
+	validation := a.app.ValidateArchive(file, opt)
+
+	a.logger.Debug("ValidateArchive",
+		mlog.String("team_id", teamID),
+		mlog.Bool("valid", validation.Valid),
+		mlog.Int("board_count", validation.BoardCount),
+	)
+
+	data, err := json.Marshal(validation)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("valid", validation.Valid)
+	auditRec.Success()
+}
+
 func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /teams/{teamID}/archive/export archiveExportTeam
 	//
@@ -234,13 +418,16 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 		BoardIDs: ids,
 	}
 
-	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
+	filename := fmt.Sprintf("archive-%s%s", time.Now().In(a.app.ServerLocation()).Format("2006-01-02"), archiveExtension)
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
-		a.errorResponse(w, r, err)
+	// the archive is streamed, so once the export starts the status
+	// can't change anymore and the errors are only logged
+	if err := a.app.ExportArchive(r.Context(), w, opts); err != nil {
+		a.logger.Error("ArchiveExportTeam ERROR exporting archive", mlog.String("teamID", teamID), mlog.Err(err))
+		return
 	}
 
 	auditRec.Success()
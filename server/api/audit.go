@@ -12,9 +12,11 @@ func (a *API) makeAuditRecord(r *http.Request, event string, initialStatus strin
 	ctx := r.Context()
 	var sessionID string
 	var userID string
+	var impersonatedBy string
 	if session, ok := ctx.Value(sessionContextKey).(*model.Session); ok {
 		sessionID = session.ID
 		userID = session.UserID
+		impersonatedBy = session.ImpersonatedBy()
 	}
 
 	teamID := "unknown"
@@ -28,6 +30,9 @@ func (a *API) makeAuditRecord(r *http.Request, event string, initialStatus strin
 		IPAddress: r.RemoteAddr,
 		Meta:      []audit.Meta{{K: audit.KeyTeamID, V: teamID}},
 	}
+	if impersonatedBy != "" {
+		rec.AddMeta(audit.KeyImpersonatedBy, impersonatedBy)
+	}
 
 	return rec
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -23,8 +24,9 @@ func (a *API) registerAuthRoutes(r *mux.Router) {
 		r.HandleFunc("/login", a.handleLogin).Methods("POST")
 		r.HandleFunc("/logout", a.sessionRequired(a.handleLogout)).Methods("POST")
 		r.HandleFunc("/register", a.handleRegister).Methods("POST")
-		r.HandleFunc("/teams/{teamID}/regenerate_signup_token", a.sessionRequired(a.handlePostTeamRegenerateSignupToken)).Methods("POST")
-		r.HandleFunc("/users/{userID}/changepassword", a.sessionRequired(a.handleChangePassword)).Methods("POST")
+		r.HandleFunc("/register/invite/{token}", a.handleRegisterWithInvite).Methods("POST")
+		r.HandleFunc("/teams/{teamID}/regenerate_signup_token", a.sessionRequired(a.notImpersonated(a.handlePostTeamRegenerateSignupToken))).Methods("POST")
+		r.HandleFunc("/users/{userID}/changepassword", a.sessionRequired(a.notImpersonated(a.handleChangePassword))).Methods("POST")
 	}
 }
 
@@ -97,6 +99,8 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		a.setSessionCookie(w, r, token)
+
 		jsonBytesResponse(w, http.StatusOK, json)
 		auditRec.Success()
 		return
@@ -148,6 +152,8 @@ func (a *API) handleLogout(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.AddMeta("sessionID", session.ID)
 
+	a.clearSessionCookie(w, r)
+
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
@@ -232,6 +238,13 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the first user sets up the instance, so the allowed domains only
+	// restrict the users that join with the signup token
+	if len(registerData.Token) > 0 && !auth.IsEmailDomainAllowed(registerData.Email, a.app.GetConfig().AllowedRegistrationDomains) {
+		a.errorResponse(w, r, model.NewErrForbidden("registration is not allowed for the domain of this email"))
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "register", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("username", registerData.Username)
@@ -246,6 +259,93 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleRegisterWithInvite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /register/invite/{token} registerWithInvite
+	//
+	// Register a new user with an invite. The user joins the open boards
+	// of the invite team with the invite role
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: token
+	//   in: path
+	//   description: Invite token
+	//   required: true
+	//   type: string
+	// - name: body
+	//   in: body
+	//   description: Register request, the token is ignored
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/RegisterRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: invite not found, expired or used up
+	//   '500':
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	if len(a.singleUserToken) > 0 {
+		// Not permitted in single-user mode
+		a.errorResponse(w, r, model.NewErrUnauthorized("not permitted in single-user mode"))
+		return
+	}
+
+	token := mux.Vars(r)["token"]
+
+	// validate the invite before reading the registration data
+	invite, err := a.app.GetUsableInvite(token)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var registerData model.RegisterRequest
+	err = json.Unmarshal(requestBody, &registerData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	registerData.Email = strings.TrimSpace(registerData.Email)
+	registerData.Username = strings.TrimSpace(registerData.Username)
+
+	if err = registerData.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the invites are created by the admins, so the invited users are
+	// not restricted by the allowed registration domains
+	auditRec := a.makeAuditRecord(r, "registerWithInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", registerData.Username)
+	auditRec.AddMeta("teamID", invite.TeamID)
+
+	err = a.app.RegisterUserWithInvite(token, registerData.Username, registerData.Email, registerData.Password)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
 func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /users/{userID}/changepassword changePassword
 	//
@@ -322,10 +422,93 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+// setSessionCookie stores the session token in a cookie, honoring the
+// configured SameSite mode. SameSite=None requires the cookie to be
+// secure, so in that case Secure is always set.
+func (a *API) setSessionCookie(w http.ResponseWriter, r *http.Request, token string) {
+	cfg := a.app.GetConfig()
+	sameSite := sameSiteFromConfig(cfg.SessionCookieSameSite)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieToken,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(cfg.SessionExpireTime),
+		HttpOnly: true,
+		Secure:   sameSite == http.SameSiteNoneMode || isSecureRequest(cfg, r),
+		SameSite: sameSite,
+	})
+}
+
+// clearSessionCookie expires the session cookie.
+func (a *API) clearSessionCookie(w http.ResponseWriter, r *http.Request) {
+	cfg := a.app.GetConfig()
+	sameSite := sameSiteFromConfig(cfg.SessionCookieSameSite)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieToken,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   sameSite == http.SameSiteNoneMode || isSecureRequest(cfg, r),
+		SameSite: sameSite,
+	})
+}
+
+func sameSiteFromConfig(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case config.SameSiteStrict:
+		return http.SameSiteStrictMode
+	case config.SameSiteNone:
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// isSecureRequest returns true if the cookies should be marked as secure,
+// either because the server is configured to use them, because it is
+// served over TLS directly or because it sits behind a TLS terminating
+// proxy.
+func isSecureRequest(cfg *config.Configuration, r *http.Request) bool {
+	if cfg.SecureCookie || cfg.UseSSL || strings.HasPrefix(cfg.ServerRoot, "https://") {
+		return true
+	}
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
 func (a *API) sessionRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
 	return a.attachSession(handler, true)
 }
 
+func (a *API) attachTrustedHeaderSession(w http.ResponseWriter, r *http.Request, username, email string, handler func(w http.ResponseWriter, r *http.Request)) {
+	user, err := a.app.GetOrCreateTrustedUser(username, email)
+	if err != nil {
+		a.logger.Error("Unable to authenticate the trusted user", mlog.String("username", username), mlog.Err(err))
+		a.errorResponse(w, r, model.NewErrUnauthorized("unable to authenticate the trusted user"))
+		return
+	}
+	if user.DeleteAt != 0 {
+		a.errorResponse(w, r, model.NewErrUnauthorized("the trusted user is deactivated"))
+		return
+	}
+
+	now := utils.GetMillis()
+	session := &model.Session{
+		ID:          user.ID,
+		Token:       user.ID,
+		UserID:      user.ID,
+		AuthService: a.authService,
+		Props:       map[string]interface{}{},
+		CreateAt:    now,
+		UpdateAt:    now,
+	}
+
+	ctx := context.WithValue(r.Context(), sessionContextKey, session)
+	handler(w, r.WithContext(ctx))
+}
+
 func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request), required bool) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token, _ := auth.ParseAuthTokenFromRequest(r)
@@ -370,6 +553,16 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		if trustedUsername, trustedEmail := a.app.GetTrustedAuthIdentity(r); trustedUsername != "" {
+			a.attachTrustedHeaderSession(w, r, trustedUsername, trustedEmail, handler)
+			return
+		}
+
+		if model.IsBoardAPIKey(token) {
+			a.attachBoardAPIKeySession(w, r, token, handler)
+			return
+		}
+
 		session, err := a.app.GetSession(token)
 		if err != nil {
 			if required {
@@ -393,8 +586,72 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 		}
 
 		ctx := context.WithValue(r.Context(), sessionContextKey, session)
-		handler(w, r.WithContext(ctx))
+		r = r.WithContext(ctx)
+
+		if session.ImpersonatedBy() != "" {
+			// every request made while impersonating a user is audited,
+			// not only the ones of the audited endpoints
+			auditRec := a.makeAuditRecord(r, "impersonatedRequest", audit.Success)
+			auditRec.AddMeta("method", r.Method)
+			a.audit.LogRecord(audit.LevelAuth, auditRec)
+		}
+
+		handler(w, r)
+	}
+}
+
+// notImpersonated rejects the requests made with a session created to
+// impersonate a user, for the endpoints that change credentials or give
+// access beyond the impersonation session.
+func (a *API) notImpersonated(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if session, ok := r.Context().Value(sessionContextKey).(*model.Session); ok && session.ImpersonatedBy() != "" {
+			a.errorResponse(w, r, model.NewErrPermission("not permitted while impersonating a user"))
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// attachBoardAPIKeySession authenticates a request made with a board API
+// key. The key only gives access to the content endpoints of its board,
+// and read keys only to the GET ones.
+func (a *API) attachBoardAPIKeySession(w http.ResponseWriter, r *http.Request, token string, handler func(w http.ResponseWriter, r *http.Request)) {
+	key, err := a.app.GetBoardAPIKey(token)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrUnauthorized("invalid board API key"))
+		return
+	}
+
+	if boardID := mux.Vars(r)["boardID"]; boardID == "" || boardID != key.BoardID {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	if !isBoardAPIKeyRoute(r) {
+		a.errorResponse(w, r, model.NewErrPermission("board API keys can only access the board contents"))
+		return
+	}
+
+	if !key.AllowsMethod(r.Method) {
+		a.errorResponse(w, r, model.NewErrPermission("board API key is read only"))
+		return
+	}
+
+	session := &model.Session{
+		ID:          key.ID,
+		Token:       key.ID,
+		UserID:      key.CreatedBy,
+		AuthService: a.authService,
+		Props:       map[string]interface{}{},
+		CreateAt:    key.CreateAt,
+		UpdateAt:    key.CreateAt,
 	}
+
+	ctx := context.WithValue(r.Context(), sessionContextKey, session)
+	ctx = context.WithValue(ctx, boardAPIKeyContextKey, key)
+	handler(w, r.WithContext(ctx))
 }
 
 func (a *API) adminRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
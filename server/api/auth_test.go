@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSameSiteFromConfig(t *testing.T) {
+	require.Equal(t, http.SameSiteLaxMode, sameSiteFromConfig(""))
+	require.Equal(t, http.SameSiteLaxMode, sameSiteFromConfig(config.SameSiteLax))
+	require.Equal(t, http.SameSiteStrictMode, sameSiteFromConfig("Strict"))
+	require.Equal(t, http.SameSiteNoneMode, sameSiteFromConfig(config.SameSiteNone))
+}
+
+func TestIsSecureRequest(t *testing.T) {
+	t.Run("plain http", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v2/login", nil)
+		require.False(t, isSecureRequest(&config.Configuration{ServerRoot: "http://localhost:8000"}, r))
+	})
+
+	t.Run("secure cookie configured", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v2/login", nil)
+		require.True(t, isSecureRequest(&config.Configuration{SecureCookie: true}, r))
+	})
+
+	t.Run("https server root", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v2/login", nil)
+		require.True(t, isSecureRequest(&config.Configuration{ServerRoot: "https://boards.example.com"}, r))
+	})
+
+	t.Run("behind a TLS terminating proxy", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v2/login", nil)
+		r.Header.Set("X-Forwarded-Proto", "https")
+		require.True(t, isSecureRequest(&config.Configuration{}, r))
+	})
+}
@@ -14,15 +14,25 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+const (
+	maxBoardsPerBlocksBatch = 50
+	maxBlocksPerDeleteBatch = 1000
+)
+
 func (a *API) registerBlocksRoutes(r *mux.Router) {
 	// Blocks APIs
 	r.HandleFunc("/boards/{boardID}/blocks", a.attachSession(a.handleGetBlocks, false)).Methods("GET")
 	r.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(a.handlePostBlocks)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(a.handlePatchBlocks)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/blocks/sync", a.sessionRequired(a.handleSyncBlocks)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handleDeleteBlock)).Methods("DELETE")
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handlePatchBlock)).Methods("PATCH")
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/undelete", a.sessionRequired(a.handleUndeleteBlock)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/duplicate", a.sessionRequired(a.handleDuplicateBlock)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/trash", a.sessionRequired(a.handleGetDeletedBlocks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/manifest", a.sessionRequired(a.handleGetBlockManifest)).Methods("GET")
+	r.HandleFunc("/blocks/batch", a.sessionRequired(a.handleGetBlocksBatch)).Methods("POST")
+	r.HandleFunc("/blocks/delete-batch", a.sessionRequired(a.handleDeleteBlocksBatch)).Methods("POST")
 }
 
 func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
@@ -166,6 +176,14 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if a.isSharedView(userID, boardID, hasValidReadToken) {
+		blocks, err = a.app.FilterSharedBlocks(boardID, blocks)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	json, err := json.Marshal(blocks)
 	if err != nil {
 		a.errorResponse(w, r, err)
@@ -178,6 +196,317 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleSyncBlocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/sync syncBlocks
+	//
+	// Reconciles the blocks changed by a client while offline. Changes
+	// based on an outdated version of a block are not applied and are
+	// returned as conflicts, along with the server state of the board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the blocks changed by the client
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SyncBlocksRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/SyncBlocksResult"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	requestBody, err := a.readBulkBody(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var syncRequest model.SyncBlocksRequest
+	if err = json.Unmarshal(requestBody, &syncRequest); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = a.checkBulkItems(len(syncRequest.Changes)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if err = syncRequest.IsValid(boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the submitted types can't be trusted, so the users that can only
+	// comment are limited to their own comments by the store, which
+	// checks the type of the stored blocks
+	onlyComments := false
+	switch {
+	case len(syncRequest.Changes) == 0:
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+			return
+		}
+	case a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards):
+	case a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionCommentBoardCards):
+		onlyComments = true
+	default:
+		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "syncBlocks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("changeCount", len(syncRequest.Changes))
+
+	result, err := a.app.SyncBlocks(boardID, syncRequest.Changes, userID, onlyComments)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SyncBlocks",
+		mlog.String("boardID", boardID),
+		mlog.Int("applied", len(result.Applied)),
+		mlog.Int("conflicts", len(result.Conflicts)),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("conflictCount", len(result.Conflicts))
+	auditRec.Success()
+}
+
+func (a *API) handleGetBlockManifest(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/manifest getBlockManifest
+	//
+	// Returns the ID, update time and type of all the blocks of a board,
+	// so clients can compare them with their local copy and fetch only
+	// the blocks that changed. Supports conditional requests through the
+	// ETag and If-None-Match headers.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BlockManifestEntry"
+	//   '304':
+	//     description: the manifest didn't change since the version in the If-None-Match header
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBlockManifest", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	manifest, err := a.app.GetBlockManifest(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBlockManifest",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("block_count", len(manifest)),
+	)
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponseWithETag(w, r, data)
+
+	auditRec.AddMeta("blockCount", len(manifest))
+	auditRec.Success()
+}
+
+func (a *API) handleGetBlocksBatch(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /blocks/batch getBlocksBatch
+	//
+	// Returns the blocks of several boards at once, keyed by board ID.
+	// Boards that the user can't access are omitted from the response.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: array of board IDs
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: object
+	//       additionalProperties:
+	//         type: array
+	//         items:
+	//           "$ref": "#/definitions/Block"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	requestBody, err := a.readBulkBody(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var boardIDs []string
+	if err = json.Unmarshal(requestBody, &boardIDs); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if len(boardIDs) == 0 {
+		a.errorResponse(w, r, model.NewErrBadRequest("at least one board ID is required"))
+		return
+	}
+
+	if len(boardIDs) > maxBoardsPerBlocksBatch {
+		message := fmt.Sprintf("a maximum of %d boards can be requested at once", maxBoardsPerBlocksBatch)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBlocksBatch", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardCount", len(boardIDs))
+
+	allowedBoardIDs := []string{}
+	seen := map[string]bool{}
+	for _, boardID := range boardIDs {
+		if seen[boardID] {
+			continue
+		}
+		seen[boardID] = true
+
+		if a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			allowedBoardIDs = append(allowedBoardIDs, boardID)
+		}
+	}
+
+	a.logger.Debug("GetBlocksBatch",
+		mlog.String("userID", userID),
+		mlog.Int("requested_count", len(boardIDs)),
+		mlog.Int("allowed_count", len(allowedBoardIDs)),
+	)
+
+	// the response is streamed one board at a time so the blocks of
+	// all the boards don't need to be held in memory together. Once
+	// the first byte is written the status can't change anymore, so
+	// errors past that point close the response early and are logged.
+	setResponseHeader(w, "Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("{"))
+
+	blockCount := 0
+	for i, boardID := range allowedBoardIDs {
+		var blocks []model.Block
+		blocks, err = a.app.GetBlocksForBoard(boardID)
+		if err != nil {
+			a.logger.Error("GetBlocksBatch ERROR fetching blocks", mlog.String("boardID", boardID), mlog.Err(err))
+			return
+		}
+
+		blocks, err = a.app.ApplyCloudLimits(blocks)
+		if err != nil {
+			a.logger.Error("GetBlocksBatch ERROR applying limits", mlog.String("boardID", boardID), mlog.Err(err))
+			return
+		}
+
+		key, _ := json.Marshal(boardID)
+		var data []byte
+		data, err = json.Marshal(blocks)
+		if err != nil {
+			a.logger.Error("GetBlocksBatch ERROR marshalling blocks", mlog.String("boardID", boardID), mlog.Err(err))
+			return
+		}
+
+		if i > 0 {
+			_, _ = w.Write([]byte(","))
+		}
+		_, _ = w.Write(key)
+		_, _ = w.Write([]byte(":"))
+		_, _ = w.Write(data)
+
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		blockCount += len(blocks)
+	}
+
+	_, _ = w.Write([]byte("}"))
+
+	auditRec.AddMeta("blockCount", blockCount)
+	auditRec.Success()
+}
+
 func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards/{boardID}/blocks updateBlocks
 	//
@@ -227,7 +556,7 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	val := r.URL.Query().Get("disable_notify")
 	disableNotify := val == True
 
-	requestBody, err := io.ReadAll(r.Body)
+	requestBody, err := a.readBulkBody(r)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -241,6 +570,11 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = a.checkBulkItems(len(blocks)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	hasComments := false
 	hasContents := false
 	for _, block := range blocks {
@@ -495,6 +829,74 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleGetDeletedBlocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/trash getDeletedBlocks
+	//
+	// Returns the deleted blocks of a board that can still be restored,
+	// most recently deleted first. The deleteAt field of each block holds
+	// the time it was deleted.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '403':
+	//     description: access denied to board
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getDeletedBlocks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	blocks, err := a.app.GetDeletedBlocks(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetDeletedBlocks",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("block_count", len(blocks)),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("blockCount", len(blocks))
+	auditRec.Success()
+}
+
 func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation PATCH /boards/{boardID}/blocks/{blockID} patchBlock
 	//
@@ -532,6 +934,10 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	//     description: success
 	//   '404':
 	//     description: block not found
+	//   '409':
+	//     description: the block was updated after baseUpdateAt
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -635,7 +1041,7 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 	val := r.URL.Query().Get("disable_notify")
 	disableNotify := val == True
 
-	requestBody, err := io.ReadAll(r.Body)
+	requestBody, err := a.readBulkBody(r)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -648,6 +1054,11 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = a.checkBulkItems(len(patches.BlockIDs)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "patchBlocks", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	for i := range patches.BlockIDs {
@@ -782,3 +1193,125 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 }
+
+func (a *API) handleDeleteBlocksBatch(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /blocks/delete-batch deleteBlocksBatch
+	//
+	// Deletes several blocks and their descendants in a single
+	// transaction. Blocks that don't exist or that the user can't delete
+	// are reported in the response instead of failing the batch.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: array of block IDs
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/DeleteBlocksResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	requestBody, err := a.readBulkBody(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var blockIDs []string
+	if err = json.Unmarshal(requestBody, &blockIDs); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if len(blockIDs) == 0 {
+		a.errorResponse(w, r, model.NewErrBadRequest("at least one block ID is required"))
+		return
+	}
+
+	if len(blockIDs) > maxBlocksPerDeleteBatch {
+		message := fmt.Sprintf("a maximum of %d blocks can be deleted at once", maxBlocksPerDeleteBatch)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBlocksBatch", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("blockCount", len(blockIDs))
+
+	result := &model.DeleteBlocksResult{Deleted: []string{}, Failed: []model.DeleteBlockFailure{}}
+	allowedBlockIDs := []string{}
+	allowedBoards := map[string]bool{}
+	seen := map[string]bool{}
+	for _, blockID := range blockIDs {
+		if seen[blockID] {
+			continue
+		}
+		seen[blockID] = true
+
+		block, bErr := a.app.GetBlockByID(blockID)
+		if model.IsErrNotFound(bErr) {
+			result.Failed = append(result.Failed, model.DeleteBlockFailure{BlockID: blockID, Error: "block not found"})
+			continue
+		}
+		if bErr != nil {
+			a.errorResponse(w, r, bErr)
+			return
+		}
+
+		allowed, ok := allowedBoards[block.BoardID]
+		if !ok {
+			allowed = a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionManageBoardCards)
+			allowedBoards[block.BoardID] = allowed
+		}
+		if !allowed {
+			result.Failed = append(result.Failed, model.DeleteBlockFailure{BlockID: blockID, Error: "access denied to make board changes"})
+			continue
+		}
+
+		allowedBlockIDs = append(allowedBlockIDs, blockID)
+	}
+
+	if len(allowedBlockIDs) > 0 {
+		result.Deleted, err = a.app.DeleteBlocks(allowedBlockIDs, userID)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
+	a.logger.Debug("DeleteBlocksBatch",
+		mlog.String("userID", userID),
+		mlog.Int("requested_count", len(blockIDs)),
+		mlog.Int("deleted_count", len(result.Deleted)),
+		mlog.Int("failed_count", len(result.Failed)),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("deletedCount", len(result.Deleted))
+	auditRec.AddMeta("failedCount", len(result.Failed))
+	auditRec.Success()
+}
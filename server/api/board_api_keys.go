@@ -0,0 +1,254 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerBoardAPIKeysRoutes(r *mux.Router) {
+	// Board API keys APIs
+	r.HandleFunc("/boards/{boardID}/apikeys", a.sessionRequired(a.handleGetBoardAPIKeys)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/apikeys", a.sessionRequired(a.notImpersonated(a.handleCreateBoardAPIKey))).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/apikeys/{keyID}", a.sessionRequired(a.handleDeleteBoardAPIKey)).Methods("DELETE")
+}
+
+// checkBoardAPIKeysAccess checks that the user can manage the API keys of
+// a board. The keys can't be managed with another key.
+func (a *API) checkBoardAPIKeysAccess(r *http.Request, userID, boardID string) error {
+	if getBoardAPIKey(r) != nil {
+		return model.NewErrPermission("board API keys cannot be managed with a board API key")
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		return model.NewErrPermission("access denied to board API keys")
+	}
+	return nil
+}
+
+func (a *API) handleGetBoardAPIKeys(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/apikeys getBoardAPIKeys
+	//
+	// Returns the API keys of a board, without the keys themselves
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardAPIKey"
+	//   '403':
+	//     description: access denied
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if err := a.checkBoardAPIKeysAccess(r, userID, boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardAPIKeys", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	keys, err := a.app.GetBoardAPIKeys(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardAPIKeys",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("key_count", len(keys)),
+	)
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardAPIKey(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/apikeys createBoardAPIKey
+	//
+	// Creates an API key for a board. The key is only returned in this
+	// response.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the name and the permission (read or write) of the key
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardAPIKey"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/BoardAPIKey'
+	//   '403':
+	//     description: access denied
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var request *model.BoardAPIKey
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if request == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid board API key"))
+		return
+	}
+
+	if err = a.checkBoardAPIKeysAccess(r, userID, boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoardAPIKey", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("permission", request.Permission)
+
+	key, err := a.app.CreateBoardAPIKey(boardID, request.Name, request.Permission, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardAPIKey",
+		mlog.String("boardID", boardID),
+		mlog.String("keyID", key.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("keyID", key.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardAPIKey(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/apikeys/{keyID} deleteBoardAPIKey
+	//
+	// Revokes an API key of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: keyID
+	//   in: path
+	//   description: API key ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '403':
+	//     description: access denied
+	//   '404':
+	//     description: key not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	keyID := vars["keyID"]
+
+	if err := a.checkBoardAPIKeysAccess(r, userID, boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardAPIKey", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("keyID", keyID)
+
+	if err := a.app.RevokeBoardAPIKey(boardID, keyID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteBoardAPIKey",
+		mlog.String("boardID", boardID),
+		mlog.String("keyID", keyID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
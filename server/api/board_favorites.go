@@ -0,0 +1,193 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerBoardFavoritesRoutes(r *mux.Router) {
+	// Board favorites APIs
+	r.HandleFunc("/users/me/favorites", a.sessionRequired(a.handleGetFavorites)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/favorite", a.sessionRequired(a.handleAddFavorite)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/favorite", a.sessionRequired(a.handleRemoveFavorite)).Methods("DELETE")
+}
+
+func (a *API) handleGetFavorites(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/favorites getFavorites
+	//
+	// Returns the favorite boards of the current user, in the order they
+	// were added
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Board"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "getFavorites", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	boards, err := a.app.ListFavorites(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the user may have lost access to a board since they added it
+	accessibleBoards := make([]*model.Board, 0, len(boards))
+	for _, board := range boards {
+		if a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+			accessibleBoards = append(accessibleBoards, board)
+		}
+	}
+
+	a.logger.Debug("GetFavorites",
+		mlog.String("userID", userID),
+		mlog.Int("boardsCount", len(accessibleBoards)),
+	)
+
+	data, err := json.Marshal(accessibleBoards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardsCount", len(accessibleBoards))
+	auditRec.Success()
+}
+
+func (a *API) handleAddFavorite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/favorite addFavorite
+	//
+	// Adds a board to the favorites of the current user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/BoardFavorite'
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "addFavorite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	favorite, err := a.app.AddFavorite(userID, boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AddFavorite",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(favorite)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleRemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/favorite removeFavorite
+	//
+	// Removes a board from the favorites of the current user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	auditRec := a.makeAuditRecord(r, "removeFavorite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	// a user can always remove a board from their own favorites, even if
+	// they can't access it anymore
+	if err := a.app.RemoveFavorite(userID, boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RemoveFavorite",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
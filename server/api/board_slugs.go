@@ -0,0 +1,240 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerBoardSlugsRoutes(r *mux.Router) {
+	// Board slugs APIs
+	r.HandleFunc("/boards/{boardID}/slug", a.sessionRequired(a.handleGetBoardSlug)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/slug", a.sessionRequired(a.handleSetBoardSlug)).Methods("PUT")
+	r.HandleFunc("/teams/{teamID}/boards/by-slug/{slug}", a.sessionRequired(a.handleGetBoardBySlug)).Methods("GET")
+}
+
+func (a *API) handleGetBoardSlug(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/slug getBoardSlug
+	//
+	// Returns the slug of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardSlug"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	slug, err := a.app.GetBoardSlug(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(model.BoardSlug{Slug: slug})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleSetBoardSlug(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/slug setBoardSlug
+	//
+	// Sets the slug of a board, unique within its team. An empty slug
+	// removes it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the new slug of the board
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardSlug"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardSlug"
+	//   '400':
+	//     description: invalid or already used slug
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var boardSlug model.BoardSlug
+	if err = json.Unmarshal(requestBody, &boardSlug); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setBoardSlug", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("slug", boardSlug.Slug)
+
+	if err = a.app.SetBoardSlug(boardID, boardSlug.Slug); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetBoardSlug",
+		mlog.String("boardID", boardID),
+		mlog.String("slug", boardSlug.Slug),
+	)
+
+	data, err := json.Marshal(boardSlug)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardBySlug(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/boards/by-slug/{slug} getBoardBySlug
+	//
+	// Returns the board of a team with the given slug
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: slug
+	//   in: path
+	//   description: Board slug
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	slug := vars["slug"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	board, err := a.app.GetBoardBySlug(teamID, slug)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+		// the open boards are visible to the team members, except the guests
+		var isGuest bool
+		isGuest, err = a.userIsGuest(userID)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+		if board.Type == model.BoardTypePrivate || isGuest {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardBySlug", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", board.ID)
+	auditRec.AddMeta("slug", slug)
+
+	a.logger.Debug("GetBoardBySlug",
+		mlog.String("teamID", teamID),
+		mlog.String("slug", slug),
+		mlog.String("boardID", board.ID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
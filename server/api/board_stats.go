@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerBoardStatsRoutes(r *mux.Router) {
+	// Board stats APIs
+	r.HandleFunc("/boards/{boardID}/stats", a.sessionRequired(a.handleGetBoardStats)).Methods("GET")
+}
+
+func (a *API) handleGetBoardStats(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/stats getBoardStats
+	//
+	// Returns the statistics of the cards of a board: the total, the
+	// count by status, the completed percentage and the overdue count
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: statusProperty
+	//   in: query
+	//   description: ID of the select property holding the status, defaults to the property named Status
+	//   required: false
+	//   type: string
+	// - name: doneOptions
+	//   in: query
+	//   description: comma separated IDs of the completed status options, defaults to the options named like Done or Completed
+	//   required: false
+	//   type: string
+	// - name: dueDateProperty
+	//   in: query
+	//   description: ID of the date property holding the due date, defaults to the first date property named like Due
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardStats"
+	//   '400':
+	//     description: invalid property or option
+	//   '403':
+	//     description: access denied to board
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	query := r.URL.Query()
+
+	var doneOptions []string
+	if value := query.Get("doneOptions"); value != "" {
+		doneOptions = strings.Split(value, ",")
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardStats", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	stats, err := a.app.GetBoardStats(boardID, query.Get("statusProperty"), query.Get("dueDateProperty"), doneOptions)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardStats",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int64("total_cards", stats.TotalCards),
+	)
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerBoardUnreadRoutes(r *mux.Router) {
+	// Board unread APIs
+	r.HandleFunc("/users/me/unread", a.sessionRequired(a.handleGetBoardUnreadCounts)).Methods("GET")
+	r.HandleFunc("/users/me/unread/{boardID}", a.sessionRequired(a.handleMarkBoardSeen)).Methods("POST")
+}
+
+func (a *API) handleGetBoardUnreadCounts(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/unread getBoardUnreadCounts
+	//
+	// Returns, for each board the current user is a member of, the number
+	// of blocks changed by other users since the user last viewed it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardUnread"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "getBoardUnreadCounts", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	unread, err := a.app.GetBoardUnreadCounts(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardUnreadCounts",
+		mlog.String("userID", userID),
+		mlog.Int("boardsCount", len(unread)),
+	)
+
+	data, err := json.Marshal(unread)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardsCount", len(unread))
+	auditRec.Success()
+}
+
+func (a *API) handleMarkBoardSeen(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/unread/{boardID} markBoardSeen
+	//
+	// Records that the current user viewed a board, so its changes until
+	// now are read
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "markBoardSeen", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.app.MarkBoardSeen(userID, boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MarkBoardSeen",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
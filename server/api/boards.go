@@ -19,6 +19,8 @@ func (a *API) registerBoardsRoutes(r *mux.Router) {
 	r.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handlePatchBoard)).Methods("PATCH")
 	r.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handleDeleteBoard)).Methods("DELETE")
 	r.HandleFunc("/boards/{boardID}/duplicate", a.sessionRequired(a.handleDuplicateBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/move", a.sessionRequired(a.handleMoveBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/transfer-owner", a.sessionRequired(a.handleTransferBoardOwner)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/undelete", a.sessionRequired(a.handleUndeleteBoard)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/metadata", a.sessionRequired(a.handleGetBoardMetadata)).Methods("GET")
 }
@@ -37,6 +39,11 @@ func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
 	//   description: Team ID
 	//   required: true
 	//   type: string
+	// - name: include_favorite
+	//   in: query
+	//   description: Sets the isFavorite flag of the boards for the current user
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -76,6 +83,13 @@ func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("include_favorite") == True {
+		if err = a.app.SetFavoriteFlags(userID, boards...); err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	a.logger.Debug("GetBoards",
 		mlog.String("teamID", teamID),
 		mlog.Int("boardsCount", len(boards)),
@@ -97,7 +111,8 @@ func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
 func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards createBoard
 	//
-	// Creates a new board
+	// Creates a new board. A board without type gets the default
+	// visibility of its team, and the creator is always its admin.
 	//
 	// ---
 	// produces:
@@ -135,6 +150,20 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the boards created without a type get the default visibility
+	// of the team
+	var visibility model.BoardVisibility
+	if newBoard.Type == "" {
+		visibility = a.app.GetDefaultBoardVisibility(newBoard.TeamID)
+		newBoard.Type = visibility.BoardType()
+	}
+
+	// the boards created without card properties get the default
+	// properties of the team
+	if !newBoard.IsTemplate && len(newBoard.CardProperties) == 0 {
+		newBoard.CardProperties = a.app.GetDefaultCardProperties(newBoard.TeamID)
+	}
+
 	if newBoard.Type == model.BoardTypeOpen {
 		if !a.permissions.HasPermissionToTeam(userID, newBoard.TeamID, model.PermissionCreatePublicChannel) {
 			a.errorResponse(w, r, model.NewErrPermission("access denied to create public boards"))
@@ -174,6 +203,15 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if visibility == model.BoardVisibilityPublic {
+		if err = a.app.ShareNewBoard(board.ID, userID); err != nil {
+			a.logger.Error("Cannot share the new public board",
+				mlog.String("boardID", board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+
 	a.logger.Debug("CreateBoard",
 		mlog.String("teamID", board.TeamID),
 		mlog.String("boardID", board.ID),
@@ -207,6 +245,11 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Board ID
 	//   required: true
 	//   type: string
+	// - name: include_favorite
+	//   in: query
+	//   description: Sets the isFavorite flag of the board for the current user
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -267,6 +310,21 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
+	if a.isSharedView(userID, boardID, hasValidReadToken) {
+		board, err = a.app.FilterSharedBoard(board)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
+	if userID != "" && r.URL.Query().Get("include_favorite") == True {
+		if err = a.app.SetFavoriteFlags(userID, board); err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
 	a.logger.Debug("GetBoard",
 		mlog.String("boardID", boardID),
 	)
@@ -547,6 +605,226 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleMoveBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/move moveBoard
+	//
+	// Moves a board and all its blocks to another team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the team to move the board to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MoveBoardRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var moveRequest model.MoveBoardRequest
+	if err = json.Unmarshal(requestBody, &moveRequest); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if moveRequest.TeamID == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("teamId is required"))
+		return
+	}
+
+	board, err := a.app.GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if board.IsTemplate && board.TeamID == model.GlobalTeamID {
+		a.errorResponse(w, r, model.NewErrBadRequest("cannot move a built-in template"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move board"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to source team"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, moveRequest.TeamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to destination team"))
+		return
+	}
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if isGuest {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("fromTeamID", board.TeamID)
+	auditRec.AddMeta("toTeamID", moveRequest.TeamID)
+
+	movedBoard, err := a.app.MoveBoard(boardID, moveRequest.TeamID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MoveBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("toTeamID", moveRequest.TeamID),
+	)
+
+	data, err := json.Marshal(movedBoard)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleTransferBoardOwner(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/transfer-owner transferBoardOwner
+	//
+	// Transfers the ownership of a board to another user. The new owner
+	// becomes an admin of the board and the previous owner is demoted to
+	// editor. Only the current owner or a team admin can transfer a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the user to transfer the board to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TransferBoardOwnerRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var transferRequest model.TransferBoardOwnerRequest
+	if err = json.Unmarshal(requestBody, &transferRequest); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if transferRequest.UserID == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("userId is required"))
+		return
+	}
+
+	board, err := a.app.GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if board.CreatedBy != userID && !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to transfer board"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(transferRequest.UserID, board.TeamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrBadRequest("new owner is not a member of the team"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "transferBoardOwner", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("fromUserID", board.CreatedBy)
+	auditRec.AddMeta("toUserID", transferRequest.UserID)
+
+	transferredBoard, err := a.app.TransferBoardOwnership(boardID, transferRequest.UserID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("TransferBoardOwner",
+		mlog.String("boardID", boardID),
+		mlog.String("toUserID", transferRequest.UserID),
+	)
+
+	data, err := json.Marshal(transferredBoard)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handleUndeleteBoard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards/{boardID}/undelete undeleteBoard
 	//
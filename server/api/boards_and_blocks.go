@@ -3,7 +3,6 @@ package api
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -49,7 +48,7 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 
 	userID := getUserID(r)
 
-	requestBody, err := io.ReadAll(r.Body)
+	requestBody, err := a.readBulkBody(r)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -61,6 +60,11 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if err = a.checkBulkItems(len(newBab.Boards) + len(newBab.Blocks)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	if len(newBab.Boards) == 0 {
 		a.errorResponse(w, r, model.NewErrBadRequest("at least one board is required"))
 		return
@@ -129,6 +133,14 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	// the boards created without card properties get the default
+	// properties of the team
+	for _, board := range newBab.Boards {
+		if !board.IsTemplate && len(board.CardProperties) == 0 {
+			board.CardProperties = a.app.GetDefaultCardProperties(board.TeamID)
+		}
+	}
+
 	// IDs of boards and blocks are used to confirm that they're
 	// linked and then regenerated by the server
 	newBab, err = model.GenerateBoardsAndBlocksIDs(newBab, a.logger)
@@ -199,7 +211,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 
 	userID := getUserID(r)
 
-	requestBody, err := io.ReadAll(r.Body)
+	requestBody, err := a.readBulkBody(r)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -211,6 +223,11 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err = a.checkBulkItems(len(pbab.BoardIDs) + len(pbab.BlockIDs)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	if err = pbab.IsValid(); err != nil {
 		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
 		return
@@ -327,7 +344,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 
 	userID := getUserID(r)
 
-	requestBody, err := io.ReadAll(r.Body)
+	requestBody, err := a.readBulkBody(r)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -339,6 +356,11 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if err = a.checkBulkItems(len(dbab.Boards) + len(dbab.Blocks)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	// user must have permission to delete all the boards, and that
 	// would include the permission to manage their blocks
 	teamID := ""
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// readBulkBody reads the body of a bulk request, failing before reading
+// it whole if it's larger than the configured maximum.
+func (a *API) readBulkBody(r *http.Request) ([]byte, error) {
+	maxSize := a.app.GetConfig().MaxBulkBodySize
+	if maxSize <= 0 {
+		return io.ReadAll(r.Body)
+	}
+
+	if r.ContentLength > maxSize {
+		return nil, model.ErrRequestEntityTooLarge
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, model.ErrRequestEntityTooLarge
+	}
+	return body, nil
+}
+
+// checkBulkItems returns an error if a bulk request has more items than
+// the configured maximum.
+func (a *API) checkBulkItems(count int) error {
+	maxItems := a.app.GetConfig().MaxBulkItems
+	if maxItems > 0 && count > maxItems {
+		return model.NewErrBadRequest(fmt.Sprintf("a maximum of %d items can be sent at once", maxItems))
+	}
+	return nil
+}
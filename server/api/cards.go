@@ -22,9 +22,15 @@ const (
 func (a *API) registerCardsRoutes(r *mux.Router) {
 	// Cards APIs
 	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleCreateCard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards/from-template", a.sessionRequired(a.handleCreateCardFromTemplate)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards/update-property", a.sessionRequired(a.handleUpdateCardsProperty)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards/assign", a.sessionRequired(a.handleAssignCards)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards/{cardID}/move", a.sessionRequired(a.handleMoveCard)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleGetCards)).Methods("GET")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handlePatchCard)).Methods("PATCH")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handleGetCard)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/progress", a.sessionRequired(a.handleGetCardProgress)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/duplicate", a.sessionRequired(a.handleDuplicateCard)).Methods("POST")
 }
 
 func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
@@ -127,6 +133,378 @@ func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleCreateCardFromTemplate(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/from-template createCardFromTemplate
+	//
+	// Creates a new card copying the content and properties of the board card template.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   '400':
+	//     description: the board has no card template
+	//   '404':
+	//     description: board or card template not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardFromTemplate", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	card, err := a.app.CreateCardFromTemplate(boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateCardFromTemplate",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateCardsProperty(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/update-property updateCardsProperty
+	//
+	// Sets a property value on several cards of a board. The cards that
+	// can't be updated are reported in the response.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the cards, the property and the value to set
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardPropertyUpdate"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardPropertyUpdateResult'
+	//   '400':
+	//     description: invalid property or value
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var update *model.CardPropertyUpdate
+	if err = json.Unmarshal(requestBody, &update); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if update == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid card property update"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateCardsProperty", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", update.PropertyID)
+	auditRec.AddMeta("cardCount", len(update.CardIDs))
+
+	result, err := a.app.UpdateCardsProperty(boardID, update, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateCardsProperty",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", update.PropertyID),
+		mlog.String("userID", userID),
+		mlog.Int("updated_count", len(result.UpdatedCardIDs)),
+		mlog.Int("failure_count", len(result.Failures)),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("updatedCount", len(result.UpdatedCardIDs))
+	auditRec.Success()
+}
+
+func (a *API) handleAssignCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/assign assignCards
+	//
+	// Assigns several cards of a board to a user, setting the person
+	// property of the board on them. The cards that can't be assigned
+	// are reported in the response.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the cards and the user to assign them to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardAssignment"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardPropertyUpdateResult'
+	//   '400':
+	//     description: invalid property, or the user is not a member of the team
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var assignment *model.CardAssignment
+	if err = json.Unmarshal(requestBody, &assignment); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if assignment == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid card assignment"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+		return
+	}
+
+	board, err := a.app.GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the cards can only be assigned to the members of the team
+	if _, err = a.app.GetUser(assignment.UserID); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("user "+assignment.UserID+" not found"))
+		return
+	}
+	if !a.permissions.HasPermissionToTeam(assignment.UserID, board.TeamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrBadRequest("user "+assignment.UserID+" is not a member of the team"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "assignCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("assigneeID", assignment.UserID)
+	auditRec.AddMeta("cardCount", len(assignment.CardIDs))
+
+	result, err := a.app.AssignCards(boardID, assignment, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AssignCards",
+		mlog.String("boardID", boardID),
+		mlog.String("assigneeID", assignment.UserID),
+		mlog.String("userID", userID),
+		mlog.Int("updated_count", len(result.UpdatedCardIDs)),
+		mlog.Int("failure_count", len(result.Failures)),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("updatedCount", len(result.UpdatedCardIDs))
+	auditRec.Success()
+}
+
+func (a *API) handleMoveCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/{cardID}/move moveCard
+	//
+	// Moves a card between two other cards of the board. Only the moved
+	// card gets a new position, so concurrent moves don't conflict.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the cards to place the moved card between
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardPositionChange"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   '400':
+	//     description: invalid position change
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var change *model.CardPositionChange
+	if err = json.Unmarshal(requestBody, &change); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if change == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid card position change"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	card, err := a.app.MoveCard(boardID, cardID, change, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MoveCard",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /boards/{boardID}/cards
 	//
@@ -387,3 +765,148 @@ func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 }
+
+func (a *API) handleGetCardProgress(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/progress getCardProgress
+	//
+	// Returns the number of checked and total checkbox blocks of the specified card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardProgress'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card progress"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardProgress", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	progress, err := a.app.GetCardProgress(card.BoardID, card.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardProgress",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Int("checked", progress.Checked),
+		mlog.Int("total", progress.Total),
+	)
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDuplicateCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/duplicate duplicateCard
+	//
+	// Duplicates a card with its content in the same board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to duplicate card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "duplicateCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	newCard, err := a.app.DuplicateCard(card.ID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DuplicateCard",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("newCardID", newCard.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newCard)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("newCardID", newCard.ID)
+	auditRec.Success()
+}
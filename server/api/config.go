@@ -5,11 +5,13 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
 )
 
 func (a *API) registerConfigRoutes(r *mux.Router) {
 	// Config APIs
 	r.HandleFunc("/clientConfig", a.getClientConfig).Methods("GET")
+	r.HandleFunc("/version", a.handleGetVersion).Methods("GET")
 }
 
 func (a *API) getClientConfig(w http.ResponseWriter, r *http.Request) {
@@ -39,3 +41,29 @@ func (a *API) getClientConfig(w http.ResponseWriter, r *http.Request) {
 	}
 	jsonBytesResponse(w, http.StatusOK, configData)
 }
+
+func (a *API) handleGetVersion(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /version getVersion
+	//
+	// Returns the version and build information of the server
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/VersionInfo"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	data, err := json.Marshal(model.GetVersionInfo())
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	jsonBytesResponse(w, http.StatusOK, data)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"net"
 	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 type contextKey int
@@ -11,6 +14,7 @@ type contextKey int
 const (
 	httpConnContextKey contextKey = iota
 	sessionContextKey
+	boardAPIKeyContextKey
 )
 
 // SetContextConn stores the connection in the request context.
@@ -27,3 +31,15 @@ func GetContextConn(r *http.Request) net.Conn {
 
 	return value.(net.Conn)
 }
+
+// getRequestID returns the ID assigned to the request, if any.
+func getRequestID(r *http.Request) string {
+	return utils.RequestIDFromContext(r.Context())
+}
+
+// getBoardAPIKey returns the board API key the request was authenticated
+// with, if any.
+func getBoardAPIKey(r *http.Request) *model.BoardAPIKey {
+	key, _ := r.Context().Value(boardAPIKeyContextKey).(*model.BoardAPIKey)
+	return key
+}
@@ -5,10 +5,12 @@ import (
 	"io"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 
@@ -72,6 +74,8 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 	//     description: success
 	//   '404':
 	//     description: file not found
+	//   '503':
+	//     description: files storage unavailable
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -93,6 +97,10 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !a.checkFilesBackend(w, r) {
+		return
+	}
+
 	board, err := a.app.GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
@@ -187,6 +195,8 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/FileUploadResponse"
 	//   '404':
 	//     description: board not found
+	//   '503':
+	//     description: too many concurrent uploads or files storage unavailable
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -201,12 +211,26 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !a.checkFilesBackend(w, r) {
+		return
+	}
+
 	board, err := a.app.GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
 
+	releaseUploadSlot, err := a.app.AcquireUploadSlot(r.Context())
+	if err != nil {
+		if model.IsErrServiceUnavailable(err) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(app.UploadQueueTimeout.Seconds())))
+		}
+		a.errorResponse(w, r, err)
+		return
+	}
+	defer releaseUploadSlot()
+
 	if a.app.GetConfig().MaxFileSize > 0 {
 		r.Body = http.MaxBytesReader(w, r.Body, a.app.GetConfig().MaxFileSize)
 	}
@@ -249,3 +273,17 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("fileID", fileID)
 	auditRec.Success()
 }
+
+// checkFilesBackend writes a 503 response and returns false if the
+// files storage is unavailable.
+func (a *API) checkFilesBackend(w http.ResponseWriter, r *http.Request) bool {
+	if a.app.IsFilesBackendAvailable() {
+		return true
+	}
+
+	if interval := a.app.GetConfig().FilesBackendCheckInterval; interval > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(interval))
+	}
+	a.errorResponse(w, r, model.NewErrServiceUnavailable("the files storage is temporarily unavailable"))
+	return false
+}
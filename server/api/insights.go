@@ -109,8 +109,8 @@ func (a *API) handleTeamBoardsInsights(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	userLocation, _ := time.LoadLocation(userTimezone)
-	if userLocation == nil {
-		userLocation = time.Now().UTC().Location()
+	if userTimezone == "" || userLocation == nil {
+		userLocation = a.app.ServerLocation()
 	}
 	// get unix time for duration
 	startTime := mmModel.StartOfDayForTimeRange(timeRange, userLocation)
@@ -222,8 +222,8 @@ func (a *API) handleUserBoardsInsights(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	userLocation, _ := time.LoadLocation(userTimezone)
-	if userLocation == nil {
-		userLocation = time.Now().UTC().Location()
+	if userTimezone == "" || userLocation == nil {
+		userLocation = a.app.ServerLocation()
 	}
 	// get unix time for duration
 	startTime := mmModel.StartOfDayForTimeRange(timeRange, userLocation)
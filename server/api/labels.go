@@ -0,0 +1,333 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerLabelsRoutes(r *mux.Router) {
+	// Labels APIs
+	r.HandleFunc("/boards/{boardID}/labels", a.sessionRequired(a.handleGetLabels)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/labels", a.sessionRequired(a.handleCreateLabel)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/labels/{labelID}", a.sessionRequired(a.handlePatchLabel)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/labels/{labelID}", a.sessionRequired(a.handleDeleteLabel)).Methods("DELETE")
+}
+
+func (a *API) handleGetLabels(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/labels getLabels
+	//
+	// Returns the labels of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Label"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch labels"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getLabels", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	labels, err := a.app.GetLabels(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetLabels",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("label_count", len(labels)),
+	)
+
+	data, err := json.Marshal(labels)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateLabel(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/labels createLabel
+	//
+	// Creates a new label in a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the label to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Label"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Label'
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var label *model.Label
+	if err = json.Unmarshal(requestBody, &label); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if label == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid label"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createLabel", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newLabel, err := a.app.CreateLabel(boardID, label, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateLabel",
+		mlog.String("boardID", boardID),
+		mlog.String("labelID", newLabel.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newLabel)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("labelID", newLabel.ID)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchLabel(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/labels/{labelID} patchLabel
+	//
+	// Renames or recolors a label of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: labelID
+	//   in: path
+	//   description: Label ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: label patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/LabelPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Label'
+	//   '404':
+	//     description: board or label not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	labelID := vars["labelID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.LabelPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid label patch"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchLabel", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("labelID", labelID)
+
+	label, err := a.app.PatchLabel(boardID, labelID, patch, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchLabel",
+		mlog.String("boardID", boardID),
+		mlog.String("labelID", labelID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(label)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteLabel(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/labels/{labelID} deleteLabel
+	//
+	// Deletes a label from a board and from all its cards
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: labelID
+	//   in: path
+	//   description: Label ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board or label not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	labelID := vars["labelID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteLabel", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("labelID", labelID)
+
+	if err := a.app.DeleteLabel(boardID, labelID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteLabel",
+		mlog.String("boardID", boardID),
+		mlog.String("labelID", labelID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
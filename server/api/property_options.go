@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerPropertyOptionsRoutes(r *mux.Router) {
+	// Property options APIs
+	r.HandleFunc("/boards/{boardID}/properties/{propertyID}/options/order", a.sessionRequired(a.handleReorderPropertyOptions)).Methods("PUT")
+}
+
+func (a *API) handleReorderPropertyOptions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/properties/{propertyID}/options/order reorderPropertyOptions
+	//
+	// Sets the order of the options of a card property, which is the
+	// order of the columns of the views grouped by it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the IDs of all the property options, in the new order
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   '404':
+	//     description: board or property not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var optionIDs []string
+	if err = json.Unmarshal(requestBody, &optionIDs); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "reorderPropertyOptions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+
+	board, err := a.app.ReorderPropertyOptions(boardID, propertyID, optionIDs, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ReorderPropertyOptions",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", propertyID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerPropertyVisibilityRoutes(r *mux.Router) {
+	// Property visibility rules APIs
+	r.HandleFunc("/boards/{boardID}/properties/visibility-rules", a.sessionRequired(a.handleGetPropertyVisibilityRules)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/properties/{propertyID}/visibility-rule", a.sessionRequired(a.handleSetPropertyVisibilityRule)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/properties/{propertyID}/visibility-rule", a.sessionRequired(a.handleDeletePropertyVisibilityRule)).Methods("DELETE")
+}
+
+func (a *API) handleGetPropertyVisibilityRules(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/properties/visibility-rules getPropertyVisibilityRules
+	//
+	// Returns the visibility rules of the card properties of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/PropertyVisibilityRule"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getPropertyVisibilityRules", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	rules, err := a.app.GetPropertyVisibilityRules(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetPropertyVisibilityRules",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("rule_count", len(rules)),
+	)
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleSetPropertyVisibilityRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/properties/{propertyID}/visibility-rule setPropertyVisibilityRule
+	//
+	// Sets the visibility rule of a card property, replacing the
+	// existing one
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the rule, the propertyId is taken from the path
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/PropertyVisibilityRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/PropertyVisibilityRule'
+	//   '400':
+	//     description: the rule references missing properties or options, or creates a cycle
+	//   '404':
+	//     description: board or property not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var rule *model.PropertyVisibilityRule
+	if err = json.Unmarshal(requestBody, &rule); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if rule == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid visibility rule"))
+		return
+	}
+	rule.PropertyID = propertyID
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setPropertyVisibilityRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+	auditRec.AddMeta("dependsOn", rule.DependsOn)
+
+	rule, err = a.app.SetPropertyVisibilityRule(boardID, rule, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetPropertyVisibilityRule",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", propertyID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeletePropertyVisibilityRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/properties/{propertyID}/visibility-rule deletePropertyVisibilityRule
+	//
+	// Removes the visibility rule of a card property, so it's always
+	// visible
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board or property not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deletePropertyVisibilityRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+
+	if err := a.app.DeletePropertyVisibilityRule(boardID, propertyID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeletePropertyVisibilityRule",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", propertyID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
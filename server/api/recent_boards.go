@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerRecentBoardsRoutes(r *mux.Router) {
+	// Recent boards APIs
+	r.HandleFunc("/users/me/recent-boards", a.sessionRequired(a.handleGetRecentBoards)).Methods("GET")
+	r.HandleFunc("/users/me/recent-boards/{boardID}", a.sessionRequired(a.handleAddRecentBoard)).Methods("POST")
+}
+
+func (a *API) handleGetRecentBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/recent-boards getRecentBoards
+	//
+	// Returns the boards recently opened by the current user, the most
+	// recently opened first
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Board"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "getRecentBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	boards, err := a.app.GetRecentBoards(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the user may have lost access to a board since they opened it
+	accessibleBoards := make([]*model.Board, 0, len(boards))
+	for _, board := range boards {
+		if a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+			accessibleBoards = append(accessibleBoards, board)
+		}
+	}
+
+	a.logger.Debug("GetRecentBoards",
+		mlog.String("userID", userID),
+		mlog.Int("boardsCount", len(accessibleBoards)),
+	)
+
+	data, err := json.Marshal(accessibleBoards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardsCount", len(accessibleBoards))
+	auditRec.Success()
+}
+
+func (a *API) handleAddRecentBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/recent-boards/{boardID} addRecentBoard
+	//
+	// Records that the current user opened a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "addRecentBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.app.AddRecentBoard(userID, boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AddRecentBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerRollupRoutes(r *mux.Router) {
+	// Rollup APIs
+	r.HandleFunc("/boards/{boardID}/rollup", a.sessionRequired(a.handleGetBoardRollup)).Methods("GET")
+}
+
+func (a *API) handleGetBoardRollup(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/rollup getBoardRollup
+	//
+	// Aggregates the cards of a board, optionally grouped by a property
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: groupBy
+	//   in: query
+	//   description: ID of the select, person or checkbox property that groups the cards
+	//   required: false
+	//   type: string
+	// - name: agg
+	//   in: query
+	//   description: The aggregation, one of count, sum or avg
+	//   required: true
+	//   type: string
+	// - name: of
+	//   in: query
+	//   description: ID of the number property aggregated by sum and avg
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/RollupGroup"
+	//   '400':
+	//     description: invalid aggregation or property
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	query := r.URL.Query()
+
+	opts := model.QueryRollupOptions{
+		GroupBy:     query.Get("groupBy"),
+		Aggregation: query.Get("agg"),
+		Of:          query.Get("of"),
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardRollup", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("aggregation", opts.Aggregation)
+
+	groups, err := a.app.GetBoardRollup(boardID, opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardRollup",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.String("aggregation", opts.Aggregation),
+		mlog.Int("group_count", len(groups)),
+	)
+
+	data, err := json.Marshal(groups)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
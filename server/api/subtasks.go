@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerSubtasksRoutes(r *mux.Router) {
+	// Subtasks APIs
+	r.HandleFunc("/cards/{cardID}/subtasks", a.sessionRequired(a.handleGetSubtaskRollup)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/subtask-rule", a.sessionRequired(a.handleSetSubtaskRule)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/subtask-rule", a.sessionRequired(a.handleDeleteSubtaskRule)).Methods("DELETE")
+}
+
+func (a *API) handleGetSubtaskRollup(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/subtasks getSubtaskRollup
+	//
+	// Returns the subtasks of the specified card and how many of them
+	// are completed, following the subtask rule of the board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/SubtaskRollup'
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card subtasks"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getSubtaskRollup", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	rollup, err := a.app.GetSubtaskRollup(card.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetSubtaskRollup",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Int("completed", rollup.Completed),
+		mlog.Int("total", rollup.Total),
+	)
+
+	data, err := json.Marshal(rollup)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleSetSubtaskRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/subtask-rule setSubtaskRule
+	//
+	// Sets the subtask rule of a board, replacing the existing one
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the subtask rule
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SubtaskRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/SubtaskRule'
+	//   '400':
+	//     description: the rule references missing properties or options
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var rule *model.SubtaskRule
+	if err = json.Unmarshal(requestBody, &rule); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if rule == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid subtask rule"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setSubtaskRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("completedPropertyID", rule.CompletedPropertyID)
+	auditRec.AddMeta("parentPropertyID", rule.ParentPropertyID)
+
+	rule, err = a.app.SetSubtaskRule(boardID, rule, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetSubtaskRule",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteSubtaskRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/subtask-rule deleteSubtaskRule
+	//
+	// Removes the subtask rule of a board, so its subtasks are never
+	// completed
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modifying board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteSubtaskRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.app.DeleteSubtaskRule(boardID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteSubtaskRule",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
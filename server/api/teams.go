@@ -8,6 +8,8 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 func (a *API) registerTeamsRoutes(r *mux.Router) {
@@ -16,6 +18,7 @@ func (a *API) registerTeamsRoutes(r *mux.Router) {
 	r.HandleFunc("/teams/{teamID}", a.sessionRequired(a.handleGetTeam)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/users", a.sessionRequired(a.handleGetTeamUsers)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeam)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/featureflags", a.sessionRequired(a.handleGetTeamFeatureFlags)).Methods("GET")
 }
 
 func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
@@ -257,3 +260,60 @@ func (a *API) handleGetTeamUsers(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("userCount", len(users))
 	auditRec.Success()
 }
+
+func (a *API) handleGetTeamFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/featureflags getTeamFeatureFlags
+	//
+	// Returns the feature flags of a team, falling back to the instance
+	// defaults for the flags that are not set for the team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: object
+	//       additionalProperties:
+	//         type: string
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	flags, err := a.app.GetFeatureFlags(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetTeamFeatureFlags",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(flags)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
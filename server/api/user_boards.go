@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerUserBoardsRoutes(r *mux.Router) {
+	// User boards APIs
+	r.HandleFunc("/users/me/boards", a.sessionRequired(a.handleGetMyBoards)).Methods("GET")
+}
+
+func (a *API) handleGetMyBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/boards getMyBoards
+	//
+	// Returns the boards the current user can access on every team, each
+	// one with the title of its team.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: filter
+	//   in: query
+	//   description: Restricts the boards to the "favorites" or to the "recent" ones
+	//   required: false
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of recent boards to return (default=20, max=100)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/UserBoard"
+	//   '400':
+	//     description: invalid filter or limit
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	query := r.URL.Query()
+	filter := query.Get("filter")
+
+	if err := model.IsValidUserBoardsFilter(filter); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	limit := 0
+	if filter == model.UserBoardsFilterRecent {
+		limit = model.DefaultRecentUserBoards
+		if strLimit := query.Get("limit"); strLimit != "" {
+			var err error
+			limit, err = strconv.Atoi(strLimit)
+			if err != nil || limit < 1 || limit > model.MaxRecentUserBoards {
+				message := fmt.Sprintf("invalid `limit` parameter, must be between 1 and %d", model.MaxRecentUserBoards)
+				a.errorResponse(w, r, model.NewErrBadRequest(message))
+				return
+			}
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getMyBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("filter", filter)
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	boards, err := a.app.GetBoardsForUserInAllTeams(userID, filter, limit, !isGuest)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetMyBoards",
+		mlog.String("userID", userID),
+		mlog.String("filter", filter),
+		mlog.Int("boardsCount", len(boards)),
+	)
+
+	data, err := json.Marshal(boards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardsCount", len(boards))
+	auditRec.Success()
+}
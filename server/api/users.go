@@ -9,6 +9,8 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/utils"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
 )
 
 func (a *API) registerUsersRoutes(r *mux.Router) {
@@ -102,18 +104,25 @@ func (a *API) handleGetUsersList(w http.ResponseWriter, r *http.Request) {
 func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /users/me getMe
 	//
-	// Returns the currently logged-in user
+	// Returns the currently logged-in user with their teams and,
+	// optionally, their effective role and permissions on a board
 	//
 	// ---
 	// produces:
 	// - application/json
+	// parameters:
+	// - name: board_id
+	//   in: query
+	//   description: Board to include the effective permissions of
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/User"
+	//       "$ref": "#/definitions/UserWithPermissions"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -146,7 +155,29 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	userData, err := json.Marshal(user)
+	teams, err := a.app.GetTeamsForUser(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	me := &model.UserWithPermissions{
+		User:  user,
+		Teams: teams,
+	}
+
+	// the permissions are checked one by one with the same service as
+	// the other endpoints, so they always match what the user can do. A
+	// board that doesn't exist gets no permissions, like one the user
+	// can't access
+	if boardID := r.URL.Query().Get("board_id"); boardID != "" {
+		me.Board = model.NewUserBoardPermissions(boardID, func(permission *mmModel.Permission) bool {
+			return a.permissions.HasPermissionToBoard(userID, boardID, permission)
+		})
+		auditRec.AddMeta("boardID", boardID)
+	}
+
+	userData, err := json.Marshal(me)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
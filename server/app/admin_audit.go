@@ -0,0 +1,24 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// RecordAdminAction adds an entry to the admin audit log.
+func (a *App) RecordAdminAction(actor, action, target string) error {
+	entry := &model.AdminAuditEntry{
+		ID:       utils.NewID(utils.IDTypeNone),
+		Actor:    actor,
+		Action:   action,
+		Target:   target,
+		CreateAt: utils.GetMillis(),
+	}
+	return a.store.InsertAdminAuditEntry(entry)
+}
+
+// GetAdminAuditEntries returns the admin audit log entries matching the
+// options, the most recent first.
+func (a *App) GetAdminAuditEntries(opts model.QueryAdminAuditOptions) ([]*model.AdminAuditEntry, error) {
+	return a.store.GetAdminAuditEntries(opts)
+}
@@ -0,0 +1,19 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// SendAdminNotice broadcasts an admin notice to the connected clients of
+// its team, or to all of them if it has no team.
+func (a *App) SendAdminNotice(notice *model.AdminNotice) error {
+	if err := notice.IsValid(); err != nil {
+		return err
+	}
+	if notice.Severity == "" {
+		notice.Severity = model.AdminNoticeInfo
+	}
+
+	a.wsAdapter.BroadcastAdminNotice(notice)
+	return nil
+}
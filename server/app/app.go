@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
@@ -52,6 +53,7 @@ type Services struct {
 	Permissions      permissions.PermissionsService
 	SkipTemplateInit bool
 	ServicesAPI      servicesAPI
+	BlockHooks       []BlockHook
 }
 
 type App struct {
@@ -66,9 +68,31 @@ type App struct {
 	logger              mlog.LoggerIFace
 	blockChangeNotifier *utils.CallbackQueue
 	servicesAPI         servicesAPI
+	blockHooks          []BlockHook
 
 	cardLimitMux sync.RWMutex
 	cardLimit    int
+
+	readOnlyMux sync.RWMutex
+
+	location *time.Location
+
+	uploadSlots chan struct{}
+
+	fileContentsMux sync.Mutex
+
+	blockTypes *blockTypeRegistry
+
+	commentLimiter *utils.RateLimiter
+
+	activeUsersMux sync.Mutex
+	activeUsers    *model.ActiveUsersStats
+
+	trustedAuthMux  sync.RWMutex
+	trustedAuth     trustedAuthSettings
+	trustedUsersMux sync.Mutex
+
+	userBoardViews *userBoardViewWriter
 }
 
 func (a *App) SetConfig(config *config.Configuration) {
@@ -79,6 +103,47 @@ func (a *App) GetConfig() *config.Configuration {
 	return a.config
 }
 
+// IsReadOnlyMode returns true if the server is in read-only
+// maintenance mode and mutating operations should be rejected.
+func (a *App) IsReadOnlyMode() bool {
+	a.readOnlyMux.RLock()
+	defer a.readOnlyMux.RUnlock()
+	return a.config.ReadOnlyMode
+}
+
+// SetReadOnlyMode enables or disables the read-only maintenance mode
+// and notifies the connected clients so they can show a notice.
+func (a *App) SetReadOnlyMode(enabled bool) {
+	a.readOnlyMux.Lock()
+	changed := a.config.ReadOnlyMode != enabled
+	a.config.ReadOnlyMode = enabled
+	a.readOnlyMux.Unlock()
+
+	if !changed {
+		return
+	}
+
+	a.logger.Info("Read-only mode changed", mlog.Bool("enabled", enabled))
+	a.wsAdapter.BroadcastConfigChange(*a.GetClientConfig())
+}
+
+// ServerLocation returns the timezone used by the server to interpret
+// dates and day boundaries.
+func (a *App) ServerLocation() *time.Location {
+	return a.location
+}
+
+// loadServerLocation returns the location configured as the server
+// timezone, falling back to UTC if it is empty or invalid.
+func loadServerLocation(timezone string, logger mlog.LoggerIFace) *time.Location {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		logger.Error("Invalid server timezone, using UTC", mlog.String("timezone", timezone), mlog.Err(err))
+		return time.UTC
+	}
+	return location
+}
+
 func New(config *config.Configuration, wsAdapter ws.Adapter, services Services) *App {
 	app := &App{
 		config:              config,
@@ -92,6 +157,16 @@ func New(config *config.Configuration, wsAdapter ws.Adapter, services Services)
 		logger:              services.Logger,
 		blockChangeNotifier: utils.NewCallbackQueue("blockChangeNotifier", blockChangeNotifierQueueSize, blockChangeNotifierPoolSize, services.Logger),
 		servicesAPI:         services.ServicesAPI,
+		blockHooks:          services.BlockHooks,
+		location:            loadServerLocation(config.ServerTimezone, services.Logger),
+		uploadSlots:         newUploadSlots(config.MaxConcurrentUploads),
+		blockTypes:          newBlockTypeRegistry(config.CustomBlockTypes, services.Logger),
+		commentLimiter:      utils.NewRateLimiter(config.CommentRateLimit, commentRateWindow),
+		userBoardViews:      newUserBoardViewWriter(services.Store, time.Duration(config.UserBoardViewDebounceMillis)*time.Millisecond, services.Logger),
+	}
+	wsAdapter.SetUserDisconnectHandler(app.userBoardViews.flushUser)
+	if err := app.SetTrustedAuth(config.TrustedAuthHeader, config.TrustedAuthEmailHeader, config.TrustedProxies); err != nil {
+		services.Logger.Error("Invalid trusted proxies, the authentication header is ignored", mlog.Err(err))
 	}
 	app.initialize(services.SkipTemplateInit)
 	return app
@@ -2,9 +2,12 @@ package app
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 func TestSetConfig(t *testing.T) {
@@ -20,3 +23,34 @@ func TestSetConfig(t *testing.T) {
 		require.True(t, th.App.config.EnablePublicSharedBoards)
 	})
 }
+
+func TestSetReadOnlyMode(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	require.False(t, th.App.IsReadOnlyMode())
+
+	th.App.SetReadOnlyMode(true)
+	require.True(t, th.App.IsReadOnlyMode())
+	require.True(t, th.App.GetClientConfig().ReadOnlyMode)
+
+	th.App.SetReadOnlyMode(false)
+	require.False(t, th.App.IsReadOnlyMode())
+}
+
+func TestLoadServerLocation(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	t.Run("empty timezone defaults to UTC", func(t *testing.T) {
+		require.Equal(t, time.UTC, loadServerLocation("", logger))
+	})
+
+	t.Run("valid timezone", func(t *testing.T) {
+		location := loadServerLocation("Europe/Madrid", logger)
+		require.Equal(t, "Europe/Madrid", location.String())
+	})
+
+	t.Run("invalid timezone falls back to UTC", func(t *testing.T) {
+		require.Equal(t, time.UTC, loadServerLocation("Not/AZone", logger))
+	})
+}
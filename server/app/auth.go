@@ -1,6 +1,10 @@
 package app
 
 import (
+	"net"
+	"net/http"
+	"strings"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/utils"
@@ -33,22 +37,65 @@ func (a *App) GetRegisteredUserCount() (int, error) {
 	return a.store.GetRegisteredUserCount()
 }
 
+// GetActiveUsersStats returns the number of daily, weekly and monthly
+// active users. As counting them scans the sessions, the stats are
+// cached for the configured refresh interval.
+func (a *App) GetActiveUsersStats() (*model.ActiveUsersStats, error) {
+	a.activeUsersMux.Lock()
+	defer a.activeUsersMux.Unlock()
+
+	refreshInterval := utils.SecondsToMillis(int64(a.config.ActiveUsersStatsRefreshInterval))
+	if a.activeUsers != nil && utils.GetMillis()-a.activeUsers.UpdateAt < refreshInterval {
+		stats := *a.activeUsers
+		return &stats, nil
+	}
+
+	daySeconds := int64(SecondsPerMinute * MinutesPerHour * HoursPerDay)
+	counts, err := a.store.GetActiveUserCounts([]int64{
+		daySeconds,
+		daySeconds * DaysPerWeek,
+		daySeconds * DaysPerMonth,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.activeUsers = &model.ActiveUsersStats{
+		DailyActiveUsers:   counts[0],
+		WeeklyActiveUsers:  counts[1],
+		MonthlyActiveUsers: counts[2],
+		UpdateAt:           utils.GetMillis(),
+	}
+
+	stats := *a.activeUsers
+	return &stats, nil
+}
+
 // GetDailyActiveUsers returns the number of daily active users.
 func (a *App) GetDailyActiveUsers() (int, error) {
-	secondsAgo := int64(SecondsPerMinute * MinutesPerHour * HoursPerDay)
-	return a.store.GetActiveUserCount(secondsAgo)
+	stats, err := a.GetActiveUsersStats()
+	if err != nil {
+		return 0, err
+	}
+	return stats.DailyActiveUsers, nil
 }
 
 // GetWeeklyActiveUsers returns the number of weekly active users.
 func (a *App) GetWeeklyActiveUsers() (int, error) {
-	secondsAgo := int64(SecondsPerMinute * MinutesPerHour * HoursPerDay * DaysPerWeek)
-	return a.store.GetActiveUserCount(secondsAgo)
+	stats, err := a.GetActiveUsersStats()
+	if err != nil {
+		return 0, err
+	}
+	return stats.WeeklyActiveUsers, nil
 }
 
 // GetMonthlyActiveUsers returns the number of monthly active users.
 func (a *App) GetMonthlyActiveUsers() (int, error) {
-	secondsAgo := int64(SecondsPerMinute * MinutesPerHour * HoursPerDay * DaysPerMonth)
-	return a.store.GetActiveUserCount(secondsAgo)
+	stats, err := a.GetActiveUsersStats()
+	if err != nil {
+		return 0, err
+	}
+	return stats.MonthlyActiveUsers, nil
 }
 
 // GetUser gets an existing active user by id.
@@ -131,6 +178,46 @@ func (a *App) Login(username, email, password, mfaToken string) (string, error)
 	return session.Token, nil
 }
 
+// ImpersonateUser creates a session of the user with the given username
+// for an admin to see what the user sees. The session is marked with the
+// admin that requested it and expires after the configured lifetime,
+// even if it's kept active.
+func (a *App) ImpersonateUser(actor, username string) (*model.Session, error) {
+	lifetime := a.config.ImpersonationLifetime
+	if lifetime <= 0 {
+		return nil, model.NewErrNotImplemented("impersonation is disabled")
+	}
+
+	user, err := a.store.GetUserByUsername(username)
+	if model.IsErrNotFound(err) {
+		return nil, model.NewErrNotFound("user username=" + username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	authService := user.AuthService
+	if authService == "" {
+		authService = "native"
+	}
+
+	session := &model.Session{
+		ID:          utils.NewID(utils.IDTypeSession),
+		Token:       utils.NewID(utils.IDTypeToken),
+		UserID:      user.ID,
+		AuthService: authService,
+		Props: map[string]interface{}{
+			model.SessionPropImpersonatedBy: actor,
+			model.SessionPropExpiresAt:      utils.GetMillis() + utils.SecondsToMillis(lifetime),
+		},
+	}
+	if err = a.store.CreateSession(session); err != nil {
+		return nil, errors.Wrap(err, "unable to create session")
+	}
+
+	return session, nil
+}
+
 // Logout invalidates the user session.
 func (a *App) Logout(sessionID string) error {
 	err := a.store.DeleteSession(sessionID)
@@ -145,15 +232,31 @@ func (a *App) Logout(sessionID string) error {
 
 // RegisterUser creates a new user if the provided data is valid.
 func (a *App) RegisterUser(username, email, password string) error {
+	user, err := a.newRegisteredUser(username, email, password)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.store.CreateUser(user)
+	if err != nil {
+		return errors.Wrap(err, "Unable to create the new user")
+	}
+
+	return nil
+}
+
+// newRegisteredUser checks that the username and email are not taken
+// and that the password is valid, and returns the user to create.
+func (a *App) newRegisteredUser(username, email, password string) (*model.User, error) {
 	var user *model.User
 	if username != "" {
 		var err error
 		user, err = a.store.GetUserByUsername(username)
 		if err != nil && !model.IsErrNotFound(err) {
-			return err
+			return nil, err
 		}
 		if user != nil {
-			return errors.New("The username already exists")
+			return nil, errors.New("The username already exists")
 		}
 	}
 
@@ -161,10 +264,10 @@ func (a *App) RegisterUser(username, email, password string) error {
 		var err error
 		user, err = a.store.GetUserByEmail(email)
 		if err != nil && !model.IsErrNotFound(err) {
-			return err
+			return nil, err
 		}
 		if user != nil {
-			return errors.New("The email already exists")
+			return nil, errors.New("The email already exists")
 		}
 	}
 
@@ -175,10 +278,10 @@ func (a *App) RegisterUser(username, email, password string) error {
 
 	err := auth.IsPasswordValid(password, passwordSettings)
 	if err != nil {
-		return errors.Wrap(err, "Invalid password")
+		return nil, errors.Wrap(err, "Invalid password")
 	}
 
-	_, err = a.store.CreateUser(&model.User{
+	return &model.User{
 		ID:          utils.NewID(utils.IDTypeUser),
 		Username:    username,
 		Email:       email,
@@ -186,14 +289,134 @@ func (a *App) RegisterUser(username, email, password string) error {
 		MfaSecret:   "",
 		AuthService: a.config.AuthMode,
 		AuthData:    "",
-	})
+	}, nil
+}
+
+// trustedAuthSettings are the settings of the header-based
+// authentication. They can be reloaded while the server runs.
+type trustedAuthSettings struct {
+	header      string
+	emailHeader string
+	proxies     []*net.IPNet
+}
+
+// SetTrustedAuth applies the settings of the header-based authentication.
+// If any of the proxies is invalid, the previous settings are kept.
+func (a *App) SetTrustedAuth(header, emailHeader string, proxies []string) error {
+	networks, err := auth.ParseTrustedProxies(proxies)
 	if err != nil {
-		return errors.Wrap(err, "Unable to create the new user")
+		return err
 	}
 
+	a.trustedAuthMux.Lock()
+	defer a.trustedAuthMux.Unlock()
+	a.trustedAuth = trustedAuthSettings{
+		header:      header,
+		emailHeader: emailHeader,
+		proxies:     networks,
+	}
 	return nil
 }
 
+func (a *App) getTrustedAuth() trustedAuthSettings {
+	a.trustedAuthMux.RLock()
+	defer a.trustedAuthMux.RUnlock()
+	return a.trustedAuth
+}
+
+// IsTrustedAuthRequest returns true if the header-based authentication is
+// enabled and the request comes from one of the trusted proxies.
+func (a *App) IsTrustedAuthRequest(remoteAddr string) bool {
+	settings := a.getTrustedAuth()
+	if settings.header == "" {
+		return false
+	}
+	return auth.IsTrustedProxy(remoteAddr, settings.proxies)
+}
+
+// GetTrustedAuthIdentity returns the username and email set in the
+// authentication headers, only if the request comes straight from one of
+// the trusted proxies. Otherwise the headers can be spoofed, so they're
+// ignored and the username is empty.
+func (a *App) GetTrustedAuthIdentity(r *http.Request) (string, string) {
+	settings := a.getTrustedAuth()
+	if settings.header == "" || !auth.IsTrustedProxy(r.RemoteAddr, settings.proxies) {
+		return "", ""
+	}
+
+	username := strings.TrimSpace(r.Header.Get(settings.header))
+	email := ""
+	if settings.emailHeader != "" {
+		email = strings.TrimSpace(r.Header.Get(settings.emailHeader))
+	}
+	return username, email
+}
+
+// GetTrustedAuthUserID returns the ID of the active user authenticated
+// by a trusted proxy, or an empty string if the request isn't
+// authenticated by one. The websocket server uses it for the upgrade
+// requests, which don't carry a stored session.
+func (a *App) GetTrustedAuthUserID(r *http.Request) string {
+	username, email := a.GetTrustedAuthIdentity(r)
+	if username == "" {
+		return ""
+	}
+
+	user, err := a.GetOrCreateTrustedUser(username, email)
+	if err != nil {
+		a.logger.Error("Unable to authenticate the trusted user", mlog.String("username", username), mlog.Err(err))
+		return ""
+	}
+	if user.DeleteAt != 0 {
+		return ""
+	}
+	return user.ID
+}
+
+// GetOrCreateTrustedUser returns the user authenticated by a trusted proxy,
+// creating it on its first request. The user has no password, so it can
+// only log in through the proxy. The email set by the proxy is saved if
+// the user has none yet.
+func (a *App) GetOrCreateTrustedUser(username, email string) (*model.User, error) {
+	a.trustedUsersMux.Lock()
+	defer a.trustedUsersMux.Unlock()
+
+	if email != "" && !auth.IsEmailValid(email) {
+		a.logger.Warn("Ignoring the invalid email set by the trusted proxy",
+			mlog.String("username", username),
+		)
+		email = ""
+	}
+
+	user, err := a.store.GetUserByUsername(username)
+	if err == nil {
+		if user.Email != "" || email == "" {
+			return user, nil
+		}
+		user.Email = email
+		return a.store.UpdateUser(user)
+	}
+	if !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	user, err = a.store.CreateUser(&model.User{
+		ID:          utils.NewID(utils.IDTypeUser),
+		Username:    username,
+		Email:       email,
+		AuthService: a.config.AuthMode,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create the trusted user")
+	}
+
+	a.logger.Info("Created user authenticated by a trusted proxy",
+		mlog.String("userID", user.ID),
+		mlog.String("username", username),
+	)
+	return user, nil
+}
+
 func (a *App) UpdateUserPassword(username, password string) error {
 	err := a.store.UpdateUserPassword(username, auth.HashPassword(password))
 	if err != nil {
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -154,6 +156,164 @@ func TestUpdateUserPassword(t *testing.T) {
 	}
 }
 
+func TestImpersonateUser(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("disabled impersonation", func(t *testing.T) {
+		th.App.config.ImpersonationLifetime = 0
+
+		session, err := th.App.ImpersonateUser("local", "testUsername")
+		require.Error(t, err)
+		require.Nil(t, session)
+	})
+
+	th.App.config.ImpersonationLifetime = 60
+	defer func() { th.App.config.ImpersonationLifetime = 0 }()
+
+	t.Run("unknown user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("badUsername").Return(nil, model.NewErrNotFound("user"))
+
+		session, err := th.App.ImpersonateUser("local", "badUsername")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, session)
+	})
+
+	t.Run("impersonation session", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(mockUser, nil)
+		th.Store.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+		session, err := th.App.ImpersonateUser("local", "testUsername")
+		require.NoError(t, err)
+		require.Equal(t, mockUser.ID, session.UserID)
+		require.Equal(t, "local", session.ImpersonatedBy())
+		require.InDelta(t, utils.GetMillis()+60*1000, session.ExpiresAt(), 1000)
+	})
+}
+
+func TestGetOrCreateTrustedUser(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("existing user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(mockUser, nil)
+
+		user, err := th.App.GetOrCreateTrustedUser("testUsername", "")
+		require.NoError(t, err)
+		require.Equal(t, mockUser.ID, user.ID)
+	})
+
+	t.Run("new user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("newUsername").Return(nil, model.NewErrNotFound("user"))
+		th.Store.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user *model.User) (*model.User, error) {
+			require.NotEmpty(t, user.ID)
+			require.Equal(t, "newUsername", user.Username)
+			require.Equal(t, "new@example.com", user.Email)
+			require.Empty(t, user.Password)
+			return user, nil
+		})
+
+		user, err := th.App.GetOrCreateTrustedUser("newUsername", "new@example.com")
+		require.NoError(t, err)
+		require.Equal(t, "newUsername", user.Username)
+	})
+
+	t.Run("existing user without email", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("noEmail").Return(&model.User{ID: "user-id", Username: "noEmail"}, nil)
+		th.Store.EXPECT().UpdateUser(gomock.Any()).DoAndReturn(func(user *model.User) (*model.User, error) {
+			require.Equal(t, "user-id", user.ID)
+			require.Equal(t, "no-email@example.com", user.Email)
+			return user, nil
+		})
+
+		user, err := th.App.GetOrCreateTrustedUser("noEmail", "no-email@example.com")
+		require.NoError(t, err)
+		require.Equal(t, "no-email@example.com", user.Email)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("noEmail").Return(&model.User{ID: "user-id", Username: "noEmail"}, nil)
+
+		user, err := th.App.GetOrCreateTrustedUser("noEmail", "not an email")
+		require.NoError(t, err)
+		require.Empty(t, user.Email)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(nil, errors.New("store error"))
+
+		user, err := th.App.GetOrCreateTrustedUser("testUsername", "")
+		require.Error(t, err)
+		require.Nil(t, user)
+	})
+}
+
+func TestIsTrustedAuthRequest(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	require.NoError(t, th.App.SetTrustedAuth("", "", []string{"10.0.0.0/8"}))
+
+	t.Run("disabled", func(t *testing.T) {
+		require.False(t, th.App.IsTrustedAuthRequest("10.1.2.3:51234"))
+	})
+
+	require.NoError(t, th.App.SetTrustedAuth("X-Forwarded-User", "", []string{"10.0.0.0/8"}))
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		require.True(t, th.App.IsTrustedAuthRequest("10.1.2.3:51234"))
+	})
+
+	t.Run("untrusted address", func(t *testing.T) {
+		require.False(t, th.App.IsTrustedAuthRequest("192.168.1.10:51234"))
+	})
+
+	t.Run("invalid proxies keep the previous settings", func(t *testing.T) {
+		require.Error(t, th.App.SetTrustedAuth("X-Forwarded-User", "", []string{"not an address"}))
+		require.True(t, th.App.IsTrustedAuthRequest("10.1.2.3:51234"))
+	})
+
+	t.Run("reloaded proxies", func(t *testing.T) {
+		require.NoError(t, th.App.SetTrustedAuth("X-Forwarded-User", "", []string{"192.168.1.0/24"}))
+		require.False(t, th.App.IsTrustedAuthRequest("10.1.2.3:51234"))
+		require.True(t, th.App.IsTrustedAuthRequest("192.168.1.10:51234"))
+	})
+}
+
+func TestGetTrustedAuthIdentity(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	require.NoError(t, th.App.SetTrustedAuth("X-Forwarded-User", "X-Forwarded-Email", []string{"10.0.0.0/8"}))
+
+	newRequest := func(remoteAddr string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Forwarded-User", "testUsername")
+		r.Header.Set("X-Forwarded-Email", "test@example.com")
+		return r
+	}
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		username, email := th.App.GetTrustedAuthIdentity(newRequest("10.1.2.3:51234"))
+		require.Equal(t, "testUsername", username)
+		require.Equal(t, "test@example.com", email)
+	})
+
+	t.Run("untrusted address", func(t *testing.T) {
+		username, email := th.App.GetTrustedAuthIdentity(newRequest("192.168.1.10:51234"))
+		require.Empty(t, username)
+		require.Empty(t, email)
+		require.Empty(t, th.App.GetTrustedAuthUserID(newRequest("192.168.1.10:51234")))
+	})
+
+	t.Run("websocket user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(&model.User{ID: "user-id", Username: "testUsername", Email: "test@example.com"}, nil)
+
+		require.Equal(t, "user-id", th.App.GetTrustedAuthUserID(newRequest("10.1.2.3:51234")))
+	})
+}
+
 func TestChangePassword(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -186,3 +346,52 @@ func TestChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestGetActiveUsersStats(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	periods := []int64{
+		SecondsPerMinute * MinutesPerHour * HoursPerDay,
+		SecondsPerMinute * MinutesPerHour * HoursPerDay * DaysPerWeek,
+		SecondsPerMinute * MinutesPerHour * HoursPerDay * DaysPerMonth,
+	}
+
+	t.Run("stats are cached for the refresh interval", func(t *testing.T) {
+		th.App.config.ActiveUsersStatsRefreshInterval = 3600
+		th.App.activeUsers = nil
+		th.Store.EXPECT().GetActiveUserCounts(periods).Return([]int{1, 2, 3}, nil).Times(1)
+
+		stats, err := th.App.GetActiveUsersStats()
+		require.NoError(t, err)
+		require.Equal(t, 1, stats.DailyActiveUsers)
+		require.Equal(t, 2, stats.WeeklyActiveUsers)
+		require.Equal(t, 3, stats.MonthlyActiveUsers)
+
+		count, err := th.App.GetMonthlyActiveUsers()
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("stats are recomputed without refresh interval", func(t *testing.T) {
+		th.App.config.ActiveUsersStatsRefreshInterval = 0
+		th.App.activeUsers = nil
+		th.Store.EXPECT().GetActiveUserCounts(periods).Return([]int{4, 5, 6}, nil).Times(2)
+
+		count, err := th.App.GetDailyActiveUsers()
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+
+		count, err = th.App.GetWeeklyActiveUsers()
+		require.NoError(t, err)
+		require.Equal(t, 5, count)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		th.App.activeUsers = nil
+		th.Store.EXPECT().GetActiveUserCounts(periods).Return(nil, errors.New("db error"))
+
+		_, err := th.App.GetActiveUsersStats()
+		require.Error(t, err)
+	})
+}
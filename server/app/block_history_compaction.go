@@ -0,0 +1,34 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// CompactBlockHistory thins the history of the blocks following the
+// configured policy, returning the number of versions removed.
+func (a *App) CompactBlockHistory() (int64, error) {
+	if !a.config.EnableBlockHistoryCompaction {
+		return 0, nil
+	}
+
+	policy := &model.BlockHistoryCompactionPolicy{
+		KeepAll:    time.Duration(a.config.BlockHistoryKeepAllHours) * time.Hour,
+		KeepHourly: time.Duration(a.config.BlockHistoryKeepHourlyDays) * 24 * time.Hour,
+	}
+	deleted, err := a.store.CompactBlockHistory(policy, utils.GetMillis())
+	if err != nil {
+		return deleted, err
+	}
+
+	a.logger.Info("Compacted the block history",
+		mlog.Int64("deletedVersions", deleted),
+		mlog.Int("keepAllHours", a.config.BlockHistoryKeepAllHours),
+		mlog.Int("keepHourlyDays", a.config.BlockHistoryKeepHourlyDays),
+	)
+	return deleted, nil
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactBlockHistory(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.config.EnableBlockHistoryCompaction = false
+
+		deleted, err := th.App.CompactBlockHistory()
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+	})
+
+	t.Run("compacts with the configured policy", func(t *testing.T) {
+		th.App.config.EnableBlockHistoryCompaction = true
+		th.App.config.BlockHistoryKeepAllHours = 24
+		th.App.config.BlockHistoryKeepHourlyDays = 7
+		defer func() { th.App.config.EnableBlockHistoryCompaction = false }()
+
+		expectedPolicy := &model.BlockHistoryCompactionPolicy{KeepAll: 24 * time.Hour, KeepHourly: 7 * 24 * time.Hour}
+		th.Store.EXPECT().CompactBlockHistory(expectedPolicy, gomock.Any()).Return(int64(5), nil)
+
+		deleted, err := th.App.CompactBlockHistory()
+		require.NoError(t, err)
+		require.EqualValues(t, 5, deleted)
+	})
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// BlockHook receives the blocks created, updated and deleted, e.g. to
+// sync them to an external system. The hooks are registered when the
+// server is created and are called asynchronously once the change is
+// saved, so their errors are logged and don't fail the request. The
+// blocks are shared with the other hooks and must not be modified.
+type BlockHook interface {
+	OnBlockCreated(block *model.Block) error
+	OnBlockUpdated(block *model.Block, oldBlock *model.Block) error
+	OnBlockDeleted(block *model.Block) error
+}
+
+// blockCreated calls the hooks for a new block. The subtask rule of the
+// board is applied after the hooks of all the block events.
+func (a *App) blockCreated(block *model.Block) {
+	a.runBlockHooks("created", block, func(hook BlockHook) error {
+		return hook.OnBlockCreated(block)
+	})
+	a.applySubtaskRule(block)
+}
+
+// blockUpdated calls the hooks for an updated block. The old block is
+// nil if it's not known.
+func (a *App) blockUpdated(block, oldBlock *model.Block) {
+	a.runBlockHooks("updated", block, func(hook BlockHook) error {
+		return hook.OnBlockUpdated(block, oldBlock)
+	})
+	a.applySubtaskRule(block)
+}
+
+// blockDeleted calls the hooks for a deleted block.
+func (a *App) blockDeleted(block *model.Block) {
+	a.runBlockHooks("deleted", block, func(hook BlockHook) error {
+		return hook.OnBlockDeleted(block)
+	})
+	a.applySubtaskRule(block)
+}
+
+func (a *App) runBlockHooks(event string, block *model.Block, call func(hook BlockHook) error) {
+	for _, hook := range a.blockHooks {
+		if err := call(hook); err != nil {
+			a.logger.Error("Error running block hook",
+				mlog.String("hook", fmt.Sprintf("%T", hook)),
+				mlog.String("event", event),
+				mlog.String("block_id", block.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
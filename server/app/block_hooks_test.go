@@ -0,0 +1,53 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+type testBlockHook struct {
+	events []string
+	err    error
+}
+
+func (h *testBlockHook) OnBlockCreated(block *model.Block) error {
+	h.events = append(h.events, "created "+block.ID)
+	return h.err
+}
+
+func (h *testBlockHook) OnBlockUpdated(block *model.Block, oldBlock *model.Block) error {
+	h.events = append(h.events, "updated "+block.ID+" from "+oldBlock.Title)
+	return h.err
+}
+
+func (h *testBlockHook) OnBlockDeleted(block *model.Block) error {
+	h.events = append(h.events, "deleted "+block.ID)
+	return h.err
+}
+
+func TestBlockHooks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	failingHook := &testBlockHook{err: errors.New("hook error")}
+	hook := &testBlockHook{}
+	th.App.blockHooks = []BlockHook{failingHook, hook}
+
+	block := &model.Block{ID: "block-id", Title: "new title"}
+	th.App.blockCreated(block)
+	th.App.blockUpdated(block, &model.Block{ID: "block-id", Title: "old title"})
+	th.App.blockDeleted(block)
+
+	expected := []string{
+		"created block-id",
+		"updated block-id from old title",
+		"deleted block-id",
+	}
+	// the errors of a hook don't stop the other hooks
+	require.Equal(t, expected, failingHook.events)
+	require.Equal(t, expected, hook.events)
+}
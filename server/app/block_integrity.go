@@ -0,0 +1,331 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// blockIntegrityRepairBatchSize is the number of blocks repaired in each
+// transaction.
+const blockIntegrityRepairBatchSize = 100
+
+const (
+	blockVisiting = iota + 1
+	blockVisited
+)
+
+// CheckBoardBlockIntegrity looks for orphaned blocks, parent cycles and
+// card values referencing options that don't exist on a board. With an
+// orphanRepair mode the problems are also repaired: the orphans are
+// reparented to the board or deleted, the cycles are broken by
+// reparenting one of their blocks to the board and the dangling options
+// are removed from the cards.
+func (a *App) CheckBoardBlockIntegrity(boardID, orphanRepair string) (*model.BlockIntegrityReport, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.BlockIntegrityReport{Issues: []model.BlockIntegrityIssue{}}
+	if err = a.checkBoardBlockIntegrity(board, orphanRepair, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// CheckTeamBlockIntegrity runs the integrity check on every board of a
+// team, including the templates.
+func (a *App) CheckTeamBlockIntegrity(teamID, orphanRepair string) (*model.BlockIntegrityReport, error) {
+	boards, err := a.store.GetBoardsForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.BlockIntegrityReport{Issues: []model.BlockIntegrityIssue{}}
+	for _, board := range boards {
+		if err = a.checkBoardBlockIntegrity(board, orphanRepair, report); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (a *App) checkBoardBlockIntegrity(board *model.Board, orphanRepair string, report *model.BlockIntegrityReport) error {
+	if !model.IsValidOrphanRepair(orphanRepair) {
+		return model.NewErrInvalidField("repair", "must be reparent or delete")
+	}
+
+	blocks, err := a.store.GetBlocksForBoard(board.ID)
+	if err != nil {
+		return err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	issues := findBlockIntegrityIssues(board, blocks, schema)
+	report.BoardCount++
+	report.BlockCount += len(blocks)
+
+	if orphanRepair != "" && len(issues) > 0 {
+		if err = a.repairBlockIntegrityIssues(board, blocks, schema, issues, orphanRepair); err != nil {
+			return err
+		}
+	}
+
+	report.Issues = append(report.Issues, issues...)
+	return nil
+}
+
+// findBlockIntegrityIssues returns the problems of the blocks of a board.
+// The blocks whose parent is empty or the board itself are root blocks.
+func findBlockIntegrityIssues(board *model.Board, blocks []model.Block, schema model.PropSchema) []model.BlockIntegrityIssue {
+	blocksByID := make(map[string]*model.Block, len(blocks))
+	for i := range blocks {
+		blocksByID[blocks[i].ID] = &blocks[i]
+	}
+
+	isRoot := func(block *model.Block) bool {
+		return block.ParentID == "" || block.ParentID == board.ID
+	}
+
+	issues := []model.BlockIntegrityIssue{}
+	state := make(map[string]int, len(blocks))
+	for i := range blocks {
+		block := &blocks[i]
+		if !isRoot(block) && blocksByID[block.ParentID] == nil {
+			issues = append(issues, model.BlockIntegrityIssue{
+				Type:     model.BlockIntegrityOrphan,
+				BoardID:  board.ID,
+				BlockID:  block.ID,
+				ParentID: block.ParentID,
+			})
+		}
+
+		// walk up the ancestors, a block already in the path closes a
+		// cycle, which is reported once from the block it was found at
+		path := []*model.Block{}
+		for current := block; current != nil && state[current.ID] != blockVisited; {
+			if state[current.ID] == blockVisiting {
+				issues = append(issues, model.BlockIntegrityIssue{
+					Type:     model.BlockIntegrityCycle,
+					BoardID:  board.ID,
+					BlockID:  current.ID,
+					ParentID: current.ParentID,
+				})
+				break
+			}
+			state[current.ID] = blockVisiting
+			path = append(path, current)
+
+			if isRoot(current) {
+				break
+			}
+			current = blocksByID[current.ParentID]
+		}
+		for _, visited := range path {
+			state[visited.ID] = blockVisited
+		}
+
+		if block.Type == model.TypeCard {
+			issues = append(issues, findDanglingOptions(board.ID, block, schema)...)
+		}
+	}
+
+	return issues
+}
+
+// findDanglingOptions returns the values of the option properties of a
+// card that reference options the property doesn't have.
+func findDanglingOptions(boardID string, card *model.Block, schema model.PropSchema) []model.BlockIntegrityIssue {
+	issues := []model.BlockIntegrityIssue{}
+	properties, _ := card.Fields["properties"].(map[string]interface{})
+	for propertyID, value := range properties {
+		def, ok := schema[propertyID]
+		if !ok {
+			continue
+		}
+
+		for _, optionID := range danglingOptionIDs(def, value) {
+			issues = append(issues, model.BlockIntegrityIssue{
+				Type:       model.BlockIntegrityDanglingOption,
+				BoardID:    boardID,
+				BlockID:    card.ID,
+				PropertyID: propertyID,
+				OptionID:   optionID,
+			})
+		}
+	}
+	return issues
+}
+
+func danglingOptionIDs(def model.PropDef, value interface{}) []string {
+	optionIDs := []string{}
+	switch def.Type {
+	case "select":
+		if optionID, ok := value.(string); ok && optionID != "" {
+			optionIDs = append(optionIDs, optionID)
+		}
+	case "multiSelect", model.PropertyTypeLabel:
+		values, _ := value.([]interface{})
+		for _, v := range values {
+			if optionID, ok := v.(string); ok {
+				optionIDs = append(optionIDs, optionID)
+			}
+		}
+	}
+
+	dangling := []string{}
+	for _, optionID := range optionIDs {
+		if _, ok := def.Options[optionID]; !ok {
+			dangling = append(dangling, optionID)
+		}
+	}
+	return dangling
+}
+
+// repairBlockIntegrityIssues repairs the issues in batches, each one in
+// its own transaction, and logs every repair. The repair done is set on
+// the issues.
+func (a *App) repairBlockIntegrityIssues(board *model.Board, blocks []model.Block, schema model.PropSchema, issues []model.BlockIntegrityIssue, orphanRepair string) error {
+	blocksByID := make(map[string]*model.Block, len(blocks))
+	for i := range blocks {
+		blocksByID[blocks[i].ID] = &blocks[i]
+	}
+
+	toDelete := map[string]bool{}
+	patches := map[string]*model.BlockPatch{}
+	patchedIDs := []string{}
+	getPatch := func(blockID string) *model.BlockPatch {
+		patch, ok := patches[blockID]
+		if !ok {
+			patch = &model.BlockPatch{}
+			patches[blockID] = patch
+			patchedIDs = append(patchedIDs, blockID)
+		}
+		return patch
+	}
+
+	rootID := board.ID
+	for i := range issues {
+		issue := &issues[i]
+		switch issue.Type {
+		case model.BlockIntegrityOrphan:
+			if orphanRepair == model.OrphanRepairDelete {
+				toDelete[issue.BlockID] = true
+				issue.Repair = model.BlockIntegrityRepairDelete
+				continue
+			}
+			getPatch(issue.BlockID).ParentID = &rootID
+			issue.Repair = model.BlockIntegrityRepairReparent
+		case model.BlockIntegrityCycle:
+			getPatch(issue.BlockID).ParentID = &rootID
+			issue.Repair = model.BlockIntegrityRepairReparent
+		}
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Type != model.BlockIntegrityDanglingOption || toDelete[issue.BlockID] {
+			continue
+		}
+
+		patch := getPatch(issue.BlockID)
+		if patch.UpdatedFields == nil {
+			patch.UpdatedFields = map[string]interface{}{
+				"properties": removeDanglingOptions(blocksByID[issue.BlockID], schema),
+			}
+		}
+		issue.Repair = model.BlockIntegrityRepairRemoveOption
+	}
+
+	for start := 0; start < len(patchedIDs); start += blockIntegrityRepairBatchSize {
+		end := start + blockIntegrityRepairBatchSize
+		if end > len(patchedIDs) {
+			end = len(patchedIDs)
+		}
+
+		batch := &model.BlockPatchBatch{}
+		for _, blockID := range patchedIDs[start:end] {
+			batch.BlockIDs = append(batch.BlockIDs, blockID)
+			batch.BlockPatches = append(batch.BlockPatches, *patches[blockID])
+		}
+
+		if err := a.PatchBlocksAndNotify(board.TeamID, batch, model.SystemUserID, true); err != nil {
+			return err
+		}
+		for _, blockID := range batch.BlockIDs {
+			a.logger.Info("Repaired block",
+				mlog.String("boardID", board.ID),
+				mlog.String("blockID", blockID),
+				mlog.Bool("reparented", patches[blockID].ParentID != nil),
+				mlog.Bool("removedOptions", patches[blockID].UpdatedFields != nil),
+			)
+		}
+	}
+
+	deleteIDs := make([]string, 0, len(toDelete))
+	for blockID := range toDelete {
+		deleteIDs = append(deleteIDs, blockID)
+	}
+	for start := 0; start < len(deleteIDs); start += blockIntegrityRepairBatchSize {
+		end := start + blockIntegrityRepairBatchSize
+		if end > len(deleteIDs) {
+			end = len(deleteIDs)
+		}
+
+		deleted, err := a.DeleteBlocks(deleteIDs[start:end], model.SystemUserID)
+		if err != nil {
+			return err
+		}
+		for _, blockID := range deleted {
+			a.logger.Info("Deleted orphaned block",
+				mlog.String("boardID", board.ID),
+				mlog.String("blockID", blockID),
+			)
+		}
+	}
+
+	return nil
+}
+
+// removeDanglingOptions returns the properties of a card without the
+// option values that don't exist anymore.
+func removeDanglingOptions(card *model.Block, schema model.PropSchema) map[string]interface{} {
+	properties, _ := card.Fields["properties"].(map[string]interface{})
+	cleaned := make(map[string]interface{}, len(properties))
+	for propertyID, value := range properties {
+		def, ok := schema[propertyID]
+		dangling := []string{}
+		if ok {
+			dangling = danglingOptionIDs(def, value)
+		}
+		if len(dangling) == 0 {
+			cleaned[propertyID] = value
+			continue
+		}
+
+		values, isList := value.([]interface{})
+		if !isList {
+			continue
+		}
+
+		isDangling := make(map[string]bool, len(dangling))
+		for _, optionID := range dangling {
+			isDangling[optionID] = true
+		}
+		kept := []interface{}{}
+		for _, v := range values {
+			if optionID, _ := v.(string); !isDangling[optionID] {
+				kept = append(kept, v)
+			}
+		}
+		if len(kept) > 0 {
+			cleaned[propertyID] = kept
+		}
+	}
+	return cleaned
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newIntegrityTestBoard() *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{
+				"id":   "tags",
+				"name": "Tags",
+				"type": "multiSelect",
+				"options": []interface{}{
+					map[string]interface{}{"id": "tag1", "value": "Tag 1"},
+				},
+			},
+		},
+	}
+}
+
+func newIntegrityTestBlocks() []model.Block {
+	return []model.Block{
+		{ID: "card1", BoardID: "board-id", ParentID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"status": "deleted-option",
+				"tags":   []interface{}{"tag1", "deleted-tag"},
+			},
+		}},
+		{ID: "text1", BoardID: "board-id", ParentID: "card1", Type: model.TypeText},
+		{ID: "orphan", BoardID: "board-id", ParentID: "deleted-card", Type: model.TypeText},
+		{ID: "cycle1", BoardID: "board-id", ParentID: "cycle2", Type: model.TypeText},
+		{ID: "cycle2", BoardID: "board-id", ParentID: "cycle1", Type: model.TypeText},
+	}
+}
+
+func TestFindBlockIntegrityIssues(t *testing.T) {
+	board := newIntegrityTestBoard()
+	schema, err := model.ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	issues := findBlockIntegrityIssues(board, newIntegrityTestBlocks(), schema)
+	require.ElementsMatch(t, []model.BlockIntegrityIssue{
+		{Type: model.BlockIntegrityDanglingOption, BoardID: "board-id", BlockID: "card1", PropertyID: "status", OptionID: "deleted-option"},
+		{Type: model.BlockIntegrityDanglingOption, BoardID: "board-id", BlockID: "card1", PropertyID: "tags", OptionID: "deleted-tag"},
+		{Type: model.BlockIntegrityOrphan, BoardID: "board-id", BlockID: "orphan", ParentID: "deleted-card"},
+		{Type: model.BlockIntegrityCycle, BoardID: "board-id", BlockID: "cycle1", ParentID: "cycle2"},
+	}, issues)
+}
+
+func TestRemoveDanglingOptions(t *testing.T) {
+	board := newIntegrityTestBoard()
+	schema, err := model.ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	card := &newIntegrityTestBlocks()[0]
+	require.Equal(t, map[string]interface{}{
+		"tags": []interface{}{"tag1"},
+	}, removeDanglingOptions(card, schema))
+}
+
+func TestCheckBoardBlockIntegrity(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("report only", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(newIntegrityTestBoard(), nil)
+		th.Store.EXPECT().GetBlocksForBoard("board-id").Return(newIntegrityTestBlocks(), nil)
+
+		report, err := th.App.CheckBoardBlockIntegrity("board-id", "")
+		require.NoError(t, err)
+		require.Equal(t, 1, report.BoardCount)
+		require.Equal(t, 5, report.BlockCount)
+		require.Len(t, report.Issues, 4)
+		for _, issue := range report.Issues {
+			require.Empty(t, issue.Repair)
+		}
+	})
+
+	t.Run("invalid repair", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-id").Return(newIntegrityTestBoard(), nil)
+
+		_, err := th.App.CheckBoardBlockIntegrity("board-id", "unknown")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("reparent orphans", func(t *testing.T) {
+		blocks := newIntegrityTestBlocks()
+		th.Store.EXPECT().GetBoard("board-id").Return(newIntegrityTestBoard(), nil).Times(2)
+		th.Store.EXPECT().GetBlocksForBoard("board-id").Return(blocks, nil)
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any()).Return(blocks, nil)
+		th.Store.EXPECT().PatchBlocks(gomock.Any(), model.SystemUserID).DoAndReturn(func(batch *model.BlockPatchBatch, _ string) error {
+			require.ElementsMatch(t, []string{"card1", "orphan", "cycle1"}, batch.BlockIDs)
+			for i, blockID := range batch.BlockIDs {
+				patch := batch.BlockPatches[i]
+				if blockID == "card1" {
+					require.Nil(t, patch.ParentID)
+					require.Equal(t, map[string]interface{}{"tags": []interface{}{"tag1"}}, patch.UpdatedFields["properties"])
+					continue
+				}
+				require.Equal(t, "board-id", *patch.ParentID)
+			}
+			return nil
+		})
+		// these calls come from the WS server notification
+		th.Store.EXPECT().GetBlock(gomock.Any()).Return(&blocks[0], nil).AnyTimes()
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).AnyTimes()
+
+		report, err := th.App.CheckBoardBlockIntegrity("board-id", model.OrphanRepairReparent)
+		require.NoError(t, err)
+		for _, issue := range report.Issues {
+			require.NotEmpty(t, issue.Repair)
+		}
+	})
+}
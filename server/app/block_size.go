@@ -0,0 +1,37 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// blockSize returns the size in bytes of the content of a block, its
+// title and its fields as they are stored.
+func blockSize(block *model.Block) (int, error) {
+	fields, err := json.Marshal(block.Fields)
+	if err != nil {
+		return 0, err
+	}
+	return len(block.Title) + len(fields), nil
+}
+
+// checkBlockSize returns an error if the block is larger than the
+// configured maximum for its type.
+func (a *App) checkBlockSize(block *model.Block) error {
+	maxSize := a.config.MaxBlockSizes[string(block.Type)]
+	if maxSize <= 0 {
+		return nil
+	}
+
+	size, err := blockSize(block)
+	if err != nil {
+		return model.NewErrInvalidField("fields", err.Error())
+	}
+
+	if size > maxSize {
+		return model.NewErrBadRequest(fmt.Sprintf("block id %s of type %s cannot be larger than %d bytes", block.ID, block.Type, maxSize))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBlockSize(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.MaxBlockSizes = map[string]int{"text": 100}
+	defer func() { th.App.config.MaxBlockSizes = nil }()
+
+	t.Run("blocks below the limit are accepted", func(t *testing.T) {
+		block := &model.Block{Type: model.TypeText, Title: "some text", Fields: map[string]interface{}{}}
+		require.NoError(t, th.App.checkBlockSize(block))
+	})
+
+	t.Run("blocks over the limit are rejected", func(t *testing.T) {
+		block := &model.Block{Type: model.TypeText, Title: strings.Repeat("a", 101)}
+		require.True(t, model.IsErrBadRequest(th.App.checkBlockSize(block)))
+
+		block = &model.Block{Type: model.TypeText, Fields: map[string]interface{}{"value": strings.Repeat("a", 100)}}
+		require.True(t, model.IsErrBadRequest(th.App.checkBlockSize(block)))
+	})
+
+	t.Run("types without a limit are accepted", func(t *testing.T) {
+		block := &model.Block{Type: model.TypeCard, Title: strings.Repeat("a", 1000)}
+		require.NoError(t, th.App.checkBlockSize(block))
+	})
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// builtInBlockTypes are the block types used by the clients.
+var builtInBlockTypes = []model.BlockType{
+	model.TypeBoard,
+	model.TypeCard,
+	model.TypeView,
+	model.TypeText,
+	model.TypeComment,
+	model.TypeImage,
+	model.TypeCheckbox,
+	model.TypeDivider,
+}
+
+// BlockTypeDefinition describes a block type accepted by the server.
+type BlockTypeDefinition struct {
+	Type model.BlockType
+
+	// RequiredFields lists the keys that must be present in the
+	// fields of the blocks of this type.
+	RequiredFields []string
+}
+
+type blockTypeRegistry struct {
+	mux   sync.RWMutex
+	types map[model.BlockType]BlockTypeDefinition
+}
+
+func newBlockTypeRegistry(customTypes []config.BlockTypeConfig, logger mlog.LoggerIFace) *blockTypeRegistry {
+	registry := &blockTypeRegistry{
+		types: make(map[model.BlockType]BlockTypeDefinition, len(builtInBlockTypes)+len(customTypes)),
+	}
+
+	for _, blockType := range builtInBlockTypes {
+		registry.types[blockType] = BlockTypeDefinition{Type: blockType}
+	}
+
+	for _, customType := range customTypes {
+		def := BlockTypeDefinition{
+			Type:           model.BlockType(customType.Type),
+			RequiredFields: customType.RequiredFields,
+		}
+		if err := registry.register(def); err != nil {
+			logger.Error("Cannot register custom block type", mlog.String("type", customType.Type), mlog.Err(err))
+		}
+	}
+
+	return registry
+}
+
+func (r *blockTypeRegistry) register(def BlockTypeDefinition) error {
+	if def.Type == "" || def.Type == model.TypeUnknown {
+		return model.NewErrBadRequest("invalid block type name")
+	}
+
+	if isBuiltInBlockType(def.Type) {
+		return model.NewErrBadRequest(fmt.Sprintf("block type %s is a built-in type", def.Type))
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.types[def.Type] = def
+	return nil
+}
+
+func (r *blockTypeRegistry) get(blockType model.BlockType) (BlockTypeDefinition, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	def, ok := r.types[blockType]
+	return def, ok
+}
+
+func isBuiltInBlockType(blockType model.BlockType) bool {
+	for _, builtIn := range builtInBlockTypes {
+		if builtIn == blockType {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterBlockType registers a custom block type so that the blocks
+// of that type are accepted. Built-in types cannot be redefined.
+func (a *App) RegisterBlockType(def BlockTypeDefinition) error {
+	return a.blockTypes.register(def)
+}
+
+// ValidateBlock checks that the block type is registered, that the
+// block contains the fields required by its type, that it's not larger
+// than the maximum size of its type and that its card property values
+// match the property definitions of the board. Every write path runs
+// it: oldBlock is the stored version of a changed block, so only the
+// property values that change are checked, and nil for new blocks.
+func (a *App) ValidateBlock(board *model.Board, block, oldBlock *model.Block) error {
+	def, ok := a.blockTypes.get(block.Type)
+	if !ok {
+		return model.NewErrBadRequest(model.ErrInvalidBlockType{Type: block.Type.String()}.Error())
+	}
+
+	for _, field := range def.RequiredFields {
+		if _, ok := block.Fields[field]; !ok {
+			return model.NewErrBadRequest(fmt.Sprintf("missing required field %s for block id %s of type %s", field, block.ID, block.Type))
+		}
+	}
+	if err := a.checkBlockSize(block); err != nil {
+		return err
+	}
+	if err := a.checkBlockIcon(block); err != nil {
+		return err
+	}
+	return model.ValidateCardProperties(board, block, oldBlock)
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestValidateBlock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-id"}
+
+	t.Run("built-in type", func(t *testing.T) {
+		block := &model.Block{ID: "block-id", Type: model.TypeCard}
+		require.NoError(t, th.App.ValidateBlock(board, block, nil))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		block := &model.Block{ID: "block-id", Type: "decision"}
+		err := th.App.ValidateBlock(board, block, nil)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("registered custom type", func(t *testing.T) {
+		err := th.App.RegisterBlockType(BlockTypeDefinition{
+			Type:           "decision",
+			RequiredFields: []string{"outcome"},
+		})
+		require.NoError(t, err)
+
+		block := &model.Block{ID: "block-id", Type: "decision", Fields: map[string]interface{}{"outcome": "approved"}}
+		require.NoError(t, th.App.ValidateBlock(board, block, nil))
+
+		block.Fields = map[string]interface{}{}
+		err = th.App.ValidateBlock(board, block, nil)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("card property values", func(t *testing.T) {
+		board := &model.Board{
+			ID: "board-id",
+			CardProperties: []map[string]interface{}{
+				{"id": "labels", "name": "Labels", "type": model.PropertyTypeLabel, "options": []interface{}{
+					map[string]interface{}{"id": "label-1", "value": "Label 1"},
+				}},
+				{"id": "budget", "name": "Budget", "type": model.PropertyTypeNumber},
+			},
+		}
+		newCard := func(props map[string]interface{}) *model.Block {
+			return &model.Block{ID: "card-id", Type: model.TypeCard, Fields: map[string]interface{}{"properties": props}}
+		}
+
+		require.NoError(t, th.App.ValidateBlock(board, newCard(map[string]interface{}{"labels": []interface{}{"label-1"}}), nil))
+		require.True(t, model.IsErrBadRequest(th.App.ValidateBlock(board, newCard(map[string]interface{}{"labels": []interface{}{"missing"}}), nil)))
+		require.True(t, model.IsErrBadRequest(th.App.ValidateBlock(board, newCard(map[string]interface{}{"budget": "a lot"}), nil)))
+
+		// the values that don't change aren't checked again
+		oldCard := newCard(map[string]interface{}{"budget": "a lot"})
+		patchedCard := newCard(map[string]interface{}{"budget": "a lot"})
+		patchedCard.Title = "renamed"
+		require.NoError(t, th.App.ValidateBlock(board, patchedCard, oldCard))
+	})
+
+	t.Run("built-in types cannot be redefined", func(t *testing.T) {
+		err := th.App.RegisterBlockType(BlockTypeDefinition{
+			Type:           model.TypeCard,
+			RequiredFields: []string{"outcome"},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestNewBlockTypeRegistry(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	registry := newBlockTypeRegistry([]config.BlockTypeConfig{
+		{Type: "decision", RequiredFields: []string{"outcome"}},
+		{Type: ""},
+	}, logger)
+
+	def, ok := registry.get("decision")
+	require.True(t, ok)
+	require.Equal(t, []string{"outcome"}, def.RequiredFields)
+
+	_, ok = registry.get(model.TypeDivider)
+	require.True(t, ok)
+
+	_, ok = registry.get("")
+	require.False(t, ok)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
@@ -45,8 +46,9 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
-		for _, block := range blocks {
-			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		for i := range blocks {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, blocks[i])
+			a.blockCreated(&blocks[i])
 		}
 		return nil
 	})
@@ -88,6 +90,15 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 		return nil, err
 	}
 
+	patchedBlock := blockPatch.Patch(copyBlock(oldBlock))
+	if err = a.ValidateBlock(board, patchedBlock, oldBlock); err != nil {
+		return nil, err
+	}
+
+	if err = a.checkCommentLength(patchedBlock); err != nil {
+		return nil, err
+	}
+
 	err = a.store.PatchBlock(blockID, blockPatch, modifiedByID)
 	if err != nil {
 		return nil, err
@@ -104,6 +115,7 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 
 		// broadcast on webhooks
 		a.webhook.NotifyUpdate(*block)
+		a.blockUpdated(block, oldBlock)
 
 		// send notifications
 		if !disableNotify {
@@ -134,6 +146,35 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 		}
 	}
 
+	oldBlocksByID := make(map[string]*model.Block, len(oldBlocks))
+	for i := range oldBlocks {
+		oldBlocksByID[oldBlocks[i].ID] = &oldBlocks[i]
+	}
+	boards := map[string]*model.Board{}
+	for i, blockID := range blockPatches.BlockIDs {
+		oldBlock, ok := oldBlocksByID[blockID]
+		if !ok || i >= len(blockPatches.BlockPatches) {
+			continue
+		}
+
+		board, ok := boards[oldBlock.BoardID]
+		if !ok {
+			board, err = a.store.GetBoard(oldBlock.BoardID)
+			if err != nil {
+				return err
+			}
+			boards[oldBlock.BoardID] = board
+		}
+
+		patchedBlock := blockPatches.BlockPatches[i].Patch(copyBlock(oldBlock))
+		if err = a.ValidateBlock(board, patchedBlock, oldBlock); err != nil {
+			return err
+		}
+		if err = a.checkCommentLength(patchedBlock); err != nil {
+			return err
+		}
+	}
+
 	if err := a.store.PatchBlocks(blockPatches, modifiedByID); err != nil {
 		return err
 	}
@@ -147,6 +188,7 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 			}
 			a.wsAdapter.BroadcastBlockChange(teamID, *newBlock)
 			a.webhook.NotifyUpdate(*newBlock)
+			a.blockUpdated(newBlock, &oldBlocks[i])
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Update, newBlock, &oldBlocks[i], modifiedByID)
 			}
@@ -166,12 +208,21 @@ func (a *App) InsertBlockAndNotify(block model.Block, modifiedByID string, disab
 		return bErr
 	}
 
+	if err := a.ValidateBlock(board, &block, nil); err != nil {
+		return err
+	}
+
+	if err := a.checkNewComments(board.ID, []model.Block{block}, modifiedByID); err != nil {
+		return err
+	}
+
 	err := a.store.InsertBlock(&block, modifiedByID)
 	if err == nil {
 		a.blockChangeNotifier.Enqueue(func() error {
 			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
 			a.metrics.IncrementBlocksInserted(1)
-			a.webhook.NotifyUpdate(block)
+			a.webhook.NotifyCreate(block)
+			a.blockCreated(&block)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Add, &block, nil, modifiedByID)
 			}
@@ -224,8 +275,8 @@ func (a *App) InsertBlocksAndNotify(blocks []model.Block, modifiedByID string, d
 
 	// all blocks must belong to the same board
 	boardID := blocks[0].BoardID
-	for _, block := range blocks {
-		if block.BoardID != boardID {
+	for i := range blocks {
+		if blocks[i].BoardID != boardID {
 			return nil, ErrBlocksFromMultipleBoards
 		}
 	}
@@ -235,6 +286,16 @@ func (a *App) InsertBlocksAndNotify(blocks []model.Block, modifiedByID string, d
 		return nil, err
 	}
 
+	for i := range blocks {
+		if err = a.ValidateBlock(board, &blocks[i], nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.checkNewComments(board.ID, blocks, modifiedByID); err != nil {
+		return nil, err
+	}
+
 	needsNotify := make([]model.Block, 0, len(blocks))
 	for i := range blocks {
 		// this check is needed to whitelist inbuilt template
@@ -264,7 +325,8 @@ func (a *App) InsertBlocksAndNotify(blocks []model.Block, modifiedByID string, d
 	a.blockChangeNotifier.Enqueue(func() error {
 		for _, b := range needsNotify {
 			block := b
-			a.webhook.NotifyUpdate(block)
+			a.webhook.NotifyCreate(block)
+			a.blockCreated(&block)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Add, &block, nil, modifiedByID)
 			}
@@ -284,6 +346,86 @@ func (a *App) InsertBlocksAndNotify(blocks []model.Block, modifiedByID string, d
 	return blocks, nil
 }
 
+// SyncBlocks reconciles the changes made by a client while offline.
+// Changes based on an outdated version of a block are not applied and
+// are returned as conflicts, along with the server state of the board.
+// With onlyComments, only the comments of the user can be synced.
+func (a *App) SyncBlocks(boardID string, changes []model.SyncBlockChange, modifiedByID string, onlyComments bool) (*model.SyncBlocksResult, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockIDs := make([]string, 0, len(changes))
+	for i := range changes {
+		blockIDs = append(blockIDs, changes[i].Block.ID)
+	}
+	existingBlocks, err := a.store.GetBlocksByIDs(blockIDs)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	existingBlocksByID := make(map[string]*model.Block, len(existingBlocks))
+	for i := range existingBlocks {
+		existingBlocksByID[existingBlocks[i].ID] = &existingBlocks[i]
+	}
+
+	for i := range changes {
+		if changes[i].Block.DeleteAt > 0 {
+			continue
+		}
+		if err = a.ValidateBlock(board, &changes[i].Block, existingBlocksByID[changes[i].Block.ID]); err != nil {
+			return nil, err
+		}
+	}
+
+	comments := []model.Block{}
+	for _, change := range changes {
+		if change.Block.DeleteAt == 0 && change.Block.Type == model.TypeComment {
+			comments = append(comments, change.Block)
+		}
+	}
+	if err = a.checkNewComments(boardID, comments, modifiedByID); err != nil {
+		return nil, err
+	}
+
+	result, err := a.store.SyncBlocks(boardID, changes, modifiedByID, onlyComments)
+	if err != nil {
+		return nil, err
+	}
+
+	serverBlocks := make(map[string]model.Block, len(result.Blocks))
+	for _, block := range result.Blocks {
+		serverBlocks[block.ID] = block
+	}
+
+	appliedIndex := make(map[string]int, len(changes))
+	for i := range changes {
+		appliedIndex[changes[i].Block.ID] = i
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, blockID := range result.Applied {
+			if block, ok := serverBlocks[blockID]; ok {
+				a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+				a.webhook.NotifyUpdate(block)
+				if changes[appliedIndex[blockID]].BaseUpdateAt == 0 {
+					a.blockCreated(&block)
+				} else {
+					a.blockUpdated(&block, nil)
+				}
+			} else {
+				a.wsAdapter.BroadcastBlockDelete(board.TeamID, blockID, boardID)
+				a.blockDeleted(&changes[appliedIndex[blockID]].Block)
+			}
+		}
+		return nil
+	})
+
+	a.metrics.IncrementBlocksPatched(len(result.Applied))
+
+	return result, nil
+}
+
 func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []model.Block) error {
 	// Images attached in cards have a path comprising the card's board ID.
 	// When we create a template from this board, we need to copy the files
@@ -330,6 +472,20 @@ func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []model.Block) er
 			mlog.String("destinationFilePath", destinationFilePath),
 		)
 
+		copied, copyErr := a.copyFileReference(fileName.(string), destTeamID, destFilename)
+		if copyErr != nil {
+			a.logger.Error(
+				"CopyCardFiles failed to reference deduplicated file",
+				mlog.String("sourceFilePath", sourceFilePath),
+				mlog.String("destinationFilePath", destinationFilePath),
+				mlog.Err(copyErr),
+			)
+		}
+		if copied {
+			block.Fields["fileId"] = destFilename
+			continue
+		}
+
 		if err := a.filesBackend.CopyFile(sourceFilePath, destinationFilePath); err != nil {
 			a.logger.Error(
 				"CopyCardFiles failed to copy file",
@@ -373,23 +529,18 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 		return err
 	}
 
-	if block.Type == model.TypeImage {
-		fileName, fileIDExists := block.Fields["fileId"]
-		if fileName, fileIDIsString := fileName.(string); fileIDExists && fileIDIsString {
-			filePath := filepath.Join(block.BoardID, fileName)
-			err = a.filesBackend.RemoveFile(filePath)
-
-			if err != nil {
-				a.logger.Error("Error deleting image file",
-					mlog.String("FilePath", filePath),
-					mlog.Err(err))
-			}
+	if block.Type == model.TypeCard && !model.IsSubtask(block) {
+		if err = a.deleteSubtasks(board, block.ID, modifiedBy); err != nil {
+			return err
 		}
 	}
 
+	a.cleanUpDeletedBlock(board, block, modifiedBy)
+
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBlockDelete(board.TeamID, blockID, block.BoardID)
 		a.metrics.IncrementBlocksDeleted(1)
+		a.blockDeleted(block)
 		if !disableNotify {
 			a.notifyBlockChanged(notify.Delete, block, block, modifiedBy)
 		}
@@ -408,6 +559,102 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 	return nil
 }
 
+// DeleteBlocks deletes several blocks and their descendants in a single
+// transaction and returns the IDs of all the deleted blocks. The clients
+// get one message per board with all its deleted blocks.
+func (a *App) DeleteBlocks(blockIDs []string, modifiedBy string) ([]string, error) {
+	// the subtasks are descendants of their card, so they are deleted
+	// with it unless they are detached first
+	if a.config.SubtaskDeleteMode == model.SubtaskDeleteDetach {
+		if err := a.detachSubtasksOfDeletedBlocks(blockIDs, modifiedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	deleted, err := a.store.DeleteBlocks(blockIDs, modifiedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := map[string]*model.Board{}
+	deletedIDsByBoard := map[string][]string{}
+	deletedIDs := make([]string, 0, len(deleted))
+	for i := range deleted {
+		block := &deleted[i]
+
+		board, ok := boards[block.BoardID]
+		if !ok {
+			board, err = a.store.GetBoard(block.BoardID)
+			if err != nil {
+				return nil, err
+			}
+			boards[block.BoardID] = board
+		}
+
+		a.cleanUpDeletedBlock(board, block, modifiedBy)
+
+		deletedIDsByBoard[block.BoardID] = append(deletedIDsByBoard[block.BoardID], block.ID)
+		deletedIDs = append(deletedIDs, block.ID)
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for boardID, ids := range deletedIDsByBoard {
+			a.wsAdapter.BroadcastBlocksDelete(boards[boardID].TeamID, boardID, ids)
+		}
+		a.metrics.IncrementBlocksDeleted(len(deleted))
+		for i := range deleted {
+			a.blockDeleted(&deleted[i])
+			a.notifyBlockChanged(notify.Delete, &deleted[i], &deleted[i], modifiedBy)
+		}
+		return nil
+	})
+
+	go func() {
+		if err := a.UpdateCardLimitTimestamp(); err != nil {
+			a.logger.Error(
+				"UpdateCardLimitTimestamp failed after deleting blocks",
+				mlog.Err(err),
+			)
+		}
+	}()
+
+	return deletedIDs, nil
+}
+
+// cleanUpDeletedBlock clears the references to a deleted block and
+// removes its files.
+func (a *App) cleanUpDeletedBlock(board *model.Board, block *model.Block, modifiedBy string) {
+	if block.Type == model.TypeCard && board.CardTemplateID == block.ID {
+		a.clearCardTemplate(board.ID, modifiedBy)
+	}
+
+	if block.Type == model.TypeImage {
+		fileName, fileIDExists := block.Fields["fileId"]
+		if fileName, fileIDIsString := fileName.(string); fileIDExists && fileIDIsString {
+			// deduplicated files only remove their content with the
+			// last reference
+			removed, refErr := a.removeFileReference(fileName)
+			if refErr != nil {
+				a.logger.Error("Error removing deduplicated file reference",
+					mlog.String("fileName", fileName),
+					mlog.Err(refErr))
+			}
+			if removed {
+				return
+			}
+
+			filePath := filepath.Join(block.BoardID, fileName)
+			err := a.filesBackend.RemoveFile(filePath)
+
+			if err != nil {
+				a.logger.Error("Error deleting image file",
+					mlog.String("FilePath", filePath),
+					mlog.Err(err))
+			}
+		}
+	}
+}
+
 func (a *App) GetLastBlockHistoryEntry(blockID string) (*model.Block, error) {
 	blocks, err := a.store.GetBlockHistory(blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
 	if err != nil {
@@ -452,7 +699,8 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, *block)
 		a.metrics.IncrementBlocksInserted(1)
-		a.webhook.NotifyUpdate(*block)
+		a.webhook.NotifyCreate(*block)
+		a.blockCreated(block)
 		a.notifyBlockChanged(notify.Add, block, nil, modifiedBy)
 
 		return nil
@@ -470,6 +718,18 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 	return block, nil
 }
 
+// GetDeletedBlocks returns the blocks of a board that are in the trash,
+// most recently deleted first. When data retention is enabled, the blocks
+// deleted before the retention period are left out, as they are about to
+// be purged and can't be restored anymore.
+func (a *App) GetDeletedBlocks(boardID string) ([]model.Block, error) {
+	var deletedAfter int64
+	if a.config.EnableDataRetention && a.config.DataRetentionDays > 0 {
+		deletedAfter = utils.GetMillisForTime(time.Now().AddDate(0, 0, -a.config.DataRetentionDays))
+	}
+	return a.store.GetDeletedBlocksForBoard(boardID, deletedAfter)
+}
+
 func (a *App) GetBlockCountsByType() (map[string]int64, error) {
 	return a.store.GetBlockCountsByType()
 }
@@ -478,6 +738,12 @@ func (a *App) GetBlocksForBoard(boardID string) ([]model.Block, error) {
 	return a.store.GetBlocksForBoard(boardID)
 }
 
+// GetBlockManifest returns the ID, type and update time of the blocks of
+// a board, so clients can find out which ones they need to fetch.
+func (a *App) GetBlockManifest(boardID string) ([]model.BlockManifestEntry, error) {
+	return a.store.GetBlockManifest(boardID)
+}
+
 func (a *App) notifyBlockChanged(action notify.Action, block *model.Block, oldBlock *model.Block, modifiedByID string) {
 	// don't notify if notifications service disabled, or block change is generated via system user.
 	if a.notifications == nil || modifiedByID == model.SystemUserID {
@@ -512,10 +778,6 @@ func (a *App) notifyBlockChanged(action notify.Action, block *model.Block, oldBl
 	a.notifications.BlockChanged(evt)
 }
 
-const (
-	maxSearchDepth = 50
-)
-
 // getBoardAndCard returns the first parent of type `card` its board for the specified block.
 // `board` and/or `card` may return nil without error if the block does not belong to a board or card.
 func (a *App) getBoardAndCard(block *model.Block) (board *model.Board, card *model.Block, err error) {
@@ -532,10 +794,19 @@ func (a *App) getBoardAndCard(block *model.Block) (board *model.Board, card *mod
 			card = iter
 		}
 
-		if iter.ParentID == "" || (board != nil && card != nil) || count > maxSearchDepth {
+		if iter.ParentID == "" || (board != nil && card != nil) {
 			break
 		}
 
+		if maxDepth := model.MaxBlockTreeDepth(a.config.MaxBlockTreeDepth); count > maxDepth {
+			a.logger.Error("Block tree too deep looking for the card of a block",
+				mlog.String("board_id", block.BoardID),
+				mlog.String("block_id", block.ID),
+				mlog.Int("max_depth", maxDepth),
+			)
+			return board, nil, fmt.Errorf("cannot get the card of block %s: %w", block.ID, model.ErrBlockTreeTooDeep)
+		}
+
 		iter, err = a.store.GetBlock(iter.ParentID)
 		if model.IsErrNotFound(err) {
 			return board, card, nil
@@ -546,3 +817,14 @@ func (a *App) getBoardAndCard(block *model.Block) (board *model.Board, card *mod
 	}
 	return board, card, nil
 }
+
+// copyBlock returns a copy of the block that can be patched without
+// modifying the original fields.
+func copyBlock(block *model.Block) *model.Block {
+	blockCopy := *block
+	blockCopy.Fields = make(map[string]interface{}, len(block.Fields))
+	for key, value := range block.Fields {
+		blockCopy.Fields[key] = value
+	}
+	return &blockCopy
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -12,6 +13,7 @@ import (
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 type blockError struct {
@@ -28,7 +30,7 @@ func TestInsertBlock(t *testing.T) {
 
 	t.Run("success scenario", func(t *testing.T) {
 		boardID := testBoardID
-		block := model.Block{BoardID: boardID}
+		block := model.Block{BoardID: boardID, Type: model.TypeCard}
 		board := &model.Board{ID: boardID}
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(&block, "user-id-1").Return(nil)
@@ -39,7 +41,7 @@ func TestInsertBlock(t *testing.T) {
 
 	t.Run("error scenario", func(t *testing.T) {
 		boardID := testBoardID
-		block := model.Block{BoardID: boardID}
+		block := model.Block{BoardID: boardID, Type: model.TypeCard}
 		board := &model.Board{ID: boardID}
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(&block, "user-id-1").Return(blockError{"error"})
@@ -60,8 +62,9 @@ func TestPatchBlocks(t *testing.T) {
 			},
 		}
 
-		block1 := model.Block{ID: "block1"}
+		block1 := model.Block{ID: "block1", Type: model.TypeCard, BoardID: testBoardID}
 		th.Store.EXPECT().GetBlocksByIDs([]string{"block1"}).Return([]model.Block{block1}, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID}, nil)
 		th.Store.EXPECT().PatchBlocks(gomock.Eq(&blockPatches), gomock.Eq("user-id-1")).Return(nil)
 		th.Store.EXPECT().GetBlock("block1").Return(&block1, nil)
 		// this call comes from the WS server notification
@@ -184,6 +187,40 @@ func TestUndeleteBlock(t *testing.T) {
 	})
 }
 
+func TestGetDeletedBlocks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	blocks := []model.Block{{ID: "block-id", BoardID: testBoardID, DeleteAt: 10}}
+
+	t.Run("data retention disabled", func(t *testing.T) {
+		th.App.config.EnableDataRetention = false
+		th.Store.EXPECT().GetDeletedBlocksForBoard(testBoardID, int64(0)).Return(blocks, nil)
+
+		deleted, err := th.App.GetDeletedBlocks(testBoardID)
+		require.NoError(t, err)
+		require.Equal(t, blocks, deleted)
+	})
+
+	t.Run("data retention enabled", func(t *testing.T) {
+		th.App.config.EnableDataRetention = true
+		th.App.config.DataRetentionDays = 30
+		defer func() { th.App.config.EnableDataRetention = false }()
+
+		before := utils.GetMillisForTime(time.Now().AddDate(0, 0, -30))
+		th.Store.EXPECT().GetDeletedBlocksForBoard(testBoardID, gomock.Any()).
+			DoAndReturn(func(boardID string, deletedAfter int64) ([]model.Block, error) {
+				require.GreaterOrEqual(t, deletedAfter, before)
+				require.LessOrEqual(t, deletedAfter, utils.GetMillisForTime(time.Now().AddDate(0, 0, -30)))
+				return blocks, nil
+			})
+
+		deleted, err := th.App.GetDeletedBlocks(testBoardID)
+		require.NoError(t, err)
+		require.Equal(t, blocks, deleted)
+	})
+}
+
 func TestIsWithinViewsLimit(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -282,7 +319,7 @@ func TestInsertBlocks(t *testing.T) {
 
 	t.Run("success scenario", func(t *testing.T) {
 		boardID := testBoardID
-		block := model.Block{BoardID: boardID}
+		block := model.Block{BoardID: boardID, Type: model.TypeCard}
 		board := &model.Board{ID: boardID}
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(&block, "user-id-1").Return(nil)
@@ -293,7 +330,7 @@ func TestInsertBlocks(t *testing.T) {
 
 	t.Run("error scenario", func(t *testing.T) {
 		boardID := testBoardID
-		block := model.Block{BoardID: boardID}
+		block := model.Block{BoardID: boardID, Type: model.TypeCard}
 		board := &model.Board{ID: boardID}
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(&block, "user-id-1").Return(blockError{"error"})
@@ -404,3 +441,20 @@ func TestInsertBlocks(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestGetBoardAndCardTooDeep(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.MaxBlockTreeDepth = 3
+
+	board := &model.Board{ID: testBoardID}
+	// the block is its own parent, so its card is never found
+	block := &model.Block{ID: "block-id", BoardID: board.ID, ParentID: "block-id", Type: model.TypeText}
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().GetBlock("block-id").Return(block, nil).Times(3)
+
+	_, card, err := th.App.getBoardAndCard(block)
+	require.ErrorIs(t, err, model.ErrBlockTreeTooDeep)
+	require.Nil(t, card)
+}
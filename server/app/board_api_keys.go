@@ -0,0 +1,62 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// hashBoardAPIKey returns the hash of a key as it is stored. The keys
+// are random, so a plain SHA-256 is enough to look them up.
+func hashBoardAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateBoardAPIKey creates an API key for a board. The returned key is
+// the only place where the plain key can be found, as only its hash is
+// stored.
+func (a *App) CreateBoardAPIKey(boardID, name, permission, userID string) (*model.BoardAPIKey, error) {
+	key := &model.BoardAPIKey{
+		ID:         utils.NewID(utils.IDTypeNone),
+		BoardID:    boardID,
+		Name:       name,
+		Permission: permission,
+		CreatedBy:  userID,
+		CreateAt:   utils.GetMillis(),
+	}
+
+	if err := key.IsValid(); err != nil {
+		return nil, err
+	}
+
+	plainKey := model.BoardAPIKeyPrefix + utils.NewID(utils.IDTypeToken) + utils.NewID(utils.IDTypeToken)
+	if err := a.store.CreateBoardAPIKey(key, hashBoardAPIKey(plainKey)); err != nil {
+		return nil, err
+	}
+
+	key.Key = plainKey
+	return key, nil
+}
+
+// GetBoardAPIKeys returns the API keys of a board, without the keys
+// themselves.
+func (a *App) GetBoardAPIKeys(boardID string) ([]*model.BoardAPIKey, error) {
+	return a.store.GetBoardAPIKeys(boardID)
+}
+
+// RevokeBoardAPIKey deletes an API key of a board so it can't be used
+// anymore.
+func (a *App) RevokeBoardAPIKey(boardID, keyID string) error {
+	return a.store.DeleteBoardAPIKey(boardID, keyID)
+}
+
+// GetBoardAPIKey returns the stored API key that matches a plain key.
+func (a *App) GetBoardAPIKey(plainKey string) (*model.BoardAPIKey, error) {
+	if !model.IsBoardAPIKey(plainKey) {
+		return nil, model.NewErrNotFound("board API key")
+	}
+	return a.store.GetBoardAPIKeyByHash(hashBoardAPIKey(plainKey))
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// AddFavorite adds the board to the favorites of the user and sends the
+// change to the other sessions of the same user.
+func (a *App) AddFavorite(userID, boardID string) (*model.BoardFavorite, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	favorite := &model.BoardFavorite{
+		UserID:   userID,
+		BoardID:  boardID,
+		CreateAt: utils.GetMillis(),
+	}
+	if err = a.store.AddBoardFavorite(favorite); err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardFavoriteChange(board.TeamID, userID, boardID, true)
+		return nil
+	})
+
+	return favorite, nil
+}
+
+// RemoveFavorite removes the board from the favorites of the user and
+// sends the change to the other sessions of the same user.
+func (a *App) RemoveFavorite(userID, boardID string) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	if err = a.store.DeleteBoardFavorite(userID, boardID); err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardFavoriteChange(board.TeamID, userID, boardID, false)
+		return nil
+	})
+
+	return nil
+}
+
+// ListFavorites returns the favorite boards of the user, in the order
+// they were added.
+func (a *App) ListFavorites(userID string) ([]*model.Board, error) {
+	boards, err := a.store.GetFavoriteBoards(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	isFavorite := true
+	for _, board := range boards {
+		board.IsFavorite = &isFavorite
+	}
+	return boards, nil
+}
+
+// SetFavoriteFlags sets the favorite flag of the boards for the user, so
+// the clients can show it without listing the favorites.
+func (a *App) SetFavoriteFlags(userID string, boards ...*model.Board) error {
+	favoriteIDs, err := a.store.GetFavoriteBoardIDs(userID)
+	if err != nil {
+		return err
+	}
+
+	favorites := make(map[string]bool, len(favoriteIDs))
+	for _, boardID := range favoriteIDs {
+		favorites[boardID] = true
+	}
+
+	for _, board := range boards {
+		isFavorite := favorites[board.ID]
+		board.IsFavorite = &isFavorite
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// SetBoardSlug sets the slug the board can be looked up by within its
+// team, or removes it if the slug is empty.
+func (a *App) SetBoardSlug(boardID, slug string) error {
+	if slug != "" {
+		if err := model.ValidateBoardSlug(slug); err != nil {
+			return err
+		}
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+	return a.store.SetBoardSlug(boardID, board.TeamID, slug)
+}
+
+// GetBoardSlug returns the slug of the board, or an empty string if the
+// board has none.
+func (a *App) GetBoardSlug(boardID string) (string, error) {
+	slug, err := a.store.GetBoardSlug(boardID)
+	if model.IsErrNotFound(err) {
+		return "", nil
+	}
+	return slug, err
+}
+
+// GetBoardBySlug returns the board of the team with the given slug.
+func (a *App) GetBoardBySlug(teamID, slug string) (*model.Board, error) {
+	boardID, err := a.store.GetBoardIDBySlug(teamID, slug)
+	if err != nil {
+		return nil, err
+	}
+	return a.store.GetBoard(boardID)
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// boardStatsDoneWords are the first words of the names of the status
+// options considered completed when the request doesn't specify them.
+var boardStatsDoneWords = map[string]bool{
+	"done":      true,
+	"complete":  true,
+	"completed": true,
+	"closed":    true,
+	"finished":  true,
+	"resolved":  true,
+}
+
+// GetBoardStats computes the statistics of the cards of a board. If they
+// are not specified, the status is the first select property named
+// Status, the completed statuses are the options named like Done or
+// Completed, and the due date is the first date property whose name
+// contains "due". The statistics that depend on a property the board
+// doesn't have are left empty.
+func (a *App) GetBoardStats(boardID, statusProperty, dueDateProperty string, doneOptions []string) (*model.BoardStats, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	statusDef, err := boardStatsProperty(schema, "statusProperty", statusProperty, "select", func(def model.PropDef) bool {
+		return strings.EqualFold(strings.TrimSpace(def.Name), "status")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dueDateDef, err := boardStatsProperty(schema, "dueDateProperty", dueDateProperty, "date", func(def model.PropDef) bool {
+		return strings.Contains(strings.ToLower(def.Name), "due")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(a.ServerLocation())
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	opts := model.QueryBoardStatsOptions{
+		OverdueBefore: utils.GetMillisForTime(startOfToday),
+	}
+
+	if statusDef != nil {
+		opts.StatusProperty = statusDef.ID
+		if len(doneOptions) > 0 {
+			for _, id := range doneOptions {
+				if _, ok := statusDef.Options[id]; !ok {
+					return nil, model.NewErrInvalidField("doneOptions", fmt.Sprintf("option %s does not exist", id))
+				}
+			}
+			opts.DoneValues = doneOptions
+		} else {
+			for id, opt := range statusDef.Options {
+				if words := strings.Fields(strings.ToLower(opt.Value)); len(words) > 0 && boardStatsDoneWords[words[0]] {
+					opts.DoneValues = append(opts.DoneValues, id)
+				}
+			}
+		}
+	}
+	if dueDateDef != nil {
+		opts.DueDateProperty = dueDateDef.ID
+	}
+
+	stats, err := a.store.GetBoardStats(boardID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusDef != nil {
+		stats.StatusPropertyID = statusDef.ID
+		for _, count := range stats.CardsByStatus {
+			count.Name = statusDef.Options[count.Value].Value
+		}
+		sortBoardStatusCounts(stats.CardsByStatus, statusDef)
+	}
+	if dueDateDef != nil {
+		stats.DueDatePropertyID = dueDateDef.ID
+	}
+	if stats.TotalCards > 0 {
+		stats.CompletedPercent = math.Round(float64(stats.CompletedCards)*1000/float64(stats.TotalCards)) / 10
+	}
+
+	return stats, nil
+}
+
+// boardStatsProperty returns the definition of the property used for a
+// statistic, either the one requested or the first one of the type that
+// matches, in the order of the board. It returns nil if the board has
+// no matching property.
+func boardStatsProperty(schema model.PropSchema, param, propertyID, propertyType string, match func(model.PropDef) bool) (*model.PropDef, error) {
+	if propertyID != "" {
+		// the property ID is checked like the rollup ones, as it becomes
+		// part of a JSON path in the query as well
+		def, err := rollupProperty(schema, param, propertyID)
+		if err != nil {
+			return nil, err
+		}
+		if def.Type != propertyType {
+			return nil, model.NewErrInvalidField(param, fmt.Sprintf("property %s is not of type %s", propertyID, propertyType))
+		}
+		return def, nil
+	}
+
+	var found *model.PropDef
+	for _, def := range schema {
+		if def.Type != propertyType || !match(def) || strings.ContainsAny(def.ID, `"\`) {
+			continue
+		}
+		if found == nil || def.Index < found.Index {
+			def := def
+			found = &def
+		}
+	}
+	return found, nil
+}
+
+// sortBoardStatusCounts sorts the status counts following the options of
+// the status property. The cards without status come first and the
+// values that don't match any option last.
+func sortBoardStatusCounts(counts []*model.BoardStatusCount, def *model.PropDef) {
+	position := func(value string) int {
+		if value == "" {
+			return -1
+		}
+		if opt, ok := def.Options[value]; ok {
+			return opt.Index
+		}
+		return len(def.Options)
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		return position(counts[i].Value) < position(counts[j].Value)
+	})
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// MarkBoardSeen records that the user viewed the board, so the changes
+// made to it until now are read.
+func (a *App) MarkBoardSeen(userID, boardID string) error {
+	if _, err := a.store.GetBoard(boardID); err != nil {
+		return err
+	}
+
+	seen := &model.BoardLastSeen{
+		UserID:  userID,
+		BoardID: boardID,
+		SeenAt:  utils.GetMillis(),
+	}
+	return a.store.SaveBoardLastSeen(seen)
+}
+
+// GetBoardUnreadCounts returns the number of changes the user hasn't
+// seen for each of the boards they are a member of.
+func (a *App) GetBoardUnreadCounts(userID string) ([]*model.BoardUnread, error) {
+	boards, err := a.store.SearchBoardsForUser("", userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	boardIDs := make([]string, 0, len(boards))
+	for _, board := range boards {
+		boardIDs = append(boardIDs, board.ID)
+	}
+
+	counts, err := a.store.GetBoardUnreadCounts(userID, boardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	unread := make([]*model.BoardUnread, 0, len(boards))
+	for _, board := range boards {
+		unread = append(unread, &model.BoardUnread{
+			BoardID: board.ID,
+			TeamID:  board.TeamID,
+			Count:   counts[board.ID],
+		})
+	}
+	return unread, nil
+}
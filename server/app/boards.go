@@ -4,8 +4,10 @@
 package app
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
@@ -173,6 +175,25 @@ func (a *App) setBoardCategoryFromSource(sourceBoardID, destinationBoardID, user
 }
 
 func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	if !asTemplate {
+		teamID := toTeam
+		if teamID == "" {
+			board, err := a.store.GetBoard(boardID)
+			if err != nil {
+				return nil, nil, err
+			}
+			teamID = board.TeamID
+		}
+
+		if err := a.checkTeamBoardLimit(teamID, 1); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := a.ensureStandaloneTeam(toTeam); err != nil {
+		return nil, nil, err
+	}
+
 	bab, members, err := a.store.DuplicateBoard(boardID, userID, toTeam, asTemplate)
 	if err != nil {
 		return nil, nil, err
@@ -228,6 +249,7 @@ func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*
 		for _, block := range bab.Blocks {
 			blk := block
 			a.wsAdapter.BroadcastBlockChange(teamID, blk)
+			a.blockCreated(&blk)
 			a.notifyBlockChanged(notify.Add, &blk, nil, userID)
 		}
 		for _, member := range members {
@@ -250,10 +272,13 @@ func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*
 	return bab, members, err
 }
 
+// GetBoardsForUserAndTeam returns the boards of a team the user can
+// access.
 func (a *App) GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error) {
 	return a.store.GetBoardsForUserAndTeam(userID, teamID, includePublicBoards)
 }
 
+// GetTemplateBoards returns the templates of a team the user can access.
 func (a *App) GetTemplateBoards(teamID, userID string) ([]*model.Board, error) {
 	return a.store.GetTemplateBoards(teamID, userID)
 }
@@ -264,6 +289,24 @@ func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*m
 	}
 	board.ID = utils.NewID(utils.IDTypeBoard)
 
+	if err := a.checkIcon(board.Icon); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkBoardPropertyLimit(board.TeamID, 0, len(board.CardProperties)); err != nil {
+		return nil, err
+	}
+
+	if !board.IsTemplate {
+		if err := a.checkTeamBoardLimit(board.TeamID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.ensureStandaloneTeam(board.TeamID); err != nil {
+		return nil, err
+	}
+
 	var newBoard *model.Board
 	var member *model.BoardMember
 	var err error
@@ -297,11 +340,97 @@ func (a *App) CreateBoard(board *model.Board, userID string, addMember bool) (*m
 	return newBoard, nil
 }
 
+// GetDefaultBoardVisibility returns the visibility of the boards created
+// without a type in a team, which is the team feature flag if it's set
+// and valid, or the configured default otherwise.
+func (a *App) GetDefaultBoardVisibility(teamID string) model.BoardVisibility {
+	visibility := model.BoardVisibility(a.config.DefaultBoardVisibility)
+
+	flags, err := a.store.GetTeamFeatureFlags(teamID)
+	if err != nil {
+		a.logger.Warn("Cannot get the feature flags of the team",
+			mlog.String("teamID", teamID),
+			mlog.String("flag", model.FeatureFlagDefaultBoardVisibility),
+			mlog.Err(err),
+		)
+	} else if value, ok := flags[model.FeatureFlagDefaultBoardVisibility]; ok && model.IsBoardVisibilityValid(model.BoardVisibility(value)) {
+		visibility = model.BoardVisibility(value)
+	}
+
+	if visibility == "" {
+		return model.BoardVisibilityPrivate
+	}
+	return visibility
+}
+
+// GetDefaultCardProperties returns the card properties of the boards
+// created without any in a team, which are the team feature flag if it's
+// set and valid, or the configured default otherwise. The properties are
+// copied, so they can be modified.
+func (a *App) GetDefaultCardProperties(teamID string) []map[string]interface{} {
+	properties := a.config.DefaultCardProperties
+
+	flags, err := a.store.GetTeamFeatureFlags(teamID)
+	if err != nil {
+		a.logger.Warn("Cannot get the feature flags of the team",
+			mlog.String("teamID", teamID),
+			mlog.String("flag", model.FeatureFlagDefaultCardProperties),
+			mlog.Err(err),
+		)
+	} else if value, ok := flags[model.FeatureFlagDefaultCardProperties]; ok {
+		teamProperties, parseErr := model.ParseCardPropertySchema(value)
+		if parseErr != nil {
+			a.logger.Warn("Invalid default card properties for the team",
+				mlog.String("teamID", teamID),
+				mlog.Err(parseErr),
+			)
+		} else {
+			properties = teamProperties
+		}
+	}
+
+	if len(properties) == 0 {
+		return []map[string]interface{}{}
+	}
+
+	data, err := json.Marshal(properties)
+	if err != nil {
+		a.logger.Error("Cannot copy the default card properties", mlog.Err(err))
+		return []map[string]interface{}{}
+	}
+	copied := []map[string]interface{}{}
+	if err = json.Unmarshal(data, &copied); err != nil {
+		a.logger.Error("Cannot copy the default card properties", mlog.Err(err))
+		return []map[string]interface{}{}
+	}
+	return copied
+}
+
 func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*model.Board, error) {
 	var oldChannelID string
 	var isTemplate bool
 	var oldMembers []*model.BoardMember
 
+	if patch.CardTemplateID != nil && *patch.CardTemplateID != "" {
+		if err := a.validateCardTemplate(boardID, *patch.CardTemplateID); err != nil {
+			return nil, err
+		}
+	}
+
+	if patch.Icon != nil {
+		if err := a.checkIcon(*patch.Icon); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.checkBoardPatchPropertyLimit(boardID, patch); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkBoardPatchVisibilityRules(boardID, patch); err != nil {
+		return nil, err
+	}
+
 	if patch.Type != nil || patch.ChannelID != nil {
 		if patch.ChannelID != nil && *patch.ChannelID == "" {
 			var err error
@@ -383,6 +512,82 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 	return updatedBoard, nil
 }
 
+// checkBoardPropertyLimit checks that a change of the board schema
+// doesn't take the board over the maximum number of card properties of
+// its team. Boards already over the limit, because it was lowered after
+// they were created, can still be edited as long as no properties are
+// added.
+func (a *App) checkBoardPropertyLimit(teamID string, oldCount, newCount int) error {
+	if newCount <= oldCount {
+		return nil
+	}
+
+	limit := a.featureFlagInt(teamID, model.FeatureFlagMaxPropertiesPerBoard, a.config.MaxPropertiesPerBoard)
+	if limit == 0 || newCount <= limit {
+		return nil
+	}
+
+	return model.NewErrInvalidField("cardProperties",
+		fmt.Sprintf("the board would have %d properties, the maximum is %d", newCount, limit))
+}
+
+// checkTeamBoardLimit checks that newBoards boards can be added to the
+// team without going over its maximum number of boards. Templates are
+// not counted, and the global team, which holds the built-in templates,
+// is not limited.
+func (a *App) checkTeamBoardLimit(teamID string, newBoards int) error {
+	if newBoards == 0 || teamID == model.GlobalTeamID {
+		return nil
+	}
+
+	limit := a.featureFlagInt(teamID, model.FeatureFlagMaxBoardsPerTeam, a.config.MaxBoardsPerTeam)
+	if limit == 0 {
+		return nil
+	}
+
+	count, err := a.store.GetTeamBoardCount(teamID)
+	if err != nil {
+		return err
+	}
+
+	if count+int64(newBoards) <= int64(limit) {
+		return nil
+	}
+
+	return model.NewErrForbidden(fmt.Sprintf("the team has %d boards, the maximum is %d", count, limit))
+}
+
+// checkBoardPatchPropertyLimit applies the property limit to the schema
+// the board would have after the patch.
+func (a *App) checkBoardPatchPropertyLimit(boardID string, patch *model.BoardPatch) error {
+	if len(patch.UpdatedCardProperties) == 0 {
+		return nil
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	oldCount := len(board.CardProperties)
+	return a.checkBoardPropertyLimit(board.TeamID, oldCount, len(patch.Patch(board).CardProperties))
+}
+
+// checkBoardPatchVisibilityRules checks that the property visibility
+// rules are still valid once the patch is applied.
+func (a *App) checkBoardPatchVisibilityRules(boardID string, patch *model.BoardPatch) error {
+	if len(patch.UpdatedCardProperties) == 0 {
+		return nil
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	return model.ValidatePropertyVisibilityRules(patch.Patch(board))
+}
+
 func (a *App) postChannelMessage(message, channelID string) {
 	err := a.store.PostMessage(message, "", channelID)
 	if err != nil {
@@ -415,6 +620,141 @@ func (a *App) broadcastTeamUsers(teamID, boardID string, boardType model.BoardTy
 	}
 }
 
+// MoveBoard moves a board and its blocks to another team. The files
+// attached to the board's cards are stored under the team path, so
+// they are moved to the new team location too.
+func (a *App) MoveBoard(boardID, toTeamID, userID string) (*model.Board, error) {
+	board, err := a.store.GetBoard(boardID)
+	if model.IsErrNotFound(err) {
+		return nil, model.NewErrNotFound("board ID=" + boardID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if board.TeamID == toTeamID {
+		return board, nil
+	}
+	fromTeamID := board.TeamID
+
+	// the moved board counts against the limit of the destination team
+	if !board.IsTemplate {
+		if err = a.checkTeamBoardLimit(toTeamID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = a.ensureStandaloneTeam(toTeamID); err != nil {
+		return nil, err
+	}
+
+	movedBoard, err := a.store.MoveBoard(boardID, toTeamID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetBlocksForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.moveBoardFiles(fromTeamID, toTeamID, boardID, blocks)
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardDelete(fromTeamID, boardID)
+		a.wsAdapter.BroadcastBoardChange(toTeamID, movedBoard)
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockChange(toTeamID, block)
+		}
+		return nil
+	})
+
+	return movedBoard, nil
+}
+
+// TransferBoardOwnership makes newOwnerID the owner and an admin of the
+// board. The previous owner stays on the board as an editor.
+func (a *App) TransferBoardOwnership(boardID, newOwnerID, userID string) (*model.Board, error) {
+	board, err := a.store.GetBoard(boardID)
+	if model.IsErrNotFound(err) {
+		return nil, model.NewErrNotFound("board ID=" + boardID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = a.store.GetUserByID(newOwnerID); err != nil {
+		if model.IsErrNotFound(err) {
+			return nil, model.NewErrBadRequest("new owner not found")
+		}
+		return nil, err
+	}
+
+	oldOwnerID := board.CreatedBy
+	transferredBoard, err := a.store.TransferBoardOwnership(boardID, newOwnerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []*model.BoardMember{}
+	for _, memberID := range []string{newOwnerID, oldOwnerID} {
+		member, mErr := a.store.GetMemberForBoard(boardID, memberID)
+		if model.IsErrNotFound(mErr) {
+			continue
+		}
+		if mErr != nil {
+			return nil, mErr
+		}
+		members = append(members, member)
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(transferredBoard.TeamID, transferredBoard)
+		for _, member := range members {
+			a.wsAdapter.BroadcastMemberChange(transferredBoard.TeamID, boardID, member)
+		}
+		return nil
+	})
+
+	return transferredBoard, nil
+}
+
+func (a *App) moveBoardFiles(fromTeamID, toTeamID, boardID string, blocks []model.Block) {
+	for _, block := range blocks {
+		fileName, ok := block.Fields["fileId"].(string)
+		if !ok || fileName == "" {
+			continue
+		}
+
+		sourceFilePath := filepath.Join(fromTeamID, boardID, fileName)
+		destinationFilePath := filepath.Join(toTeamID, boardID, fileName)
+
+		// the deduplicated files keep sharing their content on the
+		// source team, so the board gets its own copy instead
+		moved, refErr := a.detachFileReference(fileName, destinationFilePath)
+		if refErr != nil {
+			a.logger.Error(
+				"MoveBoard failed to copy deduplicated file",
+				mlog.String("fileName", fileName),
+				mlog.String("destinationFilePath", destinationFilePath),
+				mlog.Err(refErr),
+			)
+		}
+		if moved {
+			continue
+		}
+
+		if err := a.filesBackend.MoveFile(sourceFilePath, destinationFilePath); err != nil {
+			a.logger.Error(
+				"MoveBoard failed to move file",
+				mlog.String("sourceFilePath", sourceFilePath),
+				mlog.String("destinationFilePath", destinationFilePath),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
 func (a *App) DeleteBoard(boardID, userID string) error {
 	board, err := a.store.GetBoard(boardID)
 	if model.IsErrNotFound(err) {
@@ -427,6 +767,7 @@ func (a *App) DeleteBoard(boardID, userID string) error {
 	if err := a.store.DeleteBoard(boardID, userID); err != nil {
 		return err
 	}
+	a.forgetUserBoardViews(boardID, "")
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBoardDelete(board.TeamID, boardID)
@@ -576,6 +917,7 @@ func (a *App) DeleteBoardMember(boardID, userID string) error {
 	if err := a.store.DeleteMember(boardID, userID); err != nil {
 		return err
 	}
+	a.forgetUserBoardViews(boardID, userID)
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		if syntheticMember, _ := a.GetMemberForBoard(boardID, userID); syntheticMember != nil {
@@ -608,6 +950,12 @@ func (a *App) UndeleteBoard(boardID string, modifiedBy string) error {
 		return nil
 	}
 
+	if !boards[0].IsTemplate {
+		if err = a.checkTeamBoardLimit(boards[0].TeamID, 1); err != nil {
+			return err
+		}
+	}
+
 	err = a.store.UndeleteBoard(boardID, modifiedBy)
 	if err != nil {
 		return err
@@ -12,6 +12,54 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 	var members []*model.BoardMember
 	var err error
 
+	newBoardsByTeam := map[string]int{}
+	for _, board := range bab.Boards {
+		if err = a.checkIcon(board.Icon); err != nil {
+			return nil, err
+		}
+		if err = a.checkBoardPropertyLimit(board.TeamID, 0, len(board.CardProperties)); err != nil {
+			return nil, err
+		}
+		if !board.IsTemplate {
+			newBoardsByTeam[board.TeamID]++
+		}
+	}
+
+	boardsByID := make(map[string]*model.Board, len(bab.Boards))
+	for _, board := range bab.Boards {
+		boardsByID[board.ID] = board
+	}
+	for i := range bab.Blocks {
+		board, ok := boardsByID[bab.Blocks[i].BoardID]
+		if !ok {
+			board, err = a.store.GetBoard(bab.Blocks[i].BoardID)
+			if err != nil {
+				return nil, err
+			}
+			boardsByID[board.ID] = board
+		}
+		if err = a.ValidateBlock(board, &bab.Blocks[i], nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for teamID, newBoards := range newBoardsByTeam {
+		if err = a.checkTeamBoardLimit(teamID, newBoards); err != nil {
+			return nil, err
+		}
+	}
+
+	ensuredTeams := map[string]bool{}
+	for _, board := range bab.Boards {
+		if ensuredTeams[board.TeamID] {
+			continue
+		}
+		if err = a.ensureStandaloneTeam(board.TeamID); err != nil {
+			return nil, err
+		}
+		ensuredTeams[board.TeamID] = true
+	}
+
 	if addMember {
 		newBab, members, err = a.store.CreateBoardsAndBlocksWithAdmin(bab, userID)
 	} else {
@@ -34,10 +82,19 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 		b := block
 		a.wsAdapter.BroadcastBlockChange(teamID, b)
 		a.metrics.IncrementBlocksInserted(1)
-		a.webhook.NotifyUpdate(b)
+		a.webhook.NotifyCreate(b)
 		a.notifyBlockChanged(notify.Add, &b, nil, userID)
 	}
 
+	if len(a.blockHooks) > 0 {
+		a.blockChangeNotifier.Enqueue(func() error {
+			for i := range newBab.Blocks {
+				a.blockCreated(&newBab.Blocks[i])
+			}
+			return nil
+		})
+	}
+
 	if addMember {
 		for _, member := range members {
 			a.wsAdapter.BroadcastMemberChange(teamID, member.BoardID, member)
@@ -74,11 +131,59 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 		}
 	}
 
+	for i, boardID := range pbab.BoardIDs {
+		if i < len(pbab.BoardPatches) {
+			if err = a.checkBoardPatchPropertyLimit(boardID, pbab.BoardPatches[i]); err != nil {
+				return nil, err
+			}
+			if icon := pbab.BoardPatches[i].Icon; icon != nil {
+				if err = a.checkIcon(*icon); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
 	oldBlocksMap := map[string]model.Block{}
 	for _, block := range oldBlocks {
 		oldBlocksMap[block.ID] = block
 	}
 
+	// the blocks are checked against their boards with the patches of
+	// the same request applied
+	patchedBoards := map[string]*model.Board{}
+	for i, boardID := range pbab.BoardIDs {
+		if i >= len(pbab.BoardPatches) {
+			continue
+		}
+		board, bErr := a.store.GetBoard(boardID)
+		if bErr != nil {
+			return nil, bErr
+		}
+		patchedBoards[boardID] = pbab.BoardPatches[i].Patch(board)
+	}
+
+	for i, blockID := range pbab.BlockIDs {
+		oldBlock, ok := oldBlocksMap[blockID]
+		if !ok || i >= len(pbab.BlockPatches) {
+			continue
+		}
+
+		board, ok := patchedBoards[oldBlock.BoardID]
+		if !ok {
+			board, err = a.store.GetBoard(oldBlock.BoardID)
+			if err != nil {
+				return nil, err
+			}
+			patchedBoards[oldBlock.BoardID] = board
+		}
+
+		patchedBlock := pbab.BlockPatches[i].Patch(copyBlock(&oldBlock))
+		if err = a.ValidateBlock(board, patchedBlock, &oldBlock); err != nil {
+			return nil, err
+		}
+	}
+
 	bab, err := a.store.PatchBoardsAndBlocks(pbab, userID)
 	if err != nil {
 		return nil, err
@@ -98,6 +203,7 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 			a.metrics.IncrementBlocksPatched(1)
 			a.wsAdapter.BroadcastBlockChange(teamID, b)
 			a.webhook.NotifyUpdate(b)
+			a.blockUpdated(&b, &oldBlock)
 			a.notifyBlockChanged(notify.Update, &b, &oldBlock, userID)
 		}
 
@@ -130,11 +236,15 @@ func (a *App) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID st
 	if err := a.store.DeleteBoardsAndBlocks(dbab, userID); err != nil {
 		return err
 	}
+	for _, boardID := range dbab.Boards {
+		a.forgetUserBoardViews(boardID, "")
+	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		for _, block := range blocks {
 			a.wsAdapter.BroadcastBlockDelete(firstBoard.TeamID, block.ID, block.BoardID)
 			a.metrics.IncrementBlocksDeleted(1)
+			a.blockDeleted(block)
 			a.notifyBlockChanged(notify.Update, block, block, userID)
 		}
 
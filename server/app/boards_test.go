@@ -1,6 +1,8 @@
 package app
 
 import (
+	"database/sql"
+	"path/filepath"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -365,6 +367,267 @@ func TestPatchBoard(t *testing.T) {
 	})
 }
 
+func TestBoardPropertyLimit(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const boardID = "board_id_1"
+	const userID = "user_id_1"
+	const teamID = "team_id_1"
+
+	th.App.config.MaxPropertiesPerBoard = 2
+
+	board := &model.Board{
+		ID:     boardID,
+		TeamID: teamID,
+		CardProperties: []map[string]interface{}{
+			{"id": "prop1", "name": "Prop 1", "type": "text"},
+			{"id": "prop2", "name": "Prop 2", "type": "text"},
+		},
+	}
+
+	t.Run("adding a property over the limit", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+
+		patch := &model.BoardPatch{
+			UpdatedCardProperties: []map[string]interface{}{{"id": "prop3", "name": "Prop 3", "type": "text"}},
+		}
+		_, err := th.App.PatchBoard(patch, boardID, userID)
+		var ifd *model.ErrInvalidField
+		require.ErrorAs(t, err, &ifd)
+		require.Contains(t, ifd.Reason(), "3 properties")
+	})
+
+	t.Run("the limit is overridden for the team", func(t *testing.T) {
+		require.NoError(t, th.App.checkBoardPropertyLimit(teamID, 0, 2))
+
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{model.FeatureFlagMaxPropertiesPerBoard: "5"}, nil)
+		require.NoError(t, th.App.checkBoardPropertyLimit(teamID, 0, 5))
+
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{model.FeatureFlagMaxPropertiesPerBoard: "0"}, nil)
+		require.NoError(t, th.App.checkBoardPropertyLimit(teamID, 0, 50))
+	})
+
+	t.Run("boards over the limit can still remove properties", func(t *testing.T) {
+		require.NoError(t, th.App.checkBoardPropertyLimit(teamID, 5, 4))
+	})
+}
+
+func TestTeamBoardLimit(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+	const teamID = "team_id_1"
+
+	th.App.config.MaxBoardsPerTeam = 3
+
+	t.Run("creating a board over the limit", func(t *testing.T) {
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+		th.Store.EXPECT().GetTeamBoardCount(teamID).Return(int64(3), nil)
+
+		_, err := th.App.CreateBoard(&model.Board{TeamID: teamID, Title: "board"}, userID, false)
+		require.True(t, model.IsErrForbidden(err))
+		require.Contains(t, err.Error(), "the team has 3 boards, the maximum is 3")
+	})
+
+	t.Run("templates are not limited", func(t *testing.T) {
+		board := &model.Board{TeamID: teamID, Title: "template", IsTemplate: true}
+		th.Store.EXPECT().GetTeam(teamID).Return(&model.Team{ID: teamID}, nil)
+		th.Store.EXPECT().InsertBoard(board, userID).Return(board, nil)
+
+		_, err := th.App.CreateBoard(board, userID, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("importing several boards", func(t *testing.T) {
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil).Times(2)
+		th.Store.EXPECT().GetTeamBoardCount(teamID).Return(int64(1), nil).Times(2)
+
+		require.NoError(t, th.App.checkTeamBoardLimit(teamID, 2))
+		require.Error(t, th.App.checkTeamBoardLimit(teamID, 3))
+	})
+
+	t.Run("the limit is overridden for the team", func(t *testing.T) {
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{model.FeatureFlagMaxBoardsPerTeam: "10"}, nil)
+		th.Store.EXPECT().GetTeamBoardCount(teamID).Return(int64(5), nil)
+		require.NoError(t, th.App.checkTeamBoardLimit(teamID, 1))
+
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{model.FeatureFlagMaxBoardsPerTeam: "0"}, nil)
+		require.NoError(t, th.App.checkTeamBoardLimit(teamID, 100))
+	})
+
+	t.Run("the global team is not limited", func(t *testing.T) {
+		require.NoError(t, th.App.checkTeamBoardLimit(model.GlobalTeamID, 100))
+	})
+}
+
+func TestGetDefaultBoardVisibility(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const teamID = "team_id_1"
+
+	t.Run("private if not configured", func(t *testing.T) {
+		th.App.config.DefaultBoardVisibility = ""
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+
+		require.Equal(t, model.BoardVisibilityPrivate, th.App.GetDefaultBoardVisibility(teamID))
+	})
+
+	t.Run("configured default", func(t *testing.T) {
+		th.App.config.DefaultBoardVisibility = string(model.BoardVisibilityTeam)
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+
+		require.Equal(t, model.BoardVisibilityTeam, th.App.GetDefaultBoardVisibility(teamID))
+	})
+
+	t.Run("overridden for the team", func(t *testing.T) {
+		th.App.config.DefaultBoardVisibility = string(model.BoardVisibilityTeam)
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{model.FeatureFlagDefaultBoardVisibility: "public"}, nil)
+
+		require.Equal(t, model.BoardVisibilityPublic, th.App.GetDefaultBoardVisibility(teamID))
+	})
+
+	t.Run("invalid team override", func(t *testing.T) {
+		th.App.config.DefaultBoardVisibility = string(model.BoardVisibilityTeam)
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{model.FeatureFlagDefaultBoardVisibility: "everyone"}, nil)
+
+		require.Equal(t, model.BoardVisibilityTeam, th.App.GetDefaultBoardVisibility(teamID))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		th.App.config.DefaultBoardVisibility = string(model.BoardVisibilityTeam)
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(nil, sql.ErrConnDone)
+
+		require.Equal(t, model.BoardVisibilityTeam, th.App.GetDefaultBoardVisibility(teamID))
+	})
+}
+
+func TestGetDefaultCardProperties(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const teamID = "team_id_1"
+	configured := []map[string]interface{}{
+		{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+			map[string]interface{}{"id": "open", "value": "Open"},
+		}},
+	}
+
+	t.Run("empty if not configured", func(t *testing.T) {
+		th.App.config.DefaultCardProperties = nil
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+
+		require.Empty(t, th.App.GetDefaultCardProperties(teamID))
+	})
+
+	t.Run("configured default is copied", func(t *testing.T) {
+		th.App.config.DefaultCardProperties = configured
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+
+		properties := th.App.GetDefaultCardProperties(teamID)
+		require.Equal(t, configured, properties)
+
+		properties[0]["name"] = "Changed"
+		require.Equal(t, "Status", configured[0]["name"])
+	})
+
+	t.Run("overridden for the team", func(t *testing.T) {
+		th.App.config.DefaultCardProperties = configured
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{
+			model.FeatureFlagDefaultCardProperties: `[{"id":"estimate","name":"Estimate","type":"number"}]`,
+		}, nil)
+
+		properties := th.App.GetDefaultCardProperties(teamID)
+		require.Len(t, properties, 1)
+		require.Equal(t, "estimate", properties[0]["id"])
+	})
+
+	t.Run("invalid team override", func(t *testing.T) {
+		th.App.config.DefaultCardProperties = configured
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{
+			model.FeatureFlagDefaultCardProperties: "not json",
+		}, nil)
+
+		require.Equal(t, configured, th.App.GetDefaultCardProperties(teamID))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		th.App.config.DefaultCardProperties = configured
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(nil, sql.ErrConnDone)
+
+		require.Equal(t, configured, th.App.GetDefaultCardProperties(teamID))
+	})
+}
+
+func TestMoveBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const boardID = "board_id_1"
+	const userID = "user_id_1"
+	const fromTeamID = "team_id_1"
+	const toTeamID = "team_id_2"
+
+	t.Run("base case", func(t *testing.T) {
+		board := &model.Board{ID: boardID, TeamID: fromTeamID}
+		movedBoard := &model.Board{ID: boardID, TeamID: toTeamID}
+		blocks := []model.Block{
+			{ID: "card_id_1", BoardID: boardID, Type: model.TypeCard},
+			{ID: "image_id_1", BoardID: boardID, Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "7file.png"}},
+		}
+
+		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.Store.EXPECT().GetTeamFeatureFlags(toTeamID).Return(map[string]string{}, nil)
+		th.Store.EXPECT().GetTeam(toTeamID).Return(&model.Team{ID: toTeamID}, nil)
+		th.Store.EXPECT().MoveBoard(boardID, toTeamID, userID).Return(movedBoard, nil)
+		th.Store.EXPECT().GetBlocksForBoard(boardID).Return(blocks, nil)
+		th.Store.EXPECT().GetFileReference("file").Return(nil, model.NewErrNotFound("file reference"))
+		th.FilesBackend.On("MoveFile",
+			filepath.Join(fromTeamID, boardID, "7file.png"),
+			filepath.Join(toTeamID, boardID, "7file.png"),
+		).Return(nil).Once()
+
+		result, err := th.App.MoveBoard(boardID, toTeamID, userID)
+		require.NoError(t, err)
+		require.Equal(t, toTeamID, result.TeamID)
+		th.FilesBackend.AssertExpectations(t)
+	})
+
+	t.Run("same team", func(t *testing.T) {
+		board := &model.Board{ID: boardID, TeamID: toTeamID}
+		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
+
+		result, err := th.App.MoveBoard(boardID, toTeamID, userID)
+		require.NoError(t, err)
+		require.Equal(t, board, result)
+	})
+
+	t.Run("destination team over the board limit", func(t *testing.T) {
+		th.App.config.MaxBoardsPerTeam = 3
+		defer func() { th.App.config.MaxBoardsPerTeam = 0 }()
+
+		board := &model.Board{ID: boardID, TeamID: fromTeamID}
+		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.Store.EXPECT().GetTeamFeatureFlags(toTeamID).Return(map[string]string{}, nil)
+		th.Store.EXPECT().GetTeamBoardCount(toTeamID).Return(int64(3), nil)
+
+		result, err := th.App.MoveBoard(boardID, toTeamID, userID)
+		require.True(t, model.IsErrForbidden(err))
+		require.Nil(t, result)
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(boardID).Return(nil, model.NewErrNotFound(boardID))
+
+		result, err := th.App.MoveBoard(boardID, toTeamID, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, result)
+	})
+}
+
 func TestGetBoardCount(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
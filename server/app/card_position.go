@@ -0,0 +1,116 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// newCardPosition returns a new position between prev and next for a
+// card of the board, taking a value of the board sequence so concurrent
+// inserts in the same place get different positions.
+func (a *App) newCardPosition(boardID, prev, next string) (string, error) {
+	sequence, err := a.store.NextBoardSequence(boardID, 1)
+	if err != nil {
+		return "", fmt.Errorf("cannot allocate card position for board %s: %w", boardID, err)
+	}
+	return model.NewCardPosition(prev, next, sequence)
+}
+
+// lastCardPosition returns the greatest position of the board cards,
+// or an empty string if none of them has a position.
+func (a *App) lastCardPosition(boardID string) (string, error) {
+	blocks, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return "", err
+	}
+
+	last := ""
+	for i := range blocks {
+		if position, ok := blocks[i].Fields["position"].(string); ok && position > last {
+			last = position
+		}
+	}
+	return last, nil
+}
+
+// MoveCard sets the position of a card between the cards of the
+// position change. Only the moved card is updated, the rest of the
+// board cards keep their positions.
+func (a *App) MoveCard(boardID, cardID string, change *model.CardPositionChange, userID string) (*model.Card, error) {
+	if err := change.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if cardID == change.AfterCardID || cardID == change.BeforeCardID {
+		return nil, model.NewErrBadRequest("a card cannot be moved next to itself")
+	}
+
+	if _, err := a.getBoardCard(boardID, cardID); err != nil {
+		return nil, err
+	}
+
+	var err error
+	prev := ""
+	if change.AfterCardID != "" {
+		if prev, err = a.neighbourCardPosition(boardID, change.AfterCardID, "afterCardId"); err != nil {
+			return nil, err
+		}
+	}
+
+	next := ""
+	if change.BeforeCardID != "" {
+		if next, err = a.neighbourCardPosition(boardID, change.BeforeCardID, "beforeCardId"); err != nil {
+			return nil, err
+		}
+		if next == "" {
+			return nil, model.NewErrInvalidField("beforeCardId", "the card has no position")
+		}
+	} else if change.AfterCardID == "" {
+		// no neighbours moves the card to the end of the board
+		if prev, err = a.lastCardPosition(boardID); err != nil {
+			return nil, err
+		}
+	}
+
+	position, err := a.newCardPosition(boardID, prev, next)
+	if err != nil {
+		return nil, err
+	}
+
+	blockPatch := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{"position": position},
+	}
+	block, err := a.PatchBlockAndNotify(cardID, blockPatch, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot move card %s: %w", cardID, err)
+	}
+
+	return model.Block2Card(block)
+}
+
+// neighbourCardPosition returns the position of a card that the moved
+// card is placed next to.
+func (a *App) neighbourCardPosition(boardID, cardID, field string) (string, error) {
+	card, err := a.getBoardCard(boardID, cardID)
+	if model.IsErrNotFound(err) {
+		return "", model.NewErrInvalidField(field, fmt.Sprintf("card %s does not exist", cardID))
+	}
+	if err != nil {
+		return "", err
+	}
+	return card.Position, nil
+}
+
+// getBoardCard returns a card of the board. Blocks that aren't cards
+// or belong to other boards are reported as not found.
+func (a *App) getBoardCard(boardID, cardID string) (*model.Card, error) {
+	block, err := a.GetBlockByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if block.BoardID != boardID || block.Type != model.TypeCard {
+		return nil, model.NewErrNotFound("card ID=" + cardID)
+	}
+	return model.Block2Card(block)
+}
@@ -7,13 +7,22 @@ import (
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 func (a *App) CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error) {
 	// Convert the card struct to a block and insert the block.
 	now := utils.GetMillis()
 
+	if card.ParentID != "" {
+		if err := a.validateSubtaskParent(boardID, "", card.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
 	card.ID = utils.NewID(utils.IDTypeCard)
 	card.BoardID = boardID
 	card.CreatedBy = userID
@@ -22,6 +31,17 @@ func (a *App) CreateCard(card *model.Card, boardID string, userID string, disabl
 	card.UpdateAt = now
 	card.DeleteAt = 0
 
+	// new cards without a position are added at the end of the board
+	if card.Position == "" {
+		last, err := a.lastCardPosition(boardID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create card: %w", err)
+		}
+		if card.Position, err = a.newCardPosition(boardID, last, ""); err != nil {
+			return nil, fmt.Errorf("cannot create card: %w", err)
+		}
+	}
+
 	block := model.Card2Block(card)
 
 	newBlocks, err := a.InsertBlocksAndNotify([]model.Block{*block}, userID, disableNotify)
@@ -68,6 +88,12 @@ func (a *App) PatchCard(cardPatch *model.CardPatch, cardID string, userID string
 		return nil, err
 	}
 
+	if cardPatch.ParentID != nil {
+		if blockPatch.ParentID, err = a.subtaskParentPatch(cardID, *cardPatch.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
 	newBlock, err := a.PatchBlockAndNotify(cardID, blockPatch, userID, disableNotify)
 	if err != nil {
 		return nil, fmt.Errorf("cannot patch card %s: %w", cardID, err)
@@ -94,3 +120,336 @@ func (a *App) GetCardByID(cardID string) (*model.Card, error) {
 
 	return card, nil
 }
+
+// GetCardProgress returns the number of checked and total checkbox
+// blocks for a card.
+func (a *App) GetCardProgress(boardID, cardID string) (*model.CardProgress, error) {
+	return a.store.GetCardProgress(boardID, cardID)
+}
+
+// CreateCardFromTemplate creates a new card in the board copying the
+// content and the property values of the board card template.
+func (a *App) CreateCardFromTemplate(boardID, userID string) (*model.Card, error) {
+	board, err := a.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.CardTemplateID == "" {
+		return nil, model.NewErrBadRequest("board " + boardID + " has no card template")
+	}
+
+	blocks, err := a.DuplicateBlock(boardID, board.CardTemplateID, userID, false)
+	if model.IsErrNotFound(err) {
+		// the template was deleted without clearing the reference
+		a.clearCardTemplate(boardID, userID)
+		return nil, model.NewErrNotFound("card template ID=" + board.CardTemplateID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot create card from template: %w", err)
+	}
+
+	return model.Block2Card(&blocks[0])
+}
+
+// DuplicateCard copies a card with its content and property values into
+// the same board, right after the original in the board views. The card
+// files are copied too, so editing the copy doesn't affect the original.
+func (a *App) DuplicateCard(cardID, userID string) (*model.Card, error) {
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if cardBlock.Type != model.TypeCard {
+		return nil, model.NewErrNotFound("card ID=" + cardID)
+	}
+
+	subtree, err := a.store.GetSubTree2(cardBlock.BoardID, cardID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// the card goes first so it is the first block of the result
+	blocks := []model.Block{*cardBlock}
+	for _, block := range subtree {
+		if block.ID == cardID || block.Type == model.TypeComment {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+
+	blocks = model.GenerateBlockIDs(blocks, a.logger)
+
+	now := utils.GetMillis()
+	for i := range blocks {
+		blocks[i].CreatedBy = userID
+		blocks[i].ModifiedBy = userID
+		blocks[i].CreateAt = now
+		blocks[i].UpdateAt = now
+	}
+
+	if err = a.CopyCardFiles(cardBlock.BoardID, blocks); err != nil {
+		return nil, err
+	}
+
+	newBlocks, err := a.InsertBlocks(blocks, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot duplicate card %s: %w", cardID, err)
+	}
+
+	if err = a.placeCardAfter(cardBlock.BoardID, cardID, newBlocks[0].ID, userID); err != nil {
+		a.logger.Error("Cannot place the duplicated card in the board views",
+			mlog.String("board_id", cardBlock.BoardID),
+			mlog.String("card_id", newBlocks[0].ID),
+			mlog.Err(err),
+		)
+	}
+
+	return model.Block2Card(&newBlocks[0])
+}
+
+// placeCardAfter adds a card to the card order of the board views right
+// after another card. Views where the other card isn't ordered are left
+// untouched.
+func (a *App) placeCardAfter(boardID, afterCardID, cardID, userID string) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	views, err := a.store.GetBlocksWithType(boardID, model.TypeView.String())
+	if err != nil {
+		return err
+	}
+
+	patches := &model.BlockPatchBatch{}
+	for _, view := range views {
+		cardOrder, ok := view.Fields["cardOrder"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		newCardOrder := make([]interface{}, 0, len(cardOrder)+1)
+		found := false
+		for _, id := range cardOrder {
+			newCardOrder = append(newCardOrder, id)
+			if id == afterCardID {
+				newCardOrder = append(newCardOrder, cardID)
+				found = true
+			}
+		}
+
+		if !found {
+			continue
+		}
+
+		patches.BlockIDs = append(patches.BlockIDs, view.ID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"cardOrder": newCardOrder},
+		})
+	}
+
+	if len(patches.BlockIDs) == 0 {
+		return nil
+	}
+	return a.PatchBlocks(board.TeamID, patches, userID)
+}
+
+// UpdateCardsProperty sets a property value on several cards of a board
+// in a single transaction. The cards that can't be updated are reported
+// in the result instead of failing the whole update.
+func (a *App) UpdateCardsProperty(boardID string, update *model.CardPropertyUpdate, userID string) (*model.CardPropertyUpdateResult, error) {
+	if err := update.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = model.ValidateCardPropertyValue(board, update.PropertyID, update.Value); err != nil {
+		return nil, err
+	}
+
+	return a.updateCardsProperty(board, update.CardIDs, update.PropertyID, func(interface{}) interface{} {
+		return update.Value
+	}, userID)
+}
+
+// updateCardsProperty sets a property on several cards of a board in a
+// single transaction, with the value returned by newValue for the
+// current value of each card. A nil value clears the property.
+func (a *App) updateCardsProperty(board *model.Board, cardIDs []string, propertyID string, newValue func(current interface{}) interface{}, userID string) (*model.CardPropertyUpdateResult, error) {
+	boardID := board.ID
+
+	// the cards not found are reported as failures below
+	blocks, err := a.store.GetBlocksByIDs(cardIDs)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	blocksByID := make(map[string]model.Block, len(blocks))
+	for _, block := range blocks {
+		blocksByID[block.ID] = block
+	}
+
+	isCloudLimited := a.IsCloudLimited()
+	result := &model.CardPropertyUpdateResult{
+		UpdatedCardIDs: []string{},
+		Failures:       []model.CardPropertyUpdateFailure{},
+	}
+	patches := &model.BlockPatchBatch{}
+	oldBlocks := []model.Block{}
+	seen := make(map[string]bool, len(cardIDs))
+	for _, cardID := range cardIDs {
+		if seen[cardID] {
+			continue
+		}
+		seen[cardID] = true
+
+		card, ok := blocksByID[cardID]
+		// cards of other boards are reported as not found so their
+		// existence isn't disclosed
+		if !ok || card.BoardID != boardID {
+			result.Failures = append(result.Failures, model.CardPropertyUpdateFailure{CardID: cardID, Error: "card not found"})
+			continue
+		}
+
+		if card.Type != model.TypeCard {
+			result.Failures = append(result.Failures, model.CardPropertyUpdateFailure{CardID: cardID, Error: "block is not a card"})
+			continue
+		}
+
+		if isCloudLimited {
+			containsLimitedBlocks, lErr := a.ContainsLimitedBlocks([]model.Block{card})
+			if lErr != nil {
+				return nil, lErr
+			}
+			if containsLimitedBlocks {
+				result.Failures = append(result.Failures, model.CardPropertyUpdateFailure{CardID: cardID, Error: model.ErrPatchUpdatesLimitedCards.Error()})
+				continue
+			}
+		}
+
+		// the properties field is patched as a whole, so the rest of
+		// the card values are kept
+		props, _ := card.Fields["properties"].(map[string]interface{})
+		newProps := make(map[string]interface{}, len(props)+1)
+		for key, value := range props {
+			newProps[key] = value
+		}
+		if value := newValue(props[propertyID]); value == nil {
+			delete(newProps, propertyID)
+		} else {
+			newProps[propertyID] = value
+		}
+
+		patches.BlockIDs = append(patches.BlockIDs, cardID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"properties": newProps},
+		})
+		oldBlocks = append(oldBlocks, card)
+	}
+
+	if len(patches.BlockIDs) == 0 {
+		return result, nil
+	}
+
+	if err = a.store.PatchBlocks(patches, userID); err != nil {
+		return nil, err
+	}
+	result.UpdatedCardIDs = patches.BlockIDs
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.metrics.IncrementBlocksPatched(len(oldBlocks))
+		newBlocks, getErr := a.store.GetBlocksByIDs(patches.BlockIDs)
+		if getErr != nil {
+			return getErr
+		}
+
+		// a single message is broadcast for all the cards
+		a.wsAdapter.BroadcastBlocksChange(board.TeamID, boardID, newBlocks)
+
+		oldBlocksByID := make(map[string]*model.Block, len(oldBlocks))
+		for i := range oldBlocks {
+			oldBlocksByID[oldBlocks[i].ID] = &oldBlocks[i]
+		}
+		for i := range newBlocks {
+			a.webhook.NotifyUpdate(newBlocks[i])
+			a.blockUpdated(&newBlocks[i], oldBlocksByID[newBlocks[i].ID])
+			a.notifyBlockChanged(notify.Update, &newBlocks[i], oldBlocksByID[newBlocks[i].ID], userID)
+		}
+		return nil
+	})
+
+	return result, nil
+}
+
+// validateCardTemplate checks that the card template of a board is one
+// of its cards.
+func (a *App) validateCardTemplate(boardID, cardID string) error {
+	block, err := a.store.GetBlock(cardID)
+	if model.IsErrNotFound(err) {
+		return model.NewErrBadRequest("card template " + cardID + " not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if block.BoardID != boardID || block.Type != model.TypeCard {
+		return model.NewErrBadRequest("card template " + cardID + " must be a card of the board")
+	}
+	return nil
+}
+
+func (a *App) clearCardTemplate(boardID, userID string) {
+	empty := ""
+	if _, err := a.PatchBoard(&model.BoardPatch{CardTemplateID: &empty}, boardID, userID); err != nil {
+		a.logger.Error("Cannot clear the card template of the board",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+	}
+}
+
+// AssignCards sets a user as the assignee of several cards of a board in
+// a single transaction. Multi-person properties get the user added to
+// their current people. The cards that can't be updated are reported in
+// the result instead of failing the whole assignment.
+func (a *App) AssignCards(boardID string, assignment *model.CardAssignment, userID string) (*model.CardPropertyUpdateResult, error) {
+	if err := assignment.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	propertyID, err := model.AssigneePropertyID(board, assignment.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema[propertyID].Type != "multiPerson" {
+		return a.updateCardsProperty(board, assignment.CardIDs, propertyID, func(interface{}) interface{} {
+			return assignment.UserID
+		}, userID)
+	}
+
+	return a.updateCardsProperty(board, assignment.CardIDs, propertyID, func(current interface{}) interface{} {
+		people, _ := current.([]interface{})
+		for _, person := range people {
+			if person == assignment.UserID {
+				return people
+			}
+		}
+		return append(append([]interface{}{}, people...), assignment.UserID)
+	}, userID)
+}
@@ -34,6 +34,8 @@ func TestCreateCard(t *testing.T) {
 	block := model.Card2Block(card)
 
 	t.Run("success scenario", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]model.Block{}, nil)
+		th.Store.EXPECT().NextBoardSequence(board.ID, 1).Return(int64(1), nil)
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(gomock.AssignableToTypeOf(reflect.TypeOf(block)), userID).Return(nil)
 		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil)
@@ -45,9 +47,13 @@ func TestCreateCard(t *testing.T) {
 		require.Equal(t, card.Title, newCard.Title)
 		require.Equal(t, card.ContentOrder, newCard.ContentOrder)
 		require.EqualValues(t, card.Properties, newCard.Properties)
+		require.NotEmpty(t, newCard.Position)
 	})
 
 	t.Run("error scenario", func(t *testing.T) {
+		card.Position = ""
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]model.Block{}, nil)
+		th.Store.EXPECT().NextBoardSequence(board.ID, 1).Return(int64(2), nil)
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(gomock.AssignableToTypeOf(reflect.TypeOf(block)), userID).Return(blockError{"error"})
 
@@ -20,6 +20,7 @@ func TestGetClientConfig(t *testing.T) {
 		newConfiguration.FeatureFlags["BoardsFeature1"] = "true"
 		newConfiguration.FeatureFlags["BoardsFeature2"] = "true"
 		newConfiguration.TeammateNameDisplay = "username"
+		newConfiguration.ReadOnlyMode = true
 		th.App.SetConfig(&newConfiguration)
 
 		clientConfig := th.App.GetClientConfig()
@@ -28,5 +29,6 @@ func TestGetClientConfig(t *testing.T) {
 		require.Equal(t, "abcde", clientConfig.TelemetryID)
 		require.Equal(t, 2, len(clientConfig.FeatureFlags))
 		require.Equal(t, "username", clientConfig.TeammateNameDisplay)
+		require.True(t, clientConfig.ReadOnlyMode)
 	})
 }
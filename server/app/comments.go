@@ -0,0 +1,58 @@
+package app
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// commentRateWindow is the period in which a user can post up to the
+// configured number of comments on a board.
+const commentRateWindow = time.Minute
+
+// checkCommentLength returns an error if the block is a comment longer
+// than the configured maximum.
+func (a *App) checkCommentLength(block *model.Block) error {
+	maxLength := a.config.MaxCommentLength
+	if block.Type != model.TypeComment || maxLength <= 0 {
+		return nil
+	}
+
+	if utf8.RuneCountInString(block.Title) > maxLength {
+		return model.NewErrInvalidField("title", fmt.Sprintf("comment cannot be longer than %d characters", maxLength))
+	}
+	return nil
+}
+
+// checkNewComments checks the length of the comments among the blocks
+// posted by a user, and that they don't exceed the rate at which the
+// user can comment on the board.
+func (a *App) checkNewComments(boardID string, blocks []model.Block, userID string) error {
+	comments := 0
+	for i := range blocks {
+		if blocks[i].Type != model.TypeComment {
+			continue
+		}
+		if err := a.checkCommentLength(&blocks[i]); err != nil {
+			return err
+		}
+		comments++
+	}
+
+	if comments == 0 || userID == model.SystemUserID {
+		return nil
+	}
+
+	if !a.commentLimiter.AllowN(userID+"/"+boardID, comments) {
+		a.logger.Warn("Comment rate limit reached",
+			mlog.String("userID", userID),
+			mlog.String("boardID", boardID),
+		)
+		return model.NewErrTooManyRequests(fmt.Sprintf("cannot post more than %d comments per minute on a board", a.config.CommentRateLimit))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCommentLength(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.MaxCommentLength = 5
+	defer func() { th.App.config.MaxCommentLength = 0 }()
+
+	require.NoError(t, th.App.checkCommentLength(&model.Block{Type: model.TypeComment, Title: "héllo"}))
+	require.NoError(t, th.App.checkCommentLength(&model.Block{Type: model.TypeText, Title: "not a comment"}))
+
+	err := th.App.checkCommentLength(&model.Block{Type: model.TypeComment, Title: "too long"})
+	var invalidField *model.ErrInvalidField
+	require.ErrorAs(t, err, &invalidField)
+	require.Equal(t, "title", invalidField.Field)
+
+	th.App.config.MaxCommentLength = 0
+	require.NoError(t, th.App.checkCommentLength(&model.Block{Type: model.TypeComment, Title: strings.Repeat("a", 100000)}))
+}
+
+func TestCheckNewComments(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.commentLimiter = utils.NewRateLimiter(2, time.Minute)
+	comment := model.Block{Type: model.TypeComment, Title: "comment"}
+	text := model.Block{Type: model.TypeText, Title: "text"}
+
+	t.Run("other blocks are not limited", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			require.NoError(t, th.App.checkNewComments("board-1", []model.Block{text}, "user-1"))
+		}
+	})
+
+	t.Run("comments are limited per user and board", func(t *testing.T) {
+		require.NoError(t, th.App.checkNewComments("board-1", []model.Block{comment, text}, "user-1"))
+		require.NoError(t, th.App.checkNewComments("board-1", []model.Block{comment}, "user-1"))
+
+		err := th.App.checkNewComments("board-1", []model.Block{comment}, "user-1")
+		require.True(t, model.IsErrTooManyRequests(err))
+
+		require.NoError(t, th.App.checkNewComments("board-2", []model.Block{comment}, "user-1"))
+		require.NoError(t, th.App.checkNewComments("board-1", []model.Block{comment}, "user-2"))
+	})
+
+	t.Run("the system user is not limited", func(t *testing.T) {
+		require.NoError(t, th.App.checkNewComments("board-1", []model.Block{comment, comment, comment}, model.SystemUserID))
+	})
+}
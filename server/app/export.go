@@ -2,6 +2,7 @@ package app
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,7 +17,21 @@ var (
 	newline = []byte{'\n'}
 )
 
-func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs error) {
+// archiveExportPageSize is the number of blocks fetched at once from the
+// store while exporting a board.
+const archiveExportPageSize = 1000
+
+// flusher is implemented by the writers, like http.ResponseWriter, that
+// can send the buffered data to their destination.
+type flusher interface {
+	Flush()
+}
+
+// ExportArchive writes an archive of the boards to w. The blocks are read
+// from the store in pages and the archive is flushed after each of them,
+// so exporting large boards doesn't need to hold them in memory. The
+// export stops when ctx is done, e.g. when the client disconnects.
+func (a *App) ExportArchive(ctx context.Context, w io.Writer, opt model.ExportArchiveOptions) (errs error) {
 	boards, err := a.getBoardsForArchive(opt.BoardIDs)
 	if err != nil {
 		return err
@@ -39,7 +54,7 @@ func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs e
 	}
 
 	for _, board := range boards {
-		if err := a.writeArchiveBoard(zw, board, opt); err != nil {
+		if err := a.writeArchiveBoard(ctx, zw, w, board, opt); err != nil {
 			merr.Append(fmt.Errorf("cannot export board %s: %w", board.ID, err))
 			return
 		}
@@ -67,7 +82,7 @@ func (a *App) writeArchiveVersion(zw *zip.Writer) error {
 }
 
 // writeArchiveBoard writes a single board to the archive in a zip directory.
-func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.ExportArchiveOptions) error {
+func (a *App) writeArchiveBoard(ctx context.Context, zw *zip.Writer, dest io.Writer, board model.Board, opt model.ExportArchiveOptions) error {
 	// create a directory per board
 	w, err := zw.Create(board.ID + "/board.jsonl")
 	if err != nil {
@@ -80,31 +95,64 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 	}
 
 	var files []string
-	// write the board's blocks
-	// TODO: paginate this
-	blocks, err := a.GetBlocksForBoard(board.ID)
-	if err != nil {
-		return err
-	}
+	// write the board's blocks, a page at a time
+	afterID := ""
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
 
-	for _, block := range blocks {
-		if err = a.writeArchiveBlockLine(w, block); err != nil {
+		blocks, err := a.store.GetBoardBlocksPage(board.ID, afterID, archiveExportPageSize)
+		if err != nil {
 			return err
 		}
-		if block.Type == model.TypeImage {
-			filename, err := extractImageFilename(block)
-			if err != nil {
+
+		for _, block := range blocks {
+			if err = a.writeArchiveBlockLine(w, block); err != nil {
 				return err
 			}
-			files = append(files, filename)
+			if block.Type == model.TypeImage {
+				filename, err := extractImageFilename(block)
+				if err != nil {
+					return err
+				}
+				files = append(files, filename)
+			}
+		}
+
+		if err = flushArchive(zw, dest); err != nil {
+			return err
+		}
+
+		if len(blocks) < archiveExportPageSize {
+			break
 		}
+		afterID = blocks[len(blocks)-1].ID
 	}
 
 	// write the files
 	for _, filename := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if err := a.writeArchiveFile(zw, filename, board.ID, opt); err != nil {
 			return fmt.Errorf("cannot write file %s to archive: %w", filename, err)
 		}
+		if err := flushArchive(zw, dest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushArchive sends the data buffered by the zip writer and by its
+// destination, so the client receives the archive as it's built.
+func flushArchive(zw *zip.Writer, dest io.Writer) error {
+	if err := zw.Flush(); err != nil {
+		return err
+	}
+	if f, ok := dest.(flusher); ok {
+		f.Flush()
 	}
 	return nil
 }
@@ -0,0 +1,237 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// markdownUntitled is the heading of the boards and cards without title.
+const markdownUntitled = "Untitled"
+
+// ExportBoardMarkdown renders a board as a Markdown document, with a
+// section for each card holding a table of its properties and its
+// content blocks in the order they are shown in the card.
+func (a *App) ExportBoardMarkdown(boardID string) (string, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return "", err
+	}
+
+	blocks, err := a.store.GetBlocksForBoard(boardID)
+	if err != nil {
+		return "", err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return "", err
+	}
+
+	cards := []*model.Block{}
+	contents := map[string][]*model.Block{}
+	for i := range blocks {
+		block := &blocks[i]
+		switch block.Type {
+		case model.TypeCard:
+			if isTemplate, _ := block.Fields["isTemplate"].(bool); !isTemplate {
+				cards = append(cards, block)
+			}
+		case model.TypeText, model.TypeCheckbox, model.TypeImage, model.TypeDivider:
+			contents[block.ParentID] = append(contents[block.ParentID], block)
+		}
+	}
+	sortCardBlocks(cards)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", markdownHeading(board.Icon, board.Title))
+	if board.Description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", board.Description)
+	}
+
+	for _, card := range cards {
+		icon, _ := card.Fields["icon"].(string)
+		fmt.Fprintf(&sb, "\n## %s\n", markdownHeading(icon, card.Title))
+
+		if table := a.markdownPropertiesTable(board, schema, card); table != "" {
+			fmt.Fprintf(&sb, "\n%s", table)
+		}
+
+		for _, content := range orderCardContents(card, contents[card.ID]) {
+			if md := a.markdownContent(board, content); md != "" {
+				fmt.Fprintf(&sb, "\n%s\n", md)
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// sortCardBlocks sorts the cards by their position in the board. The
+// cards created before the positions existed go last, by creation time.
+func sortCardBlocks(cards []*model.Block) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		pi, _ := cards[i].Fields["position"].(string)
+		pj, _ := cards[j].Fields["position"].(string)
+		if pi != pj {
+			if pi == "" || pj == "" {
+				return pj == ""
+			}
+			return pi < pj
+		}
+		return cards[i].CreateAt < cards[j].CreateAt
+	})
+}
+
+// orderCardContents returns the content blocks of a card following its
+// content order, whose items are either a block ID or a row of block IDs
+// shown side by side. The blocks missing from the content order follow,
+// by creation time.
+func orderCardContents(card *model.Block, contents []*model.Block) []*model.Block {
+	byID := make(map[string]*model.Block, len(contents))
+	for _, content := range contents {
+		byID[content.ID] = content
+	}
+
+	ordered := make([]*model.Block, 0, len(contents))
+	add := func(id interface{}) {
+		blockID, _ := id.(string)
+		if content, ok := byID[blockID]; ok {
+			ordered = append(ordered, content)
+			delete(byID, blockID)
+		}
+	}
+
+	switch contentOrder := card.Fields["contentOrder"].(type) {
+	case []interface{}:
+		for _, item := range contentOrder {
+			if row, ok := item.([]interface{}); ok {
+				for _, id := range row {
+					add(id)
+				}
+				continue
+			}
+			add(item)
+		}
+	case []string:
+		for _, id := range contentOrder {
+			add(id)
+		}
+	}
+
+	remaining := make([]*model.Block, 0, len(byID))
+	for _, content := range contents {
+		if _, ok := byID[content.ID]; ok {
+			remaining = append(remaining, content)
+		}
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].CreateAt < remaining[j].CreateAt
+	})
+	return append(ordered, remaining...)
+}
+
+// markdownPropertiesTable returns a table with the values of the card
+// properties, in the order of the board property definitions, or an
+// empty string if the card has no values.
+func (a *App) markdownPropertiesTable(board *model.Board, schema model.PropSchema, card *model.Block) string {
+	values, _ := card.Fields["properties"].(map[string]interface{})
+
+	var rows strings.Builder
+	for _, prop := range board.CardProperties {
+		propID, _ := prop["id"].(string)
+		def, ok := schema[propID]
+		if !ok {
+			continue
+		}
+		value, ok := values[propID]
+		if !ok || value == nil || value == "" {
+			continue
+		}
+		fmt.Fprintf(&rows, "| %s | %s |\n", markdownTableCell(def.Name), markdownTableCell(a.markdownPropertyValue(def, value)))
+	}
+
+	if rows.Len() == 0 {
+		return ""
+	}
+	return "| Property | Value |\n| --- | --- |\n" + rows.String()
+}
+
+// markdownPropertyValue resolves the option IDs of a property value to
+// the option names. Other values are resolved as in the notifications,
+// falling back to the stored value if it's not valid.
+func (a *App) markdownPropertyValue(def model.PropDef, value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if opt, ok := def.Options[v]; ok {
+			return opt.Value
+		}
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			id, _ := item.(string)
+			if opt, ok := def.Options[id]; ok {
+				names = append(names, opt.Value)
+			} else {
+				names = append(names, fmt.Sprintf("%v", item))
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+
+	resolved, err := def.GetValue(value, a.store)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return resolved
+}
+
+// markdownContent renders a content block of a card.
+func (a *App) markdownContent(board *model.Board, block *model.Block) string {
+	switch block.Type {
+	case model.TypeText:
+		return strings.TrimSpace(block.Title)
+	case model.TypeCheckbox:
+		mark := " "
+		if checked, _ := block.Fields["value"].(bool); checked {
+			mark = "x"
+		}
+		return fmt.Sprintf("- [%s] %s", mark, markdownInline(block.Title))
+	case model.TypeImage:
+		fileID, _ := block.Fields["fileId"].(string)
+		if fileID == "" {
+			return ""
+		}
+		return fmt.Sprintf("![%s](%s)", markdownInline(block.Title), utils.MakeFileLink(a.config.ServerRoot, board.TeamID, board.ID, fileID))
+	case model.TypeDivider:
+		return "---"
+	}
+	return ""
+}
+
+// markdownHeading returns the text of a heading, keeping it on a single
+// line.
+func markdownHeading(icon, title string) string {
+	title = markdownInline(title)
+	if title == "" {
+		title = markdownUntitled
+	}
+	if icon != "" {
+		return icon + " " + title
+	}
+	return title
+}
+
+// markdownInline joins the lines of a text so it can be used where
+// Markdown doesn't allow line breaks.
+func markdownInline(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// markdownTableCell escapes a text to be used as a table cell.
+func markdownTableCell(text string) string {
+	return strings.ReplaceAll(markdownInline(text), "|", "\\|")
+}
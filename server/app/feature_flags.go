@@ -0,0 +1,102 @@
+package app
+
+import (
+	"strconv"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetFeatureFlags returns the feature flags of a team. The flags that
+// are not set for the team fall back to the instance defaults from the
+// configuration.
+func (a *App) GetFeatureFlags(teamID string) (map[string]string, error) {
+	teamFlags, err := a.store.GetTeamFeatureFlags(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string]string, len(a.config.FeatureFlags)+len(teamFlags))
+	for name, value := range a.config.FeatureFlags {
+		flags[name] = value
+	}
+	for name, value := range teamFlags {
+		flags[name] = value
+	}
+	return flags, nil
+}
+
+// SetFeatureFlag overrides the value of a feature flag for a team.
+func (a *App) SetFeatureFlag(teamID, name, value string) error {
+	if err := model.IsValidFeatureFlagName(name); err != nil {
+		return err
+	}
+	if name == model.FeatureFlagDefaultBoardVisibility && (value == "" || !model.IsBoardVisibilityValid(model.BoardVisibility(value))) {
+		return model.NewErrInvalidField("value", "must be one of private, team or public")
+	}
+	if name == model.FeatureFlagDefaultCardProperties {
+		if _, err := model.ParseCardPropertySchema(value); err != nil {
+			return err
+		}
+	}
+	return a.store.SetTeamFeatureFlag(teamID, name, value)
+}
+
+// DeleteFeatureFlag removes the team override of a feature flag, so the
+// team uses the instance default again.
+func (a *App) DeleteFeatureFlag(teamID, name string) error {
+	return a.store.DeleteTeamFeatureFlag(teamID, name)
+}
+
+// IsFeatureEnabled checks whether a feature flag is enabled for a team.
+// If the flag is set neither for the team nor in the configuration, or
+// its value is not a boolean, defaultEnabled is returned.
+func (a *App) IsFeatureEnabled(teamID, name string, defaultEnabled bool) bool {
+	flags, err := a.GetFeatureFlags(teamID)
+	if err != nil {
+		a.logger.Warn("Cannot get the feature flags of the team",
+			mlog.String("teamID", teamID),
+			mlog.String("flag", name),
+			mlog.Err(err),
+		)
+		return defaultEnabled
+	}
+
+	value, ok := flags[name]
+	if !ok {
+		return defaultEnabled
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultEnabled
+	}
+	return enabled
+}
+
+// featureFlagInt returns the value of a numeric feature flag for a team.
+// If the flag is not set for the team, or its value is not a
+// non-negative integer, defaultValue is returned.
+func (a *App) featureFlagInt(teamID, name string, defaultValue int) int {
+	flags, err := a.store.GetTeamFeatureFlags(teamID)
+	if err != nil {
+		a.logger.Warn("Cannot get the feature flags of the team",
+			mlog.String("teamID", teamID),
+			mlog.String("flag", name),
+			mlog.Err(err),
+		)
+		return defaultValue
+	}
+
+	value, ok := flags[name]
+	if !ok {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return defaultValue
+	}
+	return n
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeatureFlags(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.FeatureFlags = map[string]string{"comments": "true", "relations": "false"}
+
+	t.Run("team flags override the defaults", func(t *testing.T) {
+		th.Store.EXPECT().GetTeamFeatureFlags("team-id").Return(map[string]string{"relations": "true", "labels": "false"}, nil)
+
+		flags, err := th.App.GetFeatureFlags("team-id")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"comments": "true", "relations": "true", "labels": "false"}, flags)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		th.Store.EXPECT().GetTeamFeatureFlags("team-id").Return(nil, errors.New("db error"))
+
+		flags, err := th.App.GetFeatureFlags("team-id")
+		require.Error(t, err)
+		require.Nil(t, flags)
+	})
+
+	t.Run("invalid flag name", func(t *testing.T) {
+		err := th.App.SetFeatureFlag("team-id", "not a flag", "true")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid default board visibility", func(t *testing.T) {
+		err := th.App.SetFeatureFlag("team-id", model.FeatureFlagDefaultBoardVisibility, "everyone")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("invalid default card properties", func(t *testing.T) {
+		err := th.App.SetFeatureFlag("team-id", model.FeatureFlagDefaultCardProperties, `[{"id":"status","type":"select"}]`)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestIsFeatureEnabled(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.FeatureFlags = map[string]string{"comments": "true"}
+	th.Store.EXPECT().GetTeamFeatureFlags("team-id").Return(map[string]string{"relations": "false", "broken": "maybe"}, nil).AnyTimes()
+
+	require.True(t, th.App.IsFeatureEnabled("team-id", "comments", false))
+	require.False(t, th.App.IsFeatureEnabled("team-id", "relations", true))
+	require.True(t, th.App.IsFeatureEnabled("team-id", "unset", true))
+	require.False(t, th.App.IsFeatureEnabled("team-id", "broken", false))
+}
@@ -1,14 +1,18 @@
 package app
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -16,8 +20,46 @@ import (
 
 const emptyString = "empty"
 
+// UploadQueueTimeout is the maximum time an upload waits for a free
+// slot when the number of concurrent uploads is limited.
+const UploadQueueTimeout = 5 * time.Second
+
 var errEmptyFilename = errors.New("IsFileArchived: empty filename not allowed")
 
+func newUploadSlots(maxConcurrentUploads int) chan struct{} {
+	if maxConcurrentUploads <= 0 {
+		return nil
+	}
+	return make(chan struct{}, maxConcurrentUploads)
+}
+
+// AcquireUploadSlot reserves one of the upload slots, waiting up to
+// UploadQueueTimeout for one to be released. The returned function
+// must be called once the upload is done to free the slot.
+func (a *App) AcquireUploadSlot(ctx context.Context) (func(), error) {
+	if a.uploadSlots == nil {
+		a.metrics.IncrementUploadsInFlight()
+		return a.metrics.DecrementUploadsInFlight, nil
+	}
+
+	timer := time.NewTimer(UploadQueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case a.uploadSlots <- struct{}{}:
+	case <-timer.C:
+		return nil, model.NewErrServiceUnavailable("too many concurrent uploads, please retry later")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	a.metrics.IncrementUploadsInFlight()
+	return func() {
+		a.metrics.DecrementUploadsInFlight()
+		<-a.uploadSlots
+	}, nil
+}
+
 func (a *App) SaveFile(reader io.Reader, teamID, rootID, filename string) (string, error) {
 	// NOTE: File extension includes the dot
 	fileExtension := strings.ToLower(filepath.Ext(filename))
@@ -26,12 +68,42 @@ func (a *App) SaveFile(reader io.Reader, teamID, rootID, filename string) (strin
 	}
 
 	createdFilename := utils.NewID(utils.IDTypeNone)
+
+	if a.config.ImageTranscodeFormat != "" && fileExtension == ".png" {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return "", fmt.Errorf("unable to read the uploaded file: %w", err)
+		}
+		reader = bytes.NewReader(data)
+
+		if converted, extension, ok := a.transcodeImage(data, fileExtension); ok {
+			if a.config.ImageTranscodeKeepOriginal {
+				originalPath := filepath.Join(teamID, rootID, createdFilename+fileExtension)
+				if _, appErr := a.filesBackend.WriteFile(bytes.NewReader(data), originalPath); appErr != nil {
+					return "", fmt.Errorf("unable to store the original file in the files storage: %w", appErr)
+				}
+			}
+			reader = bytes.NewReader(converted)
+			fileExtension = extension
+		}
+	}
+
 	fullFilename := fmt.Sprintf(`%s%s`, createdFilename, fileExtension)
 	filePath := filepath.Join(teamID, rootID, fullFilename)
 
-	fileSize, appErr := a.filesBackend.WriteFile(reader, filePath)
-	if appErr != nil {
-		return "", fmt.Errorf("unable to store the file in the files storage: %w", appErr)
+	var fileSize int64
+	if a.config.DeduplicateUploads {
+		size, dedupErr := a.writeDeduplicatedFile(reader, teamID, createdFilename[1:])
+		if dedupErr != nil {
+			return "", dedupErr
+		}
+		fileSize = size
+	} else {
+		size, appErr := a.filesBackend.WriteFile(reader, filePath)
+		if appErr != nil {
+			return "", fmt.Errorf("unable to store the file in the files storage: %w", appErr)
+		}
+		fileSize = size
 	}
 
 	now := utils.GetMillis()
@@ -113,6 +185,17 @@ func (a *App) GetFileReader(teamID, rootID, filename string) (filestore.ReadClos
 		}
 	}
 
+	// deduplicated uploads are read from their shared content
+	if !exists {
+		ref, refErr := a.getFileReference(filename)
+		if refErr != nil {
+			return nil, refErr
+		}
+		if ref != nil && ref.TeamID == teamID {
+			filePath = fileContentPath(ref.TeamID, ref.ContentHash)
+		}
+	}
+
 	reader, err := a.filesBackend.Reader(filePath)
 	if err != nil {
 		return nil, err
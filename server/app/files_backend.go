@@ -0,0 +1,148 @@
+package app
+
+import (
+	"io"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const filesBackendUnavailableMessage = "the files storage is temporarily unavailable"
+
+// FilesBackend wraps the files storage and keeps track of whether it
+// is reachable, so the server can keep running while the storage is
+// down. The file operations fail with ErrServiceUnavailable until a
+// connection check succeeds again.
+type FilesBackend struct {
+	settings filestore.FileBackendSettings
+	logger   mlog.LoggerIFace
+
+	mux       sync.RWMutex
+	backend   filestore.FileBackend
+	available bool
+	checked   bool
+}
+
+// NewFilesBackend initializes the files storage and checks that it is
+// reachable. The returned backend is always usable, the error only
+// reports that the storage is not available yet.
+func NewFilesBackend(settings filestore.FileBackendSettings, logger mlog.LoggerIFace) (*FilesBackend, error) {
+	b := &FilesBackend{
+		settings: settings,
+		logger:   logger,
+	}
+	return b, b.CheckConnection()
+}
+
+// CheckConnection tries to reach the files storage, initializing it
+// first if needed, and updates its availability.
+func (b *FilesBackend) CheckConnection() error {
+	b.mux.RLock()
+	backend := b.backend
+	b.mux.RUnlock()
+
+	var err error
+	if backend == nil {
+		backend, err = filestore.NewFileBackend(b.settings)
+	}
+	if err == nil {
+		err = backend.TestConnection()
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if backend != nil {
+		b.backend = backend
+	}
+
+	// only the changes of availability are logged to avoid flooding
+	// the logs during an outage
+	wasAvailable, firstCheck := b.available, !b.checked
+	b.available = err == nil
+	b.checked = true
+
+	switch {
+	case err != nil && (wasAvailable || firstCheck):
+		b.logger.Error("The files storage is unavailable", mlog.String("driver", b.settings.DriverName), mlog.Err(err))
+	case err == nil && !wasAvailable:
+		b.logger.Info("The files storage is available", mlog.String("driver", b.settings.DriverName))
+	}
+	return err
+}
+
+// IsAvailable returns true if the last connection check succeeded.
+func (b *FilesBackend) IsAvailable() bool {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	return b.available
+}
+
+func (b *FilesBackend) getBackend() (filestore.FileBackend, error) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if !b.available {
+		return nil, model.NewErrServiceUnavailable(filesBackendUnavailableMessage)
+	}
+	return b.backend, nil
+}
+
+func (b *FilesBackend) Reader(path string) (ReadCloseSeeker, error) {
+	backend, err := b.getBackend()
+	if err != nil {
+		return nil, err
+	}
+	return backend.Reader(path)
+}
+
+func (b *FilesBackend) FileExists(path string) (bool, error) {
+	backend, err := b.getBackend()
+	if err != nil {
+		return false, err
+	}
+	return backend.FileExists(path)
+}
+
+func (b *FilesBackend) CopyFile(oldPath, newPath string) error {
+	backend, err := b.getBackend()
+	if err != nil {
+		return err
+	}
+	return backend.CopyFile(oldPath, newPath)
+}
+
+func (b *FilesBackend) MoveFile(oldPath, newPath string) error {
+	backend, err := b.getBackend()
+	if err != nil {
+		return err
+	}
+	return backend.MoveFile(oldPath, newPath)
+}
+
+func (b *FilesBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	backend, err := b.getBackend()
+	if err != nil {
+		return 0, err
+	}
+	return backend.WriteFile(fr, path)
+}
+
+func (b *FilesBackend) RemoveFile(path string) error {
+	backend, err := b.getBackend()
+	if err != nil {
+		return err
+	}
+	return backend.RemoveFile(path)
+}
+
+// IsFilesBackendAvailable returns false if the files storage is known
+// to be unreachable.
+func (a *App) IsFilesBackendAvailable() bool {
+	if checker, ok := a.filesBackend.(interface{ IsAvailable() bool }); ok {
+		return checker.IsAvailable()
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestFilesBackend(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	// the files directory is created as a regular file first, so the
+	// storage is unreachable until it's replaced by a directory
+	filesPath := filepath.Join(t.TempDir(), "files")
+	require.NoError(t, os.WriteFile(filesPath, []byte{}, 0600))
+
+	settings := filestore.FileBackendSettings{
+		DriverName: "local",
+		Directory:  filesPath,
+	}
+
+	backend, err := NewFilesBackend(settings, logger)
+	require.Error(t, err)
+	require.NotNil(t, backend)
+	require.False(t, backend.IsAvailable())
+
+	t.Run("operations fail while the storage is unavailable", func(t *testing.T) {
+		_, writeErr := backend.WriteFile(bytes.NewReader([]byte("data")), "test.txt")
+		require.True(t, model.IsErrServiceUnavailable(writeErr))
+
+		_, existsErr := backend.FileExists("test.txt")
+		require.True(t, model.IsErrServiceUnavailable(existsErr))
+	})
+
+	t.Run("the storage is available again after a successful check", func(t *testing.T) {
+		require.NoError(t, os.Remove(filesPath))
+		require.NoError(t, os.Mkdir(filesPath, 0700))
+
+		require.NoError(t, backend.CheckConnection())
+		require.True(t, backend.IsAvailable())
+
+		_, writeErr := backend.WriteFile(bytes.NewReader([]byte("data")), "test.txt")
+		require.NoError(t, writeErr)
+
+		exists, existsErr := backend.FileExists("test.txt")
+		require.NoError(t, existsErr)
+		require.True(t, exists)
+	})
+}
+
+func TestIsFilesBackendAvailable(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	// backends that don't track their availability are always
+	// considered available
+	require.True(t, th.App.IsFilesBackendAvailable())
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// fileContentsDir is the directory of a team in the files storage
+// where the deduplicated contents are stored.
+const fileContentsDir = "contents"
+
+// fileContentPath returns the path of a deduplicated content in the
+// files storage.
+func fileContentPath(teamID, contentHash string) string {
+	return filepath.Join(teamID, fileContentsDir, contentHash)
+}
+
+// fileIDFromFilename returns the file info ID of a stored filename,
+// which has the format 7<file info ID>.<extension>.
+func fileIDFromFilename(filename string) string {
+	name := strings.Split(filepath.Base(filename), ".")[0]
+	if len(name) < 2 {
+		return ""
+	}
+	return name[1:]
+}
+
+// writeDeduplicatedFile stores the content of an upload only if the
+// team doesn't have an identical one yet, and references it from the
+// file.
+func (a *App) writeDeduplicatedFile(reader io.Reader, teamID, fileID string) (int64, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read the uploaded file: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+	contentHash := hex.EncodeToString(hash[:])
+	contentPath := fileContentPath(teamID, contentHash)
+
+	// the reference is saved before checking the storage, so a deletion
+	// of the last other reference of the content counts it and keeps the
+	// content. The lock keeps the storage operations of this server from
+	// interleaving with the removal of a content.
+	a.fileContentsMux.Lock()
+	defer a.fileContentsMux.Unlock()
+
+	ref := &model.FileReference{
+		FileID:      fileID,
+		TeamID:      teamID,
+		ContentHash: contentHash,
+		CreateAt:    utils.GetMillis(),
+	}
+	if err = a.store.SaveFileReference(ref); err != nil {
+		return 0, err
+	}
+
+	exists, err := a.filesBackend.FileExists(contentPath)
+	if err != nil {
+		a.deleteUnwrittenFileReference(fileID)
+		return 0, fmt.Errorf("unable to check the files storage: %w", err)
+	}
+	if !exists {
+		if _, err = a.filesBackend.WriteFile(bytes.NewReader(data), contentPath); err != nil {
+			a.deleteUnwrittenFileReference(fileID)
+			return 0, fmt.Errorf("unable to store the file in the files storage: %w", err)
+		}
+	} else {
+		a.logger.Debug("Upload deduplicated",
+			mlog.String("teamID", teamID),
+			mlog.String("contentHash", contentHash),
+		)
+	}
+
+	return int64(len(data)), nil
+}
+
+// deleteUnwrittenFileReference deletes the reference of an upload that
+// couldn't be stored.
+func (a *App) deleteUnwrittenFileReference(fileID string) {
+	if _, err := a.store.DeleteFileReference(fileID); err != nil {
+		a.logger.Error("Cannot delete the reference of a failed upload",
+			mlog.String("fileID", fileID),
+			mlog.Err(err),
+		)
+	}
+}
+
+// getFileReference returns the content reference of a stored filename,
+// or nil if the file isn't deduplicated.
+func (a *App) getFileReference(filename string) (*model.FileReference, error) {
+	fileID := fileIDFromFilename(filename)
+	if fileID == "" {
+		return nil, nil
+	}
+
+	ref, err := a.store.GetFileReference(fileID)
+	if model.IsErrNotFound(err) {
+		return nil, nil
+	}
+	return ref, err
+}
+
+// copyFileReference copies a deduplicated file by referencing its
+// content from the new file, copying the content itself only when the
+// destination is on another team. It returns false if the source file
+// isn't deduplicated.
+func (a *App) copyFileReference(sourceFilename, destTeamID, destFilename string) (bool, error) {
+	ref, err := a.getFileReference(sourceFilename)
+	if err != nil || ref == nil {
+		return false, err
+	}
+
+	a.fileContentsMux.Lock()
+	defer a.fileContentsMux.Unlock()
+
+	if destTeamID != ref.TeamID {
+		destPath := fileContentPath(destTeamID, ref.ContentHash)
+		exists, existsErr := a.filesBackend.FileExists(destPath)
+		if existsErr != nil {
+			return true, existsErr
+		}
+		if !exists {
+			if err = a.filesBackend.CopyFile(fileContentPath(ref.TeamID, ref.ContentHash), destPath); err != nil {
+				return true, err
+			}
+		}
+	}
+
+	newRef := &model.FileReference{
+		FileID:      fileIDFromFilename(destFilename),
+		TeamID:      destTeamID,
+		ContentHash: ref.ContentHash,
+		CreateAt:    utils.GetMillis(),
+	}
+	return true, a.store.SaveFileReference(newRef)
+}
+
+// detachFileReference copies the content of a deduplicated file to
+// the path of a regular file and removes its reference. It returns
+// false if the file isn't deduplicated.
+func (a *App) detachFileReference(filename, destPath string) (bool, error) {
+	ref, err := a.getFileReference(filename)
+	if err != nil || ref == nil {
+		return false, err
+	}
+
+	if err = a.filesBackend.CopyFile(fileContentPath(ref.TeamID, ref.ContentHash), destPath); err != nil {
+		return true, err
+	}
+
+	_, err = a.removeFileReference(filename)
+	return true, err
+}
+
+// removeFileReference removes the reference of a deduplicated file,
+// and its content if nothing else references it. It returns false if
+// the file isn't deduplicated.
+func (a *App) removeFileReference(filename string) (bool, error) {
+	ref, err := a.getFileReference(filename)
+	if err != nil || ref == nil {
+		return false, err
+	}
+
+	// the store counts the remaining references atomically, so only
+	// the deletion of the last one removes the content
+	a.fileContentsMux.Lock()
+	defer a.fileContentsMux.Unlock()
+
+	remaining, err := a.store.DeleteFileReference(ref.FileID)
+	if err != nil {
+		return true, err
+	}
+
+	if remaining > 0 {
+		a.logger.Debug("Deduplicated file still referenced",
+			mlog.String("contentHash", ref.ContentHash),
+			mlog.Int64("references", remaining),
+		)
+		return true, nil
+	}
+	return true, a.filesBackend.RemoveFile(fileContentPath(ref.TeamID, ref.ContentHash))
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost-server/v6/shared/filestore/mocks"
+)
+
+func TestSaveFileDeduplicated(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+	th.App.config.DeduplicateUploads = true
+
+	content := []byte("shared asset")
+	hash := sha256.Sum256(content)
+	contentPath := fileContentPath("team-id", hex.EncodeToString(hash[:]))
+
+	t.Run("the first upload stores the content", func(t *testing.T) {
+		filesBackend := &mocks.FileBackend{}
+		th.App.filesBackend = filesBackend
+		filesBackend.On("FileExists", contentPath).Return(false, nil).Once()
+		filesBackend.On("WriteFile", mock.Anything, contentPath).Return(int64(len(content)), nil).Once()
+		th.Store.EXPECT().SaveFileReference(gomock.Any()).Return(nil)
+		th.Store.EXPECT().SaveFileInfo(gomock.Any()).Return(nil)
+
+		filename, err := th.App.SaveFile(bytes.NewReader(content), "team-id", testBoardID, "asset.txt")
+		require.NoError(t, err)
+		require.NotEmpty(t, filename)
+		filesBackend.AssertExpectations(t)
+	})
+
+	t.Run("an identical upload references the stored content", func(t *testing.T) {
+		filesBackend := &mocks.FileBackend{}
+		th.App.filesBackend = filesBackend
+		filesBackend.On("FileExists", contentPath).Return(true, nil).Once()
+		th.Store.EXPECT().SaveFileReference(gomock.Any()).DoAndReturn(func(ref *model.FileReference) error {
+			require.Equal(t, "team-id", ref.TeamID)
+			require.Equal(t, hex.EncodeToString(hash[:]), ref.ContentHash)
+			return nil
+		})
+		th.Store.EXPECT().SaveFileInfo(gomock.Any()).Return(nil)
+
+		_, err := th.App.SaveFile(bytes.NewReader(content), "team-id", testBoardID, "copy.txt")
+		require.NoError(t, err)
+		filesBackend.AssertNotCalled(t, "WriteFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("the reference of a failed upload is deleted", func(t *testing.T) {
+		filesBackend := &mocks.FileBackend{}
+		th.App.filesBackend = filesBackend
+		filesBackend.On("FileExists", contentPath).Return(false, nil).Once()
+		filesBackend.On("WriteFile", mock.Anything, contentPath).Return(int64(0), errors.New("storage error")).Once()
+		var fileID string
+		th.Store.EXPECT().SaveFileReference(gomock.Any()).DoAndReturn(func(ref *model.FileReference) error {
+			fileID = ref.FileID
+			return nil
+		})
+		th.Store.EXPECT().DeleteFileReference(gomock.Any()).DoAndReturn(func(id string) (int64, error) {
+			require.Equal(t, fileID, id)
+			return int64(0), nil
+		})
+
+		_, err := th.App.SaveFile(bytes.NewReader(content), "team-id", testBoardID, "failed.txt")
+		require.Error(t, err)
+	})
+}
+
+func TestRemoveFileReference(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	ref := &model.FileReference{FileID: "file-id", TeamID: "team-id", ContentHash: "hash"}
+	contentPath := fileContentPath("team-id", "hash")
+
+	t.Run("the content is kept while it's referenced", func(t *testing.T) {
+		filesBackend := &mocks.FileBackend{}
+		th.App.filesBackend = filesBackend
+		th.Store.EXPECT().GetFileReference("file-id").Return(ref, nil)
+		th.Store.EXPECT().DeleteFileReference("file-id").Return(int64(1), nil)
+
+		removed, err := th.App.removeFileReference("7file-id.png")
+		require.NoError(t, err)
+		require.True(t, removed)
+		filesBackend.AssertNotCalled(t, "RemoveFile", mock.Anything)
+	})
+
+	t.Run("the content is removed with the last reference", func(t *testing.T) {
+		filesBackend := &mocks.FileBackend{}
+		th.App.filesBackend = filesBackend
+		filesBackend.On("RemoveFile", contentPath).Return(nil).Once()
+		th.Store.EXPECT().GetFileReference("file-id").Return(ref, nil)
+		th.Store.EXPECT().DeleteFileReference("file-id").Return(int64(0), nil)
+
+		removed, err := th.App.removeFileReference("7file-id.png")
+		require.NoError(t, err)
+		require.True(t, removed)
+		filesBackend.AssertExpectations(t)
+	})
+
+	t.Run("files that aren't deduplicated are left to the caller", func(t *testing.T) {
+		th.Store.EXPECT().GetFileReference("file-id").Return(nil, model.NewErrNotFound("file reference"))
+
+		removed, err := th.App.removeFileReference("7file-id.png")
+		require.NoError(t, err)
+		require.False(t, removed)
+	})
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"io"
 	"os"
@@ -11,6 +12,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/mattermost/focalboard/server/model"
+
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin/plugintest/mock"
 	"github.com/mattermost/mattermost-server/v6/shared/filestore"
@@ -32,6 +35,7 @@ func TestGetFileReader(t *testing.T) {
 	testFilePath := filepath.Join("1", "test-board-id", "temp-file-name")
 
 	th, _ := SetupTestHelper(t)
+	th.Store.EXPECT().GetFileReference(gomock.Any()).Return(nil, model.NewErrNotFound("file reference")).AnyTimes()
 	mockedReadCloseSeek := &mocks.ReadCloseSeeker{}
 	t.Run("should get file reader from filestore successfully", func(t *testing.T) {
 		mockedFileBackend := &mocks.FileBackend{}
@@ -304,3 +308,37 @@ func TestGetFileInfo(t *testing.T) {
 		assert.Nil(t, fetchedFileInfo)
 	})
 }
+
+func TestAcquireUploadSlot(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("no limit configured", func(t *testing.T) {
+		th.App.uploadSlots = newUploadSlots(0)
+
+		release1, err := th.App.AcquireUploadSlot(context.Background())
+		assert.NoError(t, err)
+		release2, err := th.App.AcquireUploadSlot(context.Background())
+		assert.NoError(t, err)
+		release1()
+		release2()
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		th.App.uploadSlots = newUploadSlots(1)
+
+		release, err := th.App.AcquireUploadSlot(context.Background())
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = th.App.AcquireUploadSlot(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		release()
+
+		release, err = th.App.AcquireUploadSlot(context.Background())
+		assert.NoError(t, err)
+		release()
+	})
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// checkIcon validates the icon of a board or block, which is either an
+// emoji or a custom icon referencing an image uploaded through the files
+// backend.
+func (a *App) checkIcon(icon string) error {
+	filename, err := model.ValidateIcon(icon)
+	if err != nil || filename == "" {
+		return err
+	}
+
+	if _, err := a.GetFileInfo(filename); err != nil {
+		if model.IsErrNotFound(err) {
+			return model.NewErrInvalidField("icon", fmt.Sprintf("custom icon file %s does not exist", filename))
+		}
+		return err
+	}
+	return nil
+}
+
+// checkBlockIcon validates the icon field of a block, if it has one.
+func (a *App) checkBlockIcon(block *model.Block) error {
+	value, ok := block.Fields["icon"]
+	if !ok || value == nil {
+		return nil
+	}
+
+	icon, ok := value.(string)
+	if !ok {
+		return model.NewErrInvalidField("icon", "must be a string")
+	}
+	return a.checkIcon(icon)
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestCheckIcon(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const filename = "7abcdefghijklmnopqrstuvwxyz.png"
+
+	t.Run("emoji", func(t *testing.T) {
+		require.NoError(t, th.App.checkIcon("🚀"))
+		require.True(t, model.IsErrBadRequest(th.App.checkIcon("<b>")))
+	})
+
+	t.Run("existing custom icon", func(t *testing.T) {
+		th.Store.EXPECT().GetFileInfo("abcdefghijklmnopqrstuvwxyz").Return(&mmModel.FileInfo{Id: "abcdefghijklmnopqrstuvwxyz"}, nil)
+		require.NoError(t, th.App.checkIcon(model.CustomIconPrefix+filename))
+	})
+
+	t.Run("missing custom icon", func(t *testing.T) {
+		th.Store.EXPECT().GetFileInfo("abcdefghijklmnopqrstuvwxyz").Return(nil, model.NewErrNotFound("file info"))
+		require.True(t, model.IsErrBadRequest(th.App.checkIcon(model.CustomIconPrefix+filename)))
+	})
+
+	t.Run("block icon", func(t *testing.T) {
+		require.NoError(t, th.App.checkBlockIcon(&model.Block{Fields: map[string]interface{}{}}))
+		require.NoError(t, th.App.checkBlockIcon(&model.Block{Fields: map[string]interface{}{"icon": "🚀"}}))
+		require.Error(t, th.App.checkBlockIcon(&model.Block{Fields: map[string]interface{}{"icon": 42}}))
+		require.Error(t, th.App.checkBlockIcon(&model.Block{Fields: map[string]interface{}{"icon": "><svg onload=alert(1)>"}}))
+	})
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"strings"
+)
+
+const (
+	// ImageTranscodeFormatJPEG converts the uploaded PNG images without
+	// transparency to JPEG.
+	ImageTranscodeFormatJPEG = "jpeg"
+
+	// maxTranscodePixels bounds the memory used to decode an image,
+	// which takes around 4 bytes per pixel. Larger images are stored as
+	// they are.
+	maxTranscodePixels = 25_000_000
+
+	transcodeJPEGQuality = 85
+)
+
+// IsValidImageTranscodeFormat checks that uploaded images can be
+// converted to the format. An empty format disables the conversion.
+func IsValidImageTranscodeFormat(format string) error {
+	switch format {
+	case "", ImageTranscodeFormatJPEG:
+		return nil
+	case "webp", "avif":
+		// WebP and AVIF conversion is deferred until an encoder is added
+		// to the dependencies, neither the standard library nor
+		// golang.org/x/image can encode them.
+		return fmt.Errorf("no %s encoder is available in this build, the supported format is %s", format, ImageTranscodeFormatJPEG)
+	default:
+		return fmt.Errorf("unknown image format %s, the supported format is %s", format, ImageTranscodeFormatJPEG)
+	}
+}
+
+// transcodeImage converts an uploaded image to the configured format,
+// returning the converted image and its extension. It returns false
+// when the file must be stored as it is: it isn't a PNG image, it is
+// too large to be decoded within the memory bound, it has transparency
+// that the target format can't keep, or the converted image isn't
+// smaller than the original.
+func (a *App) transcodeImage(data []byte, fileExtension string) ([]byte, string, bool) {
+	if a.config.ImageTranscodeFormat != ImageTranscodeFormatJPEG || !strings.EqualFold(fileExtension, ".png") {
+		return nil, "", false
+	}
+
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > maxTranscodePixels {
+		return nil, "", false
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", false
+	}
+
+	if opaque, ok := img.(interface{ Opaque() bool }); !ok || !opaque.Opaque() {
+		return nil, "", false
+	}
+
+	var buf bytes.Buffer
+	if err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: transcodeJPEGQuality}); err != nil {
+		return nil, "", false
+	}
+
+	if buf.Len() >= len(data) {
+		return nil, "", false
+	}
+
+	return buf.Bytes(), ".jpg", true
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestPNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// noisyImage returns an image that compresses poorly in PNG, like a
+// photo.
+func noisyImage(alpha uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	r := rand.New(rand.NewSource(1)) //nolint:gosec
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 64; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(r.Intn(256)), G: uint8(r.Intn(256)), B: uint8(r.Intn(256)), A: alpha})
+		}
+	}
+	return img
+}
+
+func TestTranscodeImage(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.ImageTranscodeFormat = ImageTranscodeFormatJPEG
+
+	t.Run("opaque PNG is converted", func(t *testing.T) {
+		data := encodeTestPNG(t, noisyImage(255))
+
+		converted, extension, ok := th.App.transcodeImage(data, ".png")
+		require.True(t, ok)
+		require.Equal(t, ".jpg", extension)
+		require.Less(t, len(converted), len(data))
+
+		_, format, err := image.DecodeConfig(bytes.NewReader(converted))
+		require.NoError(t, err)
+		require.Equal(t, "jpeg", format)
+	})
+
+	t.Run("the extension is case insensitive", func(t *testing.T) {
+		_, extension, ok := th.App.transcodeImage(encodeTestPNG(t, noisyImage(255)), ".PNG")
+		require.True(t, ok)
+		require.Equal(t, ".jpg", extension)
+	})
+
+	t.Run("PNG with transparency is kept", func(t *testing.T) {
+		_, _, ok := th.App.transcodeImage(encodeTestPNG(t, noisyImage(128)), ".png")
+		require.False(t, ok)
+	})
+
+	t.Run("other files are kept", func(t *testing.T) {
+		_, _, ok := th.App.transcodeImage([]byte("not an image"), ".png")
+		require.False(t, ok)
+
+		_, _, ok = th.App.transcodeImage(encodeTestPNG(t, noisyImage(255)), ".txt")
+		require.False(t, ok)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.config.ImageTranscodeFormat = ""
+		defer func() { th.App.config.ImageTranscodeFormat = ImageTranscodeFormatJPEG }()
+
+		_, _, ok := th.App.transcodeImage(encodeTestPNG(t, noisyImage(255)), ".png")
+		require.False(t, ok)
+	})
+}
+
+func TestIsValidImageTranscodeFormat(t *testing.T) {
+	require.NoError(t, IsValidImageTranscodeFormat(""))
+	require.NoError(t, IsValidImageTranscodeFormat(ImageTranscodeFormatJPEG))
+	require.Error(t, IsValidImageTranscodeFormat("webp"))
+	require.Error(t, IsValidImageTranscodeFormat("bmp"))
+}
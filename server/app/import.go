@@ -22,6 +22,10 @@ import (
 const (
 	archiveVersion  = 2
 	legacyFileBegin = "{\"version\":1"
+
+	// importBlockIDsBatchSize bounds the number of IDs looked up at once
+	// when checking the imported block IDs.
+	importBlockIDsBatchSize = 500
 )
 
 var (
@@ -123,6 +127,59 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 // ImportBoardJSONL imports a JSONL file containing blocks for one board. The resulting
 // board id is returned.
 func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (string, error) {
+	boardsAndBlocks, err := a.parseBoardJSONL(r, opt)
+	if err != nil {
+		return "", err
+	}
+
+	a.fixBoardsandBlocks(boardsAndBlocks, opt)
+
+	newID := model.RandomIDGenerator
+	if opt.IDStrategy == model.IDStrategyDeterministic {
+		newID = model.NewDeterministicIDGenerator(opt.TeamID)
+	}
+
+	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDsWith(boardsAndBlocks, newID, a.logger)
+	if err != nil {
+		return "", fmt.Errorf("error generating archive block IDs: %w", err)
+	}
+
+	if opt.IDStrategy == model.IDStrategyDeterministic {
+		if err = a.checkCanUpdateImportedBoards(boardsAndBlocks.Boards, opt.ModifiedBy); err != nil {
+			return "", err
+		}
+		if err = a.checkImportedBlockIDs(boardsAndBlocks.Blocks); err != nil {
+			return "", err
+		}
+	}
+
+	boardsAndBlocks, err = a.CreateBoardsAndBlocks(boardsAndBlocks, opt.ModifiedBy, false)
+	if err != nil {
+		return "", fmt.Errorf("error inserting archive blocks: %w", err)
+	}
+
+	// add user to all the new boards.
+	for _, board := range boardsAndBlocks.Boards {
+		boardMember := &model.BoardMember{
+			BoardID:     board.ID,
+			UserID:      opt.ModifiedBy,
+			SchemeAdmin: true,
+		}
+		if _, err := a.AddMemberToBoard(boardMember); err != nil {
+			return "", fmt.Errorf("cannot add member to board: %w", err)
+		}
+	}
+
+	// find new board id
+	for _, board := range boardsAndBlocks.Boards {
+		return board.ID, nil
+	}
+	return "", fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
+}
+
+// parseBoardJSONL reads the boards and blocks of a JSONL file for one
+// board, without modifying or storing them.
+func (a *App) parseBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*model.BoardsAndBlocks, error) {
 	// TODO: Stream this once `model.GenerateBlockIDs` can take a stream of blocks.
 	//       We don't want to load the whole file in memory, even though it's a single board.
 	boardsAndBlocks := &model.BoardsAndBlocks{
@@ -154,7 +211,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 			if !skip {
 				var archiveLine model.ArchiveLine
 				if err := json.Unmarshal(line, &archiveLine); err != nil {
-					return "", fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
+					return nil, fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
 				}
 
 				// first line must be a board
@@ -166,7 +223,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 				case "board":
 					var board model.Board
 					if err2 := json.Unmarshal(archiveLine.Data, &board); err2 != nil {
-						return "", fmt.Errorf("invalid board in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid board in archive line %d: %w", lineNum, err2)
 					}
 					board.ModifiedBy = userID
 					board.UpdateAt = now
@@ -177,27 +234,27 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 					// legacy archives encoded boards as blocks; we need to convert them to real boards.
 					var block model.Block
 					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid board block in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid board block in archive line %d: %w", lineNum, err2)
 					}
 					block.ModifiedBy = userID
 					block.UpdateAt = now
 					board, err := a.blockToBoard(&block, opt)
 					if err != nil {
-						return "", fmt.Errorf("cannot convert archive line %d to block: %w", lineNum, err)
+						return nil, fmt.Errorf("cannot convert archive line %d to block: %w", lineNum, err)
 					}
 					boardsAndBlocks.Boards = append(boardsAndBlocks.Boards, board)
 					boardID = board.ID
 				case "block":
 					var block model.Block
 					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid block in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid block in archive line %d: %w", lineNum, err2)
 					}
 					block.ModifiedBy = userID
 					block.UpdateAt = now
 					block.BoardID = boardID
 					boardsAndBlocks.Blocks = append(boardsAndBlocks.Blocks, block)
 				default:
-					return "", model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
+					return nil, model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
 				}
 				firstLine = false
 			}
@@ -207,41 +264,66 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 			if errors.Is(errRead, io.EOF) {
 				break
 			}
-			return "", fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
+			return nil, fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
 		}
 		lineNum++
 	}
 
-	a.fixBoardsandBlocks(boardsAndBlocks, opt)
+	return boardsAndBlocks, nil
+}
 
-	var err error
-	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDs(boardsAndBlocks, a.logger)
-	if err != nil {
-		return "", fmt.Errorf("error generating archive block IDs: %w", err)
-	}
+// checkCanUpdateImportedBoards ensures that a deterministic import will
+// only update boards that already exist if the user can edit them.
+func (a *App) checkCanUpdateImportedBoards(boards []*model.Board, userID string) error {
+	for _, board := range boards {
+		_, err := a.store.GetBoard(board.ID)
+		if model.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
 
-	boardsAndBlocks, err = a.CreateBoardsAndBlocks(boardsAndBlocks, opt.ModifiedBy, false)
-	if err != nil {
-		return "", fmt.Errorf("error inserting archive blocks: %w", err)
+		member, err := a.store.GetMemberForBoard(board.ID, userID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+		if member == nil || !(member.SchemeAdmin || member.SchemeEditor) {
+			return model.NewErrPermission(fmt.Sprintf("cannot update existing board %s during import", board.ID))
+		}
 	}
+	return nil
+}
 
-	// add user to all the new boards.
-	for _, board := range boardsAndBlocks.Boards {
-		boardMember := &model.BoardMember{
-			BoardID:     board.ID,
-			UserID:      opt.ModifiedBy,
-			SchemeAdmin: true,
+// checkImportedBlockIDs ensures that a deterministic import doesn't
+// reuse the ID of a block of another board. The IDs only depend on the
+// team and the original IDs, so the archives of boards that share block
+// IDs, like a board and its duplicate, would update each other's blocks.
+func (a *App) checkImportedBlockIDs(blocks []model.Block) error {
+	for start := 0; start < len(blocks); start += importBlockIDsBatchSize {
+		end := start + importBlockIDsBatchSize
+		if end > len(blocks) {
+			end = len(blocks)
 		}
-		if _, err := a.AddMemberToBoard(boardMember); err != nil {
-			return "", fmt.Errorf("cannot add member to board: %w", err)
+
+		boardIDs := make(map[string]string, end-start)
+		blockIDs := make([]string, 0, end-start)
+		for _, block := range blocks[start:end] {
+			boardIDs[block.ID] = block.BoardID
+			blockIDs = append(blockIDs, block.ID)
 		}
-	}
 
-	// find new board id
-	for _, board := range boardsAndBlocks.Boards {
-		return board.ID, nil
+		existingBlocks, err := a.store.GetBlocksByIDs(blockIDs)
+		if err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+		for _, existing := range existingBlocks {
+			if existing.BoardID != boardIDs[existing.ID] {
+				return model.NewErrBadRequest(fmt.Sprintf("block %s already exists in another board", existing.ID))
+			}
+		}
 	}
-	return "", fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
+	return nil
 }
 
 // fixBoardsandBlocks allows the caller of `ImportArchive` to modify or filters boards and blocks being
@@ -43,6 +43,8 @@ func TestApp_ImportArchive(t *testing.T) {
 			ModifiedBy: "user",
 		}
 
+		th.Store.EXPECT().GetTeamFeatureFlags("test-team").Return(map[string]string{}, nil)
+		th.Store.EXPECT().GetTeam("test-team").Return(&model.Team{ID: "test-team"}, nil)
 		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").Return(babs, nil)
 		th.Store.EXPECT().GetMembersForBoard(board.ID).AnyTimes().Return([]*model.BoardMember{boardMember}, nil)
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
@@ -51,6 +53,23 @@ func TestApp_ImportArchive(t *testing.T) {
 		err := th.App.ImportArchive(r, opts)
 		require.NoError(t, err, "import archive should not fail")
 	})
+
+	t.Run("deterministic import with a block of another board", func(t *testing.T) {
+		r := bytes.NewReader([]byte(asana))
+		opts := model.ImportArchiveOptions{
+			TeamID:     "test-team",
+			ModifiedBy: "user",
+			IDStrategy: model.IDStrategyDeterministic,
+		}
+
+		th.Store.EXPECT().GetBoard(gomock.Any()).Return(nil, model.NewErrNotFound("board"))
+		th.Store.EXPECT().GetBlocksByIDs(gomock.Any()).DoAndReturn(func(ids []string) ([]model.Block, error) {
+			return []model.Block{{ID: ids[0], BoardID: "other-board"}}, model.NewErrNotAllFound("block", ids)
+		})
+
+		err := th.App.ImportArchive(r, opts)
+		require.True(t, model.IsErrBadRequest(err), "the import should be rejected, got %v", err)
+	})
 }
 
 //nolint:lll
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+
+	"github.com/krolaw/zipstream"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// archiveBoardSummary holds what's needed to check the references of
+// a board once the whole archive has been read.
+type archiveBoardSummary struct {
+	name       string
+	legacy     bool
+	blocks     []model.Block
+	blockIDs   map[string]bool
+	fileNames  map[string]bool
+	boardIDs   map[string]bool
+	imageFiles []string
+}
+
+// ValidateArchive reads an archive as ImportArchive does and returns a
+// summary of its content and of the issues found, without storing
+// anything.
+func (a *App) ValidateArchive(r io.Reader, opt model.ImportArchiveOptions) *model.ArchiveValidation {
+	validation := &model.ArchiveValidation{
+		Errors:   []string{},
+		Warnings: []string{},
+	}
+
+	br := bufio.NewReader(r)
+	peek, errPeek := br.Peek(len(legacyFileBegin))
+	if errPeek == nil && string(peek) == legacyFileBegin {
+		summary := a.validateArchiveBoard(validation, br, "", opt)
+		if summary != nil {
+			summary.legacy = true
+			checkArchiveBoardReferences(validation, summary)
+		}
+		validation.Valid = len(validation.Errors) == 0
+		return validation
+	}
+
+	zr := zipstream.NewReader(br)
+	foundVersion := false
+	boards := map[string]*archiveBoardSummary{}
+	boardDirs := []string{}
+
+	for {
+		hdr, err := zr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			validation.Errors = append(validation.Errors, fmt.Sprintf("cannot read the archive: %s", err))
+			break
+		}
+
+		dir, filename := filepath.Split(hdr.Name)
+		dir = path.Clean(dir)
+
+		switch filename {
+		case "version.json":
+			foundVersion = true
+			ver, errVer := parseVersionFile(zr)
+			if errVer != nil {
+				validation.Errors = append(validation.Errors, errVer.Error())
+				continue
+			}
+			if ver != archiveVersion {
+				validation.Errors = append(validation.Errors, model.NewErrUnsupportedArchiveVersion(ver, archiveVersion).Error())
+			}
+		case "board.jsonl":
+			if summary := a.validateArchiveBoard(validation, zr, dir, opt); summary != nil {
+				boards[dir] = summary
+				boardDirs = append(boardDirs, dir)
+			}
+		default:
+			summary, ok := boards[dir]
+			if !ok {
+				validation.Warnings = append(validation.Warnings,
+					fmt.Sprintf("file %s is not in a board directory and will be skipped", hdr.Name))
+				continue
+			}
+			summary.fileNames[filename] = true
+			validation.AttachmentCount++
+		}
+	}
+
+	if !foundVersion {
+		validation.Warnings = append(validation.Warnings, "the archive has no version.json file")
+	}
+
+	for _, dir := range boardDirs {
+		checkArchiveBoardReferences(validation, boards[dir])
+	}
+
+	validation.Valid = len(validation.Errors) == 0
+	return validation
+}
+
+// validateArchiveBoard parses a board file with the same logic as the
+// import, adds its content to the validation and returns its summary,
+// or nil if the board can't be imported.
+func (a *App) validateArchiveBoard(validation *model.ArchiveValidation, r io.Reader, dir string, opt model.ImportArchiveOptions) *archiveBoardSummary {
+	prefix := "board"
+	if dir != "" {
+		prefix = "board " + dir
+	}
+
+	boardsAndBlocks, err := a.parseBoardJSONL(r, opt)
+	if err != nil {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("%s: %s", prefix, err))
+		return nil
+	}
+
+	if err = boardsAndBlocks.IsValid(); err != nil {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("%s: %s", prefix, err))
+		return nil
+	}
+
+	summary := &archiveBoardSummary{
+		name:      prefix,
+		blocks:    boardsAndBlocks.Blocks,
+		blockIDs:  map[string]bool{},
+		fileNames: map[string]bool{},
+		boardIDs:  map[string]bool{},
+	}
+
+	for _, board := range boardsAndBlocks.Boards {
+		summary.boardIDs[board.ID] = true
+	}
+	validation.BoardCount += len(boardsAndBlocks.Boards)
+
+	for _, block := range boardsAndBlocks.Blocks {
+		summary.blockIDs[block.ID] = true
+
+		switch block.Type {
+		case model.TypeCard:
+			validation.CardCount++
+		case model.TypeImage:
+			filename, errImage := extractImageFilename(block)
+			if errImage != nil {
+				validation.Warnings = append(validation.Warnings,
+					fmt.Sprintf("%s: image block %s has no file", prefix, block.ID))
+				continue
+			}
+			summary.imageFiles = append(summary.imageFiles, filename)
+		}
+	}
+
+	return summary
+}
+
+// checkArchiveBoardReferences checks that the blocks reference parents
+// and files included in the archive. Dangling references don't make the
+// import fail, so they are reported as warnings.
+func checkArchiveBoardReferences(validation *model.ArchiveValidation, summary *archiveBoardSummary) {
+	for _, block := range summary.blocks {
+		if block.ParentID == "" || summary.boardIDs[block.ParentID] || summary.blockIDs[block.ParentID] {
+			continue
+		}
+		validation.Warnings = append(validation.Warnings,
+			fmt.Sprintf("%s: block %s references the missing parent %s", summary.name, block.ID, block.ParentID))
+	}
+
+	// legacy archives don't include the files, so they can't be checked.
+	if summary.legacy {
+		return
+	}
+
+	for _, filename := range summary.imageFiles {
+		if !summary.fileNames[filename] {
+			validation.Warnings = append(validation.Warnings,
+				fmt.Sprintf("%s: image file %s is missing from the archive", summary.name, filename))
+		}
+	}
+}
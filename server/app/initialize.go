@@ -23,4 +23,10 @@ func (a *App) Shutdown() {
 			a.logger.Warn("blockChangeNotifier shutdown timed out")
 		}
 	}
+
+	if a.webhook != nil {
+		a.webhook.Flush()
+	}
+
+	a.userBoardViews.flush()
 }
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/pkg/errors"
+)
+
+// CreateInvite creates an invite to register and join a team with the
+// given role. A zero ttl uses DefaultInviteTTL and a zero maxUses makes
+// the invite single-use.
+func (a *App) CreateInvite(teamID string, role model.BoardRole, ttl time.Duration, maxUses int) (*model.Invite, error) {
+	if ttl == 0 {
+		ttl = model.DefaultInviteTTL
+	}
+	if maxUses == 0 {
+		maxUses = 1
+	}
+
+	now := utils.GetMillis()
+	invite := &model.Invite{
+		Token:     utils.NewID(utils.IDTypeToken),
+		TeamID:    teamID,
+		Role:      role,
+		MaxUses:   maxUses,
+		CreateAt:  now,
+		ExpiresAt: now + ttl.Milliseconds(),
+	}
+
+	if err := invite.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.store.CreateInvite(invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// GetInvitesForTeam returns the invites of a team, including the ones
+// that expired or were used up.
+func (a *App) GetInvitesForTeam(teamID string) ([]*model.Invite, error) {
+	return a.store.GetInvitesForTeam(teamID)
+}
+
+// RevokeInvite deletes an invite so it can't be used anymore.
+func (a *App) RevokeInvite(token string) error {
+	return a.store.DeleteInvite(token)
+}
+
+// GetUsableInvite returns an invite if it exists and can still be used
+// to register.
+func (a *App) GetUsableInvite(token string) (*model.Invite, error) {
+	invite, err := a.store.GetInvite(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !invite.IsUsable(utils.GetMillis()) {
+		return nil, model.NewErrNotFound("invite")
+	}
+	return invite, nil
+}
+
+// RegisterUserWithInvite registers a new user with an invite and adds
+// them to the open boards of the invite team with the invite role.
+func (a *App) RegisterUserWithInvite(token, username, email, password string) error {
+	invite, err := a.GetUsableInvite(token)
+	if err != nil {
+		return err
+	}
+
+	user, err := a.newRegisteredUser(username, email, password)
+	if err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	user, err = a.store.CreateUserWithInvite(user, token)
+	if err != nil {
+		if model.IsErrNotFound(err) {
+			return err
+		}
+		return errors.Wrap(err, "Unable to create the new user")
+	}
+
+	boards, err := a.store.GetBoardsForUserAndTeam(user.ID, invite.TeamID, true)
+	if err != nil {
+		return errors.Wrap(err, "Unable to get the boards of the invite team")
+	}
+
+	for _, board := range boards {
+		if board.Type != model.BoardTypeOpen {
+			continue
+		}
+
+		member := &model.BoardMember{
+			BoardID:         board.ID,
+			UserID:          user.ID,
+			SchemeAdmin:     invite.Role == model.BoardRoleAdmin,
+			SchemeEditor:    invite.Role == model.BoardRoleAdmin || invite.Role == model.BoardRoleEditor,
+			SchemeCommenter: invite.Role == model.BoardRoleCommenter,
+			SchemeViewer:    invite.Role == model.BoardRoleViewer,
+		}
+		if _, err := a.AddMemberToBoard(member); err != nil {
+			a.logger.Error("Unable to add the invited user to a board",
+				mlog.String("boardID", board.ID),
+				mlog.String("userID", user.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	return nil
+}
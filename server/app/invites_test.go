@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInvite(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("defaults to a single-use invite", func(t *testing.T) {
+		th.Store.EXPECT().CreateInvite(gomock.Any()).Return(nil)
+
+		invite, err := th.App.CreateInvite("team-id", model.BoardRoleViewer, 0, 0)
+		require.NoError(t, err)
+		require.NotEmpty(t, invite.Token)
+		require.Equal(t, 1, invite.MaxUses)
+		require.Equal(t, model.DefaultInviteTTL.Milliseconds(), invite.ExpiresAt-invite.CreateAt)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		_, err := th.App.CreateInvite("team-id", model.BoardRoleNone, time.Hour, 1)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("TTL too long", func(t *testing.T) {
+		_, err := th.App.CreateInvite("team-id", model.BoardRoleEditor, model.MaxInviteTTL+time.Hour, 1)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestGetUsableInvite(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	invite := &model.Invite{
+		Token:     "token",
+		TeamID:    "team-id",
+		Role:      model.BoardRoleEditor,
+		MaxUses:   1,
+		UseCount:  1,
+		ExpiresAt: model.GetMillis() + time.Hour.Milliseconds(),
+	}
+	th.Store.EXPECT().GetInvite("token").Return(invite, nil)
+
+	_, err := th.App.GetUsableInvite("token")
+	require.True(t, model.IsErrNotFound(err))
+}
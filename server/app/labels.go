@@ -0,0 +1,175 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetLabels returns the labels defined in a board.
+func (a *App) GetLabels(boardID string) ([]model.Label, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return board.GetLabels(), nil
+}
+
+// CreateLabel adds a new label to a board, creating the board label
+// property if it doesn't exist yet.
+func (a *App) CreateLabel(boardID string, label *model.Label, userID string) (*model.Label, error) {
+	if err := label.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.IsFeatureEnabled(board.TeamID, model.FeatureFlagLabels, true) {
+		return nil, model.NewErrForbidden("labels are disabled for this team")
+	}
+
+	label.ID = utils.NewID(utils.IDTypeNone)
+	labels := append(board.GetLabels(), *label)
+
+	if err := a.updateLabelProperty(board, labels, userID); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// PatchLabel renames or recolors a label. As the cards reference the
+// labels by ID, the change is reflected in all of them.
+func (a *App) PatchLabel(boardID, labelID string, patch *model.LabelPatch, userID string) (*model.Label, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := board.GetLabels()
+	var patchedLabel *model.Label
+	for i := range labels {
+		if labels[i].ID == labelID {
+			patchedLabel = patch.Patch(&labels[i])
+			break
+		}
+	}
+
+	if patchedLabel == nil {
+		return nil, model.NewErrNotFound("label ID=" + labelID)
+	}
+
+	if err := patchedLabel.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.updateLabelProperty(board, labels, userID); err != nil {
+		return nil, err
+	}
+	return patchedLabel, nil
+}
+
+// DeleteLabel removes a label from the board and from all the cards
+// that use it in a single transaction.
+func (a *App) DeleteLabel(boardID, labelID, userID string) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	labels := []model.Label{}
+	for _, label := range board.GetLabels() {
+		if label.ID == labelID {
+			found = true
+			continue
+		}
+		labels = append(labels, label)
+	}
+
+	if !found {
+		return model.NewErrNotFound("label ID=" + labelID)
+	}
+
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard.String())
+	if err != nil {
+		return fmt.Errorf("cannot get the cards of board %s: %w", boardID, err)
+	}
+
+	prop := labelPropertyWithLabels(board, labels)
+	propertyID, _ := prop["id"].(string)
+
+	pbab := &model.PatchBoardsAndBlocks{
+		BoardIDs: []string{boardID},
+		BoardPatches: []*model.BoardPatch{
+			{UpdatedCardProperties: []map[string]interface{}{prop}},
+		},
+	}
+
+	for _, card := range cards {
+		props, ok := card.Fields["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		labelIDs, ok := props[propertyID].([]interface{})
+		if !ok {
+			continue
+		}
+
+		newLabelIDs := []interface{}{}
+		for _, id := range labelIDs {
+			if id != labelID {
+				newLabelIDs = append(newLabelIDs, id)
+			}
+		}
+
+		if len(newLabelIDs) == len(labelIDs) {
+			continue
+		}
+
+		newProps := make(map[string]interface{}, len(props))
+		for key, value := range props {
+			newProps[key] = value
+		}
+		newProps[propertyID] = newLabelIDs
+
+		pbab.BlockIDs = append(pbab.BlockIDs, card.ID)
+		pbab.BlockPatches = append(pbab.BlockPatches, &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"properties": newProps},
+		})
+	}
+
+	_, err = a.PatchBoardsAndBlocks(pbab, userID)
+	return err
+}
+
+func (a *App) updateLabelProperty(board *model.Board, labels []model.Label, userID string) error {
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{labelPropertyWithLabels(board, labels)},
+	}
+
+	_, err := a.PatchBoard(patch, board.ID, userID)
+	return err
+}
+
+// labelPropertyWithLabels returns the label property of the board with
+// its options replaced by the labels, keeping the rest of its fields.
+func labelPropertyWithLabels(board *model.Board, labels []model.Label) map[string]interface{} {
+	existingProp := board.GetLabelProperty()
+	if existingProp == nil {
+		return model.NewLabelProperty(utils.NewID(utils.IDTypeNone), labels)
+	}
+
+	propertyID, _ := existingProp["id"].(string)
+	prop := model.NewLabelProperty(propertyID, labels)
+	for key, value := range existingProp {
+		if key != "options" {
+			prop[key] = value
+		}
+	}
+	return prop
+}
@@ -26,6 +26,8 @@ func TestPrepareOnboardingTour(t *testing.T) {
 		}
 
 		th.Store.EXPECT().GetTemplateBoards("0", "").Return([]*model.Board{&welcomeBoard}, nil)
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+		th.Store.EXPECT().GetTeam(teamID).Return(&model.Team{ID: teamID}, nil)
 		th.Store.EXPECT().DuplicateBoard(welcomeBoard.ID, userID, teamID, false).Return(&model.BoardsAndBlocks{Boards: []*model.Board{&welcomeBoard}},
 			nil, nil)
 		th.Store.EXPECT().GetMembersForBoard(welcomeBoard.ID).Return([]*model.BoardMember{}, nil).Times(3)
@@ -74,6 +76,8 @@ func TestCreateWelcomeBoard(t *testing.T) {
 			IsTemplate: true,
 		}
 		th.Store.EXPECT().GetTemplateBoards("0", "").Return([]*model.Board{&welcomeBoard}, nil)
+		th.Store.EXPECT().GetTeamFeatureFlags(teamID).Return(map[string]string{}, nil)
+		th.Store.EXPECT().GetTeam(teamID).Return(&model.Team{ID: teamID}, nil)
 		th.Store.EXPECT().DuplicateBoard(welcomeBoard.ID, userID, teamID, false).
 			Return(&model.BoardsAndBlocks{Boards: []*model.Board{&welcomeBoard}}, nil, nil)
 		th.Store.EXPECT().GetMembersForBoard(welcomeBoard.ID).Return([]*model.BoardMember{}, nil).Times(3)
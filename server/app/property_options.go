@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// ReorderPropertyOptions changes the order of the options of a card
+// property, which is the order of the columns of the views grouped by
+// it. The cards reference the options by ID, so their values are not
+// affected.
+func (a *App) ReorderPropertyOptions(boardID, propertyID string, optionIDs []string, userID string) (*model.Board, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var property map[string]interface{}
+	for _, prop := range board.CardProperties {
+		if id, _ := prop["id"].(string); id == propertyID {
+			property = prop
+			break
+		}
+	}
+
+	if property == nil {
+		return nil, model.NewErrNotFound("property ID=" + propertyID)
+	}
+
+	options, ok := property["options"].([]interface{})
+	if !ok {
+		return nil, model.NewErrBadRequest("property " + propertyID + " has no options")
+	}
+
+	optionsByID := make(map[string]interface{}, len(options))
+	for _, optionIface := range options {
+		option, ok := optionIface.(map[string]interface{})
+		if !ok {
+			return nil, model.ErrInvalidPropSchema
+		}
+		id, _ := option["id"].(string)
+		optionsByID[id] = option
+	}
+
+	if len(optionIDs) != len(optionsByID) {
+		return nil, model.NewErrInvalidField("optionIds", "must contain all the options of the property")
+	}
+
+	newOptions := make([]interface{}, 0, len(optionIDs))
+	for _, optionID := range optionIDs {
+		option, ok := optionsByID[optionID]
+		if !ok {
+			return nil, model.NewErrInvalidField("optionIds", fmt.Sprintf("option %s does not exist or is repeated", optionID))
+		}
+		newOptions = append(newOptions, option)
+		delete(optionsByID, optionID)
+	}
+
+	newProperty := make(map[string]interface{}, len(property))
+	for key, value := range property {
+		newProperty[key] = value
+	}
+	newProperty["options"] = newOptions
+
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{newProperty},
+	}
+	return a.PatchBoard(patch, boardID, userID)
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetPropertyVisibilityRules returns the visibility rules of the
+// properties of a board.
+func (a *App) GetPropertyVisibilityRules(boardID string) ([]model.PropertyVisibilityRule, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return board.GetPropertyVisibilityRules(), nil
+}
+
+// SetPropertyVisibilityRule sets the visibility rule of a property,
+// replacing the existing one. The rule is validated with the rest of
+// the board properties.
+func (a *App) SetPropertyVisibilityRule(boardID string, rule *model.PropertyVisibilityRule, userID string) (*model.PropertyVisibilityRule, error) {
+	if err := a.updatePropertyVisibilityRule(boardID, rule.PropertyID, rule, userID); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeletePropertyVisibilityRule removes the visibility rule of a
+// property, so it's always visible.
+func (a *App) DeletePropertyVisibilityRule(boardID, propertyID, userID string) error {
+	return a.updatePropertyVisibilityRule(boardID, propertyID, nil, userID)
+}
+
+func (a *App) updatePropertyVisibilityRule(boardID, propertyID string, rule *model.PropertyVisibilityRule, userID string) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	var property map[string]interface{}
+	for _, prop := range board.CardProperties {
+		if id, _ := prop["id"].(string); id == propertyID {
+			property = prop
+			break
+		}
+	}
+
+	if property == nil {
+		return model.NewErrNotFound("property ID=" + propertyID)
+	}
+
+	patch := &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{model.SetPropertyVisibilityRule(property, rule)},
+	}
+	_, err = a.PatchBoard(patch, boardID, userID)
+	return err
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// AddRecentBoard records that the user opened the board. Only the last
+// boards opened by each user are kept.
+func (a *App) AddRecentBoard(userID, boardID string) error {
+	if a.config.MaxRecentBoards <= 0 {
+		return nil
+	}
+
+	if _, err := a.store.GetBoard(boardID); err != nil {
+		return err
+	}
+
+	recent := &model.RecentBoard{
+		UserID:   userID,
+		BoardID:  boardID,
+		ViewedAt: utils.GetMillis(),
+	}
+	return a.store.SaveRecentBoard(recent, a.config.MaxRecentBoards)
+}
+
+// GetRecentBoards returns the boards the user opened, the most recently
+// opened first.
+func (a *App) GetRecentBoards(userID string) ([]*model.Board, error) {
+	if a.config.MaxRecentBoards <= 0 {
+		return []*model.Board{}, nil
+	}
+
+	boards, err := a.store.GetRecentBoards(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the limit may have been lowered since the boards were opened
+	if len(boards) > a.config.MaxRecentBoards {
+		boards = boards[:a.config.MaxRecentBoards]
+	}
+	return boards, nil
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// rollupGroupByTypes are the property types that can group the cards of
+// a rollup, as they hold a single value per card.
+var rollupGroupByTypes = map[string]bool{
+	"select":   true,
+	"person":   true,
+	"checkbox": true,
+}
+
+// GetBoardRollup aggregates the cards of a board, optionally grouped by
+// the value of a property. Select groups are returned in the order of
+// the property options, like the board columns.
+func (a *App) GetBoardRollup(boardID string, opts model.QueryRollupOptions) ([]*model.RollupGroup, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupByDef, ofDef *model.PropDef
+	if opts.GroupBy != "" {
+		def, err := rollupProperty(schema, "groupBy", opts.GroupBy)
+		if err != nil {
+			return nil, err
+		}
+		if !rollupGroupByTypes[def.Type] {
+			return nil, model.NewErrInvalidField("groupBy", fmt.Sprintf("cannot group by a property of type %s", def.Type))
+		}
+		groupByDef = def
+	}
+
+	switch opts.Aggregation {
+	case model.RollupCount:
+		opts.Of = ""
+	case model.RollupSum, model.RollupAvg:
+		if opts.Of == "" {
+			return nil, model.NewErrInvalidField("of", fmt.Sprintf("is required by the %s aggregation", opts.Aggregation))
+		}
+		def, err := rollupProperty(schema, "of", opts.Of)
+		if err != nil {
+			return nil, err
+		}
+		if def.Type != model.PropertyTypeNumber {
+			return nil, model.NewErrInvalidField("of", fmt.Sprintf("cannot aggregate a property of type %s", def.Type))
+		}
+		ofDef = def
+	default:
+		return nil, model.NewErrInvalidField("agg", fmt.Sprintf("unknown aggregation %s", opts.Aggregation))
+	}
+
+	groups, err := a.store.GetBoardRollup(boardID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if ofDef != nil {
+		for _, group := range groups {
+			group.FormattedResult = ofDef.NumberFormat.FormatValue(group.Result)
+		}
+	}
+
+	if groupByDef != nil && groupByDef.Type == "select" {
+		sortRollupGroupsByOption(groups, groupByDef)
+	}
+
+	return groups, nil
+}
+
+// rollupProperty returns the definition of a property referenced by a
+// rollup parameter.
+func rollupProperty(schema model.PropSchema, param, propertyID string) (*model.PropDef, error) {
+	// the ID becomes part of a JSON path in the query
+	if strings.ContainsAny(propertyID, `"\`) {
+		return nil, model.NewErrInvalidField(param, "invalid property ID")
+	}
+
+	def, ok := schema[propertyID]
+	if !ok {
+		return nil, model.NewErrInvalidField(param, fmt.Sprintf("property %s does not exist", propertyID))
+	}
+	return &def, nil
+}
+
+// sortRollupGroupsByOption sorts the groups following the options of a
+// select property. The cards without value come first and the values
+// that don't match any option last.
+func sortRollupGroupsByOption(groups []*model.RollupGroup, def *model.PropDef) {
+	position := func(value string) int {
+		if value == "" {
+			return -1
+		}
+		if opt, ok := def.Options[value]; ok {
+			return opt.Index
+		}
+		return len(def.Options)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return position(groups[i].Value) < position(groups[j].Value)
+	})
+}
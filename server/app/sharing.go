@@ -2,6 +2,7 @@ package app
 
 import (
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 func (a *App) GetSharing(boardID string) (*model.Sharing, error) {
@@ -15,3 +16,48 @@ func (a *App) GetSharing(boardID string) (*model.Sharing, error) {
 func (a *App) UpsertSharing(sharing model.Sharing) error {
 	return a.store.UpsertSharing(sharing)
 }
+
+// ShareNewBoard enables the shared link of a board created as public.
+// The board is not shared if the public shared boards are disabled.
+func (a *App) ShareNewBoard(boardID, userID string) error {
+	if !a.config.EnablePublicSharedBoards {
+		return nil
+	}
+
+	return a.store.UpsertSharing(model.Sharing{
+		ID:         boardID,
+		Enabled:    true,
+		Token:      utils.NewID(utils.IDTypeToken),
+		ModifiedBy: userID,
+	})
+}
+
+// FilterSharedBoard removes from the board the card properties that are
+// not visible through its shared link.
+func (a *App) FilterSharedBoard(board *model.Board) (*model.Board, error) {
+	sharing, err := a.getSharingForFilter(board.ID)
+	if err != nil {
+		return nil, err
+	}
+	return sharing.FilterBoard(board), nil
+}
+
+// FilterSharedBlocks removes from the cards the property values that are
+// not visible through the shared link of their board.
+func (a *App) FilterSharedBlocks(boardID string, blocks []model.Block) ([]model.Block, error) {
+	sharing, err := a.getSharingForFilter(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return sharing.FilterBlocks(blocks), nil
+}
+
+// getSharingForFilter returns the sharing of the board, or nil if the
+// board has never been shared, which doesn't restrict any property.
+func (a *App) getSharingForFilter(boardID string) (*model.Sharing, error) {
+	sharing, err := a.store.GetSharing(boardID)
+	if model.IsErrNotFound(err) {
+		return nil, nil
+	}
+	return sharing, err
+}
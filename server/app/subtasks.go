@@ -0,0 +1,280 @@
+package app
+
+import (
+	"reflect"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetSubtaskRollup returns the completion of the subtasks of a card,
+// following the subtask rule of its board.
+func (a *App) GetSubtaskRollup(cardID string) (*model.SubtaskRollup, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Type != model.TypeCard {
+		return nil, model.NewErrNotFound("card ID=" + cardID)
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	return a.getSubtaskRollup(board, cardID)
+}
+
+func (a *App) getSubtaskRollup(board *model.Board, cardID string) (*model.SubtaskRollup, error) {
+	subtasks, err := a.store.GetBlocksWithParentAndType(board.ID, cardID, model.TypeCard.String())
+	if err != nil {
+		return nil, err
+	}
+
+	rule := board.GetSubtaskRule()
+	rollup := &model.SubtaskRollup{
+		CardID:       cardID,
+		SubtaskIDs:   []string{},
+		CompletedIDs: []string{},
+		Total:        len(subtasks),
+	}
+	for i := range subtasks {
+		rollup.SubtaskIDs = append(rollup.SubtaskIDs, subtasks[i].ID)
+		if rule != nil && rule.IsCompleted(&subtasks[i]) {
+			rollup.CompletedIDs = append(rollup.CompletedIDs, subtasks[i].ID)
+		}
+	}
+	rollup.Completed = len(rollup.CompletedIDs)
+	return rollup, nil
+}
+
+// SetSubtaskRule sets the subtask rule of a board, replacing the
+// existing one.
+func (a *App) SetSubtaskRule(boardID string, rule *model.SubtaskRule, userID string) (*model.SubtaskRule, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = rule.IsValid(board); err != nil {
+		return nil, err
+	}
+
+	if _, err = a.PatchBoard(model.SubtaskRuleBoardPatch(rule), boardID, userID); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteSubtaskRule removes the subtask rule of a board, so its subtasks
+// are never completed.
+func (a *App) DeleteSubtaskRule(boardID, userID string) error {
+	_, err := a.PatchBoard(model.SubtaskRuleBoardPatch(nil), boardID, userID)
+	return err
+}
+
+// validateSubtaskParent checks that a card can be a subtask of a parent
+// card. Only the cards of the board can have subtasks, so there is a
+// single level of them.
+func (a *App) validateSubtaskParent(boardID, cardID, parentID string) error {
+	if parentID == cardID {
+		return model.NewErrBadRequest("a card cannot be a subtask of itself")
+	}
+
+	parent, err := a.store.GetBlock(parentID)
+	if model.IsErrNotFound(err) {
+		return model.NewErrBadRequest("parent card " + parentID + " not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if parent.BoardID != boardID || parent.Type != model.TypeCard {
+		return model.NewErrBadRequest("parent card " + parentID + " must be a card of the board")
+	}
+	if model.IsSubtask(parent) {
+		return model.NewErrBadRequest("parent card " + parentID + " is a subtask")
+	}
+
+	if cardID == "" {
+		return nil
+	}
+
+	subtasks, err := a.store.GetBlocksWithParentAndType(boardID, cardID, model.TypeCard.String())
+	if err != nil {
+		return err
+	}
+	if len(subtasks) > 0 {
+		return model.NewErrBadRequest("card " + cardID + " has subtasks")
+	}
+	return nil
+}
+
+// subtaskParentPatch returns the parent ID to patch a card with to make
+// it a subtask of a parent card, or a card of the board if the parent
+// is empty.
+func (a *App) subtaskParentPatch(cardID, parentID string) (*string, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Type != model.TypeCard {
+		return nil, model.NewErrNotFound("card ID=" + cardID)
+	}
+
+	if parentID == "" {
+		boardID := card.BoardID
+		return &boardID, nil
+	}
+
+	if err = a.validateSubtaskParent(card.BoardID, cardID, parentID); err != nil {
+		return nil, err
+	}
+	return &parentID, nil
+}
+
+// applySubtaskRule sets the parent value of the subtask rule on the
+// parent card of a changed subtask once all of its subtasks are
+// completed. The value isn't removed if a subtask is reopened later.
+func (a *App) applySubtaskRule(block *model.Block) {
+	if !model.IsSubtask(block) {
+		return
+	}
+
+	board, err := a.store.GetBoard(block.BoardID)
+	if err != nil {
+		a.logger.Error("Cannot get the board of the subtask", mlog.String("block_id", block.ID), mlog.Err(err))
+		return
+	}
+
+	rule := board.GetSubtaskRule()
+	if rule == nil || rule.ParentPropertyID == "" {
+		return
+	}
+
+	rollup, err := a.getSubtaskRollup(board, block.ParentID)
+	if err != nil {
+		a.logger.Error("Cannot get the subtask rollup", mlog.String("card_id", block.ParentID), mlog.Err(err))
+		return
+	}
+	if !rollup.AllCompleted() {
+		return
+	}
+
+	parent, err := a.store.GetBlock(block.ParentID)
+	if model.IsErrNotFound(err) {
+		return
+	}
+	if err != nil {
+		a.logger.Error("Cannot get the parent card of the subtask", mlog.String("card_id", block.ParentID), mlog.Err(err))
+		return
+	}
+
+	value := rule.ParentCardValue(board)
+	props, _ := parent.Fields["properties"].(map[string]interface{})
+	if current, ok := props[rule.ParentPropertyID]; ok && reflect.DeepEqual(current, value) {
+		return
+	}
+
+	newProps := make(map[string]interface{}, len(props)+1)
+	for key, v := range props {
+		newProps[key] = v
+	}
+	newProps[rule.ParentPropertyID] = value
+
+	patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{"properties": newProps}}
+	if _, err = a.PatchBlockAndNotify(parent.ID, patch, model.SystemUserID, true); err != nil {
+		a.logger.Error("Cannot apply the subtask rule to the parent card", mlog.String("card_id", parent.ID), mlog.Err(err))
+		return
+	}
+
+	a.logger.Debug("Applied the subtask rule",
+		mlog.String("board_id", board.ID),
+		mlog.String("card_id", parent.ID),
+		mlog.String("property_id", rule.ParentPropertyID),
+	)
+}
+
+// deleteSubtasks handles the subtasks of a deleted card as configured:
+// they are deleted too, or detached to be cards of the board.
+func (a *App) deleteSubtasks(board *model.Board, cardID, modifiedBy string) error {
+	if a.config.SubtaskDeleteMode == model.SubtaskDeleteDetach {
+		return a.detachSubtasks(board, cardID, nil, modifiedBy)
+	}
+
+	subtasks, err := a.store.GetBlocksWithParentAndType(board.ID, cardID, model.TypeCard.String())
+	if err != nil {
+		return err
+	}
+	if len(subtasks) == 0 {
+		return nil
+	}
+
+	subtaskIDs := make([]string, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		subtaskIDs = append(subtaskIDs, subtask.ID)
+	}
+	_, err = a.DeleteBlocks(subtaskIDs, modifiedBy)
+	return err
+}
+
+// detachSubtasks parents the subtasks of a card to its board, except the
+// ones in skipIDs.
+func (a *App) detachSubtasks(board *model.Board, cardID string, skipIDs map[string]bool, modifiedBy string) error {
+	subtasks, err := a.store.GetBlocksWithParentAndType(board.ID, cardID, model.TypeCard.String())
+	if err != nil {
+		return err
+	}
+
+	boardID := board.ID
+	patches := &model.BlockPatchBatch{}
+	for _, subtask := range subtasks {
+		if skipIDs[subtask.ID] {
+			continue
+		}
+		patches.BlockIDs = append(patches.BlockIDs, subtask.ID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{ParentID: &boardID})
+	}
+
+	if len(patches.BlockIDs) == 0 {
+		return nil
+	}
+	return a.PatchBlocksAndNotify(board.TeamID, patches, modifiedBy, true)
+}
+
+// detachSubtasksOfDeletedBlocks detaches the subtasks of the cards about
+// to be deleted, unless they are deleted too.
+func (a *App) detachSubtasksOfDeletedBlocks(blockIDs []string, modifiedBy string) error {
+	blocks, err := a.store.GetBlocksByIDs(blockIDs)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+
+	deleted := make(map[string]bool, len(blockIDs))
+	for _, blockID := range blockIDs {
+		deleted[blockID] = true
+	}
+
+	boards := map[string]*model.Board{}
+	for i := range blocks {
+		block := &blocks[i]
+		if block.Type != model.TypeCard || model.IsSubtask(block) {
+			continue
+		}
+
+		board, ok := boards[block.BoardID]
+		if !ok {
+			board, err = a.store.GetBoard(block.BoardID)
+			if err != nil {
+				return err
+			}
+			boards[block.BoardID] = board
+		}
+
+		if err = a.detachSubtasks(board, block.ID, deleted, modifiedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSubtaskRollup(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+		},
+		Properties: map[string]interface{}{},
+	}
+	card := &model.Block{ID: "card-id", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}
+	subtasks := []model.Block{
+		{ID: "subtask-1", BoardID: board.ID, ParentID: card.ID, Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done"},
+		}},
+		{ID: "subtask-2", BoardID: board.ID, ParentID: card.ID, Type: model.TypeCard},
+	}
+
+	t.Run("without a rule", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(board.ID, card.ID, "card").Return(subtasks, nil)
+
+		rollup, err := th.App.GetSubtaskRollup(card.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"subtask-1", "subtask-2"}, rollup.SubtaskIDs)
+		require.Empty(t, rollup.CompletedIDs)
+		require.Equal(t, 2, rollup.Total)
+		require.False(t, rollup.AllCompleted())
+	})
+
+	t.Run("with a rule", func(t *testing.T) {
+		ruleBoard := *board
+		ruleBoard.Properties = map[string]interface{}{
+			model.SubtaskRuleKey: map[string]interface{}{"completedPropertyId": "status", "completedValue": "done"},
+		}
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(&ruleBoard, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(board.ID, card.ID, "card").Return(subtasks, nil)
+
+		rollup, err := th.App.GetSubtaskRollup(card.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"subtask-1"}, rollup.CompletedIDs)
+		require.Equal(t, 1, rollup.Completed)
+	})
+
+	t.Run("not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("text-id").Return(&model.Block{ID: "text-id", Type: model.TypeText}, nil)
+
+		_, err := th.App.GetSubtaskRollup("text-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func TestValidateSubtaskParent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const boardID = "board-id"
+
+	t.Run("valid parent", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("parent-id").Return(&model.Block{ID: "parent-id", BoardID: boardID, ParentID: boardID, Type: model.TypeCard}, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(boardID, "card-id", "card").Return([]model.Block{}, nil)
+
+		require.NoError(t, th.App.validateSubtaskParent(boardID, "card-id", "parent-id"))
+	})
+
+	t.Run("card of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("parent-id").Return(&model.Block{ID: "parent-id", BoardID: "other-board-id", Type: model.TypeCard}, nil)
+
+		require.True(t, model.IsErrBadRequest(th.App.validateSubtaskParent(boardID, "card-id", "parent-id")))
+	})
+
+	t.Run("parent is a subtask", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("parent-id").Return(&model.Block{ID: "parent-id", BoardID: boardID, ParentID: "other-card-id", Type: model.TypeCard}, nil)
+
+		require.True(t, model.IsErrBadRequest(th.App.validateSubtaskParent(boardID, "card-id", "parent-id")))
+	})
+
+	t.Run("card with subtasks", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("parent-id").Return(&model.Block{ID: "parent-id", BoardID: boardID, ParentID: boardID, Type: model.TypeCard}, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(boardID, "card-id", "card").Return([]model.Block{{ID: "subtask-id"}}, nil)
+
+		require.True(t, model.IsErrBadRequest(th.App.validateSubtaskParent(boardID, "card-id", "parent-id")))
+	})
+
+	t.Run("card as its own parent", func(t *testing.T) {
+		require.True(t, model.IsErrBadRequest(th.App.validateSubtaskParent(boardID, "card-id", "card-id")))
+	})
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/webhook"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// CreateTeamWebhook creates a webhook called for the block events of all
+// the boards of a team. Empty eventTypes calls it for every event.
+func (a *App) CreateTeamWebhook(teamID, url string, eventTypes []string) (*model.TeamWebhook, error) {
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	now := utils.GetMillis()
+	teamWebhook := &model.TeamWebhook{
+		ID:         utils.NewID(utils.IDTypeNone),
+		TeamID:     teamID,
+		URL:        url,
+		EventTypes: eventTypes,
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+
+	if err := a.validateTeamWebhook(teamWebhook); err != nil {
+		return nil, err
+	}
+
+	if err := a.store.CreateTeamWebhook(teamWebhook); err != nil {
+		return nil, err
+	}
+	return teamWebhook, nil
+}
+
+// GetTeamWebhooks returns the webhooks of a team.
+func (a *App) GetTeamWebhooks(teamID string) ([]*model.TeamWebhook, error) {
+	return a.store.GetTeamWebhooks(teamID)
+}
+
+// UpdateTeamWebhook changes the URL and the event types of a webhook.
+func (a *App) UpdateTeamWebhook(webhookID, url string, eventTypes []string) (*model.TeamWebhook, error) {
+	teamWebhook, err := a.store.GetTeamWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	teamWebhook.URL = url
+	teamWebhook.EventTypes = eventTypes
+	teamWebhook.UpdateAt = utils.GetMillis()
+
+	if err = a.validateTeamWebhook(teamWebhook); err != nil {
+		return nil, err
+	}
+
+	if err = a.store.UpdateTeamWebhook(teamWebhook); err != nil {
+		return nil, err
+	}
+	return teamWebhook, nil
+}
+
+// DeleteTeamWebhook deletes a webhook so it isn't called anymore.
+func (a *App) DeleteTeamWebhook(webhookID string) error {
+	return a.store.DeleteTeamWebhook(webhookID)
+}
+
+// validateTeamWebhook checks the webhook fields and that its URL is
+// allowed by the webhook hosts policy.
+func (a *App) validateTeamWebhook(teamWebhook *model.TeamWebhook) error {
+	if err := teamWebhook.IsValid(); err != nil {
+		return err
+	}
+
+	if err := webhook.ValidateURL(a.config, teamWebhook.URL); err != nil {
+		return model.NewErrInvalidField("url", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTeamWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("valid webhook", func(t *testing.T) {
+		th.Store.EXPECT().CreateTeamWebhook(gomock.Any()).Return(nil)
+
+		teamWebhook, err := th.App.CreateTeamWebhook("team-id", "https://example.com/hook", []string{model.WebhookEventCreate})
+		require.NoError(t, err)
+		require.NotEmpty(t, teamWebhook.ID)
+		require.Equal(t, "team-id", teamWebhook.TeamID)
+		require.Equal(t, []string{model.WebhookEventCreate}, teamWebhook.EventTypes)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		_, err := th.App.CreateTeamWebhook("team-id", "https://example.com/hook", []string{"delete"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("private address", func(t *testing.T) {
+		_, err := th.App.CreateTeamWebhook("team-id", "http://127.0.0.1/hook", nil)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestUpdateTeamWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		th.Store.EXPECT().GetTeamWebhook("webhook-id").Return(nil, model.NewErrNotFound("team webhook"))
+
+		_, err := th.App.UpdateTeamWebhook("webhook-id", "https://example.com/hook", nil)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("valid webhook", func(t *testing.T) {
+		th.Store.EXPECT().GetTeamWebhook("webhook-id").Return(&model.TeamWebhook{
+			ID:     "webhook-id",
+			TeamID: "team-id",
+			URL:    "https://example.com/hook",
+		}, nil)
+		th.Store.EXPECT().UpdateTeamWebhook(gomock.Any()).Return(nil)
+
+		teamWebhook, err := th.App.UpdateTeamWebhook("webhook-id", "https://example.com/other", []string{model.WebhookEventUpdate})
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/other", teamWebhook.URL)
+		require.Equal(t, []string{model.WebhookEventUpdate}, teamWebhook.EventTypes)
+		require.NotZero(t, teamWebhook.UpdateAt)
+	})
+}
@@ -1,14 +1,26 @@
 package app
 
 import (
+	"time"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+// GetRootTeam returns the root team used by the standalone server,
+// creating it if it doesn't exist yet.
 func (a *App) GetRootTeam() (*model.Team, error) {
-	teamID := "0"
+	return a.EnsureTeam(model.GlobalTeamID)
+}
+
+// EnsureTeam returns the team with the given ID, creating it with a new
+// signup token if it doesn't exist yet. This allows teams to be lazily
+// materialized on first access instead of at server startup: the board
+// creations, imports, duplications and moves call it through
+// ensureStandaloneTeam.
+func (a *App) EnsureTeam(teamID string) (*model.Team, error) {
 	team, _ := a.store.GetTeam(teamID)
 	if team == nil {
 		team = &model.Team{
@@ -17,22 +29,58 @@ func (a *App) GetRootTeam() (*model.Team, error) {
 		}
 		err := a.store.UpsertTeamSignupToken(*team)
 		if err != nil {
-			a.logger.Error("Unable to initialize team", mlog.Err(err))
+			a.logger.Error("Unable to initialize team", mlog.String("teamID", teamID), mlog.Err(err))
 			return nil, err
 		}
 
 		team, err = a.store.GetTeam(teamID)
 		if err != nil {
-			a.logger.Error("Unable to get initialized team", mlog.Err(err))
+			a.logger.Error("Unable to get initialized team", mlog.String("teamID", teamID), mlog.Err(err))
 			return nil, err
 		}
 
-		a.logger.Info("initialized team")
+		a.logger.Info("initialized team", mlog.String("teamID", teamID))
 	}
 
 	return team, nil
 }
 
+// ensureStandaloneTeam creates the team when running standalone and a
+// board is first created in it. It's only called from the write paths,
+// so reading a team never creates it. When integrated with Mattermost
+// the teams are managed by the Mattermost server, so nothing is
+// created. The root team, which also holds the global templates, is
+// created through GetRootTeam instead.
+func (a *App) ensureStandaloneTeam(teamID string) error {
+	if a.servicesAPI != nil || teamID == "" || teamID == model.GlobalTeamID || a.IsReadOnlyMode() {
+		return nil
+	}
+
+	_, err := a.EnsureTeam(teamID)
+	return err
+}
+
+// CleanUpEmptyTeams deletes the teams that have no boards and no members
+// and haven't been updated in the configured number of days, returning
+// their IDs. The root team is never deleted.
+func (a *App) CleanUpEmptyTeams() ([]string, error) {
+	days := a.config.EmptyTeamCleanupDays
+	if days <= 0 {
+		return []string{}, nil
+	}
+
+	maxAge := time.Duration(days) * 24 * time.Hour
+	teamIDs, err := a.store.DeleteEmptyTeams(utils.GetMillis() - maxAge.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, teamID := range teamIDs {
+		a.logger.Info("Deleted empty team", mlog.String("teamID", teamID), mlog.Int("cleanupDays", days))
+	}
+	return teamIDs, nil
+}
+
 func (a *App) GetTeam(id string) (*model.Team, error) {
 	team, err := a.store.GetTeam(id)
 	if model.IsErrNotFound(err) {
@@ -7,6 +7,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
+	mockservicesapi "github.com/mattermost/focalboard/server/model/mocks"
+	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -84,6 +86,114 @@ func TestGetRootTeam(t *testing.T) {
 	}
 }
 
+func TestEnsureTeam(t *testing.T) {
+	t.Run("Success, Return existing team", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.Store.EXPECT().GetTeam("mock-team-id").Return(mockTeam, nil)
+
+		team, err := th.App.EnsureTeam("mock-team-id")
+		require.NoError(t, err)
+		assert.Equal(t, mockTeam, team)
+	})
+
+	t.Run("Success, Create team when it doesn't exist", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.Store.EXPECT().GetTeam("mock-team-id").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().UpsertTeamSignupToken(gomock.Any()).DoAndReturn(
+			func(arg0 model.Team) error {
+				require.Equal(t, "mock-team-id", arg0.ID)
+				require.NotEmpty(t, arg0.SignupToken)
+				th.Store.EXPECT().GetTeam("mock-team-id").Return(mockTeam, nil)
+				return nil
+			})
+
+		team, err := th.App.EnsureTeam("mock-team-id")
+		require.NoError(t, err)
+		assert.Equal(t, mockTeam, team)
+	})
+
+	t.Run("Fail, Return error when the team can't be created", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.Store.EXPECT().GetTeam("mock-team-id").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().UpsertTeamSignupToken(gomock.Any()).Return(errUpsertSignupToken)
+
+		team, err := th.App.EnsureTeam("mock-team-id")
+		require.ErrorIs(t, err, errUpsertSignupToken)
+		require.Nil(t, team)
+	})
+}
+
+func TestTeamCreatedOnFirstBoard(t *testing.T) {
+	newTemplate := func(teamID string) *model.Board {
+		return &model.Board{TeamID: teamID, Title: "template", IsTemplate: true}
+	}
+
+	t.Run("the team is created with its first board", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		board := newTemplate("new-team-id")
+		th.Store.EXPECT().GetTeam("new-team-id").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().UpsertTeamSignupToken(gomock.Any()).DoAndReturn(
+			func(team model.Team) error {
+				require.Equal(t, "new-team-id", team.ID)
+				th.Store.EXPECT().GetTeam("new-team-id").Return(&model.Team{ID: "new-team-id"}, nil)
+				return nil
+			})
+		th.Store.EXPECT().InsertBoard(board, "user-id").Return(board, nil)
+
+		_, err := th.App.CreateBoard(board, "user-id", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("an existing team is not created again", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		board := newTemplate("mock-team-id")
+		th.Store.EXPECT().GetTeam("mock-team-id").Return(mockTeam, nil)
+		th.Store.EXPECT().InsertBoard(board, "user-id").Return(board, nil)
+
+		_, err := th.App.CreateBoard(board, "user-id", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("the board isn't created if the team can't be", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.Store.EXPECT().GetTeam("new-team-id").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().UpsertTeamSignupToken(gomock.Any()).Return(errUpsertSignupToken)
+
+		board, err := th.App.CreateBoard(newTemplate("new-team-id"), "user-id", false)
+		require.ErrorIs(t, err, errUpsertSignupToken)
+		require.Nil(t, board)
+	})
+
+	t.Run("reading a team doesn't create it", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.Store.EXPECT().GetTemplateBoards("new-team-id", "user-id").Return([]*model.Board{}, nil)
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "new-team-id", true).Return([]*model.Board{}, nil)
+
+		_, err := th.App.GetTemplateBoards("new-team-id", "user-id")
+		require.NoError(t, err)
+		_, err = th.App.GetBoardsForUserAndTeam("user-id", "new-team-id", true)
+		require.NoError(t, err)
+	})
+
+	t.Run("the Mattermost teams are not created", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		th.App.servicesAPI = mockservicesapi.NewMockServicesAPI(gomock.NewController(t))
+		board := newTemplate("mm-team-id")
+		th.Store.EXPECT().InsertBoard(board, "user-id").Return(board, nil)
+
+		_, err := th.App.CreateBoard(board, "user-id", false)
+		require.NoError(t, err)
+	})
+}
+
 func TestGetTeam(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -152,3 +262,42 @@ func TestTeamOperations(t *testing.T) {
 	assert.NoError(t, errGetTeamCount)
 	assert.Equal(t, int64(10), count)
 }
+
+func TestCleanUpEmptyTeams(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.config.EmptyTeamCleanupDays = 0
+
+		teamIDs, err := th.App.CleanUpEmptyTeams()
+		require.NoError(t, err)
+		require.Empty(t, teamIDs)
+	})
+
+	t.Run("deletes the teams older than the configured days", func(t *testing.T) {
+		th.App.config.EmptyTeamCleanupDays = 30
+		defer func() { th.App.config.EmptyTeamCleanupDays = 0 }()
+
+		maxUpdatedBefore := utils.GetMillis() - int64(30*24*60*60*1000)
+		th.Store.EXPECT().DeleteEmptyTeams(gomock.Any()).DoAndReturn(func(updatedBefore int64) ([]string, error) {
+			require.LessOrEqual(t, updatedBefore, utils.GetMillis()-int64(30*24*60*60*1000))
+			require.GreaterOrEqual(t, updatedBefore, maxUpdatedBefore)
+			return []string{"team-1"}, nil
+		})
+
+		teamIDs, err := th.App.CleanUpEmptyTeams()
+		require.NoError(t, err)
+		require.Equal(t, []string{"team-1"}, teamIDs)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		th.App.config.EmptyTeamCleanupDays = 30
+		defer func() { th.App.config.EmptyTeamCleanupDays = 0 }()
+
+		th.Store.EXPECT().DeleteEmptyTeams(gomock.Any()).Return(nil, errUpsertSignupToken)
+
+		_, err := th.App.CleanUpEmptyTeams()
+		require.ErrorIs(t, err, errUpsertSignupToken)
+	})
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type userBoardViewStore interface {
+	SaveUserBoardView(view *model.UserBoardView) error
+}
+
+type pendingUserBoardView struct {
+	view  *model.UserBoardView
+	timer *time.Timer
+}
+
+// userBoardViewWriter coalesces the writes of the user view states. The
+// changes of a view state received within the write window are saved
+// once, with the latest state. The window starts with the first change,
+// so a state that keeps changing is still saved once per window.
+type userBoardViewWriter struct {
+	store  userBoardViewStore
+	window time.Duration
+	logger mlog.LoggerIFace
+
+	mux     sync.Mutex
+	pending map[string]*pendingUserBoardView
+}
+
+func newUserBoardViewWriter(store userBoardViewStore, window time.Duration, logger mlog.LoggerIFace) *userBoardViewWriter {
+	return &userBoardViewWriter{
+		store:   store,
+		window:  window,
+		logger:  logger,
+		pending: map[string]*pendingUserBoardView{},
+	}
+}
+
+func userBoardViewKey(userID, boardID string) string {
+	return userID + "/" + boardID
+}
+
+// save saves the view state right away if there is no write window, or
+// when the window of its first pending change ends.
+func (w *userBoardViewWriter) save(view *model.UserBoardView) error {
+	if w.window <= 0 {
+		return w.store.SaveUserBoardView(view)
+	}
+
+	key := userBoardViewKey(view.UserID, view.BoardID)
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if pending, ok := w.pending[key]; ok {
+		pending.view = view
+		return nil
+	}
+
+	pending := &pendingUserBoardView{view: view}
+	pending.timer = time.AfterFunc(w.window, func() {
+		w.savePending(key)
+	})
+	w.pending[key] = pending
+	return nil
+}
+
+// get returns the pending view state of a user for a board, or nil if
+// there is none.
+func (w *userBoardViewWriter) get(userID, boardID string) *model.UserBoardView {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if pending, ok := w.pending[userBoardViewKey(userID, boardID)]; ok {
+		return pending.view
+	}
+	return nil
+}
+
+// forget drops the pending view states that match the condition, for
+// the states that are deleted from the store.
+func (w *userBoardViewWriter) forget(match func(view *model.UserBoardView) bool) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	for key, pending := range w.pending {
+		if match(pending.view) {
+			pending.timer.Stop()
+			delete(w.pending, key)
+		}
+	}
+}
+
+// flushUser saves the pending view states of a user right away. It is
+// called when a connection of the user closes.
+func (w *userBoardViewWriter) flushUser(userID string) {
+	w.flushMatching(func(view *model.UserBoardView) bool {
+		return view.UserID == userID
+	})
+}
+
+// flush saves all the pending view states right away. It is called on
+// shutdown so no change is lost.
+func (w *userBoardViewWriter) flush() {
+	w.flushMatching(func(view *model.UserBoardView) bool {
+		return true
+	})
+}
+
+func (w *userBoardViewWriter) flushMatching(match func(view *model.UserBoardView) bool) {
+	w.mux.Lock()
+	views := []*model.UserBoardView{}
+	for key, pending := range w.pending {
+		if !match(pending.view) {
+			continue
+		}
+		pending.timer.Stop()
+		delete(w.pending, key)
+		views = append(views, pending.view)
+	}
+	w.mux.Unlock()
+
+	for _, view := range views {
+		w.write(view)
+	}
+}
+
+func (w *userBoardViewWriter) savePending(key string) {
+	w.mux.Lock()
+	pending, ok := w.pending[key]
+	delete(w.pending, key)
+	w.mux.Unlock()
+
+	if ok {
+		w.write(pending.view)
+	}
+}
+
+func (w *userBoardViewWriter) write(view *model.UserBoardView) {
+	if err := w.store.SaveUserBoardView(view); err != nil {
+		w.logger.Error("Cannot save the user board view",
+			mlog.String("userID", view.UserID),
+			mlog.String("boardID", view.BoardID),
+			mlog.Err(err),
+		)
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type fakeUserBoardViewStore struct {
+	mux   sync.Mutex
+	saved []model.UserBoardView
+}
+
+func (s *fakeUserBoardViewStore) SaveUserBoardView(view *model.UserBoardView) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.saved = append(s.saved, *view)
+	return nil
+}
+
+func (s *fakeUserBoardViewStore) savedViews() []model.UserBoardView {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return append([]model.UserBoardView{}, s.saved...)
+}
+
+func TestUserBoardViewWriter(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() { _ = logger.Shutdown() }()
+
+	t.Run("without a window every change is saved", func(t *testing.T) {
+		store := &fakeUserBoardViewStore{}
+		writer := newUserBoardViewWriter(store, 0, logger)
+
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-1", BoardID: "board-1", ActiveViewID: "view-1"}))
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-1", BoardID: "board-1", ActiveViewID: "view-2"}))
+		require.Len(t, store.savedViews(), 2)
+		require.Nil(t, writer.get("user-1", "board-1"))
+	})
+
+	t.Run("the changes within the window are saved once", func(t *testing.T) {
+		store := &fakeUserBoardViewStore{}
+		writer := newUserBoardViewWriter(store, 50*time.Millisecond, logger)
+
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-1", BoardID: "board-1", ActiveViewID: "view-1"}))
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-1", BoardID: "board-1", ActiveViewID: "view-2"}))
+		require.Empty(t, store.savedViews())
+		require.Equal(t, "view-2", writer.get("user-1", "board-1").ActiveViewID)
+
+		require.Eventually(t, func() bool { return len(store.savedViews()) == 1 }, time.Second, 10*time.Millisecond)
+		require.Equal(t, "view-2", store.savedViews()[0].ActiveViewID)
+		require.Nil(t, writer.get("user-1", "board-1"))
+	})
+
+	t.Run("the changes of a user are flushed on disconnect", func(t *testing.T) {
+		store := &fakeUserBoardViewStore{}
+		writer := newUserBoardViewWriter(store, time.Hour, logger)
+
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-1", BoardID: "board-1"}))
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-2", BoardID: "board-1"}))
+
+		writer.flushUser("user-1")
+		saved := store.savedViews()
+		require.Len(t, saved, 1)
+		require.Equal(t, "user-1", saved[0].UserID)
+		require.NotNil(t, writer.get("user-2", "board-1"))
+
+		writer.flush()
+		require.Len(t, store.savedViews(), 2)
+		require.Nil(t, writer.get("user-2", "board-1"))
+	})
+
+	t.Run("forgotten changes are not saved", func(t *testing.T) {
+		store := &fakeUserBoardViewStore{}
+		writer := newUserBoardViewWriter(store, time.Hour, logger)
+
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-1", BoardID: "board-1"}))
+		require.NoError(t, writer.save(&model.UserBoardView{UserID: "user-1", BoardID: "board-2"}))
+
+		writer.forget(func(view *model.UserBoardView) bool { return view.BoardID == "board-1" })
+		writer.flush()
+
+		saved := store.savedViews()
+		require.Len(t, saved, 1)
+		require.Equal(t, "board-2", saved[0].BoardID)
+	})
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetUserBoardView returns the view state of a board for a user. Users
+// that haven't set one yet get an empty state, so the clients fall back
+// to the board defaults.
+func (a *App) GetUserBoardView(userID, boardID string) (*model.UserBoardView, error) {
+	if pending := a.userBoardViews.get(userID, boardID); pending != nil {
+		return pending, nil
+	}
+
+	view, err := a.store.GetUserBoardView(userID, boardID)
+	if model.IsErrNotFound(err) {
+		return &model.UserBoardView{UserID: userID, BoardID: boardID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// SetUserBoardView stores the view state of a board for a user and sends
+// it to the other sessions of the same user. The state is sent right
+// away, but its write is debounced, and flushed when a connection of the
+// user closes.
+func (a *App) SetUserBoardView(userID, boardID string, view *model.UserBoardView) (*model.UserBoardView, error) {
+	if err := view.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.ActiveViewID != "" {
+		block, bErr := a.store.GetBlock(view.ActiveViewID)
+		if bErr != nil && !model.IsErrNotFound(bErr) {
+			return nil, bErr
+		}
+		if block == nil || block.BoardID != boardID || block.Type != model.TypeView {
+			return nil, model.NewErrInvalidField("activeViewId", "must be a view of the board")
+		}
+	}
+
+	view.UserID = userID
+	view.BoardID = boardID
+	view.UpdateAt = utils.GetMillis()
+
+	if err := a.userBoardViews.save(view); err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastUserBoardViewChange(board.TeamID, view)
+		return nil
+	})
+
+	return view, nil
+}
+
+// forgetUserBoardViews drops the pending view states of a board that
+// are deleted from the store, for all its users if userID is empty.
+func (a *App) forgetUserBoardViews(boardID, userID string) {
+	a.userBoardViews.forget(func(view *model.UserBoardView) bool {
+		return view.BoardID == boardID && (userID == "" || view.UserID == userID)
+	})
+}
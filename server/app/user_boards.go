@@ -0,0 +1,75 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetBoardsForUserInAllTeams returns the boards the user can access on
+// every team, tagged with their team. The filter restricts them to the
+// favorites or to the limit most recently updated ones.
+func (a *App) GetBoardsForUserInAllTeams(userID, filter string, limit int, includePublicBoards bool) ([]*model.UserBoard, error) {
+	if err := model.IsValidUserBoardsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	boards, err := a.store.SearchBoardsForUser("", userID, includePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+
+	switch filter {
+	case model.UserBoardsFilterFavorites:
+		if boards, err = a.filterFavoriteBoards(userID, boards); err != nil {
+			return nil, err
+		}
+	case model.UserBoardsFilterRecent:
+		sort.SliceStable(boards, func(i, j int) bool {
+			return boards[i].UpdateAt > boards[j].UpdateAt
+		})
+		if limit > 0 && len(boards) > limit {
+			boards = boards[:limit]
+		}
+	}
+
+	teams, err := a.store.GetTeamsForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	teamTitles := make(map[string]string, len(teams))
+	for _, team := range teams {
+		teamTitles[team.ID] = team.Title
+	}
+
+	userBoards := make([]*model.UserBoard, 0, len(boards))
+	for _, board := range boards {
+		userBoards = append(userBoards, &model.UserBoard{
+			Board:     board,
+			TeamTitle: teamTitles[board.TeamID],
+		})
+	}
+	return userBoards, nil
+}
+
+// filterFavoriteBoards keeps the boards that the user added to their
+// favorites.
+func (a *App) filterFavoriteBoards(userID string, boards []*model.Board) ([]*model.Board, error) {
+	favoriteIDs, err := a.store.GetFavoriteBoardIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites := make(map[string]bool, len(favoriteIDs))
+	for _, boardID := range favoriteIDs {
+		favorites[boardID] = true
+	}
+
+	filtered := make([]*model.Board, 0, len(favorites))
+	for _, board := range boards {
+		if favorites[board.ID] {
+			filtered = append(filtered, board)
+		}
+	}
+	return filtered, nil
+}
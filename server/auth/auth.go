@@ -29,6 +29,8 @@ func New(config *config.Configuration, store store.Store, permissions permission
 }
 
 // GetSession Get a user active session and refresh the session if needed.
+// Sessions older than the configured max lifetime are deleted, even if
+// they are still active.
 func (a *Auth) GetSession(token string) (*model.Session, error) {
 	if len(token) < 1 {
 		return nil, errors.New("no session token")
@@ -38,6 +40,15 @@ func (a *Auth) GetSession(token string) (*model.Session, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get the session for the token")
 	}
+	if a.config.SessionMaxLifetime > 0 &&
+		session.CreateAt < (utils.GetMillis()-utils.SecondsToMillis(a.config.SessionMaxLifetime)) {
+		_ = a.store.DeleteSession(session.ID)
+		return nil, errors.New("session exceeded its max lifetime")
+	}
+	if expiresAt := session.ExpiresAt(); expiresAt != 0 && expiresAt < utils.GetMillis() {
+		_ = a.store.DeleteSession(session.ID)
+		return nil, errors.New("session expired")
+	}
 	if session.UpdateAt < (utils.GetMillis() - utils.SecondsToMillis(a.config.SessionRefreshTime)) {
 		_ = a.store.RefreshSession(session)
 	}
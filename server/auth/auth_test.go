@@ -87,6 +87,69 @@ func TestGetSession(t *testing.T) {
 	}
 }
 
+func TestGetSessionMaxLifetime(t *testing.T) {
+	th := setupTestHelper(t)
+	th.Auth.config.SessionRefreshTime = 1000
+
+	oldSession := *mockSession
+	oldSession.Token = "oldToken"
+
+	th.Store.EXPECT().GetSession("goodToken", gomock.Any()).Return(mockSession, nil)
+	th.Store.EXPECT().GetSession("oldToken", gomock.Any()).Return(&oldSession, nil)
+	th.Store.EXPECT().RefreshSession(gomock.Any()).Return(nil)
+
+	t.Run("session within the max lifetime", func(t *testing.T) {
+		th.Auth.config.SessionMaxLifetime = 3000
+
+		session, err := th.Auth.GetSession("goodToken")
+		require.NoError(t, err)
+		require.NotNil(t, session)
+	})
+
+	t.Run("session older than the max lifetime", func(t *testing.T) {
+		th.Auth.config.SessionMaxLifetime = 1500
+		th.Store.EXPECT().DeleteSession(oldSession.ID).Return(nil)
+
+		session, err := th.Auth.GetSession("oldToken")
+		require.Error(t, err)
+		require.Nil(t, session)
+	})
+}
+
+func TestGetSessionExpiresAt(t *testing.T) {
+	th := setupTestHelper(t)
+	th.Auth.config.SessionRefreshTime = 1000000
+
+	activeSession := *mockSession
+	activeSession.Token = "activeToken"
+	activeSession.Props = map[string]interface{}{
+		model.SessionPropExpiresAt: float64(utils.GetMillis() + utils.SecondsToMillis(60)),
+	}
+
+	expiredSession := *mockSession
+	expiredSession.Token = "expiredToken"
+	expiredSession.Props = map[string]interface{}{
+		model.SessionPropExpiresAt: float64(utils.GetMillis() - 1),
+	}
+
+	th.Store.EXPECT().GetSession("activeToken", gomock.Any()).Return(&activeSession, nil)
+	th.Store.EXPECT().GetSession("expiredToken", gomock.Any()).Return(&expiredSession, nil)
+
+	t.Run("session before its expiration", func(t *testing.T) {
+		session, err := th.Auth.GetSession("activeToken")
+		require.NoError(t, err)
+		require.NotNil(t, session)
+	})
+
+	t.Run("session past its expiration", func(t *testing.T) {
+		th.Store.EXPECT().DeleteSession(expiredSession.ID).Return(nil)
+
+		session, err := th.Auth.GetSession("expiredToken")
+		require.Error(t, err)
+		require.Nil(t, session)
+	})
+}
+
 func TestIsValidReadToken(t *testing.T) {
 	// ToDo: reimplement
 
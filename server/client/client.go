@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/api"
@@ -111,12 +112,16 @@ func (c *Client) DoAPIRequest(method, url, data, etag string) (*http.Response, e
 
 type requestOption func(r *http.Request)
 
-func (c *Client) doAPIRequestReader(method, url string, data io.Reader, _ /* etag */ string, opts ...requestOption) (*http.Response, error) {
+func (c *Client) doAPIRequestReader(method, url string, data io.Reader, etag string, opts ...requestOption) (*http.Response, error) {
 	rq, err := http.NewRequest(method, url, data)
 	if err != nil {
 		return nil, err
 	}
 
+	if etag != "" {
+		rq.Header.Set("If-None-Match", etag)
+	}
+
 	for _, opt := range opts {
 		opt(rq)
 	}
@@ -192,6 +197,10 @@ func (c *Client) GetAllBlocksRoute(boardID string) string {
 	return fmt.Sprintf("%s/blocks?all=true", c.GetBoardRoute(boardID))
 }
 
+func (c *Client) GetBlocksBatchRoute() string {
+	return "/blocks/batch"
+}
+
 func (c *Client) GetBoardsAndBlocksRoute() string {
 	return "/boards-and-blocks"
 }
@@ -204,6 +213,10 @@ func (c *Client) GetCardRoute(cardID string) string {
 	return fmt.Sprintf("%s/%s", c.GetCardsRoute(), cardID)
 }
 
+func (c *Client) GetCardProgressRoute(cardID string) string {
+	return fmt.Sprintf("%s/progress", c.GetCardRoute(cardID))
+}
+
 func (c *Client) GetTeam(teamID string) (*model.Team, *Response) {
 	r, err := c.DoAPIGet(c.GetTeamRoute(teamID), "")
 	if err != nil {
@@ -214,6 +227,20 @@ func (c *Client) GetTeam(teamID string) (*model.Team, *Response) {
 	return model.TeamFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) GetTeamFeatureFlags(teamID string) (map[string]string, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/featureflags", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var flags map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&flags); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return flags, BuildResponse(r)
+}
+
 func (c *Client) GetTeamBoardsInsights(teamID string, userID string, timeRange string, page int, perPage int) (*model.BoardInsightsList, *Response) {
 	query := fmt.Sprintf("?time_range=%v&page=%v&per_page=%v", timeRange, page, perPage)
 	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/insights"+query, "")
@@ -244,6 +271,24 @@ func (c *Client) GetUserBoardsInsights(teamID string, userID string, timeRange s
 	return boardInsightsList, BuildResponse(r)
 }
 
+func (c *Client) GetMyBoards(filter string, limit int) ([]*model.UserBoard, *Response) {
+	query := fmt.Sprintf("?filter=%v", filter)
+	if limit > 0 {
+		query += fmt.Sprintf("&limit=%v", limit)
+	}
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/boards"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boards []*model.UserBoard
+	if jsonErr := json.NewDecoder(r.Body).Decode(&boards); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return boards, BuildResponse(r)
+}
+
 func (c *Client) GetBlocksForBoard(boardID string) ([]model.Block, *Response) {
 	r, err := c.DoAPIGet(c.GetBlocksRoute(boardID), "")
 	if err != nil {
@@ -264,6 +309,87 @@ func (c *Client) GetAllBlocksForBoard(boardID string) ([]model.Block, *Response)
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+// GetSharedBlocksForBoard fetches all the blocks of a board through its
+// shared link.
+func (c *Client) GetSharedBlocksForBoard(boardID, readToken string) ([]model.Block, *Response) {
+	url := c.GetAllBlocksRoute(boardID) + fmt.Sprintf("&read_token=%s", readToken)
+
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+// GetBlockManifest fetches the manifest of the blocks of a board. If
+// etag is the ETag of the current manifest, the response has a 304
+// status code and the returned manifest is nil.
+func (c *Client) GetBlockManifest(boardID, etag string) ([]model.BlockManifestEntry, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/manifest", etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	if r.StatusCode == http.StatusNotModified {
+		return nil, BuildResponse(r)
+	}
+
+	var manifest []model.BlockManifestEntry
+	if jsonErr := json.NewDecoder(r.Body).Decode(&manifest); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+
+	return manifest, BuildResponse(r)
+}
+
+func (c *Client) GetBlocksForBoards(boardIDs []string) (map[string][]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetBlocksBatchRoute(), toJSON(boardIDs))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var blocksByBoard map[string][]model.Block
+	if err := json.NewDecoder(r.Body).Decode(&blocksByBoard); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return blocksByBoard, BuildResponse(r)
+}
+
+func (c *Client) DeleteBlocksBatch(blockIDs []string) (*model.DeleteBlocksResult, *Response) {
+	r, err := c.DoAPIPost("/blocks/delete-batch", toJSON(blockIDs))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.DeleteBlocksResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) SyncBlocks(boardID string, changes []model.SyncBlockChange) (*model.SyncBlocksResult, *Response) {
+	r, err := c.DoAPIPost(c.GetBlocksRoute(boardID)+"/sync", toJSON(model.SyncBlocksRequest{Changes: changes}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.SyncBlocksResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
 const disableNotifyQueryParam = "disable_notify=true"
 
 func (c *Client) PatchBlock(boardID, blockID string, blockPatch *model.BlockPatch, disableNotify bool) (bool, *Response) {
@@ -321,6 +447,16 @@ func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) GetDeletedBlocks(boardID string) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/trash", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) InsertBlocks(boardID string, blocks []model.Block, disableNotify bool) ([]model.Block, *Response) {
 	var queryParams string
 	if disableNotify {
@@ -372,6 +508,81 @@ func (c *Client) CreateCard(boardID string, card *model.Card, disableNotify bool
 	return cardNew, BuildResponse(r)
 }
 
+func (c *Client) CreateCardFromTemplate(boardID string) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/from-template", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) UpdateCardsProperty(boardID string, update *model.CardPropertyUpdate) (*model.CardPropertyUpdateResult, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/update-property", toJSON(update))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CardPropertyUpdateResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) AssignCards(boardID string, assignment *model.CardAssignment) (*model.CardPropertyUpdateResult, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/assign", toJSON(assignment))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CardPropertyUpdateResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) MoveCard(boardID, cardID string, change *model.CardPositionChange) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/"+cardID+"/move", toJSON(change))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) DuplicateCard(cardID string) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/duplicate", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
 func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card, *Response) {
 	url := fmt.Sprintf("%s/cards?page=%d&per_page=%d", c.GetBoardRoute(boardID), page, perPage)
 	r, err := c.DoAPIGet(url, "")
@@ -419,6 +630,21 @@ func (c *Client) GetCard(cardID string) (*model.Card, *Response) {
 	return card, BuildResponse(r)
 }
 
+func (c *Client) GetCardProgress(cardID string) (*model.CardProgress, *Response) {
+	r, err := c.DoAPIGet(c.GetCardProgressRoute(cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var progress *model.CardProgress
+	if err := json.NewDecoder(r.Body).Decode(&progress); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return progress, BuildResponse(r)
+}
+
 //
 // Boards and blocks.
 //
@@ -526,6 +752,16 @@ func (c *Client) Register(request *model.RegisterRequest) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) RegisterWithInvite(token string, request *model.RegisterRequest) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetRegisterRoute()+"/invite/"+token, toJSON(&request))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
 func (c *Client) GetLoginRoute() string {
 	return "/login"
 }
@@ -567,6 +803,20 @@ func (c *Client) GetMe() (*model.User, *Response) {
 	return me, BuildResponse(r)
 }
 
+func (c *Client) GetMeWithBoardPermissions(boardID string) (*model.UserWithPermissions, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"?board_id="+boardID, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var me *model.UserWithPermissions
+	if err := json.NewDecoder(r.Body).Decode(&me); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return me, BuildResponse(r)
+}
+
 func (c *Client) GetUserID() string {
 	me, _ := c.GetMe()
 	if me == nil {
@@ -575,6 +825,20 @@ func (c *Client) GetUserID() string {
 	return me.ID
 }
 
+func (c *Client) GetVersion() (*model.VersionInfo, *Response) {
+	r, err := c.DoAPIGet("/version", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var versionInfo *model.VersionInfo
+	if err := json.NewDecoder(r.Body).Decode(&versionInfo); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return versionInfo, BuildResponse(r)
+}
+
 func (c *Client) GetUserRoute(id string) string {
 	return fmt.Sprintf("/users/%s", id)
 }
@@ -637,118 +901,120 @@ func (c *Client) DeleteBoard(boardID string) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
-func (c *Client) UndeleteBoard(boardID string) (bool, *Response) {
-	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/undelete", "")
-	if err != nil {
-		return false, BuildErrorResponse(r, err)
-	}
-	defer closeBody(r)
-
-	return true, BuildResponse(r)
+func (c *Client) GetLabelsRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/labels"
 }
 
-func (c *Client) GetBoard(boardID, readToken string) (*model.Board, *Response) {
-	url := c.GetBoardRoute(boardID)
-	if readToken != "" {
-		url += fmt.Sprintf("?read_token=%s", readToken)
-	}
-
-	r, err := c.DoAPIGet(url, "")
+func (c *Client) GetLabels(boardID string) ([]model.Label, *Response) {
+	r, err := c.DoAPIGet(c.GetLabelsRoute(boardID), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardFromJSON(r.Body), BuildResponse(r)
-}
-
-func (c *Client) GetBoardMetadata(boardID, readToken string) (*model.BoardMetadata, *Response) {
-	url := c.GetBoardMetadataRoute(boardID)
-	if readToken != "" {
-		url += fmt.Sprintf("?read_token=%s", readToken)
-	}
-
-	r, err := c.DoAPIGet(url, "")
-	if err != nil {
+	var labels []model.Label
+	if err := json.NewDecoder(r.Body).Decode(&labels); err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-	defer closeBody(r)
 
-	return model.BoardMetadataFromJSON(r.Body), BuildResponse(r)
+	return labels, BuildResponse(r)
 }
 
-func (c *Client) GetBoardsForTeam(teamID string) ([]*model.Board, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards", "")
+func (c *Client) CreateLabel(boardID string, label *model.Label) (*model.Label, *Response) {
+	r, err := c.DoAPIPost(c.GetLabelsRoute(boardID), toJSON(label))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsFromJSON(r.Body), BuildResponse(r)
-}
-
-func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/search?q="+term, "")
-	if err != nil {
+	var newLabel *model.Label
+	if err := json.NewDecoder(r.Body).Decode(&newLabel); err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-	defer closeBody(r)
 
-	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+	return newLabel, BuildResponse(r)
 }
 
-func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
-	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members", "")
+func (c *Client) PatchLabel(boardID, labelID string, patch *model.LabelPatch) (*model.Label, *Response) {
+	r, err := c.DoAPIPatch(c.GetLabelsRoute(boardID)+"/"+labelID, toJSON(patch))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+	var label *model.Label
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return label, BuildResponse(r)
 }
 
-func (c *Client) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPost(c.GetBoardRoute(member.BoardID)+"/members", toJSON(member))
+func (c *Client) DeleteLabel(boardID, labelID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetLabelsRoute(boardID)+"/"+labelID, "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return false, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	return true, BuildResponse(r)
 }
 
-func (c *Client) JoinBoard(boardID string) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPost(c.GetJoinBoardRoute(boardID), "")
+func (c *Client) GetUserBoardViewRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/users/me/view"
+}
+
+func (c *Client) GetUserBoardView(boardID string) (*model.UserBoardView, *Response) {
+	r, err := c.DoAPIGet(c.GetUserBoardViewRoute(boardID), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	var view *model.UserBoardView
+	if err := json.NewDecoder(r.Body).Decode(&view); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return view, BuildResponse(r)
 }
 
-func (c *Client) LeaveBoard(boardID string) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPost(c.GetLeaveBoardRoute(boardID), "")
+func (c *Client) SetUserBoardView(boardID string, view *model.UserBoardView) (*model.UserBoardView, *Response) {
+	r, err := c.DoAPIPut(c.GetUserBoardViewRoute(boardID), toJSON(view))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	var newView *model.UserBoardView
+	if err := json.NewDecoder(r.Body).Decode(&newView); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return newView, BuildResponse(r)
 }
 
-func (c *Client) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember, *Response) {
-	r, err := c.DoAPIPut(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, toJSON(member))
+func (c *Client) GetFavoriteRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/favorite"
+}
+
+func (c *Client) AddFavorite(boardID string) (*model.BoardFavorite, *Response) {
+	r, err := c.DoAPIPost(c.GetFavoriteRoute(boardID), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+	var favorite *model.BoardFavorite
+	if err := json.NewDecoder(r.Body).Decode(&favorite); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return favorite, BuildResponse(r)
 }
 
-func (c *Client) DeleteBoardMember(member *model.BoardMember) (bool, *Response) {
-	r, err := c.DoAPIDelete(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, "")
+func (c *Client) RemoveFavorite(boardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetFavoriteRoute(boardID), "")
 	if err != nil {
 		return false, BuildErrorResponse(r, err)
 	}
@@ -757,7 +1023,459 @@ func (c *Client) DeleteBoardMember(member *model.BoardMember) (bool, *Response)
 	return true, BuildResponse(r)
 }
 
-func (c *Client) GetTeamUploadFileRoute(teamID, boardID string) string {
+func (c *Client) GetFavorites() ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/favorites", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boards []*model.Board
+	if err := json.NewDecoder(r.Body).Decode(&boards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return boards, BuildResponse(r)
+}
+
+func (c *Client) GetRecentBoardsRoute() string {
+	return c.GetMeRoute() + "/recent-boards"
+}
+
+func (c *Client) AddRecentBoard(boardID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetRecentBoardsRoute()+"/"+boardID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetRecentBoards() ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetRecentBoardsRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boards []*model.Board
+	if err := json.NewDecoder(r.Body).Decode(&boards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return boards, BuildResponse(r)
+}
+
+func (c *Client) GetBoardUnreadRoute() string {
+	return c.GetMeRoute() + "/unread"
+}
+
+func (c *Client) MarkBoardSeen(boardID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardUnreadRoute()+"/"+boardID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoardUnreadCounts() ([]*model.BoardUnread, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardUnreadRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var unread []*model.BoardUnread
+	if err := json.NewDecoder(r.Body).Decode(&unread); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return unread, BuildResponse(r)
+}
+
+func (c *Client) GetBoardAPIKeysRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/apikeys"
+}
+
+func (c *Client) GetBoardAPIKeys(boardID string) ([]*model.BoardAPIKey, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardAPIKeysRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var keys []*model.BoardAPIKey
+	if err := json.NewDecoder(r.Body).Decode(&keys); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return keys, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardAPIKey(boardID, name, permission string) (*model.BoardAPIKey, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardAPIKeysRoute(boardID), toJSON(model.BoardAPIKey{Name: name, Permission: permission}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var key *model.BoardAPIKey
+	if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return key, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardAPIKey(boardID, keyID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardAPIKeysRoute(boardID)+"/"+keyID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) ReorderPropertyOptions(boardID, propertyID string, optionIDs []string) (*model.Board, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/properties/"+propertyID+"/options/order", toJSON(optionIDs))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var board *model.Board
+	if err := json.NewDecoder(r.Body).Decode(&board); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return board, BuildResponse(r)
+}
+
+func (c *Client) GetPropertyVisibilityRules(boardID string) ([]model.PropertyVisibilityRule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/properties/visibility-rules", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rules []model.PropertyVisibilityRule
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return rules, BuildResponse(r)
+}
+
+func (c *Client) SetPropertyVisibilityRule(boardID string, rule *model.PropertyVisibilityRule) (*model.PropertyVisibilityRule, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/properties/"+rule.PropertyID+"/visibility-rule", toJSON(rule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newRule *model.PropertyVisibilityRule
+	if err := json.NewDecoder(r.Body).Decode(&newRule); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return newRule, BuildResponse(r)
+}
+
+func (c *Client) DeletePropertyVisibilityRule(boardID, propertyID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/properties/"+propertyID+"/visibility-rule", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetSubtaskRollup(cardID string) (*model.SubtaskRollup, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/subtasks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rollup *model.SubtaskRollup
+	if err := json.NewDecoder(r.Body).Decode(&rollup); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return rollup, BuildResponse(r)
+}
+
+func (c *Client) SetSubtaskRule(boardID string, rule *model.SubtaskRule) (*model.SubtaskRule, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/subtask-rule", toJSON(rule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newRule *model.SubtaskRule
+	if err := json.NewDecoder(r.Body).Decode(&newRule); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return newRule, BuildResponse(r)
+}
+
+func (c *Client) DeleteSubtaskRule(boardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/subtask-rule", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoardRollup(boardID string, opts model.QueryRollupOptions) ([]*model.RollupGroup, *Response) {
+	query := url.Values{}
+	query.Set("agg", opts.Aggregation)
+	if opts.GroupBy != "" {
+		query.Set("groupBy", opts.GroupBy)
+	}
+	if opts.Of != "" {
+		query.Set("of", opts.Of)
+	}
+
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/rollup?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var groups []*model.RollupGroup
+	if err := json.NewDecoder(r.Body).Decode(&groups); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return groups, BuildResponse(r)
+}
+
+func (c *Client) GetBoardStats(boardID, statusProperty, dueDateProperty string, doneOptions []string) (*model.BoardStats, *Response) {
+	query := url.Values{}
+	if statusProperty != "" {
+		query.Set("statusProperty", statusProperty)
+	}
+	if dueDateProperty != "" {
+		query.Set("dueDateProperty", dueDateProperty)
+	}
+	if len(doneOptions) > 0 {
+		query.Set("doneOptions", strings.Join(doneOptions, ","))
+	}
+
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/stats?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var stats *model.BoardStats
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return stats, BuildResponse(r)
+}
+
+func (c *Client) MoveBoard(boardID, teamID string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/move", toJSON(model.MoveBoardRequest{TeamID: teamID}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) TransferBoardOwner(boardID, userID string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/transfer-owner", toJSON(model.TransferBoardOwnerRequest{UserID: userID}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardSlug(boardID string) (string, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/slug", "")
+	if err != nil {
+		return "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boardSlug model.BoardSlug
+	if err := json.NewDecoder(r.Body).Decode(&boardSlug); err != nil {
+		return "", BuildErrorResponse(r, err)
+	}
+
+	return boardSlug.Slug, BuildResponse(r)
+}
+
+func (c *Client) SetBoardSlug(boardID, slug string) (string, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(boardID)+"/slug", toJSON(model.BoardSlug{Slug: slug}))
+	if err != nil {
+		return "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boardSlug model.BoardSlug
+	if err := json.NewDecoder(r.Body).Decode(&boardSlug); err != nil {
+		return "", BuildErrorResponse(r, err)
+	}
+
+	return boardSlug.Slug, BuildResponse(r)
+}
+
+func (c *Client) GetBoardBySlug(teamID, slug string) (*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/by-slug/"+slug, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) UndeleteBoard(boardID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/undelete", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoard(boardID, readToken string) (*model.Board, *Response) {
+	url := c.GetBoardRoute(boardID)
+	if readToken != "" {
+		url += fmt.Sprintf("?read_token=%s", readToken)
+	}
+
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardWithFavorite(boardID string) (*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"?include_favorite=true", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardMetadata(boardID, readToken string) (*model.BoardMetadata, *Response) {
+	url := c.GetBoardMetadataRoute(boardID)
+	if readToken != "" {
+		url += fmt.Sprintf("?read_token=%s", readToken)
+	}
+
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMetadataFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBoardsForTeam(teamID string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/search?q="+term, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(member.BoardID)+"/members", toJSON(member))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) JoinBoard(boardID string) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetJoinBoardRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) LeaveBoard(boardID string) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPost(c.GetLeaveBoardRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, toJSON(member))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardMemberFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardMember(member *model.BoardMember) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(member.BoardID)+"/members/"+member.UserID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetTeamUploadFileRoute(teamID, boardID string) string {
 	return fmt.Sprintf("%s/%s/files", c.GetTeamRoute(teamID), boardID)
 }
 
@@ -863,6 +1581,20 @@ func (c *Client) ExportBoardArchive(boardID string) ([]byte, *Response) {
 	return buf, BuildResponse(r)
 }
 
+func (c *Client) ExportBoardMarkdown(boardID string) (string, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/export?format=markdown", "")
+	if err != nil {
+		return "", BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", BuildErrorResponse(r, err)
+	}
+	return string(buf), BuildResponse(r)
+}
+
 func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -888,6 +1620,36 @@ func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
 	return BuildResponse(r)
 }
 
+func (c *Client) ValidateArchive(teamID string, data io.Reader) (*model.ArchiveValidation, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/archive/import/validate", body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var validation *model.ArchiveValidation
+	if err := json.NewDecoder(r.Body).Decode(&validation); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return validation, BuildResponse(r)
+}
+
 func (c *Client) GetLimits() (*model.BoardsCloudLimits, *Response) {
 	r, err := c.DoAPIGet("/limits", "")
 	if err != nil {
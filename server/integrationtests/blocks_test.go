@@ -1,6 +1,7 @@
 package integrationtests
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -52,6 +53,282 @@ func TestGetBlocks(t *testing.T) {
 	require.Contains(t, blockIDs, blockID2)
 }
 
+func TestGetBlocksBatch(t *testing.T) {
+	th := SetupTestHelperWithToken(t).Start()
+	defer th.TearDown()
+
+	board1 := th.CreateBoard("team-id", model.BoardTypeOpen)
+	board2 := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	for _, board := range []*model.Board{board1, board2} {
+		newBlocks := []model.Block{
+			{
+				ID:       utils.NewID(utils.IDTypeBlock),
+				BoardID:  board.ID,
+				CreateAt: 1,
+				UpdateAt: 1,
+				Type:     model.TypeCard,
+			},
+		}
+		_, resp := th.Client.InsertBlocks(board.ID, newBlocks, false)
+		require.NoError(t, resp.Error)
+	}
+
+	t.Run("fetch blocks of several boards", func(t *testing.T) {
+		blocksByBoard, resp := th.Client.GetBlocksForBoards([]string{board1.ID, board2.ID, "not-a-board"})
+		require.NoError(t, resp.Error)
+		require.Len(t, blocksByBoard, 2)
+		require.Len(t, blocksByBoard[board1.ID], 1)
+		require.Len(t, blocksByBoard[board2.ID], 1)
+	})
+
+	t.Run("too many boards", func(t *testing.T) {
+		boardIDs := make([]string, 51)
+		for i := range boardIDs {
+			boardIDs[i] = utils.NewID(utils.IDTypeBoard)
+		}
+		blocksByBoard, resp := th.Client.GetBlocksForBoards(boardIDs)
+		require.Error(t, resp.Error)
+		require.Nil(t, blocksByBoard)
+	})
+}
+
+func TestGetBlockManifest(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	newBlocks := []model.Block{
+		{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    "card",
+		},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, newBlocks, false)
+	require.NoError(t, resp.Error)
+	blockID := newBlocks[0].ID
+
+	var etag string
+	t.Run("fetch the manifest", func(t *testing.T) {
+		manifest, resp := th.Client.GetBlockManifest(board.ID, "")
+		th.CheckOK(resp)
+		require.Len(t, manifest, 1)
+		require.Equal(t, blockID, manifest[0].ID)
+		require.Equal(t, model.TypeCard, manifest[0].Type)
+		require.Equal(t, newBlocks[0].UpdateAt, manifest[0].UpdateAt)
+
+		etag = resp.Header.Get("ETag")
+		require.NotEmpty(t, etag)
+	})
+
+	t.Run("unchanged manifest", func(t *testing.T) {
+		manifest, resp := th.Client.GetBlockManifest(board.ID, etag)
+		require.NoError(t, resp.Error)
+		require.Equal(t, http.StatusNotModified, resp.StatusCode)
+		require.Nil(t, manifest)
+	})
+
+	t.Run("changed manifest", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		title := "new title"
+		_, resp := th.Client.PatchBlock(board.ID, blockID, &model.BlockPatch{Title: &title}, false)
+		require.NoError(t, resp.Error)
+
+		manifest, resp := th.Client.GetBlockManifest(board.ID, etag)
+		th.CheckOK(resp)
+		require.Len(t, manifest, 1)
+		require.NotEqual(t, etag, resp.Header.Get("ETag"))
+	})
+
+	t.Run("a user without access to the board", func(t *testing.T) {
+		manifest, resp := th.Client2.GetBlockManifest(board.ID, "")
+		th.CheckForbidden(resp)
+		require.Nil(t, manifest)
+	})
+}
+
+func TestSyncBlocks(t *testing.T) {
+	th := SetupTestHelperWithToken(t).Start()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	newBlocks := []model.Block{
+		{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    "original",
+		},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, newBlocks, false)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 1)
+
+	serverBlocks, resp := th.Client.GetBlocksForBoard(board.ID)
+	require.NoError(t, resp.Error)
+	require.Len(t, serverBlocks, 1)
+	existingBlock := serverBlocks[0]
+
+	t.Run("apply non conflicting changes", func(t *testing.T) {
+		updatedBlock := existingBlock
+		updatedBlock.Title = "updated offline"
+		createdBlock := model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    "created offline",
+		}
+
+		result, resp := th.Client.SyncBlocks(board.ID, []model.SyncBlockChange{
+			{Block: updatedBlock, BaseUpdateAt: existingBlock.UpdateAt},
+			{Block: createdBlock, BaseUpdateAt: 0},
+		})
+		require.NoError(t, resp.Error)
+		require.ElementsMatch(t, []string{updatedBlock.ID, createdBlock.ID}, result.Applied)
+		require.Empty(t, result.Conflicts)
+		require.Len(t, result.Blocks, 2)
+	})
+
+	t.Run("report conflicts for outdated changes", func(t *testing.T) {
+		outdatedBlock := existingBlock
+		outdatedBlock.Title = "stale change"
+
+		result, resp := th.Client.SyncBlocks(board.ID, []model.SyncBlockChange{
+			{Block: outdatedBlock, BaseUpdateAt: existingBlock.UpdateAt},
+		})
+		require.NoError(t, resp.Error)
+		require.Empty(t, result.Applied)
+		require.Len(t, result.Conflicts, 1)
+		require.Equal(t, existingBlock.ID, result.Conflicts[0].BlockID)
+		require.NotNil(t, result.Conflicts[0].ServerBlock)
+		require.Equal(t, "updated offline", result.Conflicts[0].ServerBlock.Title)
+	})
+
+	t.Run("reject blocks from another board", func(t *testing.T) {
+		otherBlock := existingBlock
+		otherBlock.BoardID = "another-board-id"
+
+		result, resp := th.Client.SyncBlocks(board.ID, []model.SyncBlockChange{
+			{Block: otherBlock, BaseUpdateAt: existingBlock.UpdateAt},
+		})
+		th.CheckBadRequest(resp)
+		require.Nil(t, result)
+	})
+}
+
+func TestSyncBlocksCommenter(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypePrivate)
+	_, err := th.Server.App().AddMemberToBoard(&model.BoardMember{
+		BoardID:         board.ID,
+		UserID:          th.GetUser2().ID,
+		SchemeViewer:    true,
+		SchemeCommenter: true,
+	})
+	require.NoError(t, err)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "card",
+	}
+	comment := model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: card.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeComment,
+		Title:    "comment of user1",
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{card, comment}, true)
+	th.CheckOK(resp)
+	require.Len(t, newBlocks, 2)
+	card = newBlocks[0]
+	comment = newBlocks[1]
+
+	t.Run("a commenter can't replace a card with a comment", func(t *testing.T) {
+		replaced := card
+		replaced.Type = model.TypeComment
+		replaced.Title = "replaced"
+
+		result, resp := th.Client2.SyncBlocks(board.ID, []model.SyncBlockChange{
+			{Block: replaced, BaseUpdateAt: card.UpdateAt},
+		})
+		require.Error(t, resp.Error)
+		require.Nil(t, result)
+	})
+
+	t.Run("a commenter can't delete a card", func(t *testing.T) {
+		deleted := card
+		deleted.Type = model.TypeComment
+		deleted.DeleteAt = 1
+
+		result, resp := th.Client2.SyncBlocks(board.ID, []model.SyncBlockChange{
+			{Block: deleted, BaseUpdateAt: card.UpdateAt},
+		})
+		require.Error(t, resp.Error)
+		require.Nil(t, result)
+	})
+
+	t.Run("a commenter can't change the comments of others", func(t *testing.T) {
+		changed := comment
+		changed.Title = "changed"
+
+		result, resp := th.Client2.SyncBlocks(board.ID, []model.SyncBlockChange{
+			{Block: changed, BaseUpdateAt: comment.UpdateAt},
+		})
+		th.CheckForbidden(resp)
+		require.Nil(t, result)
+	})
+
+	t.Run("a commenter can sync its own comments", func(t *testing.T) {
+		ownComment := model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: card.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeComment,
+			Title:    "comment of user2",
+		}
+
+		result, resp := th.Client2.SyncBlocks(board.ID, []model.SyncBlockChange{
+			{Block: ownComment, BaseUpdateAt: 0},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, []string{ownComment.ID}, result.Applied)
+	})
+
+	blocks, err := th.Server.App().GetBlocksForBoard(board.ID)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		if block.ID == card.ID {
+			require.Equal(t, model.TypeCard, block.Type)
+			require.Equal(t, "card", block.Title)
+			require.Zero(t, block.DeleteAt)
+		}
+		if block.ID == comment.ID {
+			require.Equal(t, "comment of user1", block.Title)
+		}
+	}
+}
+
 func TestPostBlock(t *testing.T) {
 	th := SetupTestHelperWithToken(t).Start()
 	defer th.TearDown()
@@ -257,6 +534,51 @@ func TestPatchBlock(t *testing.T) {
 		require.Equal(t, "test value 2", updatedBlock.Fields["test2"])
 		require.Equal(t, nil, updatedBlock.Fields["test3"])
 	})
+
+	getBlock := func(t *testing.T) model.Block {
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 1)
+		return blocks[0]
+	}
+
+	t.Run("Patch a block based on its current version", func(t *testing.T) {
+		current := getBlock(t)
+
+		newTitle := "Versioned title"
+		blockPatch := &model.BlockPatch{
+			Title:        &newTitle,
+			BaseUpdateAt: &current.UpdateAt,
+		}
+
+		_, resp := th.Client.PatchBlock(board.ID, blockID, blockPatch, false)
+		th.CheckOK(resp)
+		require.Equal(t, newTitle, getBlock(t).Title)
+	})
+
+	t.Run("Patch a block based on an outdated version", func(t *testing.T) {
+		current := getBlock(t)
+		outdated := current.UpdateAt - 1
+
+		newTitle := "Conflicting title"
+		blockPatch := &model.BlockPatch{
+			Title:        &newTitle,
+			BaseUpdateAt: &outdated,
+		}
+
+		_, resp := th.Client.PatchBlock(board.ID, blockID, blockPatch, false)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+		require.Error(t, resp.Error)
+		require.Contains(t, resp.Error.Error(), `"serverBlock"`)
+		require.Contains(t, resp.Error.Error(), current.Title)
+		require.Equal(t, current.Title, getBlock(t).Title)
+
+		// omitting the version keeps the last writer wins behavior
+		blockPatch.BaseUpdateAt = nil
+		_, resp = th.Client.PatchBlock(board.ID, blockID, blockPatch, false)
+		th.CheckOK(resp)
+		require.Equal(t, newTitle, getBlock(t).Title)
+	})
 }
 
 func TestDeleteBlock(t *testing.T) {
@@ -310,6 +632,121 @@ func TestDeleteBlock(t *testing.T) {
 	})
 }
 
+func TestDeleteBlocksBatch(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	// a board the user isn't a member of
+	otherBoard, err := th.Server.App().CreateBoard(&model.Board{
+		TeamID: testTeamID,
+		Type:   model.BoardTypePrivate,
+	}, th.GetUser2().ID, true)
+	require.NoError(t, err)
+
+	newBlock := func(boardID, parentID string, blockType model.BlockType) model.Block {
+		return model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  boardID,
+			ParentID: parentID,
+			Type:     blockType,
+			CreateAt: 1,
+			UpdateAt: 1,
+		}
+	}
+
+	card := newBlock(board.ID, board.ID, model.TypeCard)
+	text := newBlock(board.ID, card.ID, model.TypeText)
+	otherCard := newBlock(board.ID, board.ID, model.TypeCard)
+	_, resp := th.Client.InsertBlocks(board.ID, []model.Block{card, text, otherCard}, false)
+	th.CheckOK(resp)
+
+	otherBoardCard := newBlock(otherBoard.ID, otherBoard.ID, model.TypeCard)
+	_, err = th.Server.App().InsertBlocks([]model.Block{otherBoardCard}, th.GetUser2().ID)
+	require.NoError(t, err)
+
+	t.Run("empty batch", func(t *testing.T) {
+		_, resp := th.Client.DeleteBlocksBatch([]string{})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("delete blocks and their descendants", func(t *testing.T) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
+		result, resp := th.Client.DeleteBlocksBatch([]string{card.ID, "missing-id", otherBoardCard.ID})
+		th.CheckOK(resp)
+		require.ElementsMatch(t, []string{card.ID, text.ID}, result.Deleted)
+		require.Len(t, result.Failed, 2)
+		require.Equal(t, "missing-id", result.Failed[0].BlockID)
+		require.Equal(t, otherBoardCard.ID, result.Failed[1].BlockID)
+
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		require.Equal(t, otherCard.ID, blocks[0].ID)
+
+		// the blocks the user can't delete are kept
+		_, err := th.Server.App().GetBlockByID(otherBoardCard.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestGetDeletedBlocks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypePrivate)
+
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{
+		{ID: "card-1", BoardID: board.ID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "Card 1"},
+		{ID: "card-2", BoardID: board.ID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "Card 2"},
+	}, false)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 2)
+	cardID := newBlocks[0].ID
+
+	t.Run("no deleted blocks", func(t *testing.T) {
+		blocks, resp := th.Client.GetDeletedBlocks(board.ID)
+		require.NoError(t, resp.Error)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("deleted block", func(t *testing.T) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
+		_, resp := th.Client.DeleteBlock(board.ID, cardID, false)
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client.GetDeletedBlocks(board.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 1)
+		require.Equal(t, cardID, blocks[0].ID)
+		require.Equal(t, newBlocks[0].Title, blocks[0].Title)
+		require.NotZero(t, blocks[0].DeleteAt)
+	})
+
+	t.Run("undeleted block", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+
+		_, resp := th.Client.UndeleteBlock(board.ID, cardID)
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client.GetDeletedBlocks(board.ID)
+		require.NoError(t, resp.Error)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("without permissions", func(t *testing.T) {
+		_, resp := th.Client2.GetDeletedBlocks(board.ID)
+		th.CheckForbidden(resp)
+	})
+}
+
 func TestUndeleteBlock(t *testing.T) {
 	th := SetupTestHelper(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,99 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardAPIKeys(t *testing.T) {
+	t.Run("manage keys", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		key, resp := th.Client.CreateBoardAPIKey(board.ID, "integration", model.BoardAPIKeyPermissionRead)
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, key.BoardID)
+		require.True(t, model.IsBoardAPIKey(key.Key))
+
+		_, resp = th.Client.CreateBoardAPIKey(board.ID, "integration", "admin")
+		th.CheckBadRequest(resp)
+
+		keys, resp := th.Client.GetBoardAPIKeys(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, keys, 1)
+		require.Equal(t, key.ID, keys[0].ID)
+		require.Empty(t, keys[0].Key)
+
+		// only board admins can manage the keys
+		_, resp = th.Client2.GetBoardAPIKeys(board.ID)
+		th.CheckForbidden(resp)
+		_, resp = th.Client2.CreateBoardAPIKey(board.ID, "integration", model.BoardAPIKeyPermissionWrite)
+		th.CheckForbidden(resp)
+
+		success, resp := th.Client.DeleteBoardAPIKey(board.ID, key.ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		_, resp = th.Client.DeleteBoardAPIKey(board.ID, key.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("authenticate with keys", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		otherBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		readKey, resp := th.Client.CreateBoardAPIKey(board.ID, "reader", model.BoardAPIKeyPermissionRead)
+		th.CheckOK(resp)
+		writeKey, resp := th.Client.CreateBoardAPIKey(board.ID, "writer", model.BoardAPIKeyPermissionWrite)
+		th.CheckOK(resp)
+
+		readClient := client.NewClient(th.Server.Config().ServerRoot, readKey.Key)
+		writeClient := client.NewClient(th.Server.Config().ServerRoot, writeKey.Key)
+
+		card := &model.Card{Title: "card"}
+
+		_, resp = readClient.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+
+		// read keys can't modify the board contents
+		_, resp = readClient.CreateCard(board.ID, card, true)
+		th.CheckForbidden(resp)
+
+		_, resp = writeClient.CreateCard(board.ID, card, true)
+		th.CheckOK(resp)
+
+		// keys only give access to their board
+		_, resp = readClient.GetBoard(otherBoard.ID, "")
+		th.CheckForbidden(resp)
+		_, resp = writeClient.CreateCard(otherBoard.ID, card, true)
+		th.CheckForbidden(resp)
+
+		// keys only give access to the board contents
+		title := "renamed"
+		_, resp = writeClient.PatchBoard(board.ID, &model.BoardPatch{Title: &title})
+		th.CheckForbidden(resp)
+		_, resp = writeClient.GetMembersForBoard(board.ID)
+		th.CheckForbidden(resp)
+		_, resp = writeClient.DeleteBoard(board.ID)
+		th.CheckForbidden(resp)
+
+		// keys can't be used to manage keys
+		_, resp = writeClient.CreateBoardAPIKey(board.ID, "other", model.BoardAPIKeyPermissionWrite)
+		th.CheckForbidden(resp)
+
+		// revoked keys stop working
+		_, resp = th.Client.DeleteBoardAPIKey(board.ID, readKey.ID)
+		th.CheckOK(resp)
+
+		_, resp = readClient.GetBoard(board.ID, "")
+		th.CheckUnauthorized(resp)
+	})
+}
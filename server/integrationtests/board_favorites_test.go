@@ -0,0 +1,90 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardFavorites(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	otherBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	t.Run("no favorites by default", func(t *testing.T) {
+		boards, resp := th.Client.GetFavorites()
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+
+		got, resp := th.Client.GetBoardWithFavorite(board.ID)
+		th.CheckOK(resp)
+		require.NotNil(t, got.IsFavorite)
+		require.False(t, *got.IsFavorite)
+
+		// the flag is only set when requested
+		got, resp = th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Nil(t, got.IsFavorite)
+	})
+
+	t.Run("add favorites", func(t *testing.T) {
+		favorite, resp := th.Client.AddFavorite(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser1().ID, favorite.UserID)
+		require.Equal(t, board.ID, favorite.BoardID)
+
+		// adding it twice doesn't fail
+		_, resp = th.Client.AddFavorite(board.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.AddFavorite(otherBoard.ID)
+		th.CheckOK(resp)
+
+		boards, resp := th.Client.GetFavorites()
+		th.CheckOK(resp)
+		require.Len(t, boards, 2)
+		require.Equal(t, board.ID, boards[0].ID)
+		require.Equal(t, otherBoard.ID, boards[1].ID)
+
+		got, resp := th.Client.GetBoardWithFavorite(board.ID)
+		th.CheckOK(resp)
+		require.True(t, *got.IsFavorite)
+
+		userBoards, resp := th.Client.GetMyBoards(model.UserBoardsFilterFavorites, 0)
+		th.CheckOK(resp)
+		require.Len(t, userBoards, 2)
+	})
+
+	t.Run("favorites are private to the user", func(t *testing.T) {
+		boards, resp := th.Client2.GetFavorites()
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+	})
+
+	t.Run("users without access cannot add the board", func(t *testing.T) {
+		_, resp := th.Client2.AddFavorite(board.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("remove a favorite", func(t *testing.T) {
+		_, resp := th.Client.RemoveFavorite(otherBoard.ID)
+		th.CheckOK(resp)
+
+		boards, resp := th.Client.GetFavorites()
+		th.CheckOK(resp)
+		require.Len(t, boards, 1)
+		require.Equal(t, board.ID, boards[0].ID)
+	})
+
+	t.Run("deleting the board removes the favorite", func(t *testing.T) {
+		_, resp := th.Client.DeleteBoard(board.ID)
+		th.CheckOK(resp)
+
+		boards, resp := th.Client.GetFavorites()
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+	})
+}
@@ -0,0 +1,59 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardSlugs(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board1 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	board2 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	t.Run("no slug by default", func(t *testing.T) {
+		slug, resp := th.Client.GetBoardSlug(board1.ID)
+		th.CheckOK(resp)
+		require.Empty(t, slug)
+	})
+
+	t.Run("set a slug and get the board by it", func(t *testing.T) {
+		slug, resp := th.Client.SetBoardSlug(board1.ID, "roadmap")
+		th.CheckOK(resp)
+		require.Equal(t, "roadmap", slug)
+
+		board, resp := th.Client.GetBoardBySlug(testTeamID, "roadmap")
+		th.CheckOK(resp)
+		require.Equal(t, board1.ID, board.ID)
+
+		_, resp = th.Client.GetBoardBySlug(testTeamID, "unknown")
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("invalid and taken slugs are rejected", func(t *testing.T) {
+		_, resp := th.Client.SetBoardSlug(board2.ID, "Not A Slug")
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.SetBoardSlug(board2.ID, "roadmap")
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("non members cannot set or resolve the slug of a private board", func(t *testing.T) {
+		_, resp := th.Client2.SetBoardSlug(board1.ID, "other")
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.GetBoardBySlug(testTeamID, "roadmap")
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("an empty slug removes it", func(t *testing.T) {
+		_, resp := th.Client.SetBoardSlug(board1.ID, "")
+		th.CheckOK(resp)
+
+		_, resp = th.Client.GetBoardBySlug(testTeamID, "roadmap")
+		th.CheckNotFound(resp)
+	})
+}
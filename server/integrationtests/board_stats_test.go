@@ -0,0 +1,142 @@
+package integrationtests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardStats(t *testing.T) {
+	dateValue := func(date time.Time) string {
+		return fmt.Sprintf(`{"from":%d}`, utils.GetMillisForTime(date))
+	}
+
+	setupBoard := func(th *TestHelper) *model.Board {
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		board, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{
+			UpdatedCardProperties: []map[string]interface{}{
+				{"id": "priority", "name": "Priority", "type": "select", "options": []interface{}{}},
+				{
+					"id":   "status",
+					"name": "Status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To Do"},
+						map[string]interface{}{"id": "doing", "value": "In Progress"},
+						map[string]interface{}{"id": "done", "value": "Completed 🙌"},
+					},
+				},
+				{"id": "due", "name": "Due date", "type": "date"},
+			},
+		})
+		th.CheckOK(resp)
+
+		lastWeek := dateValue(time.Now().AddDate(0, 0, -7))
+		nextWeek := dateValue(time.Now().AddDate(0, 0, 7))
+		cards := []*model.Card{
+			{Title: "1", Properties: map[string]any{"status": "done", "due": lastWeek}},
+			{Title: "2", Properties: map[string]any{"status": "todo", "due": lastWeek}},
+			{Title: "3", Properties: map[string]any{"status": "doing", "due": nextWeek}},
+			{Title: "4", Properties: map[string]any{"status": "done"}},
+			{Title: "5", Properties: map[string]any{"due": lastWeek}},
+		}
+		for _, card := range cards {
+			_, resp = th.Client.CreateCard(board.ID, card, true)
+			th.CheckOK(resp)
+		}
+
+		return board
+	}
+
+	t.Run("a non member should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		stats, resp := th.Client2.GetBoardStats(board.ID, "", "", nil)
+		th.CheckForbidden(resp)
+		require.Nil(t, stats)
+	})
+
+	t.Run("default properties", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		stats, resp := th.Client.GetBoardStats(board.ID, "", "", nil)
+		th.CheckOK(resp)
+		require.Equal(t, &model.BoardStats{
+			BoardID:          board.ID,
+			TotalCards:       5,
+			StatusPropertyID: "status",
+			CardsByStatus: []*model.BoardStatusCount{
+				{Value: "", Count: 1},
+				{Value: "todo", Name: "To Do", Count: 1},
+				{Value: "doing", Name: "In Progress", Count: 1},
+				{Value: "done", Name: "Completed 🙌", Completed: true, Count: 2},
+			},
+			CompletedCards:    2,
+			CompletedPercent:  40,
+			DueDatePropertyID: "due",
+			OverdueCards:      2,
+		}, stats)
+	})
+
+	t.Run("explicit completed options", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		stats, resp := th.Client.GetBoardStats(board.ID, "status", "due", []string{"done", "doing"})
+		th.CheckOK(resp)
+		require.Equal(t, int64(3), stats.CompletedCards)
+		require.Equal(t, float64(60), stats.CompletedPercent)
+		require.Equal(t, int64(2), stats.OverdueCards)
+	})
+
+	t.Run("board without status nor due date", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		_, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card"}, true)
+		th.CheckOK(resp)
+
+		stats, resp := th.Client.GetBoardStats(board.ID, "", "", nil)
+		th.CheckOK(resp)
+		require.Equal(t, int64(1), stats.TotalCards)
+		require.Empty(t, stats.StatusPropertyID)
+		require.Empty(t, stats.DueDatePropertyID)
+		require.Equal(t, []*model.BoardStatusCount{{Value: "", Count: 1}}, stats.CardsByStatus)
+		require.Zero(t, stats.CompletedCards)
+		require.Zero(t, stats.CompletedPercent)
+		require.Zero(t, stats.OverdueCards)
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		_, resp := th.Client.GetBoardStats(board.ID, "missing", "", nil)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetBoardStats(board.ID, "due", "", nil)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetBoardStats(board.ID, "", "status", nil)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetBoardStats(board.ID, "", "", []string{"missing"})
+		th.CheckBadRequest(resp)
+	})
+}
@@ -268,20 +268,6 @@ func TestCreateBoard(t *testing.T) {
 			require.Empty(t, boards)
 		})
 
-		t.Run("no type", func(t *testing.T) {
-			newBoard := &model.Board{
-				Title:  title,
-				TeamID: teamID,
-			}
-			board, resp := th.Client.CreateBoard(newBoard)
-			th.CheckBadRequest(resp)
-			require.Nil(t, board)
-
-			boards, err := th.Server.App().GetBoardsForUserAndTeam(user1.ID, teamID, true)
-			require.NoError(t, err)
-			require.Empty(t, boards)
-		})
-
 		t.Run("no team ID", func(t *testing.T) {
 			newBoard := &model.Board{
 				Title: title,
@@ -297,6 +283,74 @@ func TestCreateBoard(t *testing.T) {
 			require.Empty(t, boards)
 		})
 	})
+
+	t.Run("create board without type", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		me := th.GetUser1()
+
+		createBoard := func(t *testing.T) *model.Board {
+			board, resp := th.Client.CreateBoard(&model.Board{Title: "board without type", TeamID: testTeamID})
+			th.CheckOK(resp)
+			require.NotNil(t, board)
+
+			members, err := th.Server.App().GetMembersForBoard(board.ID)
+			require.NoError(t, err)
+			require.Len(t, members, 1)
+			require.Equal(t, me.ID, members[0].UserID)
+			require.True(t, members[0].SchemeAdmin)
+			return board
+		}
+
+		t.Run("private by default", func(t *testing.T) {
+			board := createBoard(t)
+			require.Equal(t, model.BoardTypePrivate, board.Type)
+
+			_, resp := th.Client2.GetBoard(board.ID, "")
+			th.CheckForbidden(resp)
+		})
+
+		t.Run("team visibility from the configuration", func(t *testing.T) {
+			th.Server.Config().DefaultBoardVisibility = string(model.BoardVisibilityTeam)
+			defer func() { th.Server.Config().DefaultBoardVisibility = "" }()
+
+			board := createBoard(t)
+			require.Equal(t, model.BoardTypeOpen, board.Type)
+
+			_, resp := th.Client2.GetBoard(board.ID, "")
+			th.CheckOK(resp)
+
+			sharing, _ := th.Client.GetSharing(board.ID)
+			require.Nil(t, sharing)
+		})
+
+		t.Run("public visibility from the team feature flag", func(t *testing.T) {
+			th.Server.Config().EnablePublicSharedBoards = true
+			require.NoError(t, th.Server.App().SetFeatureFlag(testTeamID, model.FeatureFlagDefaultBoardVisibility, string(model.BoardVisibilityPublic)))
+			defer func() {
+				require.NoError(t, th.Server.App().DeleteFeatureFlag(testTeamID, model.FeatureFlagDefaultBoardVisibility))
+			}()
+
+			board := createBoard(t)
+			require.Equal(t, model.BoardTypeOpen, board.Type)
+
+			sharing, resp := th.Client.GetSharing(board.ID)
+			th.CheckOK(resp)
+			require.NotNil(t, sharing)
+			require.True(t, sharing.Enabled)
+			require.NotEmpty(t, sharing.Token)
+		})
+
+		t.Run("an explicit type is kept", func(t *testing.T) {
+			th.Server.Config().DefaultBoardVisibility = string(model.BoardVisibilityTeam)
+			defer func() { th.Server.Config().DefaultBoardVisibility = "" }()
+
+			board, resp := th.Client.CreateBoard(&model.Board{Title: "private board", Type: model.BoardTypePrivate, TeamID: testTeamID})
+			th.CheckOK(resp)
+			require.Equal(t, model.BoardTypePrivate, board.Type)
+		})
+	})
 }
 
 func TestCreateBoardTemplate(t *testing.T) {
@@ -742,6 +796,7 @@ func TestGetBoardMetadata(t *testing.T) {
 		card1 := model.Block{
 			ID:      "card1",
 			BoardID: rBoard.ID,
+			Type:    model.TypeCard,
 			Title:   "Card 1",
 		}
 		time.Sleep(20 * time.Millisecond)
@@ -763,6 +818,7 @@ func TestGetBoardMetadata(t *testing.T) {
 		card2 := model.Block{
 			ID:      "card2",
 			BoardID: rBoard.ID,
+			Type:    model.TypeCard,
 			Title:   "Card 2",
 		}
 		time.Sleep(20 * time.Millisecond)
@@ -1074,6 +1130,156 @@ func TestDeleteBoard(t *testing.T) {
 	})
 }
 
+func TestMoveBoard(t *testing.T) {
+	teamID := testTeamID
+	toTeamID := "other-team-id"
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		require.NoError(t, err)
+
+		movedBoard, resp := th.Client.MoveBoard(board.ID, toTeamID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, movedBoard)
+	})
+
+	t.Run("a user without permissions should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(newBoard, "some-user-id", false)
+		require.NoError(t, err)
+
+		movedBoard, resp := th.Client.MoveBoard(board.ID, toTeamID)
+		th.CheckForbidden(resp)
+		require.Nil(t, movedBoard)
+
+		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, teamID, dbBoard.TeamID)
+	})
+
+	t.Run("a missing team should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(teamID, model.BoardTypeOpen)
+
+		movedBoard, resp := th.Client.MoveBoard(board.ID, "")
+		th.CheckBadRequest(resp)
+		require.Nil(t, movedBoard)
+	})
+
+	t.Run("an existing board should be correctly moved", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+		require.NoError(t, err)
+
+		movedBoard, resp := th.Client.MoveBoard(board.ID, toTeamID)
+		th.CheckOK(resp)
+		require.NotNil(t, movedBoard)
+		require.Equal(t, toTeamID, movedBoard.TeamID)
+
+		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, toTeamID, dbBoard.TeamID)
+	})
+}
+
+func TestTransferBoardOwner(t *testing.T) {
+	teamID := testTeamID
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(newBoard, "user-id", false)
+		require.NoError(t, err)
+
+		transferredBoard, resp := th.Client.TransferBoardOwner(board.ID, "other-user-id")
+		th.CheckUnauthorized(resp)
+		require.Nil(t, transferredBoard)
+	})
+
+	t.Run("a user that is not the owner should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(teamID, model.BoardTypeOpen)
+
+		transferredBoard, resp := th.Client2.TransferBoardOwner(board.ID, th.GetUser2().ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, transferredBoard)
+
+		dbBoard, err := th.Server.App().GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, th.GetUser1().ID, dbBoard.CreatedBy)
+	})
+
+	t.Run("a missing or unknown user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(teamID, model.BoardTypeOpen)
+
+		transferredBoard, resp := th.Client.TransferBoardOwner(board.ID, "")
+		th.CheckBadRequest(resp)
+		require.Nil(t, transferredBoard)
+
+		transferredBoard, resp = th.Client.TransferBoardOwner(board.ID, "nonexistent-user-id")
+		th.CheckBadRequest(resp)
+		require.Nil(t, transferredBoard)
+	})
+
+	t.Run("the owner should be able to transfer the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(teamID, model.BoardTypeOpen)
+
+		transferredBoard, resp := th.Client.TransferBoardOwner(board.ID, th.GetUser2().ID)
+		th.CheckOK(resp)
+		require.NotNil(t, transferredBoard)
+		require.Equal(t, th.GetUser2().ID, transferredBoard.CreatedBy)
+
+		newOwner, err := th.Server.App().GetMemberForBoard(board.ID, th.GetUser2().ID)
+		require.NoError(t, err)
+		require.True(t, newOwner.SchemeAdmin)
+
+		oldOwner, err := th.Server.App().GetMemberForBoard(board.ID, th.GetUser1().ID)
+		require.NoError(t, err)
+		require.False(t, oldOwner.SchemeAdmin)
+		require.True(t, oldOwner.SchemeEditor)
+	})
+}
+
 func TestUndeleteBoard(t *testing.T) {
 	teamID := testTeamID
 
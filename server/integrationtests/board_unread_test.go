@@ -0,0 +1,66 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardUnreadCounts(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		BoardID:      board.ID,
+		UserID:       th.GetUser2().ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	getCount := func(t *testing.T, boardID string) int64 {
+		unread, resp := th.Client.GetBoardUnreadCounts()
+		th.CheckOK(resp)
+		for _, u := range unread {
+			if u.BoardID == boardID {
+				return u.Count
+			}
+		}
+		require.Failf(t, "board not found", "board %s is not in the unread counts", boardID)
+		return 0
+	}
+
+	t.Run("the changes of the user are not unread", func(t *testing.T) {
+		_, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "own card"}, true)
+		th.CheckOK(resp)
+		require.Zero(t, getCount(t, board.ID))
+	})
+
+	t.Run("the changes of other users are unread", func(t *testing.T) {
+		_, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "card 1"}, true)
+		th.CheckOK(resp)
+		_, resp = th.Client2.CreateCard(board.ID, &model.Card{Title: "card 2"}, true)
+		th.CheckOK(resp)
+		require.EqualValues(t, 2, getCount(t, board.ID))
+	})
+
+	t.Run("viewing the board reads its changes", func(t *testing.T) {
+		time.Sleep(2 * time.Millisecond)
+		_, resp := th.Client.MarkBoardSeen(board.ID)
+		th.CheckOK(resp)
+		require.Zero(t, getCount(t, board.ID))
+
+		time.Sleep(2 * time.Millisecond)
+		_, resp = th.Client2.CreateCard(board.ID, &model.Card{Title: "card 3"}, true)
+		th.CheckOK(resp)
+		require.EqualValues(t, 1, getCount(t, board.ID))
+	})
+
+	t.Run("users without access cannot mark the board as seen", func(t *testing.T) {
+		otherBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		_, resp := th.Client2.MarkBoardSeen(otherBoard.ID)
+		th.CheckForbidden(resp)
+	})
+}
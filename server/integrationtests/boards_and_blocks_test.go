@@ -206,6 +206,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock1 := model.Block{
 			ID:      "block-id-1",
 			BoardID: board1.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
@@ -216,6 +217,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock2 := model.Block{
 			ID:      "block-id-2",
 			BoardID: board2.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
@@ -345,6 +347,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock1 := model.Block{
 			ID:      "block-id-1",
 			BoardID: board1.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
@@ -355,6 +358,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock2 := model.Block{
 			ID:      "block-id-2",
 			BoardID: board2.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
@@ -409,6 +413,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock1 := model.Block{
 			ID:      "block-id-1",
 			BoardID: board1.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
@@ -419,6 +424,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock2 := model.Block{
 			ID:      "block-id-2",
 			BoardID: board2.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
@@ -473,6 +479,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock1 := model.Block{
 			ID:      "block-id-1",
 			BoardID: board1.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
@@ -483,6 +490,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock2 := model.Block{
 			ID:      "block-id-2",
 			BoardID: board2.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
@@ -540,6 +548,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock1 := model.Block{
 			ID:      "block-id-1",
 			BoardID: board1.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
@@ -550,6 +559,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock2 := model.Block{
 			ID:      "block-id-2",
 			BoardID: board2.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
@@ -603,6 +613,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock1 := model.Block{
 			ID:      "block-id-1",
 			BoardID: board1.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock1, userID))
@@ -613,6 +624,7 @@ func TestPatchBoardsAndBlocks(t *testing.T) {
 		newBlock2 := model.Block{
 			ID:      "block-id-2",
 			BoardID: board2.ID,
+			Type:    model.TypeCard,
 			Title:   initialTitle,
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock2, userID))
@@ -686,6 +698,7 @@ func TestDeleteBoardsAndBlocks(t *testing.T) {
 		newBlock := model.Block{
 			ID:      "block-id-1",
 			BoardID: board.ID,
+			Type:    model.TypeCard,
 			Title:   "title",
 		}
 		require.NoError(t, th.Server.App().InsertBlock(newBlock, th.GetUser1().ID))
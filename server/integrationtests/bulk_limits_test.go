@@ -0,0 +1,62 @@
+package integrationtests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkLimits(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	newCards := func(count int, title string) []model.Block {
+		blocks := make([]model.Block, 0, count)
+		for i := 0; i < count; i++ {
+			blocks = append(blocks, model.Block{
+				ID:       utils.NewID(utils.IDTypeBlock),
+				BoardID:  board.ID,
+				CreateAt: 1,
+				UpdateAt: 1,
+				Type:     model.TypeCard,
+				Title:    title,
+			})
+		}
+		return blocks
+	}
+
+	t.Run("too many items", func(t *testing.T) {
+		th.Server.Config().MaxBulkItems = 2
+		defer func() { th.Server.Config().MaxBulkItems = 0 }()
+
+		_, resp := th.Client.InsertBlocks(board.ID, newCards(3, "card"), false)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateBoardsAndBlocks(&model.BoardsAndBlocks{
+			Boards: []*model.Board{{ID: "board-id", TeamID: testTeamID, Type: model.BoardTypePrivate}},
+			Blocks: newCards(2, "card"),
+		})
+		th.CheckBadRequest(resp)
+
+		blocks, resp := th.Client.InsertBlocks(board.ID, newCards(2, "card"), false)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 2)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		th.Server.Config().MaxBulkBodySize = 1024
+		defer func() { th.Server.Config().MaxBulkBodySize = 0 }()
+
+		_, resp := th.Client.InsertBlocks(board.ID, newCards(1, strings.Repeat("a", 2048)), false)
+		th.CheckRequestEntityTooLarge(resp)
+
+		blocks, resp := th.Client.InsertBlocks(board.ID, newCards(1, "card"), false)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+	})
+}
@@ -0,0 +1,87 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignCards(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	assigneeID := th.GetUser2().ID
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	_, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{UpdatedCardProperties: []map[string]interface{}{
+		{"id": "owner", "name": "Owner", "type": "person"},
+		{"id": "reviewers", "name": "Reviewers", "type": "multiPerson"},
+	}})
+	th.CheckOK(resp)
+
+	card1, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card 1"}, false)
+	th.CheckOK(resp)
+	card2, resp := th.Client.CreateCard(board.ID, &model.Card{
+		Title:      "card 2",
+		Properties: map[string]any{"reviewers": []interface{}{"other-user"}},
+	}, false)
+	th.CheckOK(resp)
+
+	otherBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	otherCard, resp := th.Client.CreateCard(otherBoard.ID, &model.Card{Title: "other card"}, false)
+	th.CheckOK(resp)
+
+	t.Run("assign to the first person property", func(t *testing.T) {
+		assignment := &model.CardAssignment{
+			CardIDs: []string{card1.ID, card2.ID, otherCard.ID},
+			UserID:  assigneeID,
+		}
+		result, resp := th.Client.AssignCards(board.ID, assignment)
+		th.CheckOK(resp)
+		require.ElementsMatch(t, []string{card1.ID, card2.ID}, result.UpdatedCardIDs)
+		require.Len(t, result.Failures, 1)
+		require.Equal(t, otherCard.ID, result.Failures[0].CardID)
+
+		for _, cardID := range []string{card1.ID, card2.ID} {
+			card, resp := th.Client.GetCard(cardID)
+			th.CheckOK(resp)
+			require.Equal(t, assigneeID, card.Properties["owner"])
+		}
+	})
+
+	t.Run("add to a multi-person property", func(t *testing.T) {
+		assignment := &model.CardAssignment{CardIDs: []string{card2.ID}, UserID: assigneeID, PropertyID: "reviewers"}
+		_, resp := th.Client.AssignCards(board.ID, assignment)
+		th.CheckOK(resp)
+
+		// assigning twice doesn't repeat the user
+		_, resp = th.Client.AssignCards(board.ID, assignment)
+		th.CheckOK(resp)
+
+		card, resp := th.Client.GetCard(card2.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []interface{}{"other-user", assigneeID}, card.Properties["reviewers"])
+	})
+
+	t.Run("invalid assignments", func(t *testing.T) {
+		assignments := []*model.CardAssignment{
+			{UserID: assigneeID},
+			{CardIDs: []string{card1.ID}},
+			{CardIDs: []string{card1.ID}, UserID: "missing-user"},
+			{CardIDs: []string{card1.ID}, UserID: assigneeID, PropertyID: "missing"},
+		}
+		for _, assignment := range assignments {
+			_, resp := th.Client.AssignCards(board.ID, assignment)
+			th.CheckBadRequest(resp)
+		}
+
+		_, resp := th.Client.AssignCards(otherBoard.ID, &model.CardAssignment{CardIDs: []string{otherCard.ID}, UserID: assigneeID})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access to the board", func(t *testing.T) {
+		_, resp := th.Client2.AssignCards(board.ID, &model.CardAssignment{CardIDs: []string{card1.ID}, UserID: assigneeID})
+		th.CheckForbidden(resp)
+	})
+}
@@ -287,3 +287,316 @@ func modifyCardProps(m map[string]any) map[string]any {
 	}
 	return out
 }
+
+func TestCreateCardFromTemplate(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	t.Run("board without card template", func(t *testing.T) {
+		card, resp := th.Client.CreateCardFromTemplate(board.ID)
+		th.CheckBadRequest(resp)
+		require.Nil(t, card)
+	})
+
+	templateCard, resp := th.Client.CreateCard(board.ID, &model.Card{
+		Title:      "template",
+		Properties: map[string]any{"status": "todo"},
+	}, false)
+	th.CheckOK(resp)
+
+	checkbox := model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: templateCard.ID,
+		Type:     model.TypeCheckbox,
+		Title:    "review",
+		CreateAt: 1,
+		UpdateAt: 1,
+	}
+	_, resp = th.Client.InsertBlocks(board.ID, []model.Block{checkbox}, false)
+	th.CheckOK(resp)
+
+	t.Run("card template must belong to the board", func(t *testing.T) {
+		otherBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		_, resp := th.Client.PatchBoard(otherBoard.ID, &model.BoardPatch{CardTemplateID: &templateCard.ID})
+		th.CheckBadRequest(resp)
+	})
+
+	patchedBoard, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{CardTemplateID: &templateCard.ID})
+	th.CheckOK(resp)
+	require.Equal(t, templateCard.ID, patchedBoard.CardTemplateID)
+
+	t.Run("create a card from the template", func(t *testing.T) {
+		card, resp := th.Client.CreateCardFromTemplate(board.ID)
+		th.CheckOK(resp)
+		require.NotEqual(t, templateCard.ID, card.ID)
+		require.Equal(t, "template", card.Title)
+		require.Equal(t, "todo", card.Properties["status"])
+
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+
+		var copiedChecklist []model.Block
+		for _, block := range blocks {
+			if block.Type == model.TypeCheckbox && block.ParentID == card.ID {
+				copiedChecklist = append(copiedChecklist, block)
+			}
+		}
+		require.Len(t, copiedChecklist, 1)
+		require.Equal(t, "review", copiedChecklist[0].Title)
+	})
+
+	t.Run("deleting the template clears the reference", func(t *testing.T) {
+		_, resp := th.Client.DeleteBlock(board.ID, templateCard.ID, false)
+		th.CheckOK(resp)
+
+		updatedBoard, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Empty(t, updatedBoard.CardTemplateID)
+
+		card, resp := th.Client.CreateCardFromTemplate(board.ID)
+		th.CheckBadRequest(resp)
+		require.Nil(t, card)
+	})
+}
+
+func TestDuplicateCard(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{
+		Title:      "original",
+		Properties: map[string]any{"status": "todo"},
+	}, false)
+	th.CheckOK(resp)
+
+	otherCard, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "other"}, false)
+	th.CheckOK(resp)
+
+	checkbox := model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: card.ID,
+		Type:     model.TypeCheckbox,
+		Title:    "review",
+		CreateAt: 1,
+		UpdateAt: 1,
+	}
+	view := model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Title:    "view",
+		Fields:   map[string]interface{}{"cardOrder": []interface{}{card.ID, otherCard.ID}},
+		CreateAt: 1,
+		UpdateAt: 1,
+	}
+	_, resp = th.Client.InsertBlocks(board.ID, []model.Block{checkbox, view}, false)
+	th.CheckOK(resp)
+
+	t.Run("missing card", func(t *testing.T) {
+		_, resp := th.Client.DuplicateCard(utils.NewID(utils.IDTypeCard))
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		_, resp := th.Client2.DuplicateCard(card.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("duplicate a card", func(t *testing.T) {
+		newCard, resp := th.Client.DuplicateCard(card.ID)
+		th.CheckOK(resp)
+		require.NotEqual(t, card.ID, newCard.ID)
+		require.Equal(t, board.ID, newCard.BoardID)
+		require.Equal(t, "original", newCard.Title)
+		require.Equal(t, "todo", newCard.Properties["status"])
+
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+
+		var copiedChecklist []model.Block
+		var cardOrder []interface{}
+		for _, block := range blocks {
+			if block.Type == model.TypeCheckbox && block.ParentID == newCard.ID {
+				copiedChecklist = append(copiedChecklist, block)
+			}
+			if block.ID == view.ID {
+				cardOrder, _ = block.Fields["cardOrder"].([]interface{})
+			}
+		}
+		require.Len(t, copiedChecklist, 1)
+		require.Equal(t, "review", copiedChecklist[0].Title)
+		require.NotEqual(t, checkbox.ID, copiedChecklist[0].ID)
+
+		// the copy is placed right after the original
+		require.Equal(t, []interface{}{card.ID, newCard.ID, otherCard.ID}, cardOrder)
+	})
+}
+
+func TestUpdateCardsProperty(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	statusProp := map[string]interface{}{
+		"id":   "status",
+		"name": "Status",
+		"type": "select",
+		"options": []interface{}{
+			map[string]interface{}{"id": "todo", "value": "To do", "color": "propColorGray"},
+			map[string]interface{}{"id": "done", "value": "Done", "color": "propColorGreen"},
+		},
+	}
+	_, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{UpdatedCardProperties: []map[string]interface{}{statusProp}})
+	th.CheckOK(resp)
+
+	card1, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card 1", Properties: map[string]any{"status": "todo"}}, false)
+	th.CheckOK(resp)
+	card2, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card 2"}, false)
+	th.CheckOK(resp)
+
+	otherBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	otherCard, resp := th.Client.CreateCard(otherBoard.ID, &model.Card{Title: "other card"}, false)
+	th.CheckOK(resp)
+
+	t.Run("update the cards and report the failures", func(t *testing.T) {
+		update := &model.CardPropertyUpdate{
+			CardIDs:    []string{card1.ID, card2.ID, otherCard.ID, "missing-card"},
+			PropertyID: "status",
+			Value:      "done",
+		}
+		result, resp := th.Client.UpdateCardsProperty(board.ID, update)
+		th.CheckOK(resp)
+		require.ElementsMatch(t, []string{card1.ID, card2.ID}, result.UpdatedCardIDs)
+		require.Len(t, result.Failures, 2)
+		require.Equal(t, otherCard.ID, result.Failures[0].CardID)
+		require.Equal(t, "missing-card", result.Failures[1].CardID)
+
+		for _, cardID := range []string{card1.ID, card2.ID} {
+			card, resp := th.Client.GetCard(cardID)
+			th.CheckOK(resp)
+			require.Equal(t, "done", card.Properties["status"])
+		}
+
+		card, resp := th.Client.GetCard(otherCard.ID)
+		th.CheckOK(resp)
+		require.Nil(t, card.Properties["status"])
+	})
+
+	t.Run("clear the property", func(t *testing.T) {
+		update := &model.CardPropertyUpdate{CardIDs: []string{card1.ID}, PropertyID: "status"}
+		result, resp := th.Client.UpdateCardsProperty(board.ID, update)
+		th.CheckOK(resp)
+		require.Equal(t, []string{card1.ID}, result.UpdatedCardIDs)
+
+		card, resp := th.Client.GetCard(card1.ID)
+		th.CheckOK(resp)
+		require.NotContains(t, card.Properties, "status")
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		update := &model.CardPropertyUpdate{CardIDs: []string{card1.ID}, PropertyID: "status", Value: "unknown-option"}
+		_, resp := th.Client.UpdateCardsProperty(board.ID, update)
+		th.CheckBadRequest(resp)
+
+		update = &model.CardPropertyUpdate{CardIDs: []string{card1.ID}, PropertyID: "unknown-property", Value: "done"}
+		_, resp = th.Client.UpdateCardsProperty(board.ID, update)
+		th.CheckBadRequest(resp)
+
+		update = &model.CardPropertyUpdate{PropertyID: "status", Value: "done"}
+		_, resp = th.Client.UpdateCardsProperty(board.ID, update)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("a user without access to the board", func(t *testing.T) {
+		update := &model.CardPropertyUpdate{CardIDs: []string{card1.ID}, PropertyID: "status", Value: "done"}
+		result, resp := th.Client2.UpdateCardsProperty(board.ID, update)
+		th.CheckForbidden(resp)
+		require.Nil(t, result)
+	})
+}
+
+func TestMoveCard(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	cards := make([]*model.Card, 0, 3)
+	for i := 0; i < 3; i++ {
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: fmt.Sprintf("card %d", i)}, false)
+		th.CheckOK(resp)
+		require.NotEmpty(t, card.Position)
+		cards = append(cards, card)
+	}
+
+	// new cards are added at the end
+	require.Less(t, cards[0].Position, cards[1].Position)
+	require.Less(t, cards[1].Position, cards[2].Position)
+
+	t.Run("move a card between two others", func(t *testing.T) {
+		change := &model.CardPositionChange{AfterCardID: cards[0].ID, BeforeCardID: cards[1].ID}
+		moved, resp := th.Client.MoveCard(board.ID, cards[2].ID, change)
+		th.CheckOK(resp)
+		require.Greater(t, moved.Position, cards[0].Position)
+		require.Less(t, moved.Position, cards[1].Position)
+
+		// the rest of the cards keep their positions
+		card, resp := th.Client.GetCard(cards[1].ID)
+		th.CheckOK(resp)
+		require.Equal(t, cards[1].Position, card.Position)
+	})
+
+	t.Run("moves to the same place get different positions", func(t *testing.T) {
+		change := &model.CardPositionChange{AfterCardID: cards[0].ID, BeforeCardID: cards[2].ID}
+		first, resp := th.Client.MoveCard(board.ID, cards[1].ID, change)
+		th.CheckOK(resp)
+
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card 3"}, false)
+		th.CheckOK(resp)
+		second, resp := th.Client.MoveCard(board.ID, card.ID, change)
+		th.CheckOK(resp)
+
+		require.NotEqual(t, first.Position, second.Position)
+	})
+
+	t.Run("move a card to the end", func(t *testing.T) {
+		moved, resp := th.Client.MoveCard(board.ID, cards[0].ID, &model.CardPositionChange{})
+		th.CheckOK(resp)
+
+		allCards, resp := th.Client.GetCards(board.ID, 0, 100)
+		th.CheckOK(resp)
+		for _, card := range allCards {
+			if card.ID != moved.ID {
+				require.Less(t, card.Position, moved.Position)
+			}
+		}
+	})
+
+	t.Run("invalid moves", func(t *testing.T) {
+		_, resp := th.Client.MoveCard(board.ID, cards[0].ID, &model.CardPositionChange{AfterCardID: cards[0].ID})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.MoveCard(board.ID, cards[0].ID, &model.CardPositionChange{AfterCardID: "missing-card"})
+		th.CheckBadRequest(resp)
+
+		// the cards are in the wrong order
+		change := &model.CardPositionChange{AfterCardID: cards[0].ID, BeforeCardID: cards[2].ID}
+		_, resp = th.Client.MoveCard(board.ID, cards[1].ID, change)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.MoveCard(board.ID, "missing-card", &model.CardPositionChange{})
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		_, resp := th.Client2.MoveCard(board.ID, cards[0].ID, &model.CardPositionChange{})
+		th.CheckForbidden(resp)
+	})
+}
@@ -1,7 +1,9 @@
 package integrationtests
 
 import (
+	"archive/zip"
 	"bytes"
+	"net/http"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -64,3 +66,189 @@ func TestExportBoard(t *testing.T) {
 		require.Equal(t, block.Title, blocksImported[0].Title)
 	})
 }
+
+func TestValidateArchive(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := &model.Board{
+		ID:        utils.NewID(utils.IDTypeBoard),
+		TeamID:    "test-team",
+		Title:     "Validate Test Board",
+		CreatedBy: th.GetUser1().ID,
+		Type:      model.BoardTypeOpen,
+		CreateAt:  utils.GetMillis(),
+		UpdateAt:  utils.GetMillis(),
+	}
+
+	cards := []model.Block{}
+	for i := 0; i < 2; i++ {
+		cards = append(cards, model.Block{
+			ID:        utils.NewID(utils.IDTypeCard),
+			ParentID:  board.ID,
+			Type:      model.TypeCard,
+			BoardID:   board.ID,
+			Title:     "Test card",
+			CreatedBy: th.GetUser1().ID,
+			CreateAt:  utils.GetMillis(),
+			UpdateAt:  utils.GetMillis(),
+		})
+	}
+
+	babs, resp := th.Client.CreateBoardsAndBlocks(&model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: cards,
+	})
+	th.CheckOK(resp)
+
+	buf, resp := th.Client.ExportBoardArchive(babs.Boards[0].ID)
+	th.CheckOK(resp)
+
+	t.Run("valid archive", func(t *testing.T) {
+		validation, resp := th.Client.ValidateArchive(model.GlobalTeamID, bytes.NewReader(buf))
+		th.CheckOK(resp)
+		require.True(t, validation.Valid)
+		require.Equal(t, 1, validation.BoardCount)
+		require.Equal(t, 2, validation.CardCount)
+		require.Empty(t, validation.Errors)
+
+		// nothing is imported
+		boards, err := th.Server.App().GetBoardsForUserAndTeam(th.GetUser1().ID, model.GlobalTeamID, true)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		var archive bytes.Buffer
+		zw := zip.NewWriter(&archive)
+		w, err := zw.Create("version.json")
+		require.NoError(t, err)
+		_, err = w.Write([]byte(`{"version":99,"date":0}`))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		validation, resp := th.Client.ValidateArchive(model.GlobalTeamID, &archive)
+		th.CheckOK(resp)
+		require.False(t, validation.Valid)
+		require.Len(t, validation.Errors, 1)
+		require.Contains(t, validation.Errors[0], "unsupported archive version")
+	})
+
+	t.Run("invalid board file", func(t *testing.T) {
+		var archive bytes.Buffer
+		zw := zip.NewWriter(&archive)
+		w, err := zw.Create("board-id/board.jsonl")
+		require.NoError(t, err)
+		_, err = w.Write([]byte(`{"type":"unknown","data":{}}`))
+		require.NoError(t, err)
+		w, err = zw.Create("other-board/image.png")
+		require.NoError(t, err)
+		_, err = w.Write([]byte("image"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		validation, resp := th.Client.ValidateArchive(model.GlobalTeamID, &archive)
+		th.CheckOK(resp)
+		require.False(t, validation.Valid)
+		require.Len(t, validation.Errors, 1)
+		require.Contains(t, validation.Errors[0], "board-id")
+		require.NotEmpty(t, validation.Warnings)
+	})
+}
+
+func TestExportBoardMarkdown(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := &model.Board{
+		ID:          utils.NewID(utils.IDTypeBoard),
+		TeamID:      testTeamID,
+		Title:       "Release plan",
+		Description: "Tasks for the next release",
+		Type:        model.BoardTypePrivate,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "done", "value": "Done", "color": "propColorGreen"},
+				},
+			},
+			{"id": "notes", "name": "Notes", "type": "text", "options": []interface{}{}},
+		},
+	}
+
+	now := utils.GetMillis()
+	cardID := utils.NewID(utils.IDTypeCard)
+	newContent := func(id, blockType, title string, fields map[string]interface{}) model.Block {
+		return model.Block{
+			ID:       id,
+			ParentID: cardID,
+			BoardID:  board.ID,
+			Type:     model.BlockType(blockType),
+			Title:    title,
+			Fields:   fields,
+			CreateAt: now,
+			UpdateAt: now,
+		}
+	}
+
+	blocks := []model.Block{
+		{
+			ID:       cardID,
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+			Title:    "Write | docs",
+			Fields: map[string]interface{}{
+				"icon":         "📝",
+				"properties":   map[string]interface{}{"status": "done", "notes": "a | b"},
+				"contentOrder": []interface{}{"text-1", []interface{}{"check-1", "check-2"}, "image-1"},
+			},
+			CreateAt: now,
+			UpdateAt: now,
+		},
+		newContent("image-1", model.TypeImage, "", map[string]interface{}{"fileId": "7abc.png"}),
+		newContent("check-2", model.TypeCheckbox, "Review", map[string]interface{}{"value": false}),
+		newContent("check-1", model.TypeCheckbox, "Draft", map[string]interface{}{"value": true}),
+		newContent("text-1", model.TypeText, "Some **text**", nil),
+		newContent("divider-1", model.TypeDivider, "", nil),
+	}
+
+	babs, resp := th.Client.CreateBoardsAndBlocks(&model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: blocks,
+	})
+	th.CheckOK(resp)
+	boardID := babs.Boards[0].ID
+
+	t.Run("renders the board", func(t *testing.T) {
+		document, resp := th.Client.ExportBoardMarkdown(boardID)
+		th.CheckOK(resp)
+		require.Equal(t, "text/markdown; charset=utf-8", resp.Header.Get("Content-Type"))
+
+		fileURL := utils.MakeFileLink(th.Server.Config().ServerRoot, testTeamID, boardID, "7abc.png")
+		expected := "# Release plan\n" +
+			"\nTasks for the next release\n" +
+			"\n## 📝 Write | docs\n" +
+			"\n| Property | Value |\n| --- | --- |\n| Status | Done |\n| Notes | a \\| b |\n" +
+			"\nSome **text**\n" +
+			"\n- [x] Draft\n" +
+			"\n- [ ] Review\n" +
+			"\n![](" + fileURL + ")\n" +
+			"\n---\n"
+		require.Equal(t, expected, document)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		r, err := th.Client.DoAPIGet("/boards/"+boardID+"/export?format=pdf", "")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, r.StatusCode)
+	})
+
+	t.Run("not a board member", func(t *testing.T) {
+		_, resp := th.Client2.ExportBoardMarkdown(boardID)
+		th.CheckForbidden(resp)
+	})
+}
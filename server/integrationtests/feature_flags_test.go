@@ -0,0 +1,55 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTeamFeatureFlags(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		flags, resp := th.Client.GetTeamFeatureFlags(testTeamID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, flags)
+	})
+
+	t.Run("team flags override the instance defaults", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		th.Server.Config().FeatureFlags = map[string]string{"comments": "true", "relations": "false"}
+
+		flags, resp := th.Client.GetTeamFeatureFlags(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, map[string]string{"comments": "true", "relations": "false"}, flags)
+
+		require.NoError(t, th.Server.App().SetFeatureFlag(testTeamID, "relations", "true"))
+
+		flags, resp = th.Client.GetTeamFeatureFlags(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, map[string]string{"comments": "true", "relations": "true"}, flags)
+
+		require.NoError(t, th.Server.App().DeleteFeatureFlag(testTeamID, "relations"))
+
+		flags, resp = th.Client.GetTeamFeatureFlags(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, "false", flags["relations"])
+	})
+
+	t.Run("labels can be disabled for a team", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		require.NoError(t, th.Server.App().SetFeatureFlag(testTeamID, model.FeatureFlagLabels, "false"))
+
+		label, resp := th.Client.CreateLabel(board.ID, &model.Label{Name: "urgent", Color: "propColorRed"})
+		th.CheckForbidden(resp)
+		require.Nil(t, label)
+	})
+}
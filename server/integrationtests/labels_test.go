@@ -0,0 +1,119 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabels(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		th.Logout(th.Client)
+
+		label, resp := th.Client.CreateLabel(board.ID, &model.Label{Name: "urgent", Color: "propColorRed"})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, label)
+	})
+
+	t.Run("invalid label", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		label, resp := th.Client.CreateLabel(board.ID, &model.Label{Name: "urgent", Color: "blue-ish"})
+		th.CheckBadRequest(resp)
+		require.Nil(t, label)
+	})
+
+	t.Run("create, rename and recolor", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		label, resp := th.Client.CreateLabel(board.ID, &model.Label{Name: "urgent", Color: "propColorRed"})
+		th.CheckOK(resp)
+		require.NotEmpty(t, label.ID)
+
+		name := "blocker"
+		color := "propColorOrange"
+		patchedLabel, resp := th.Client.PatchLabel(board.ID, label.ID, &model.LabelPatch{Name: &name, Color: &color})
+		th.CheckOK(resp)
+		require.Equal(t, label.ID, patchedLabel.ID)
+
+		labels, resp := th.Client.GetLabels(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []model.Label{{ID: label.ID, Name: "blocker", Color: "propColorOrange"}}, labels)
+
+		_, resp = th.Client.PatchLabel(board.ID, "missing-label", &model.LabelPatch{Name: &name})
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("cards can only reference existing labels", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		label, resp := th.Client.CreateLabel(board.ID, &model.Label{Name: "urgent", Color: "propColorRed"})
+		th.CheckOK(resp)
+
+		updatedBoard, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		propertyID := updatedBoard.GetLabelProperty()["id"].(string)
+
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{
+			Title:      "valid labels",
+			Properties: map[string]any{propertyID: []string{label.ID}},
+		}, false)
+		th.CheckOK(resp)
+		require.NotNil(t, card)
+
+		card, resp = th.Client.CreateCard(board.ID, &model.Card{
+			Title:      "invalid labels",
+			Properties: map[string]any{propertyID: []string{"missing-label"}},
+		}, false)
+		th.CheckBadRequest(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("deleting a label removes it from the cards", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		urgent, resp := th.Client.CreateLabel(board.ID, &model.Label{Name: "urgent", Color: "propColorRed"})
+		th.CheckOK(resp)
+		backend, resp := th.Client.CreateLabel(board.ID, &model.Label{Name: "backend", Color: "propColorBlue"})
+		th.CheckOK(resp)
+
+		updatedBoard, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		propertyID := updatedBoard.GetLabelProperty()["id"].(string)
+
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{
+			Title:      "labeled card",
+			Properties: map[string]any{propertyID: []string{urgent.ID, backend.ID}},
+		}, false)
+		th.CheckOK(resp)
+
+		success, resp := th.Client.DeleteLabel(board.ID, urgent.ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		labels, resp := th.Client.GetLabels(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []model.Label{*backend}, labels)
+
+		card, resp = th.Client.GetCard(card.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []any{backend.ID}, card.Properties[propertyID])
+	})
+}
@@ -2863,6 +2863,28 @@ func TestPermissionsClientConfig(t *testing.T) {
 	})
 }
 
+func TestPermissionsVersion(t *testing.T) {
+	ttCases := []TestCase{
+		{"/version", methodGet, "", userAnon, http.StatusOK, 1},
+		{"/version", methodGet, "", userAdmin, http.StatusOK, 1},
+	}
+
+	t.Run("plugin", func(t *testing.T) {
+		th := SetupTestHelperPluginMode(t)
+		defer th.TearDown()
+		clients := setupClients(th)
+		testData := setupData(t, th)
+		runTestCases(t, ttCases, testData, clients)
+	})
+	t.Run("local", func(t *testing.T) {
+		th := SetupTestHelperLocalMode(t)
+		defer th.TearDown()
+		clients := setupLocalClients(th)
+		testData := setupData(t, th)
+		runTestCases(t, ttCases, testData, clients)
+	})
+}
+
 func TestPermissionsGetCategories(t *testing.T) {
 	ttCases := []TestCase{
 		{"/teams/test-team/categories", methodGet, "", userAnon, http.StatusUnauthorized, 0},
@@ -0,0 +1,94 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderPropertyOptions(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	board, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do", "color": "propColorGray"},
+					map[string]interface{}{"id": "doing", "value": "Doing", "color": "propColorBlue"},
+					map[string]interface{}{"id": "done", "value": "Done", "color": "propColorGreen"},
+				},
+			},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	})
+	th.CheckOK(resp)
+
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{
+		Title:      "card",
+		Properties: map[string]any{"status": "doing"},
+	}, false)
+	th.CheckOK(resp)
+
+	optionIDs := func(board *model.Board) []string {
+		schema, err := model.ParsePropertySchema(board)
+		require.NoError(t, err)
+
+		ids := make([]string, len(schema["status"].Options))
+		for id, option := range schema["status"].Options {
+			ids[option.Index] = id
+		}
+		return ids
+	}
+
+	t.Run("reorder the options", func(t *testing.T) {
+		newBoard, resp := th.Client.ReorderPropertyOptions(board.ID, "status", []string{"done", "todo", "doing"})
+		th.CheckOK(resp)
+		require.Equal(t, []string{"done", "todo", "doing"}, optionIDs(newBoard))
+
+		// the order is persisted
+		newBoard, resp = th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, []string{"done", "todo", "doing"}, optionIDs(newBoard))
+		require.Equal(t, "Done", newBoard.CardProperties[0]["options"].([]interface{})[0].(map[string]interface{})["value"])
+
+		// the card values are kept
+		newCard, resp := th.Client.GetCard(card.ID)
+		th.CheckOK(resp)
+		require.Equal(t, "doing", newCard.Properties["status"])
+	})
+
+	t.Run("all the options are required once", func(t *testing.T) {
+		_, resp := th.Client.ReorderPropertyOptions(board.ID, "status", []string{"done", "todo"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.ReorderPropertyOptions(board.ID, "status", []string{"done", "done", "todo"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.ReorderPropertyOptions(board.ID, "status", []string{"done", "todo", "missing"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("property without options", func(t *testing.T) {
+		_, resp := th.Client.ReorderPropertyOptions(board.ID, "notes", []string{})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("missing property", func(t *testing.T) {
+		_, resp := th.Client.ReorderPropertyOptions(board.ID, "missing", []string{})
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("users without access cannot reorder the options", func(t *testing.T) {
+		privateBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		_, resp := th.Client2.ReorderPropertyOptions(privateBoard.ID, "status", []string{})
+		th.CheckForbidden(resp)
+	})
+}
@@ -0,0 +1,98 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyVisibilityRules(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	board, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "open", "value": "Open", "color": "propColorGray"},
+					map[string]interface{}{"id": "closed", "value": "Closed", "color": "propColorGreen"},
+				},
+			},
+			{"id": "resolution", "name": "Resolution", "type": "text"},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	})
+	th.CheckOK(resp)
+
+	t.Run("set a rule", func(t *testing.T) {
+		rule := &model.PropertyVisibilityRule{PropertyID: "resolution", DependsOn: "status", Values: []string{"closed"}}
+		newRule, resp := th.Client.SetPropertyVisibilityRule(board.ID, rule)
+		th.CheckOK(resp)
+		require.Equal(t, rule, newRule)
+
+		rules, resp := th.Client.GetPropertyVisibilityRules(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []model.PropertyVisibilityRule{*rule}, rules)
+
+		// the rules are part of the board reads
+		updatedBoard, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, []model.PropertyVisibilityRule{*rule}, updatedBoard.GetPropertyVisibilityRules())
+	})
+
+	t.Run("invalid rules are rejected", func(t *testing.T) {
+		rules := []*model.PropertyVisibilityRule{
+			{PropertyID: "notes", DependsOn: "missing", Values: []string{"closed"}},
+			{PropertyID: "notes", DependsOn: "status", Values: []string{"missing"}},
+			{PropertyID: "notes", DependsOn: "status", Values: []string{}},
+			{PropertyID: "notes", DependsOn: "notes", Values: []string{"value"}},
+			{PropertyID: "status", DependsOn: "resolution", Values: []string{"fixed"}},
+		}
+		for _, rule := range rules {
+			_, resp := th.Client.SetPropertyVisibilityRule(board.ID, rule)
+			th.CheckBadRequest(resp)
+		}
+
+		rule := &model.PropertyVisibilityRule{PropertyID: "missing", DependsOn: "status", Values: []string{"closed"}}
+		_, resp := th.Client.SetPropertyVisibilityRule(board.ID, rule)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("deleting a property removes the rules depending on it", func(t *testing.T) {
+		rule := &model.PropertyVisibilityRule{PropertyID: "notes", DependsOn: "resolution", Values: []string{"fixed"}}
+		_, resp := th.Client.SetPropertyVisibilityRule(board.ID, rule)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.PatchBoard(board.ID, &model.BoardPatch{DeletedCardProperties: []string{"resolution"}})
+		th.CheckOK(resp)
+
+		rules, resp := th.Client.GetPropertyVisibilityRules(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, rules)
+	})
+
+	t.Run("delete a rule", func(t *testing.T) {
+		rule := &model.PropertyVisibilityRule{PropertyID: "notes", DependsOn: "status", Values: []string{"open"}}
+		_, resp := th.Client.SetPropertyVisibilityRule(board.ID, rule)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.DeletePropertyVisibilityRule(board.ID, "notes")
+		th.CheckOK(resp)
+
+		rules, resp := th.Client.GetPropertyVisibilityRules(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, rules)
+	})
+
+	t.Run("a user without access to the board", func(t *testing.T) {
+		rule := &model.PropertyVisibilityRule{PropertyID: "notes", DependsOn: "status", Values: []string{"open"}}
+		_, resp := th.Client2.SetPropertyVisibilityRule(board.ID, rule)
+		th.CheckForbidden(resp)
+	})
+}
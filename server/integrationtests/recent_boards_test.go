@@ -0,0 +1,84 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentBoards(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	th.Server.Config().MaxRecentBoards = 2
+
+	board1 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	board2 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	board3 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	openBoard := func(t *testing.T, boardID string) {
+		_, resp := th.Client.AddRecentBoard(boardID)
+		th.CheckOK(resp)
+		// the boards are ordered by the time they were opened
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	t.Run("no recent boards by default", func(t *testing.T) {
+		boards, resp := th.Client.GetRecentBoards()
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+	})
+
+	t.Run("the most recently opened boards go first", func(t *testing.T) {
+		openBoard(t, board1.ID)
+		openBoard(t, board2.ID)
+
+		boards, resp := th.Client.GetRecentBoards()
+		th.CheckOK(resp)
+		require.Len(t, boards, 2)
+		require.Equal(t, board2.ID, boards[0].ID)
+		require.Equal(t, board1.ID, boards[1].ID)
+
+		// opening a board again moves it to the top
+		openBoard(t, board1.ID)
+
+		boards, resp = th.Client.GetRecentBoards()
+		th.CheckOK(resp)
+		require.Len(t, boards, 2)
+		require.Equal(t, board1.ID, boards[0].ID)
+		require.Equal(t, board2.ID, boards[1].ID)
+	})
+
+	t.Run("only the last boards are kept", func(t *testing.T) {
+		openBoard(t, board3.ID)
+
+		boards, resp := th.Client.GetRecentBoards()
+		th.CheckOK(resp)
+		require.Len(t, boards, 2)
+		require.Equal(t, board3.ID, boards[0].ID)
+		require.Equal(t, board1.ID, boards[1].ID)
+	})
+
+	t.Run("recent boards are private to the user", func(t *testing.T) {
+		boards, resp := th.Client2.GetRecentBoards()
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+	})
+
+	t.Run("users without access cannot add the board", func(t *testing.T) {
+		_, resp := th.Client2.AddRecentBoard(board1.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("deleting the board removes it", func(t *testing.T) {
+		_, resp := th.Client.DeleteBoard(board3.ID)
+		th.CheckOK(resp)
+
+		boards, resp := th.Client.GetRecentBoards()
+		th.CheckOK(resp)
+		require.Len(t, boards, 1)
+		require.Equal(t, board1.ID, boards[0].ID)
+	})
+}
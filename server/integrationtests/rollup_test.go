@@ -0,0 +1,128 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardRollup(t *testing.T) {
+	setupBoard := func(th *TestHelper) *model.Board {
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		board, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{
+			UpdatedCardProperties: []map[string]interface{}{
+				{
+					"id":   "status",
+					"name": "Status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To Do"},
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+				{
+					"id":           "estimate",
+					"name":         "Estimate",
+					"type":         model.PropertyTypeNumber,
+					"numberFormat": model.NumberFormatDecimal,
+					"decimals":     1,
+				},
+				{"id": "title", "name": "Notes", "type": "text"},
+			},
+		})
+		th.CheckOK(resp)
+
+		cards := []*model.Card{
+			{Title: "1", Properties: map[string]any{"status": "done", "estimate": "2"}},
+			{Title: "2", Properties: map[string]any{"status": "todo", "estimate": "3"}},
+			{Title: "3", Properties: map[string]any{"status": "done", "estimate": 4.6}},
+			{Title: "4", Properties: map[string]any{"estimate": "1"}},
+		}
+		for _, card := range cards {
+			_, resp = th.Client.CreateCard(board.ID, card, true)
+			th.CheckOK(resp)
+		}
+
+		return board
+	}
+
+	t.Run("a non member should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		groups, resp := th.Client2.GetBoardRollup(board.ID, model.QueryRollupOptions{Aggregation: model.RollupCount})
+		th.CheckForbidden(resp)
+		require.Nil(t, groups)
+	})
+
+	t.Run("count grouped by a select property", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		groups, resp := th.Client.GetBoardRollup(board.ID, model.QueryRollupOptions{
+			GroupBy:     "status",
+			Aggregation: model.RollupCount,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, []*model.RollupGroup{
+			{Value: "", Count: 1, Result: 1},
+			{Value: "todo", Count: 1, Result: 1},
+			{Value: "done", Count: 2, Result: 2},
+		}, groups)
+	})
+
+	t.Run("sum and average of a number property", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		groups, resp := th.Client.GetBoardRollup(board.ID, model.QueryRollupOptions{
+			Aggregation: model.RollupSum,
+			Of:          "estimate",
+		})
+		th.CheckOK(resp)
+		require.Len(t, groups, 1)
+		require.Equal(t, int64(4), groups[0].Count)
+		require.InDelta(t, 10.6, groups[0].Result, 0.001)
+		require.Equal(t, "10.6", groups[0].FormattedResult)
+
+		groups, resp = th.Client.GetBoardRollup(board.ID, model.QueryRollupOptions{
+			GroupBy:     "status",
+			Aggregation: model.RollupAvg,
+			Of:          "estimate",
+		})
+		th.CheckOK(resp)
+		require.Len(t, groups, 3)
+		require.Equal(t, "done", groups[2].Value)
+		require.InDelta(t, 3.3, groups[2].Result, 0.001)
+		require.Equal(t, "3.3", groups[2].FormattedResult)
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := setupBoard(th)
+
+		testCases := []model.QueryRollupOptions{
+			{Aggregation: "median", Of: "estimate"},
+			{Aggregation: model.RollupSum},
+			{Aggregation: model.RollupSum, Of: "missing"},
+			{Aggregation: model.RollupSum, Of: "title"},
+			{GroupBy: "missing", Aggregation: model.RollupCount},
+			{GroupBy: "estimate", Aggregation: model.RollupCount},
+		}
+		for _, opts := range testCases {
+			groups, resp := th.Client.GetBoardRollup(board.ID, opts)
+			th.CheckBadRequest(resp)
+			require.Nil(t, groups)
+		}
+	})
+}
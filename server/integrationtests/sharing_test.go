@@ -92,3 +92,87 @@ func TestSharing(t *testing.T) {
 		})
 	})
 }
+
+func TestSharingVisibleProperties(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	th.Server.Config().EnablePublicSharedBoards = true
+	token := utils.NewID(utils.IDTypeToken)
+
+	newBoard := &model.Board{
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{"id": "visible", "name": "Status", "type": "text"},
+			{"id": "internal", "name": "Cost", "type": "text"},
+		},
+	}
+	board, err := th.Server.App().CreateBoard(newBoard, th.GetUser1().ID, true)
+	require.NoError(t, err)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeCard,
+		Title:    "card",
+		CreateAt: 1,
+		UpdateAt: 1,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"visible": "done", "internal": "100"},
+		},
+	}
+	_, resp := th.Client.InsertBlocks(board.ID, []model.Block{card}, false)
+	th.CheckOK(resp)
+
+	sharing := &model.Sharing{
+		ID:                board.ID,
+		Token:             token,
+		Enabled:           true,
+		VisibleProperties: []string{"visible"},
+	}
+	success, resp := th.Client.PostSharing(sharing)
+	th.CheckOK(resp)
+	require.True(t, success)
+
+	t.Run("the shared link only exposes the visible properties", func(t *testing.T) {
+		th.Logout(th.Client2)
+		defer th.Login2()
+
+		sharedBoard, resp := th.Client2.GetBoard(board.ID, token)
+		th.CheckOK(resp)
+		require.Len(t, sharedBoard.CardProperties, 1)
+		require.Equal(t, "visible", sharedBoard.CardProperties[0]["id"])
+
+		blocks, resp := th.Client2.GetSharedBlocksForBoard(board.ID, token)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		require.Equal(t, map[string]interface{}{"visible": "done"}, blocks[0].Fields["properties"])
+	})
+
+	t.Run("the board members see all the properties", func(t *testing.T) {
+		memberBoard, resp := th.Client.GetBoard(board.ID, token)
+		th.CheckOK(resp)
+		require.Len(t, memberBoard.CardProperties, 2)
+
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		require.Equal(t, map[string]interface{}{"visible": "done", "internal": "100"}, blocks[0].Fields["properties"])
+	})
+
+	t.Run("a null whitelist exposes all the properties", func(t *testing.T) {
+		sharing.VisibleProperties = nil
+		success, resp := th.Client.PostSharing(sharing)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		th.Logout(th.Client2)
+		defer th.Login2()
+
+		sharedBoard, resp := th.Client2.GetBoard(board.ID, token)
+		th.CheckOK(resp)
+		require.Len(t, sharedBoard.CardProperties, 2)
+	})
+}
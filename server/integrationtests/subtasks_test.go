@@ -0,0 +1,128 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtasks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	board, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{
+		UpdatedCardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "open", "value": "Open", "color": "propColorGray"},
+					map[string]interface{}{"id": "done", "value": "Done", "color": "propColorGreen"},
+				},
+			},
+		},
+	})
+	th.CheckOK(resp)
+
+	createCard := func(parentID string) *model.Card {
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{
+			Title:      "card",
+			ParentID:   parentID,
+			Properties: map[string]any{"status": "open"},
+		}, true)
+		th.CheckOK(resp)
+		return card
+	}
+
+	parent := createCard("")
+	subtask1 := createCard(parent.ID)
+	subtask2 := createCard(parent.ID)
+	require.Equal(t, parent.ID, subtask1.ParentID)
+
+	t.Run("subtasks cannot have subtasks", func(t *testing.T) {
+		_, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card", ParentID: subtask1.ID}, true)
+		th.CheckBadRequest(resp)
+
+		otherBoard, otherCards := th.CreateBoardAndCards(testTeamID, model.BoardTypePrivate, 1)
+		require.NotEqual(t, board.ID, otherBoard.ID)
+		_, resp = th.Client.CreateCard(board.ID, &model.Card{Title: "card", ParentID: otherCards[0].ID}, true)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("rollup without a rule", func(t *testing.T) {
+		rollup, resp := th.Client.GetSubtaskRollup(parent.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 2, rollup.Total)
+		require.Equal(t, 0, rollup.Completed)
+		require.ElementsMatch(t, []string{subtask1.ID, subtask2.ID}, rollup.SubtaskIDs)
+	})
+
+	t.Run("invalid rules are rejected", func(t *testing.T) {
+		rules := []*model.SubtaskRule{
+			{CompletedPropertyID: "missing", CompletedValue: "done"},
+			{CompletedPropertyID: "status", CompletedValue: "missing"},
+			{CompletedPropertyID: "status", CompletedValue: "done", ParentPropertyID: "status"},
+		}
+		for _, rule := range rules {
+			_, resp := th.Client.SetSubtaskRule(board.ID, rule)
+			th.CheckBadRequest(resp)
+		}
+	})
+
+	t.Run("completing all the subtasks applies the rule", func(t *testing.T) {
+		rule := &model.SubtaskRule{CompletedPropertyID: "status", CompletedValue: "done", ParentPropertyID: "status", ParentValue: "done"}
+		newRule, resp := th.Client.SetSubtaskRule(board.ID, rule)
+		th.CheckOK(resp)
+		require.Equal(t, rule, newRule)
+
+		for _, subtask := range []*model.Card{subtask1, subtask2} {
+			_, resp = th.Client.PatchCard(subtask.ID, &model.CardPatch{UpdatedProperties: map[string]any{"status": "done"}}, true)
+			th.CheckOK(resp)
+		}
+
+		rollup, resp := th.Client.GetSubtaskRollup(parent.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 2, rollup.Completed)
+
+		require.Eventually(t, func() bool {
+			card, resp := th.Client.GetCard(parent.ID)
+			return resp.Error == nil && card.Properties["status"] == "done"
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("detach a subtask", func(t *testing.T) {
+		empty := ""
+		card, resp := th.Client.PatchCard(subtask2.ID, &model.CardPatch{ParentID: &empty}, true)
+		th.CheckOK(resp)
+		require.Empty(t, card.ParentID)
+
+		rollup, resp := th.Client.GetSubtaskRollup(parent.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []string{subtask1.ID}, rollup.SubtaskIDs)
+	})
+
+	t.Run("deleting the parent deletes its subtasks", func(t *testing.T) {
+		_, resp := th.Client.DeleteBlock(board.ID, parent.ID, true)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.GetCard(subtask1.ID)
+		require.Error(t, resp.Error)
+
+		_, resp = th.Client.GetCard(subtask2.ID)
+		th.CheckOK(resp)
+	})
+
+	t.Run("delete the rule", func(t *testing.T) {
+		_, resp := th.Client.DeleteSubtaskRule(board.ID)
+		th.CheckOK(resp)
+
+		updatedBoard, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Nil(t, updatedBoard.GetSubtaskRule())
+	})
+}
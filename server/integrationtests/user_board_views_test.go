@@ -0,0 +1,73 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserBoardView(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		BoardID:      board.ID,
+		UserID:       th.GetUser2().ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	view := model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Title:    "view",
+		CreateAt: 1,
+		UpdateAt: 1,
+	}
+	_, resp = th.Client.InsertBlocks(board.ID, []model.Block{view}, false)
+	th.CheckOK(resp)
+
+	t.Run("empty state by default", func(t *testing.T) {
+		userView, resp := th.Client.GetUserBoardView(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, userView.BoardID)
+		require.Empty(t, userView.ActiveViewID)
+	})
+
+	t.Run("active view must belong to the board", func(t *testing.T) {
+		_, resp := th.Client.SetUserBoardView(board.ID, &model.UserBoardView{ActiveViewID: utils.NewID(utils.IDTypeView)})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the state is only visible to its user", func(t *testing.T) {
+		userView, resp := th.Client.SetUserBoardView(board.ID, &model.UserBoardView{
+			ActiveViewID:    view.ID,
+			SortOptions:     []interface{}{map[string]interface{}{"propertyId": "status", "reversed": true}},
+			CollapsedGroups: []string{"done"},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser1().ID, userView.UserID)
+
+		userView, resp = th.Client.GetUserBoardView(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, view.ID, userView.ActiveViewID)
+		require.Equal(t, []string{"done"}, userView.CollapsedGroups)
+
+		otherUserView, resp := th.Client2.GetUserBoardView(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, otherUserView.ActiveViewID)
+		require.Empty(t, otherUserView.CollapsedGroups)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		otherBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		_, resp := th.Client2.SetUserBoardView(otherBoard.ID, &model.UserBoardView{})
+		th.CheckForbidden(resp)
+	})
+}
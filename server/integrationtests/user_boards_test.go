@@ -0,0 +1,72 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMyBoards(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board1 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	board2 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	board3 := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	boardIDs := func(boards []*model.UserBoard) []string {
+		ids := make([]string, 0, len(boards))
+		for _, board := range boards {
+			ids = append(ids, board.ID)
+		}
+		return ids
+	}
+
+	t.Run("all boards", func(t *testing.T) {
+		boards, resp := th.Client.GetMyBoards("", 0)
+		th.CheckOK(resp)
+		require.ElementsMatch(t, []string{board1.ID, board2.ID, board3.ID}, boardIDs(boards))
+	})
+
+	t.Run("other users don't get the boards", func(t *testing.T) {
+		boards, resp := th.Client2.GetMyBoards("", 0)
+		th.CheckOK(resp)
+		require.NotContains(t, boardIDs(boards), board1.ID)
+	})
+
+	t.Run("recent boards", func(t *testing.T) {
+		newTitle := "updated"
+		_, resp := th.Client.PatchBoard(board1.ID, &model.BoardPatch{Title: &newTitle})
+		th.CheckOK(resp)
+
+		boards, resp := th.Client.GetMyBoards(model.UserBoardsFilterRecent, 2)
+		th.CheckOK(resp)
+		require.Len(t, boards, 2)
+		require.Equal(t, board1.ID, boards[0].ID)
+		require.Equal(t, newTitle, boards[0].Title)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, resp := th.Client.GetMyBoards(model.UserBoardsFilterRecent, model.MaxRecentUserBoards+1)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, resp := th.Client.GetMyBoards("invalid", 0)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("favorite boards", func(t *testing.T) {
+		boards, resp := th.Client.GetMyBoards(model.UserBoardsFilterFavorites, 0)
+		th.CheckOK(resp)
+		require.Empty(t, boards)
+
+		_, resp = th.Client.AddFavorite(board2.ID)
+		th.CheckOK(resp)
+
+		boards, resp = th.Client.GetMyBoards(model.UserBoardsFilterFavorites, 0)
+		th.CheckOK(resp)
+		require.Equal(t, []string{board2.ID}, boardIDs(boards))
+	})
+}
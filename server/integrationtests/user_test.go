@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
@@ -35,6 +36,82 @@ func TestUserRegister(t *testing.T) {
 	require.False(t, success)
 }
 
+func TestUserRegisterAllowedDomains(t *testing.T) {
+	th := SetupTestHelper(t).Start()
+	defer th.TearDown()
+
+	th.Server.Config().AllowedRegistrationDomains = []string{"example.com"}
+
+	// the first user can always register
+	th.RegisterAndLogin(th.Client, fakeUsername, fakeEmail, utils.NewID(utils.IDTypeNone), "")
+
+	team, resp := th.Client.GetTeam(model.GlobalTeamID)
+	th.CheckOK(resp)
+
+	success, resp := th.Client2.Register(&model.RegisterRequest{
+		Username: "otheruser",
+		Email:    "otheruser@example.org",
+		Password: utils.NewID(utils.IDTypeNone),
+		Token:    team.SignupToken,
+	})
+	th.CheckForbidden(resp)
+	require.False(t, success)
+
+	success, resp = th.Client2.Register(&model.RegisterRequest{
+		Username: "otheruser",
+		Email:    "otheruser@example.com",
+		Password: utils.NewID(utils.IDTypeNone),
+		Token:    team.SignupToken,
+	})
+	th.CheckOK(resp)
+	require.True(t, success)
+}
+
+func TestUserRegisterWithInvite(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	// invited users are not restricted by the allowed domains
+	th.Server.Config().AllowedRegistrationDomains = []string{"example.com"}
+
+	invite, err := th.Server.App().CreateInvite(testTeamID, model.BoardRoleCommenter, time.Hour, 1)
+	require.NoError(t, err)
+
+	client := th.Client2
+	th.Logout(client)
+
+	registerRequest := &model.RegisterRequest{
+		Username: "inviteduser",
+		Email:    "inviteduser@example.org",
+		Password: utils.NewID(utils.IDTypeNone),
+	}
+
+	success, resp := client.RegisterWithInvite("missing-token", registerRequest)
+	th.CheckNotFound(resp)
+	require.False(t, success)
+
+	success, resp = client.RegisterWithInvite(invite.Token, registerRequest)
+	th.CheckOK(resp)
+	require.True(t, success)
+
+	user, err := th.Server.Store().GetUserByUsername("inviteduser")
+	require.NoError(t, err)
+
+	member, err := th.Server.App().GetMemberForBoard(board.ID, user.ID)
+	require.NoError(t, err)
+	require.True(t, member.SchemeCommenter)
+	require.False(t, member.SchemeEditor)
+
+	// the invite is single-use
+	registerRequest.Username = "otherinviteduser"
+	registerRequest.Email = "otherinviteduser@example.org"
+	success, resp = client.RegisterWithInvite(invite.Token, registerRequest)
+	th.CheckNotFound(resp)
+	require.False(t, success)
+}
+
 func TestUserLogin(t *testing.T) {
 	th := SetupTestHelper(t).Start()
 	defer th.TearDown()
@@ -119,6 +196,50 @@ func TestGetMe(t *testing.T) {
 	})
 }
 
+func TestGetMeWithBoardPermissions(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	t.Run("board admin", func(t *testing.T) {
+		me, resp := th.Client.GetMeWithBoardPermissions(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser1().ID, me.ID)
+		require.NotNil(t, me.Teams)
+		require.Equal(t, board.ID, me.Board.BoardID)
+		require.Equal(t, model.BoardRoleAdmin, me.Board.Role)
+		require.Len(t, me.Board.Permissions, len(model.BoardPermissions))
+	})
+
+	t.Run("users without access get no permissions", func(t *testing.T) {
+		me, resp := th.Client2.GetMeWithBoardPermissions(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardRoleNone, me.Board.Role)
+		require.Empty(t, me.Board.Permissions)
+	})
+
+	t.Run("board viewer", func(t *testing.T) {
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeViewer: true,
+		})
+		th.CheckOK(resp)
+
+		me, resp := th.Client2.GetMeWithBoardPermissions(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardRoleViewer, me.Board.Role)
+		require.Equal(t, []string{model.PermissionViewBoard.Id}, me.Board.Permissions)
+	})
+
+	t.Run("the board is only included when requested", func(t *testing.T) {
+		me, resp := th.Client.GetMeWithBoardPermissions("")
+		th.CheckOK(resp)
+		require.Nil(t, me.Board)
+	})
+}
+
 func TestGetUser(t *testing.T) {
 	th := SetupTestHelper(t).Start()
 	defer th.TearDown()
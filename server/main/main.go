@@ -4,6 +4,7 @@ package main
 import (
 	"C"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -60,6 +61,8 @@ func main() {
 	pSingleUser := flag.Bool("single-user", false, "single user mode")
 	pDBType := flag.String("dbtype", "", "Database type")
 	pDBConfig := flag.String("dbconfig", "", "Database config")
+	pMigrate := flag.Bool("migrate", false, "run the database migrations and exit")
+	pCheck := flag.Bool("check", false, "run the startup self-check and exit")
 	pConfigFilePath := flag.String(
 		"config",
 		"",
@@ -131,6 +134,36 @@ func main() {
 		config.Port = *pPort
 	}
 
+	if pCheck != nil && *pCheck {
+		report := server.RunSelfCheck(config)
+		fmt.Print(report.String())
+		if report.HasFailures() {
+			logger.Fatal("Startup self-check failed")
+		}
+		return
+	}
+
+	if pMigrate != nil && *pMigrate {
+		// migrations always run from the command, even if they are
+		// disabled for the server startup
+		config.RunMigrations = true
+		migrationsDB, mErr := server.NewStore(config, singleUser, logger)
+		if mErr != nil {
+			logger.Fatal("Database migration failed", mlog.Err(mErr))
+		}
+		_ = migrationsDB.Shutdown()
+		logger.Info("Database migrations applied")
+		return
+	}
+
+	// the summary is printed to the standard output so it's readable
+	// whatever the logging configuration is
+	report := server.RunSelfCheck(config)
+	fmt.Print(report.String())
+	if report.HasFailures() {
+		logger.Fatal("Startup self-check failed, see the summary above for the problems to fix")
+	}
+
 	db, err := server.NewStore(config, singleUser, logger)
 	if err != nil {
 		logger.Fatal("server.NewStore ERROR", mlog.Err(err))
@@ -157,12 +190,52 @@ func main() {
 
 	// Setting up signal capturing
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	// SIGHUP reloads the settings that can be changed at runtime
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	fileReadOnlyMode := config.ReadOnlyMode
+
+	// Waiting for SIGINT (pkill -2) or SIGTERM
+	for {
+		select {
+		case <-reload:
+			fileReadOnlyMode = reloadConfig(server, *pConfigFilePath, fileReadOnlyMode, logger)
+		case <-stop:
+			server.PrepareShutdown()
+			_ = server.Shutdown()
+			return
+		}
+	}
+}
+
+// reloadConfig re-reads the config file and applies the settings that
+// can be changed without restarting the server. It returns the
+// readonly_mode value of the file, to compare with on the next reload.
+//
+// The read-only mode can also be changed through the admin API, so the
+// file value is only applied when it changed since the last load. A
+// reload for other settings keeps the mode set by an admin.
+func reloadConfig(srv *server.Server, configFilePath string, fileReadOnlyMode bool, logger mlog.LoggerIFace) bool {
+	newConfig, err := config.ReadConfigFile(configFilePath)
+	if err != nil {
+		logger.Error("Unable to reload the config file", mlog.Err(err))
+		return fileReadOnlyMode
+	}
 
-	// Waiting for SIGINT (pkill -2)
-	<-stop
+	logger.Info("Config file reloaded")
+	if newConfig.ReadOnlyMode != fileReadOnlyMode {
+		srv.App().SetReadOnlyMode(newConfig.ReadOnlyMode)
+	}
+
+	if newConfig.TrustedAuthHeader != "" && len(newConfig.TrustedProxies) == 0 {
+		logger.Error("The trusted proxies cannot be empty when the trusted auth header is set, keeping the previous settings")
+	} else if err := srv.App().SetTrustedAuth(newConfig.TrustedAuthHeader, newConfig.TrustedAuthEmailHeader, newConfig.TrustedProxies); err != nil {
+		logger.Error("Invalid trusted proxies, keeping the previous settings", mlog.Err(err))
+	}
 
-	_ = server.Shutdown()
+	return newConfig.ReadOnlyMode
 }
 
 // StartServer starts the server
@@ -0,0 +1,53 @@
+package model
+
+// Admin actions recorded in the admin audit log.
+const (
+	AdminActionSetPassword       = "setPassword"
+	AdminActionSetReadOnlyMode   = "setReadOnlyMode"
+	AdminActionSetFeatureFlag    = "setFeatureFlag"
+	AdminActionDeleteFeatureFlag = "deleteFeatureFlag"
+	AdminActionCreateInvite      = "createInvite"
+	AdminActionRevokeInvite      = "revokeInvite"
+	AdminActionImpersonate       = "impersonate"
+	AdminActionCreateTeamWebhook = "createTeamWebhook"
+	AdminActionUpdateTeamWebhook = "updateTeamWebhook"
+	AdminActionDeleteTeamWebhook = "deleteTeamWebhook"
+	AdminActionRepairBlocks      = "repairBlocks"
+	AdminActionSendNotice        = "sendNotice"
+)
+
+// AdminAuditEntry records an operation done through the admin API.
+// Entries are never modified once inserted.
+// swagger:model
+type AdminAuditEntry struct {
+	// The entry ID
+	// required: true
+	ID string `json:"id"`
+
+	// Who performed the action
+	// required: true
+	Actor string `json:"actor"`
+
+	// The action performed
+	// required: true
+	Action string `json:"action"`
+
+	// The object the action was performed on
+	// required: true
+	Target string `json:"target"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// QueryAdminAuditOptions are query options that can be passed to GetAdminAuditEntries.
+type QueryAdminAuditOptions struct {
+	Actor          string // if not empty then filter for entries of the specified actor
+	Action         string // if not empty then filter for entries of the specified action
+	AfterCreateAt  int64  // if non-zero then filter for entries with create_at greater than AfterCreateAt
+	BeforeCreateAt int64  // if non-zero then filter for entries with create_at less than BeforeCreateAt
+	Limit          uint64 // if non-zero then limit the number of returned entries
+	Offset         uint64 // if non-zero then skip the first Offset entries, requires Limit
+	Ascending      bool   // if true then return the oldest entries first
+}
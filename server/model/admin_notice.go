@@ -0,0 +1,51 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// AdminNoticeMaxLength is the maximum length, in characters, of the
+// message of an admin notice.
+const AdminNoticeMaxLength = 1000
+
+// Severities of an admin notice.
+const (
+	AdminNoticeInfo    = "info"
+	AdminNoticeWarning = "warning"
+	AdminNoticeError   = "error"
+)
+
+// AdminNotice is a message sent by an admin to the connected clients,
+// which can show it as a banner.
+// swagger:model
+type AdminNotice struct {
+	// The message to show
+	// required: true
+	Message string `json:"message"`
+
+	// The severity of the notice: info, warning or error. Defaults to info
+	// required: false
+	Severity string `json:"severity"`
+
+	// The team whose clients get the notice, all of the clients if empty
+	// required: false
+	TeamID string `json:"teamId,omitempty"`
+}
+
+// IsValid checks the message and the severity of a notice. An empty
+// severity is valid and means info.
+func (n *AdminNotice) IsValid() error {
+	if strings.TrimSpace(n.Message) == "" {
+		return NewErrInvalidField("message", "cannot be empty")
+	}
+	if utf8.RuneCountInString(n.Message) > AdminNoticeMaxLength {
+		return NewErrInvalidField("message", "is too long")
+	}
+
+	switch n.Severity {
+	case "", AdminNoticeInfo, AdminNoticeWarning, AdminNoticeError:
+		return nil
+	}
+	return NewErrInvalidField("severity", "must be info, warning or error")
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminNoticeIsValid(t *testing.T) {
+	notice := &AdminNotice{Message: "Maintenance in 10 minutes"}
+	require.NoError(t, notice.IsValid())
+
+	notice.Severity = AdminNoticeWarning
+	require.NoError(t, notice.IsValid())
+
+	notice.Severity = "critical"
+	require.True(t, IsErrBadRequest(notice.IsValid()))
+
+	notice.Severity = AdminNoticeInfo
+	notice.Message = "  "
+	require.True(t, IsErrBadRequest(notice.IsValid()))
+
+	notice.Message = strings.Repeat("a", AdminNoticeMaxLength+1)
+	require.True(t, IsErrBadRequest(notice.IsValid()))
+}
@@ -94,6 +94,12 @@ type BlockPatch struct {
 	// The block removed fields
 	// required: false
 	DeletedFields []string `json:"deletedFields"`
+
+	// The updateAt of the block the client based its change on. If the
+	// block was modified after it, the patch is rejected with a conflict.
+	// Omitting it applies the patch anyway
+	// required: false
+	BaseUpdateAt *int64 `json:"baseUpdateAt,omitempty"`
 }
 
 // BlockPatchBatch is a batch of IDs and patches for modify blocks
@@ -106,6 +112,47 @@ type BlockPatchBatch struct {
 	BlockPatches []BlockPatch `json:"block_patches"`
 }
 
+// DeleteBlocksResult is the result of a batch deletion of blocks.
+// swagger:model
+type DeleteBlocksResult struct {
+	// The IDs of the deleted blocks, including their descendants
+	// required: true
+	Deleted []string `json:"deleted"`
+
+	// The blocks that couldn't be deleted
+	// required: true
+	Failed []DeleteBlockFailure `json:"failed"`
+}
+
+// DeleteBlockFailure is a block that couldn't be deleted in a batch.
+// swagger:model
+type DeleteBlockFailure struct {
+	// The block ID
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The reason why the block couldn't be deleted
+	// required: true
+	Error string `json:"error"`
+}
+
+// BlockManifestEntry is the minimal information of a block that allows
+// a client to find out which blocks changed since it fetched them.
+// swagger:model
+type BlockManifestEntry struct {
+	// The block ID
+	// required: true
+	ID string `json:"id"`
+
+	// Updated time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The block type
+	// required: true
+	Type BlockType `json:"type"`
+}
+
 // BoardModifier is a callback that can modify each board during an import.
 // A cache of arbitrary data will be passed for each call and any changes
 // to the cache will be preserved for the next call.
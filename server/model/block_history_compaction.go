@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	blockHistoryHour = int64(time.Hour / time.Millisecond)
+	blockHistoryDay  = 24 * blockHistoryHour
+)
+
+// BlockHistoryCompactionPolicy defines which versions of a block are
+// kept in its history: all the versions newer than KeepAll, the last
+// version of every hour for the ones newer than KeepHourly and the last
+// version of every day for the older ones. The latest version of a block
+// and the versions that deleted it are always kept, as they are the ones
+// the blocks are restored from.
+type BlockHistoryCompactionPolicy struct {
+	KeepAll    time.Duration
+	KeepHourly time.Duration
+}
+
+// BlockVersion is a version of a block in its history.
+type BlockVersion struct {
+	UpdateAt int64
+	DeleteAt int64
+}
+
+type blockHistoryBucket struct {
+	size  int64
+	index int64
+}
+
+// VersionsToDelete returns the update times of the versions of a block
+// the policy removes from its history at the time now, in miliseconds.
+// The versions are the whole history of the block.
+func (p *BlockHistoryCompactionPolicy) VersionsToDelete(versions []BlockVersion, now int64) []int64 {
+	keepAll := p.KeepAll.Milliseconds()
+	keepHourly := p.KeepHourly.Milliseconds()
+
+	latest := int64(0)
+	for _, v := range versions {
+		if v.UpdateAt > latest {
+			latest = v.UpdateAt
+		}
+	}
+
+	keep := map[int64]bool{}
+	lastInBucket := map[blockHistoryBucket]int64{}
+	for _, v := range versions {
+		age := now - v.UpdateAt
+		if age < keepAll {
+			keep[v.UpdateAt] = true
+			continue
+		}
+		if v.DeleteAt > 0 || v.UpdateAt == latest {
+			keep[v.UpdateAt] = true
+		}
+
+		size := blockHistoryDay
+		if age < keepHourly {
+			size = blockHistoryHour
+		}
+		bucket := blockHistoryBucket{size: size, index: v.UpdateAt / size}
+		if v.UpdateAt > lastInBucket[bucket] {
+			lastInBucket[bucket] = v.UpdateAt
+		}
+	}
+	for _, updateAt := range lastInBucket {
+		keep[updateAt] = true
+	}
+
+	toDelete := []int64{}
+	for _, v := range versions {
+		if !keep[v.UpdateAt] {
+			toDelete = append(toDelete, v.UpdateAt)
+			// versions with the same update time are deleted once
+			keep[v.UpdateAt] = true
+		}
+	}
+	return toDelete
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockHistoryVersionsToDelete(t *testing.T) {
+	policy := &BlockHistoryCompactionPolicy{KeepAll: 24 * time.Hour, KeepHourly: 7 * 24 * time.Hour}
+	hour := int64(time.Hour / time.Millisecond)
+	day := 24 * hour
+	now := 100 * day
+
+	t.Run("recent versions are kept", func(t *testing.T) {
+		versions := []BlockVersion{
+			{UpdateAt: now - 3*hour},
+			{UpdateAt: now - 3*hour + 1},
+			{UpdateAt: now - 1},
+		}
+		require.Empty(t, policy.VersionsToDelete(versions, now))
+	})
+
+	t.Run("the last version of each hour is kept", func(t *testing.T) {
+		versions := []BlockVersion{
+			{UpdateAt: now - 2*day},
+			{UpdateAt: now - 2*day + 10},
+			{UpdateAt: now - 2*day + 20},
+			{UpdateAt: now - 2*day + hour},
+			{UpdateAt: now},
+		}
+		require.ElementsMatch(t, []int64{now - 2*day, now - 2*day + 10}, policy.VersionsToDelete(versions, now))
+	})
+
+	t.Run("the last version of each day is kept", func(t *testing.T) {
+		versions := []BlockVersion{
+			{UpdateAt: now - 10*day},
+			{UpdateAt: now - 10*day + hour},
+			{UpdateAt: now - 10*day + 2*hour},
+			{UpdateAt: now - 9*day},
+			{UpdateAt: now},
+		}
+		require.ElementsMatch(t, []int64{now - 10*day, now - 10*day + hour}, policy.VersionsToDelete(versions, now))
+	})
+
+	t.Run("the latest version and the deletions are kept", func(t *testing.T) {
+		versions := []BlockVersion{
+			{UpdateAt: now - 10*day},
+			{UpdateAt: now - 10*day + hour, DeleteAt: now - 10*day + hour},
+			{UpdateAt: now - 10*day + 2*hour},
+			{UpdateAt: now - 10*day + 3*hour},
+		}
+		require.ElementsMatch(t, []int64{now - 10*day, now - 10*day + 2*hour}, policy.VersionsToDelete(versions, now))
+	})
+
+	t.Run("versions with the same update time are deleted once", func(t *testing.T) {
+		versions := []BlockVersion{
+			{UpdateAt: now - 10*day},
+			{UpdateAt: now - 10*day},
+			{UpdateAt: now},
+		}
+		require.Equal(t, []int64{now - 10*day}, policy.VersionsToDelete(versions, now))
+	})
+}
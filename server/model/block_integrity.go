@@ -0,0 +1,101 @@
+package model
+
+import "errors"
+
+// DefaultMaxBlockTreeDepth is the number of levels the block trees are
+// walked at most when no depth is configured.
+const DefaultMaxBlockTreeDepth = 100
+
+// ErrBlockTreeTooDeep is returned when walking a block tree goes deeper
+// than the maximum depth, usually because the blocks have a cycle.
+var ErrBlockTreeTooDeep = errors.New("block tree is deeper than the maximum depth")
+
+// MaxBlockTreeDepth returns the configured maximum depth of the block
+// trees, or the default one if it isn't set.
+func MaxBlockTreeDepth(configured int) int {
+	if configured <= 0 {
+		return DefaultMaxBlockTreeDepth
+	}
+	return configured
+}
+
+// Block integrity problems found on a board.
+const (
+	// BlockIntegrityOrphan is a block whose parent doesn't exist.
+	BlockIntegrityOrphan = "orphan"
+
+	// BlockIntegrityCycle is a block that is its own ancestor.
+	BlockIntegrityCycle = "cycle"
+
+	// BlockIntegrityDanglingOption is a card property value that
+	// references an option the property doesn't have.
+	BlockIntegrityDanglingOption = "danglingOption"
+)
+
+// How the orphaned blocks are repaired.
+const (
+	OrphanRepairReparent = "reparent"
+	OrphanRepairDelete   = "delete"
+)
+
+// Repairs done to the blocks with integrity problems.
+const (
+	BlockIntegrityRepairReparent     = "reparent"
+	BlockIntegrityRepairDelete       = "delete"
+	BlockIntegrityRepairRemoveOption = "removeOption"
+)
+
+// IsValidOrphanRepair checks that the repair mode of the orphaned blocks
+// is known. Empty means that the problems are only reported.
+func IsValidOrphanRepair(repair string) bool {
+	return repair == "" || repair == OrphanRepairReparent || repair == OrphanRepairDelete
+}
+
+// BlockIntegrityIssue is a referential integrity problem of a block.
+// swagger:model
+type BlockIntegrityIssue struct {
+	// The kind of problem, orphan, cycle or danglingOption
+	// required: true
+	Type string `json:"type"`
+
+	// The board of the block
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The block with the problem
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The parent of the block, for orphans and cycles
+	// required: false
+	ParentID string `json:"parentId,omitempty"`
+
+	// The property with the dangling option
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The dangling option
+	// required: false
+	OptionID string `json:"optionId,omitempty"`
+
+	// The repair done, reparent, delete or removeOption, empty if it was
+	// only reported
+	// required: false
+	Repair string `json:"repair,omitempty"`
+}
+
+// BlockIntegrityReport is the result of an integrity check.
+// swagger:model
+type BlockIntegrityReport struct {
+	// The number of boards checked
+	// required: true
+	BoardCount int `json:"boardCount"`
+
+	// The number of blocks checked
+	// required: true
+	BlockCount int `json:"blockCount"`
+
+	// The problems found
+	// required: true
+	Issues []BlockIntegrityIssue `json:"issues"`
+}
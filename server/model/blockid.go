@@ -8,11 +8,35 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+// IDGenerator returns the new ID to use for an entity, given its
+// original ID and its type.
+type IDGenerator func(id string, idType utils.IDType) string
+
+// RandomIDGenerator generates a new random ID each time it's called.
+func RandomIDGenerator(_ string, idType utils.IDType) string {
+	return utils.NewID(idType)
+}
+
+// NewDeterministicIDGenerator returns a generator that derives the new
+// IDs from the namespace and the original IDs, so generating IDs for the
+// same entities twice yields the same results.
+func NewDeterministicIDGenerator(namespace string) IDGenerator {
+	return func(id string, idType utils.IDType) string {
+		return utils.NewDeterministicID(idType, namespace, id)
+	}
+}
+
 // GenerateBlockIDs generates new IDs for all the blocks of the list,
 // keeping consistent any references that other blocks would made to
 // the original IDs, so a tree of blocks can get new IDs and maintain
 // its shape.
 func GenerateBlockIDs(blocks []Block, logger mlog.LoggerIFace) []Block {
+	return GenerateBlockIDsWith(blocks, RandomIDGenerator, logger)
+}
+
+// GenerateBlockIDsWith works as GenerateBlockIDs, using newID to
+// generate the new IDs of the blocks.
+func GenerateBlockIDsWith(blocks []Block, newID IDGenerator, logger mlog.LoggerIFace) []Block {
 	blockIDs := map[string]BlockType{}
 	referenceIDs := map[string]bool{}
 	for _, block := range blocks {
@@ -73,7 +97,7 @@ func GenerateBlockIDs(blocks []Block, logger mlog.LoggerIFace) []Block {
 	for id, blockType := range blockIDs {
 		for referenceID := range referenceIDs {
 			if id == referenceID {
-				newIDs[id] = utils.NewID(BlockType2IDType(blockType))
+				newIDs[id] = newID(id, BlockType2IDType(blockType))
 				continue
 			}
 		}
@@ -90,7 +114,7 @@ func GenerateBlockIDs(blocks []Block, logger mlog.LoggerIFace) []Block {
 		if existingID, ok := newIDs[id]; ok {
 			return existingID
 		}
-		return utils.NewID(BlockType2IDType(blockIDs[id]))
+		return newID(id, BlockType2IDType(blockIDs[id]))
 	}
 
 	newBlocks := make([]Block, len(blocks))
@@ -14,13 +14,15 @@ import (
 type BlockType string
 
 const (
-	TypeUnknown = "unknown"
-	TypeBoard   = "board"
-	TypeCard    = "card"
-	TypeView    = "view"
-	TypeText    = "text"
-	TypeComment = "comment"
-	TypeImage   = "image"
+	TypeUnknown  = "unknown"
+	TypeBoard    = "board"
+	TypeCard     = "card"
+	TypeView     = "view"
+	TypeText     = "text"
+	TypeComment  = "comment"
+	TypeImage    = "image"
+	TypeCheckbox = "checkbox"
+	TypeDivider  = "divider"
 )
 
 func (bt BlockType) String() string {
@@ -42,6 +44,10 @@ func BlockTypeFromString(s string) (BlockType, error) {
 		return TypeComment, nil
 	case "image":
 		return TypeImage, nil
+	case "checkbox":
+		return TypeCheckbox, nil
+	case "divider":
+		return TypeDivider, nil
 	}
 	return TypeUnknown, ErrInvalidBlockType{s}
 }
@@ -55,7 +61,7 @@ func BlockType2IDType(blockType BlockType) utils.IDType {
 		return utils.IDTypeCard
 	case TypeView:
 		return utils.IDTypeView
-	case TypeText, TypeComment:
+	case TypeText, TypeComment, TypeCheckbox, TypeDivider:
 		return utils.IDTypeBlock
 	}
 	return utils.IDTypeNone
@@ -8,6 +8,7 @@ import (
 
 type BoardType string
 type BoardRole string
+type BoardVisibility string
 
 const (
 	BoardTypeOpen    BoardType = "O"
@@ -22,6 +23,15 @@ const (
 	BoardRoleAdmin     BoardRole = "admin"
 )
 
+// The visibilities of the boards created without a type. Team boards are
+// open to the members of the team, and public boards are also shared
+// through a link if public shared boards are enabled.
+const (
+	BoardVisibilityPrivate BoardVisibility = "private"
+	BoardVisibilityTeam    BoardVisibility = "team"
+	BoardVisibilityPublic  BoardVisibility = "public"
+)
+
 // Board groups a set of blocks and its layout
 // swagger:model
 type Board struct {
@@ -85,6 +95,14 @@ type Board struct {
 	// required: false
 	CardProperties []map[string]interface{} `json:"cardProperties"`
 
+	// The ID of the card used as template for the new cards of the board
+	// required: false
+	CardTemplateID string `json:"cardTemplateId"`
+
+	// The last time the board or any of its blocks changed in miliseconds since the current epoch
+	// required: false
+	LastActivityAt int64 `json:"lastActivityAt"`
+
 	// The creation time in miliseconds since the current epoch
 	// required: true
 	CreateAt int64 `json:"createAt"`
@@ -96,6 +114,11 @@ type Board struct {
 	// The deleted time in miliseconds since the current epoch. Set to indicate this block is deleted
 	// required: false
 	DeleteAt int64 `json:"deleteAt"`
+
+	// Whether the board is a favorite of the current user, only set when
+	// requested
+	// required: false
+	IsFavorite *bool `json:"isFavorite,omitempty"`
 }
 
 // BoardPatch is a patch for modify boards
@@ -144,6 +167,10 @@ type BoardPatch struct {
 	// The board removed card properties
 	// required: false
 	DeletedCardProperties []string `json:"deletedCardProperties"`
+
+	// The ID of the card used as template for the new cards, empty to clear it
+	// required: false
+	CardTemplateID *string `json:"cardTemplateId"`
 }
 
 // BoardMember stores the information of the membership of a user on a board
@@ -210,6 +237,22 @@ type BoardMetadata struct {
 	LastModifiedBy string `json:"lastModifiedBy"`
 }
 
+// MoveBoardRequest is the request to move a board to another team
+// swagger:model
+type MoveBoardRequest struct {
+	// The ID of the team the board is moved to
+	// required: true
+	TeamID string `json:"teamId"`
+}
+
+// TransferBoardOwnerRequest is the request to transfer the ownership of a board
+// swagger:model
+type TransferBoardOwnerRequest struct {
+	// The ID of the user that becomes the new owner of the board
+	// required: true
+	UserID string `json:"userId"`
+}
+
 func BoardFromJSON(data io.Reader) *Board {
 	var board *Board
 	_ = json.NewDecoder(data).Decode(&board)
@@ -270,6 +313,10 @@ func (p *BoardPatch) Patch(board *Board) *Board {
 		board.ChannelID = *p.ChannelID
 	}
 
+	if p.CardTemplateID != nil {
+		board.CardTemplateID = *p.CardTemplateID
+	}
+
 	for key, property := range p.UpdatedProperties {
 		board.Properties[key] = property
 	}
@@ -323,7 +370,8 @@ func (p *BoardPatch) Patch(board *Board) *Board {
 			}
 		}
 
-		board.CardProperties = newCardProperties
+		// the visibility rules can't depend on the deleted properties
+		board.CardProperties = removeVisibilityRulesDependingOn(newCardProperties, p.DeletedCardProperties)
 	}
 
 	return board
@@ -333,6 +381,20 @@ func IsBoardTypeValid(t BoardType) bool {
 	return t == BoardTypeOpen || t == BoardTypePrivate
 }
 
+// IsBoardVisibilityValid returns true if the value is a supported board
+// visibility. An empty value is valid, and means private.
+func IsBoardVisibilityValid(v BoardVisibility) bool {
+	return v == "" || v == BoardVisibilityPrivate || v == BoardVisibilityTeam || v == BoardVisibilityPublic
+}
+
+// BoardType returns the type of the boards created with the visibility.
+func (v BoardVisibility) BoardType() BoardType {
+	if v == BoardVisibilityTeam || v == BoardVisibilityPublic {
+		return BoardTypeOpen
+	}
+	return BoardTypePrivate
+}
+
 func IsBoardMinimumRoleValid(r BoardRole) bool {
 	return r == BoardRoleNone || r == BoardRoleAdmin || r == BoardRoleEditor || r == BoardRoleCommenter || r == BoardRoleViewer
 }
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// BoardAPIKeyPrefix starts the board API keys, so they can be told
+	// apart from the session tokens.
+	BoardAPIKeyPrefix = "fbk_"
+
+	// Permissions of the board API keys. Read keys can only be used for
+	// GET requests.
+	BoardAPIKeyPermissionRead  = "read"
+	BoardAPIKeyPermissionWrite = "write"
+
+	boardAPIKeyNameMaxLength = 100
+)
+
+// BoardAPIKey is an API key that authenticates integrations on a single
+// board. The requests made with the key act on behalf of the user that
+// created it. Only the hash of the key is stored.
+// swagger:model
+type BoardAPIKey struct {
+	// The key ID
+	// required: true
+	ID string `json:"id"`
+
+	// The board the key gives access to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The key name
+	// required: true
+	Name string `json:"name"`
+
+	// The key permission, read or write
+	// required: true
+	Permission string `json:"permission"`
+
+	// The ID of the user that created the key
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The key, only returned when the key is created
+	// required: false
+	Key string `json:"key,omitempty"`
+}
+
+// IsValid checks the name and the permission of a new key.
+func (k *BoardAPIKey) IsValid() error {
+	if strings.TrimSpace(k.Name) == "" {
+		return NewErrInvalidField("name", "cannot be empty")
+	}
+
+	if len(k.Name) > boardAPIKeyNameMaxLength {
+		return NewErrInvalidField("name", fmt.Sprintf("cannot be longer than %d characters", boardAPIKeyNameMaxLength))
+	}
+
+	if k.Permission != BoardAPIKeyPermissionRead && k.Permission != BoardAPIKeyPermissionWrite {
+		return NewErrInvalidField("permission", fmt.Sprintf("unknown permission %s", k.Permission))
+	}
+
+	return nil
+}
+
+// AllowsMethod checks if the key can be used for a request with the
+// given HTTP method.
+func (k *BoardAPIKey) AllowsMethod(method string) bool {
+	if k.Permission == BoardAPIKeyPermissionWrite {
+		return true
+	}
+	return method == "GET" || method == "HEAD"
+}
+
+// IsBoardAPIKey checks if a token is a board API key.
+func IsBoardAPIKey(token string) bool {
+	return strings.HasPrefix(token, BoardAPIKeyPrefix)
+}
@@ -0,0 +1,18 @@
+package model
+
+// BoardFavorite marks a board as a favorite of a user. Favorites are
+// private to the user, the other board members don't see them.
+// swagger:model
+type BoardFavorite struct {
+	// The user ID
+	// required: true
+	UserID string `json:"userId"`
+
+	// The board ID
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The time the board was added to the favorites in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
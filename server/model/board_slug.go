@@ -0,0 +1,32 @@
+package model
+
+import (
+	"regexp"
+)
+
+// MaxBoardSlugLength is the maximum length of a board slug.
+const MaxBoardSlugLength = 64
+
+var boardSlugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// BoardSlug is a human readable name that identifies a board within its
+// team, used to build friendlier links. The board ID is still the
+// canonical reference of the board.
+// swagger:model
+type BoardSlug struct {
+	// The slug of the board, empty if the board has none
+	// required: true
+	Slug string `json:"slug"`
+}
+
+// ValidateBoardSlug returns an error if the slug isn't made of lowercase
+// letters and digits, optionally separated by single hyphens.
+func ValidateBoardSlug(slug string) error {
+	if len(slug) > MaxBoardSlugLength {
+		return NewErrInvalidField("slug", "cannot be longer than 64 characters")
+	}
+	if !boardSlugRegexp.MatchString(slug) {
+		return NewErrInvalidField("slug", "must contain only lowercase letters, digits and single hyphens between them")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBoardSlug(t *testing.T) {
+	for _, slug := range []string{"roadmap", "q3-roadmap", "2022", strings.Repeat("a", MaxBoardSlugLength)} {
+		require.NoError(t, ValidateBoardSlug(slug), slug)
+	}
+
+	for _, slug := range []string{"", "Roadmap", "road map", "-roadmap", "roadmap-", "road--map", "road_map", strings.Repeat("a", MaxBoardSlugLength+1)} {
+		require.True(t, IsErrBadRequest(ValidateBoardSlug(slug)), slug)
+	}
+}
@@ -0,0 +1,70 @@
+package model
+
+// QueryBoardStatsOptions are the options used to compute the statistics
+// of a board.
+type QueryBoardStatsOptions struct {
+	StatusProperty  string   // if not empty, the ID of the property holding the status of the cards
+	DoneValues      []string // the values of the status property of the completed cards
+	DueDateProperty string   // if not empty, the ID of the date property holding the due date of the cards
+	OverdueBefore   int64    // the cards not completed that are due before this time are overdue
+}
+
+// BoardStats are the statistics of the cards of a board.
+// swagger:model
+type BoardStats struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The number of cards of the board, not counting the templates
+	// required: true
+	TotalCards int64 `json:"totalCards"`
+
+	// The ID of the property used as the status of the cards, empty if
+	// the board has none
+	// required: false
+	StatusPropertyID string `json:"statusPropertyId,omitempty"`
+
+	// The number of cards for each value of the status property, in the
+	// order of its options
+	// required: true
+	CardsByStatus []*BoardStatusCount `json:"cardsByStatus"`
+
+	// The number of cards with a completed status
+	// required: true
+	CompletedCards int64 `json:"completedCards"`
+
+	// The percentage of cards with a completed status
+	// required: true
+	CompletedPercent float64 `json:"completedPercent"`
+
+	// The ID of the date property used as the due date of the cards,
+	// empty if the board has none
+	// required: false
+	DueDatePropertyID string `json:"dueDatePropertyId,omitempty"`
+
+	// The number of cards not completed whose due date is before today
+	// required: true
+	OverdueCards int64 `json:"overdueCards"`
+}
+
+// BoardStatusCount is the number of cards of a board with the same
+// status.
+// swagger:model
+type BoardStatusCount struct {
+	// The ID of the status option, empty for the cards without status
+	// required: true
+	Value string `json:"value"`
+
+	// The name of the status option
+	// required: false
+	Name string `json:"name,omitempty"`
+
+	// Whether the status is a completed status
+	// required: true
+	Completed bool `json:"completed"`
+
+	// The number of cards with the status
+	// required: true
+	Count int64 `json:"count"`
+}
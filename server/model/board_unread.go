@@ -0,0 +1,26 @@
+package model
+
+// BoardLastSeen is the last time a user viewed a board, which the
+// changes made to the board after it are unread for the user.
+type BoardLastSeen struct {
+	UserID  string
+	BoardID string
+	SeenAt  int64
+}
+
+// BoardUnread is the number of changes to a board the user hasn't seen.
+// swagger:model
+type BoardUnread struct {
+	// The board ID
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The team ID of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The number of blocks changed by other users since the user last
+	// viewed the board, or ever if they never did
+	// required: true
+	Count int64 `json:"count"`
+}
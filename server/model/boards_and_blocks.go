@@ -135,6 +135,12 @@ func (dbab *PatchBoardsAndBlocks) IsValid() error {
 }
 
 func GenerateBoardsAndBlocksIDs(bab *BoardsAndBlocks, logger mlog.LoggerIFace) (*BoardsAndBlocks, error) {
+	return GenerateBoardsAndBlocksIDsWith(bab, RandomIDGenerator, logger)
+}
+
+// GenerateBoardsAndBlocksIDsWith works as GenerateBoardsAndBlocksIDs,
+// using newID to generate the new IDs of the boards and blocks.
+func GenerateBoardsAndBlocksIDsWith(bab *BoardsAndBlocks, newID IDGenerator, logger mlog.LoggerIFace) (*BoardsAndBlocks, error) {
 	if err := bab.IsValid(); err != nil {
 		return nil, err
 	}
@@ -147,19 +153,19 @@ func GenerateBoardsAndBlocksIDs(bab *BoardsAndBlocks, logger mlog.LoggerIFace) (
 	boards := []*Board{}
 	blocks := []Block{}
 	for _, board := range bab.Boards {
-		newID := utils.NewID(utils.IDTypeBoard)
+		newBoardID := newID(board.ID, utils.IDTypeBoard)
 		for _, block := range blocksByBoard[board.ID] {
-			block.BoardID = newID
+			block.BoardID = newBoardID
 			blocks = append(blocks, block)
 		}
 
-		board.ID = newID
+		board.ID = newBoardID
 		boards = append(boards, board)
 	}
 
 	newBab := &BoardsAndBlocks{
 		Boards: boards,
-		Blocks: GenerateBlockIDs(blocks, logger),
+		Blocks: GenerateBlockIDsWith(blocks, newID, logger),
 	}
 
 	return newBab, nil
@@ -249,3 +249,44 @@ func TestIsValidDeleteBoardsAndBlocks(t *testing.T) {
 		})
 	*/
 }
+
+func TestGenerateBoardsAndBlocksIDsWithDeterministicGenerator(t *testing.T) {
+	logger, err := mlog.NewLogger()
+	require.NoError(t, err)
+
+	newBab := func() *BoardsAndBlocks {
+		return &BoardsAndBlocks{
+			Boards: []*Board{
+				{ID: "board-id-1", Type: BoardTypeOpen, Title: "board1"},
+			},
+			Blocks: []Block{
+				{ID: "block-id-1", BoardID: "board-id-1", Type: TypeCard},
+				{ID: "block-id-2", BoardID: "board-id-1", ParentID: "block-id-1", Type: TypeText},
+			},
+		}
+	}
+
+	t.Run("same namespace generates the same IDs", func(t *testing.T) {
+		rBab1, err := GenerateBoardsAndBlocksIDsWith(newBab(), NewDeterministicIDGenerator("team-id-1"), logger)
+		require.NoError(t, err)
+		rBab2, err := GenerateBoardsAndBlocksIDsWith(newBab(), NewDeterministicIDGenerator("team-id-1"), logger)
+		require.NoError(t, err)
+
+		require.NotEqual(t, "board-id-1", rBab1.Boards[0].ID)
+		require.Equal(t, rBab1.Boards[0].ID, rBab2.Boards[0].ID)
+		require.ElementsMatch(t, rBab1.Blocks, rBab2.Blocks)
+
+		for _, block := range rBab1.Blocks {
+			require.Equal(t, rBab1.Boards[0].ID, block.BoardID)
+		}
+	})
+
+	t.Run("different namespaces generate different IDs", func(t *testing.T) {
+		rBab1, err := GenerateBoardsAndBlocksIDsWith(newBab(), NewDeterministicIDGenerator("team-id-1"), logger)
+		require.NoError(t, err)
+		rBab2, err := GenerateBoardsAndBlocksIDsWith(newBab(), NewDeterministicIDGenerator("team-id-2"), logger)
+		require.NoError(t, err)
+
+		require.NotEqual(t, rBab1.Boards[0].ID, rBab2.Boards[0].ID)
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/utils"
-	"github.com/rivo/uniseg"
 )
 
 var ErrBoardIDMismatch = errors.New("Board IDs do not match")
@@ -45,6 +44,10 @@ type Card struct {
 	// required: false
 	BoardID string `json:"boardId"`
 
+	// The id for the parent card of a subtask, empty for the cards of the board
+	// required: false
+	ParentID string `json:"parentId,omitempty"`
+
 	// The id for user who created this card
 	// required: false
 	CreatedBy string `json:"createdBy"`
@@ -73,6 +76,10 @@ type Card struct {
 	// required: false
 	Properties map[string]any `json:"properties"`
 
+	// The position of the card in the board, cards are sorted by comparing their positions as strings
+	// required: false
+	Position string `json:"position,omitempty"`
+
 	// The creation time in milliseconds since the current epoch
 	// required: false
 	CreateAt int64 `json:"createAt"`
@@ -86,6 +93,22 @@ type Card struct {
 	DeleteAt int64 `json:"deleteAt"`
 }
 
+// CardProgress reports how many of the checkbox content blocks of a card are checked.
+// swagger:model
+type CardProgress struct {
+	// The id for the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The number of checked checkbox blocks of the card
+	// required: true
+	Checked int `json:"checked"`
+
+	// The total number of checkbox blocks of the card
+	// required: true
+	Total int `json:"total"`
+}
+
 // Populate populates a Card with default values.
 func (c *Card) Populate() {
 	if c.ID == "" {
@@ -122,8 +145,8 @@ func (c *Card) CheckValid() error {
 	if c.ContentOrder == nil {
 		return ErrInvalidCard{"ContentOrder is missing"}
 	}
-	if uniseg.GraphemeClusterCount(c.Icon) > 1 {
-		return ErrInvalidCard{"Icon can have only one grapheme"}
+	if _, err := ValidateIcon(c.Icon); err != nil {
+		return ErrInvalidCard{"Icon must be an emoji or a custom icon"}
 	}
 	if c.Properties == nil {
 		return ErrInvalidCard{"Properties"}
@@ -155,6 +178,17 @@ type CardPatch struct {
 	// A map of property ids to property option ids to be updated
 	// required: false
 	UpdatedProperties map[string]any `json:"updatedProperties"`
+
+	// The id for the parent card to make the card one of its subtasks,
+	// or empty to make it a card of the board again
+	// required: false
+	ParentID *string `json:"parentId,omitempty"`
+
+	// The updateAt of the card the client based its change on. If the
+	// card was modified after it, the patch is rejected with a conflict.
+	// Omitting it applies the patch anyway
+	// required: false
+	BaseUpdateAt *int64 `json:"baseUpdateAt,omitempty"`
 }
 
 // Patch returns an updated version of the card.
@@ -171,6 +205,10 @@ func (p *CardPatch) Patch(card *Card) *Card {
 		card.Icon = *p.Icon
 	}
 
+	if p.ParentID != nil {
+		card.ParentID = *p.ParentID
+	}
+
 	if card.Properties == nil {
 		card.Properties = make(map[string]any)
 	}
@@ -186,8 +224,10 @@ func (p *CardPatch) Patch(card *Card) *Card {
 
 // CheckValid returns an error if the CardPatch has invalid field values.
 func (p *CardPatch) CheckValid() error {
-	if p.Icon != nil && uniseg.GraphemeClusterCount(*p.Icon) > 1 {
-		return ErrInvalidCard{"Icon can have only one grapheme"}
+	if p.Icon != nil {
+		if _, err := ValidateIcon(*p.Icon); err != nil {
+			return ErrInvalidCard{"Icon must be an emoji or a custom icon"}
+		}
 	}
 	return nil
 }
@@ -200,10 +240,19 @@ func Card2Block(card *Card) *Block {
 	fields["icon"] = card.Icon
 	fields["isTemplate"] = card.IsTemplate
 	fields["properties"] = card.Properties
+	if card.Position != "" {
+		fields["position"] = card.Position
+	}
+
+	// the subtasks are parented to their card
+	parentID := card.BoardID
+	if card.ParentID != "" {
+		parentID = card.ParentID
+	}
 
 	return &Block{
 		ID:         card.ID,
-		ParentID:   card.BoardID,
+		ParentID:   parentID,
 		CreatedBy:  card.CreatedBy,
 		ModifiedBy: card.ModifiedBy,
 		Schema:     1,
@@ -227,6 +276,7 @@ func Block2Card(block *Block) (*Card, error) {
 	icon := ""
 	isTemplate := false
 	properties := make(map[string]any)
+	position := ""
 
 	if co, ok := block.Fields["contentOrder"]; ok {
 		switch arr := co.(type) {
@@ -271,9 +321,23 @@ func Block2Card(block *Block) (*Card, error) {
 		}
 	}
 
+	if positionAny, ok := block.Fields["position"]; ok {
+		if p, ok := positionAny.(string); ok {
+			position = p
+		} else {
+			return nil, ErrInvalidFieldType{"position"}
+		}
+	}
+
+	parentID := ""
+	if IsSubtask(block) {
+		parentID = block.ParentID
+	}
+
 	card := &Card{
 		ID:           block.ID,
 		BoardID:      block.BoardID,
+		ParentID:     parentID,
 		CreatedBy:    block.CreatedBy,
 		ModifiedBy:   block.ModifiedBy,
 		Title:        block.Title,
@@ -281,6 +345,7 @@ func Block2Card(block *Block) (*Card, error) {
 		Icon:         icon,
 		IsTemplate:   isTemplate,
 		Properties:   properties,
+		Position:     position,
 		CreateAt:     block.CreateAt,
 		UpdateAt:     block.UpdateAt,
 		DeleteAt:     block.DeleteAt,
@@ -296,7 +361,8 @@ func CardPatch2BlockPatch(cardPatch *CardPatch) (*BlockPatch, error) {
 	}
 
 	blockPatch := &BlockPatch{
-		Title: cardPatch.Title,
+		Title:        cardPatch.Title,
+		BaseUpdateAt: cardPatch.BaseUpdateAt,
 	}
 
 	updatedFields := make(map[string]any, 0)
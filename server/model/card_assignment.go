@@ -0,0 +1,68 @@
+package model
+
+import (
+	"fmt"
+)
+
+// CardAssignment assigns several cards of a board to a user.
+// swagger:model
+type CardAssignment struct {
+	// The IDs of the cards to assign
+	// required: true
+	CardIDs []string `json:"cardIds"`
+
+	// The ID of the user to assign the cards to
+	// required: true
+	UserID string `json:"userId"`
+
+	// The ID of the person or multi-person property holding the
+	// assignee. If empty, the first one of the board is used
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+}
+
+// IsValid checks that the assignment has cards and a user.
+func (a *CardAssignment) IsValid() error {
+	if len(a.CardIDs) == 0 {
+		return NewErrInvalidField("cardIds", "cannot be empty")
+	}
+
+	if len(a.CardIDs) > MaxCardPropertyUpdateCards {
+		return NewErrInvalidField("cardIds", fmt.Sprintf("cannot have more than %d cards", MaxCardPropertyUpdateCards))
+	}
+
+	if a.UserID == "" {
+		return NewErrInvalidField("userId", "cannot be empty")
+	}
+
+	return nil
+}
+
+// AssigneePropertyID returns the ID of the property holding the assignee
+// of the cards of a board, which must be a person or multi-person
+// property. If no property is given, the first one of the board is
+// used.
+func AssigneePropertyID(board *Board, propertyID string) (string, error) {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return "", err
+	}
+
+	if propertyID != "" {
+		def, ok := schema[propertyID]
+		if !ok {
+			return "", NewErrInvalidField("propertyId", fmt.Sprintf("property %s does not exist", propertyID))
+		}
+		if def.Type != "person" && def.Type != "multiPerson" {
+			return "", NewErrInvalidField("propertyId", fmt.Sprintf("property %s is not a person property", propertyID))
+		}
+		return propertyID, nil
+	}
+
+	for _, prop := range board.CardProperties {
+		if propType := getMapString("type", prop); propType == "person" || propType == "multiPerson" {
+			return getMapString("id", prop), nil
+		}
+	}
+	return "", NewErrInvalidField("propertyId", "the board has no person property")
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssigneePropertyID(t *testing.T) {
+	board := &Board{CardProperties: []map[string]interface{}{
+		{"id": "status", "name": "Status", "type": "select"},
+		{"id": "owner", "name": "Owner", "type": "person"},
+		{"id": "reviewers", "name": "Reviewers", "type": "multiPerson"},
+	}}
+
+	t.Run("first person property", func(t *testing.T) {
+		propertyID, err := AssigneePropertyID(board, "")
+		require.NoError(t, err)
+		require.Equal(t, "owner", propertyID)
+	})
+
+	t.Run("given property", func(t *testing.T) {
+		propertyID, err := AssigneePropertyID(board, "reviewers")
+		require.NoError(t, err)
+		require.Equal(t, "reviewers", propertyID)
+	})
+
+	t.Run("invalid properties", func(t *testing.T) {
+		_, err := AssigneePropertyID(board, "status")
+		require.True(t, IsErrBadRequest(err))
+
+		_, err = AssigneePropertyID(board, "missing")
+		require.True(t, IsErrBadRequest(err))
+
+		_, err = AssigneePropertyID(&Board{}, "")
+		require.True(t, IsErrBadRequest(err))
+	})
+}
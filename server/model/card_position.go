@@ -0,0 +1,115 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// positionDigits are the digits of the card position keys, in ASCII
+// order so the keys can be compared as plain strings.
+const positionDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// positionSuffixDigits are the digits used to encode the board sequence
+// at the end of a position. They don't include the zero digit, so the
+// positions never end with it and there is always room for a new
+// position between two others.
+const positionSuffixDigits = "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// positionSuffixLength is the length of the encoded sequence, enough to
+// hold any int64 value.
+const positionSuffixLength = 11
+
+// CardPositionChange moves a card between two other cards of the same
+// board. Leaving both cards empty moves the card to the end.
+// swagger:model
+type CardPositionChange struct {
+	// The ID of the card that will be right before the moved card
+	// required: false
+	AfterCardID string `json:"afterCardId"`
+
+	// The ID of the card that will be right after the moved card
+	// required: false
+	BeforeCardID string `json:"beforeCardId"`
+}
+
+// IsValid checks that the position change doesn't reference the same
+// card on both sides.
+func (c *CardPositionChange) IsValid() error {
+	if c.AfterCardID != "" && c.AfterCardID == c.BeforeCardID {
+		return NewErrInvalidField("beforeCardId", "cannot be the same as afterCardId")
+	}
+	return nil
+}
+
+// NewCardPosition returns a position key that sorts after prev and
+// before next. An empty prev means the start of the board and an empty
+// next its end. The sequence, allocated atomically per board, is
+// encoded at the end of the key so concurrent inserts between the same
+// cards get different positions.
+func NewCardPosition(prev, next string, sequence int64) (string, error) {
+	if sequence < 0 {
+		return "", NewErrInvalidField("sequence", fmt.Sprintf("invalid position sequence %d", sequence))
+	}
+
+	mid, err := positionBetween(prev, next)
+	if err != nil {
+		return "", err
+	}
+
+	suffix := make([]byte, positionSuffixLength)
+	base := int64(len(positionSuffixDigits))
+	for i := positionSuffixLength - 1; i >= 0; i-- {
+		suffix[i] = positionSuffixDigits[sequence%base]
+		sequence /= base
+	}
+
+	return mid + string(suffix), nil
+}
+
+// positionBetween returns the shortest key between prev and next that
+// is not a prefix of next, so anything can be appended to it without
+// leaving the range.
+func positionBetween(prev, next string) (string, error) {
+	if next != "" && prev >= next {
+		return "", NewErrInvalidField("position", fmt.Sprintf("position %q is not before %q", prev, next))
+	}
+
+	for _, key := range []string{prev, next} {
+		for _, c := range key {
+			if !strings.ContainsRune(positionDigits, c) {
+				return "", NewErrInvalidField("position", fmt.Sprintf("invalid position %q", key))
+			}
+		}
+	}
+
+	base := len(positionDigits)
+	upperBound := next != ""
+	var key strings.Builder
+	for i := 0; ; i++ {
+		low := 0
+		if i < len(prev) {
+			low = strings.IndexByte(positionDigits, prev[i])
+		}
+
+		high := base
+		if upperBound {
+			if i >= len(next) {
+				// the key reached next, there is no room left
+				return "", NewErrInvalidField("position", fmt.Sprintf("no position between %q and %q", prev, next))
+			}
+			high = strings.IndexByte(positionDigits, next[i])
+		}
+
+		if high-low > 1 {
+			key.WriteByte(positionDigits[(low+high)/2])
+			return key.String(), nil
+		}
+
+		key.WriteByte(positionDigits[low])
+		if high-low == 1 {
+			// the key is already before next, the following digits
+			// only need to be after prev
+			upperBound = false
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCardPosition(t *testing.T) {
+	testCases := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{"empty board", "", ""},
+		{"at the end", "V", ""},
+		{"at the start", "", "V"},
+		{"between", "A", "a"},
+		{"between consecutive digits", "A", "B"},
+		{"between a prefix", "A", "A1"},
+		{"between long keys", "AzzzzzzzzzzzK", "B000000000001"},
+		{"after the last digit", "z", ""},
+		{"before the first digit", "", "01"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			position, err := NewCardPosition(tc.prev, tc.next, 42)
+			require.NoError(t, err)
+			require.Greater(t, position, tc.prev)
+			if tc.next != "" {
+				require.Less(t, position, tc.next)
+			}
+			require.False(t, strings.HasSuffix(position, "0"))
+		})
+	}
+}
+
+func TestNewCardPositionSequence(t *testing.T) {
+	t.Run("concurrent inserts get different positions", func(t *testing.T) {
+		first, err := NewCardPosition("A", "B", 1)
+		require.NoError(t, err)
+		second, err := NewCardPosition("A", "B", 2)
+		require.NoError(t, err)
+
+		require.NotEqual(t, first, second)
+		for _, position := range []string{first, second} {
+			require.Greater(t, position, "A")
+			require.Less(t, position, "B")
+		}
+	})
+
+	t.Run("repeated inserts at the start stay ordered", func(t *testing.T) {
+		next := ""
+		for i := int64(0); i < 100; i++ {
+			position, err := NewCardPosition("", next, i)
+			require.NoError(t, err)
+			if next != "" {
+				require.Less(t, position, next)
+			}
+			next = position
+		}
+	})
+
+	t.Run("repeated inserts after a card stay ordered", func(t *testing.T) {
+		prev, next := "A", "B"
+		for i := int64(0); i < 100; i++ {
+			position, err := NewCardPosition(prev, next, i)
+			require.NoError(t, err)
+			require.Greater(t, position, prev)
+			require.Less(t, position, next)
+			next = position
+		}
+	})
+}
+
+func TestNewCardPositionErrors(t *testing.T) {
+	_, err := NewCardPosition("B", "A", 1)
+	require.True(t, IsErrBadRequest(err))
+
+	_, err = NewCardPosition("A", "A", 1)
+	require.True(t, IsErrBadRequest(err))
+
+	_, err = NewCardPosition("A-", "", 1)
+	require.True(t, IsErrBadRequest(err))
+
+	_, err = NewCardPosition("A", "B", -1)
+	require.True(t, IsErrBadRequest(err))
+}
+
+func TestCardPositionChangeIsValid(t *testing.T) {
+	require.NoError(t, (&CardPositionChange{}).IsValid())
+	require.NoError(t, (&CardPositionChange{AfterCardID: "a", BeforeCardID: "b"}).IsValid())
+	require.True(t, IsErrBadRequest((&CardPositionChange{AfterCardID: "a", BeforeCardID: "a"}).IsValid()))
+}
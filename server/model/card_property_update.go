@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+)
+
+// MaxCardPropertyUpdateCards is the maximum number of cards that can be
+// updated with a single CardPropertyUpdate.
+const MaxCardPropertyUpdateCards = 1000
+
+// readOnlyPropertyTypes are the property types whose values are
+// computed from the card and can't be set.
+var readOnlyPropertyTypes = map[string]bool{
+	"createdTime": true,
+	"createdBy":   true,
+	"updatedTime": true,
+	"updatedBy":   true,
+}
+
+// CardPropertyUpdate sets the value of a property on several cards of
+// a board.
+// swagger:model
+type CardPropertyUpdate struct {
+	// The IDs of the cards to update
+	// required: true
+	CardIDs []string `json:"cardIds"`
+
+	// The ID of the property to set
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The new value of the property, null clears it
+	// required: true
+	Value interface{} `json:"value"`
+}
+
+// IsValid checks that the update has cards and a property.
+func (u *CardPropertyUpdate) IsValid() error {
+	if len(u.CardIDs) == 0 {
+		return NewErrInvalidField("cardIds", "cannot be empty")
+	}
+
+	if len(u.CardIDs) > MaxCardPropertyUpdateCards {
+		return NewErrInvalidField("cardIds", fmt.Sprintf("cannot have more than %d cards", MaxCardPropertyUpdateCards))
+	}
+
+	if u.PropertyID == "" {
+		return NewErrInvalidField("propertyId", "cannot be empty")
+	}
+
+	return nil
+}
+
+// CardPropertyUpdateFailure reports a card that couldn't be updated.
+// swagger:model
+type CardPropertyUpdateFailure struct {
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The reason why the card wasn't updated
+	// required: true
+	Error string `json:"error"`
+}
+
+// CardPropertyUpdateResult is the outcome of a CardPropertyUpdate.
+// swagger:model
+type CardPropertyUpdateResult struct {
+	// The IDs of the updated cards
+	// required: true
+	UpdatedCardIDs []string `json:"updatedCardIds"`
+
+	// The cards that couldn't be updated
+	// required: true
+	Failures []CardPropertyUpdateFailure `json:"failures"`
+}
+
+// ValidateCardPropertyValue checks that a value can be set on a board
+// property, based on the property type. A nil value clears the
+// property and is always valid.
+func ValidateCardPropertyValue(board *Board, propertyID string, value interface{}) error {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	def, ok := schema[propertyID]
+	if !ok {
+		return NewErrInvalidField("propertyId", fmt.Sprintf("property %s does not exist", propertyID))
+	}
+
+	if readOnlyPropertyTypes[def.Type] {
+		return NewErrInvalidField("propertyId", fmt.Sprintf("property %s is read-only", propertyID))
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	switch def.Type {
+	case "select":
+		optionID, ok := value.(string)
+		if !ok {
+			return NewErrInvalidField("value", "must be an option ID")
+		}
+		if _, ok := def.Options[optionID]; optionID != "" && !ok {
+			return NewErrInvalidField("value", fmt.Sprintf("option %s does not exist", optionID))
+		}
+	case "multiSelect":
+		optionIDs, ok := value.([]interface{})
+		if !ok {
+			return NewErrInvalidField("value", "must be a list of option IDs")
+		}
+		for _, optionIDIface := range optionIDs {
+			optionID, ok := optionIDIface.(string)
+			if !ok {
+				return NewErrInvalidField("value", "must be a list of option IDs")
+			}
+			if _, ok := def.Options[optionID]; !ok {
+				return NewErrInvalidField("value", fmt.Sprintf("option %s does not exist", optionID))
+			}
+		}
+	default:
+		// the rest of the typed properties are checked as they would
+		// be on a card
+		card := &Block{
+			Type:   TypeCard,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{propertyID: value}},
+		}
+		return ValidateCardProperties(board, card, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardPropertyUpdateIsValid(t *testing.T) {
+	require.NoError(t, (&CardPropertyUpdate{CardIDs: []string{"card"}, PropertyID: "status"}).IsValid())
+
+	var invalidField *ErrInvalidField
+	require.ErrorAs(t, (&CardPropertyUpdate{PropertyID: "status"}).IsValid(), &invalidField)
+	require.Equal(t, "cardIds", invalidField.Field)
+
+	require.ErrorAs(t, (&CardPropertyUpdate{CardIDs: make([]string, MaxCardPropertyUpdateCards+1), PropertyID: "status"}).IsValid(), &invalidField)
+	require.Equal(t, "cardIds", invalidField.Field)
+
+	require.ErrorAs(t, (&CardPropertyUpdate{CardIDs: []string{"card"}}).IsValid(), &invalidField)
+	require.Equal(t, "propertyId", invalidField.Field)
+}
+
+func TestValidateCardPropertyValue(t *testing.T) {
+	options := []interface{}{
+		map[string]interface{}{"id": "todo", "value": "To do"},
+		map[string]interface{}{"id": "done", "value": "Done"},
+	}
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "type": "select", "options": options},
+			{"id": "tags", "type": "multiSelect", "options": options},
+			{"id": "estimate", "type": PropertyTypeNumber},
+			{"id": "created", "type": "createdTime"},
+			{"id": "notes", "type": "text"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		propertyID string
+		value      interface{}
+		valid      bool
+	}{
+		{"select option", "status", "done", true},
+		{"empty select", "status", "", true},
+		{"unknown select option", "status", "missing", false},
+		{"select with a list", "status", []interface{}{"done"}, false},
+		{"multi select options", "tags", []interface{}{"todo", "done"}, true},
+		{"unknown multi select option", "tags", []interface{}{"missing"}, false},
+		{"multi select with a string", "tags", "done", false},
+		{"number", "estimate", "12.5", true},
+		{"not a number", "estimate", "twelve", false},
+		{"read-only property", "created", "1234", false},
+		{"text", "notes", "some notes", true},
+		{"unknown property", "missing", "value", false},
+		{"cleared value", "status", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCardPropertyValue(board, tc.propertyID, tc.value)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.True(t, IsErrBadRequest(err))
+			}
+		})
+	}
+}
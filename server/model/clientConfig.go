@@ -22,4 +22,8 @@ type ClientConfig struct {
 	// The server feature flags
 	// required: true
 	FeatureFlags map[string]string `json:"featureFlags"`
+
+	// Is the server in read-only maintenance mode
+	// required: true
+	ReadOnlyMode bool `json:"readOnlyMode"`
 }
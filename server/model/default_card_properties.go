@@ -0,0 +1,86 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// cardPropertyTypes are the types of card properties supported by the
+// clients.
+var cardPropertyTypes = map[string]bool{
+	"text":             true,
+	PropertyTypeNumber: true,
+	"select":           true,
+	"multiSelect":      true,
+	"date":             true,
+	"person":           true,
+	"multiPerson":      true,
+	"file":             true,
+	"checkbox":         true,
+	"url":              true,
+	"email":            true,
+	"phone":            true,
+	"createdTime":      true,
+	"createdBy":        true,
+	"updatedTime":      true,
+	"updatedBy":        true,
+	PropertyTypeLabel:  true,
+}
+
+// ValidateCardPropertySchema checks the card properties used as the
+// default schema of new boards: every property and option needs a unique
+// ID, the properties need a name and a known type, and their visibility
+// rules must be valid.
+func ValidateCardPropertySchema(properties []map[string]interface{}) error {
+	board := &Board{CardProperties: properties}
+	if _, err := ParsePropertySchema(board); err != nil {
+		return NewErrInvalidField("cardProperties", err.Error())
+	}
+
+	propertyIDs := map[string]bool{}
+	for i, prop := range properties {
+		id := getMapString("id", prop)
+		if id == "" {
+			return NewErrInvalidField(fmt.Sprintf("cardProperties.%d.id", i), "cannot be empty")
+		}
+		if propertyIDs[id] {
+			return NewErrInvalidField("cardProperties."+id, "is repeated")
+		}
+		propertyIDs[id] = true
+
+		if getMapString("name", prop) == "" {
+			return NewErrInvalidField("cardProperties."+id+".name", "cannot be empty")
+		}
+
+		if propType := getMapString("type", prop); !cardPropertyTypes[propType] {
+			return NewErrInvalidField("cardProperties."+id+".type", fmt.Sprintf("unknown type %s", propType))
+		}
+
+		options, _ := prop["options"].([]interface{})
+		optionIDs := map[string]bool{}
+		for _, optionIface := range options {
+			option, _ := optionIface.(map[string]interface{})
+			optionID := getMapString("id", option)
+			if optionID == "" || optionIDs[optionID] {
+				return NewErrInvalidField("cardProperties."+id+".options", "the options need a unique ID")
+			}
+			optionIDs[optionID] = true
+		}
+	}
+
+	return ValidatePropertyVisibilityRules(board)
+}
+
+// ParseCardPropertySchema parses and validates a default schema stored
+// as JSON, as in the team feature flags.
+func ParseCardPropertySchema(data string) ([]map[string]interface{}, error) {
+	var properties []map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &properties); err != nil {
+		return nil, NewErrInvalidField("cardProperties", "must be a JSON list of properties")
+	}
+
+	if err := ValidateCardPropertySchema(properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
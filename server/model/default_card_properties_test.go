@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCardPropertySchema(t *testing.T) {
+	validProperties := func() []map[string]interface{} {
+		return []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "open", "value": "Open"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+		}
+	}
+
+	t.Run("valid schema", func(t *testing.T) {
+		require.NoError(t, ValidateCardPropertySchema(validProperties()))
+	})
+
+	t.Run("empty schema", func(t *testing.T) {
+		require.NoError(t, ValidateCardPropertySchema(nil))
+	})
+
+	t.Run("missing property ID", func(t *testing.T) {
+		properties := validProperties()
+		delete(properties[1], "id")
+		require.True(t, IsErrBadRequest(ValidateCardPropertySchema(properties)))
+	})
+
+	t.Run("repeated property ID", func(t *testing.T) {
+		properties := validProperties()
+		properties[1]["id"] = "status"
+		require.True(t, IsErrBadRequest(ValidateCardPropertySchema(properties)))
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		properties := validProperties()
+		properties[1]["name"] = ""
+		require.True(t, IsErrBadRequest(ValidateCardPropertySchema(properties)))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		properties := validProperties()
+		properties[1]["type"] = "formula"
+		require.True(t, IsErrBadRequest(ValidateCardPropertySchema(properties)))
+	})
+
+	t.Run("repeated option ID", func(t *testing.T) {
+		properties := validProperties()
+		properties[0]["options"] = []interface{}{
+			map[string]interface{}{"id": "open", "value": "Open"},
+			map[string]interface{}{"id": "open", "value": "Reopened"},
+		}
+		require.True(t, IsErrBadRequest(ValidateCardPropertySchema(properties)))
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		properties := validProperties()
+		properties[0]["options"] = "open"
+		require.True(t, IsErrBadRequest(ValidateCardPropertySchema(properties)))
+	})
+}
+
+func TestParseCardPropertySchema(t *testing.T) {
+	t.Run("valid JSON", func(t *testing.T) {
+		properties, err := ParseCardPropertySchema(`[{"id":"status","name":"Status","type":"select","options":[{"id":"open","value":"Open"}]}]`)
+		require.NoError(t, err)
+		require.Len(t, properties, 1)
+		require.Equal(t, "Status", properties[0]["name"])
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseCardPropertySchema(`{"id":"status"}`)
+		require.True(t, IsErrBadRequest(err))
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := ParseCardPropertySchema(`[{"id":"status","type":"select"}]`)
+		require.True(t, IsErrBadRequest(err))
+	})
+}
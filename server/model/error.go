@@ -79,6 +79,30 @@ func (br *ErrBadRequest) Error() string {
 	return br.reason
 }
 
+// ErrInvalidField can be returned when a field of the request fails
+// the validation.
+type ErrInvalidField struct {
+	Field  string
+	reason string
+}
+
+// NewErrInvalidField creates a new ErrInvalidField instance.
+func NewErrInvalidField(field, reason string) *ErrInvalidField {
+	return &ErrInvalidField{
+		Field:  field,
+		reason: reason,
+	}
+}
+
+func (ifd *ErrInvalidField) Error() string {
+	return fmt.Sprintf("invalid field %s: %s", ifd.Field, ifd.reason)
+}
+
+// Reason returns why the field is invalid.
+func (ifd *ErrInvalidField) Reason() string {
+	return ifd.reason
+}
+
 // ErrUnauthorized can be returned when requester has provided an
 // invalid authorization for a given resource or has not provided any.
 type ErrUnauthorized struct {
@@ -158,8 +182,65 @@ func (ni *ErrNotImplemented) Error() string {
 	return ni.msg
 }
 
+// ErrServiceUnavailable can be returned when the server is temporarily
+// unable to handle the request, e.g. while in maintenance mode.
+type ErrServiceUnavailable struct {
+	reason string
+}
+
+// NewErrServiceUnavailable creates a new ErrServiceUnavailable instance.
+func NewErrServiceUnavailable(reason string) *ErrServiceUnavailable {
+	return &ErrServiceUnavailable{
+		reason: reason,
+	}
+}
+
+func (su *ErrServiceUnavailable) Error() string {
+	return su.reason
+}
+
+// ErrTooManyRequests can be returned when a user performed an action
+// too many times in a short period.
+type ErrTooManyRequests struct {
+	reason string
+}
+
+// NewErrTooManyRequests creates a new ErrTooManyRequests instance.
+func NewErrTooManyRequests(reason string) *ErrTooManyRequests {
+	return &ErrTooManyRequests{
+		reason: reason,
+	}
+}
+
+func (tm *ErrTooManyRequests) Error() string {
+	return tm.reason
+}
+
+// ErrBlockConflict is returned when a block update is based on a
+// version of the block older than the stored one.
+type ErrBlockConflict struct {
+	BaseUpdateAt int64
+
+	// Block is the stored version of the block, so the client can
+	// merge its change
+	Block *Block
+}
+
+// NewErrBlockConflict creates a new ErrBlockConflict instance.
+func NewErrBlockConflict(baseUpdateAt int64, block *Block) *ErrBlockConflict {
+	return &ErrBlockConflict{
+		BaseUpdateAt: baseUpdateAt,
+		Block:        block,
+	}
+}
+
+func (bc *ErrBlockConflict) Error() string {
+	return fmt.Sprintf("block %s was modified at %d, after the base version %d", bc.Block.ID, bc.Block.UpdateAt, bc.BaseUpdateAt)
+}
+
 // IsErrBadRequest returns true if `err` is or wraps one of:
 // - model.ErrBadRequest
+// - model.ErrInvalidField
 // - model.ErrViewsLimitReached
 // - model.ErrAuthParam
 // - model.ErrInvalidCategory
@@ -176,6 +257,12 @@ func IsErrBadRequest(err error) bool {
 		return true
 	}
 
+	// check if this is a model.ErrInvalidField
+	var ifd *ErrInvalidField
+	if errors.As(err, &ifd) {
+		return true
+	}
+
 	// check if this is a model.ErrAuthParam
 	var ap *ErrAuthParam
 	if errors.As(err, &ap) {
@@ -314,3 +401,27 @@ func IsErrNotImplemented(err error) bool {
 	// check if this is a model.ErrInsufficientLicense
 	return errors.Is(err, ErrInsufficientLicense)
 }
+
+// IsErrServiceUnavailable returns true if `err` is or wraps one of:
+// - model.ErrServiceUnavailable.
+func IsErrServiceUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// check if this is a model.ErrServiceUnavailable
+	var su *ErrServiceUnavailable
+	return errors.As(err, &su)
+}
+
+// IsErrTooManyRequests returns true if `err` is or wraps one of:
+// - model.ErrTooManyRequests.
+func IsErrTooManyRequests(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// check if this is a model.ErrTooManyRequests
+	var tm *ErrTooManyRequests
+	return errors.As(err, &tm)
+}
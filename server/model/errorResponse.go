@@ -1,5 +1,21 @@
 package model
 
+// Stable identifiers of the API errors, meant to be checked by the
+// clients instead of the error messages.
+const (
+	ErrorIDBadRequest            = "bad_request"
+	ErrorIDInvalidField          = "invalid_field"
+	ErrorIDUnauthorized          = "unauthorized"
+	ErrorIDForbidden             = "forbidden"
+	ErrorIDNotFound              = "not_found"
+	ErrorIDRequestEntityTooLarge = "request_entity_too_large"
+	ErrorIDNotImplemented        = "not_implemented"
+	ErrorIDServiceUnavailable    = "service_unavailable"
+	ErrorIDTooManyRequests       = "too_many_requests"
+	ErrorIDConflict              = "conflict"
+	ErrorIDInternal              = "internal_error"
+)
+
 // ErrorResponse is an error response
 // swagger:model
 type ErrorResponse struct {
@@ -7,7 +23,23 @@ type ErrorResponse struct {
 	// required: false
 	Error string `json:"error"`
 
-	// The error code
+	// The HTTP status code of the error
 	// required: false
 	ErrorCode int `json:"errorCode"`
+
+	// The machine readable identifier of the error
+	// required: false
+	ErrorID string `json:"errorId"`
+
+	// The ID of the request that failed
+	// required: false
+	RequestID string `json:"requestId,omitempty"`
+
+	// The invalid fields of the request and the reason they are invalid
+	// required: false
+	Details map[string]string `json:"details,omitempty"`
+
+	// The stored version of a block the request conflicted with
+	// required: false
+	ServerBlock *Block `json:"serverBlock,omitempty"`
 }
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// FeatureFlagLabels enables the creation of board labels. It is
+	// enabled unless a team or the instance disable it.
+	FeatureFlagLabels = "labels"
+
+	// FeatureFlagMaxPropertiesPerBoard overrides for a team the maximum
+	// number of card properties of a board. Its value is a number, and
+	// 0 disables the limit.
+	FeatureFlagMaxPropertiesPerBoard = "maxPropertiesPerBoard"
+
+	// FeatureFlagMaxBoardsPerTeam overrides for a team the maximum number
+	// of boards. Its value is a number, and 0 disables the limit.
+	FeatureFlagMaxBoardsPerTeam = "maxBoardsPerTeam"
+
+	// FeatureFlagDefaultBoardVisibility overrides for a team the
+	// visibility of the boards created without a type. Its value is one
+	// of private, team or public.
+	FeatureFlagDefaultBoardVisibility = "defaultBoardVisibility"
+
+	// FeatureFlagDefaultCardProperties overrides for a team the card
+	// properties of the boards created without any. Its value is the
+	// JSON list of properties, and an empty list disables them.
+	FeatureFlagDefaultCardProperties = "defaultCardProperties"
+
+	featureFlagNameMaxLength = 64
+)
+
+var featureFlagNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_\-.]+$`)
+
+// IsValidFeatureFlagName checks that a feature flag name can be stored.
+func IsValidFeatureFlagName(name string) error {
+	if name == "" {
+		return NewErrInvalidField("name", "cannot be empty")
+	}
+
+	if len(name) > featureFlagNameMaxLength {
+		return NewErrInvalidField("name", fmt.Sprintf("cannot be longer than %d characters", featureFlagNameMaxLength))
+	}
+
+	if !featureFlagNameRegexp.MatchString(name) {
+		return NewErrInvalidField("name", "can only contain letters, numbers, '-', '_' and '.'")
+	}
+
+	return nil
+}
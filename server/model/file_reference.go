@@ -0,0 +1,18 @@
+package model
+
+// FileReference links an uploaded file to its content when the uploads
+// are deduplicated. The files of a team with the same content share a
+// single stored copy, which is removed with its last reference.
+type FileReference struct {
+	// The ID of the file info of the upload
+	FileID string `json:"fileId"`
+
+	// The team the content belongs to
+	TeamID string `json:"teamId"`
+
+	// The SHA-256 hash of the content, hex encoded
+	ContentHash string `json:"contentHash"`
+
+	// The creation time in milliseconds since the current epoch
+	CreateAt int64 `json:"createAt"`
+}
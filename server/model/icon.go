@@ -0,0 +1,75 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// CustomIconPrefix starts the icons of the boards and cards that reference
+// an uploaded image instead of being an emoji. It's followed by the file
+// name returned by the upload, e.g. file:7abc...xyz.png.
+const CustomIconPrefix = "file:"
+
+// customIconRegexp matches a custom icon referencing an uploaded raster
+// image. SVG images are not allowed as they can hold scripts.
+var customIconRegexp = regexp.MustCompile(`^file:(7[a-z0-9]{26}\.(?:png|jpg|gif|webp))$`)
+
+// maxEmojiIconLength is the maximum length in bytes of an emoji icon,
+// enough for the longest emoji sequences.
+const maxEmojiIconLength = 64
+
+// ValidateIcon checks that an icon is empty, a single emoji or a custom
+// icon. For custom icons it returns the name of the referenced file, so
+// the caller can check that it exists.
+func ValidateIcon(icon string) (string, error) {
+	if icon == "" {
+		return "", nil
+	}
+
+	if strings.HasPrefix(icon, CustomIconPrefix) {
+		match := customIconRegexp.FindStringSubmatch(icon)
+		if match == nil {
+			return "", NewErrInvalidField("icon", "invalid custom icon reference")
+		}
+		return match[1], nil
+	}
+
+	if !isEmoji(icon) {
+		return "", NewErrInvalidField("icon", "must be a single emoji or a custom icon")
+	}
+	return "", nil
+}
+
+// isEmoji returns true if the text is a single emoji, including the
+// sequences joined by zero width joiners, with skin tone modifiers, flags
+// and keycaps.
+func isEmoji(text string) bool {
+	if len(text) > maxEmojiIconLength || uniseg.GraphemeClusterCount(text) != 1 {
+		return false
+	}
+
+	keycap := strings.ContainsRune(text, '⃣')
+	hasSymbol := false
+	for _, r := range text {
+		switch {
+		case r > unicode.MaxASCII && unicode.In(r, unicode.So, unicode.Sm, unicode.Po, unicode.Pd):
+			hasSymbol = true
+		case r == 'ℹ': // information source, which is a letter
+			hasSymbol = true
+		case keycap && (r >= '0' && r <= '9' || r == '#' || r == '*'):
+			hasSymbol = true
+		case r == '‍', r == '︎', r == '️', r == '⃣':
+			// joiners, variation selectors and the keycap
+		case r >= 0x1f3fb && r <= 0x1f3ff:
+			// skin tone modifiers
+		case r >= 0xe0020 && r <= 0xe007f:
+			// tags of the subdivision flags
+		default:
+			return false
+		}
+	}
+	return hasSymbol
+}
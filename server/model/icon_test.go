@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIcon(t *testing.T) {
+	t.Run("valid emoji", func(t *testing.T) {
+		for _, icon := range []string{"", "😀", "🗺️", "✔️", "👍🏽", "👩‍💻", "👨‍👩‍👧‍👦", "🇫🇷", "#️⃣", "1️⃣", "ℹ️", "‼️"} {
+			filename, err := ValidateIcon(icon)
+			require.NoError(t, err, icon)
+			require.Empty(t, filename)
+		}
+	})
+
+	t.Run("invalid icons", func(t *testing.T) {
+		for _, icon := range []string{"a", "1", "#", "<", "😀😀", "😀a", "<script>", "<img src=x onerror=alert(1)>", "javascript:alert(1)", "‍", "️"} {
+			_, err := ValidateIcon(icon)
+			require.Error(t, err, icon)
+			require.True(t, IsErrBadRequest(err))
+		}
+	})
+
+	t.Run("custom icons", func(t *testing.T) {
+		filename, err := ValidateIcon("file:7abcdefghijklmnopqrstuvwxyz.png")
+		require.NoError(t, err)
+		require.Equal(t, "7abcdefghijklmnopqrstuvwxyz.png", filename)
+
+		for _, icon := range []string{
+			"file:",
+			"file:7abcdefghijklmnopqrstuvwxyz.svg",
+			"file:7abcdefghijklmnopqrstuvwxyz",
+			"file:../7abcdefghijklmnopqrstuvwxyz.png",
+			"file:7abcdefghijklmnopqrstuvwxyz.png\"><script>",
+			"file:https://example.com/icon.png",
+		} {
+			_, err := ValidateIcon(icon)
+			require.Error(t, err, icon)
+		}
+	})
+}
@@ -40,12 +40,63 @@ type ExportArchiveOptions struct {
 	BoardIDs []string
 }
 
+const (
+	// IDStrategyRandom generates new random IDs for the imported
+	// boards and blocks.
+	IDStrategyRandom = "random"
+
+	// IDStrategyDeterministic derives the IDs of the imported boards
+	// and blocks from the team and their original IDs, so importing
+	// the same archive twice updates the existing boards and blocks
+	// instead of duplicating them.
+	IDStrategyDeterministic = "deterministic"
+)
+
 // ImportArchiveOptions provides options when importing an archive.
 type ImportArchiveOptions struct {
 	TeamID        string
 	ModifiedBy    string
 	BoardModifier BoardModifier
 	BlockModifier BlockModifier
+
+	// IDStrategy is the strategy used to generate the new IDs. An empty
+	// value means IDStrategyRandom.
+	IDStrategy string
+}
+
+// ArchiveValidation is the summary of an archive checked without
+// importing it.
+// swagger:model
+type ArchiveValidation struct {
+	// Whether the archive can be imported, which is when it has no errors
+	// required: true
+	Valid bool `json:"valid"`
+
+	// The number of boards in the archive
+	// required: true
+	BoardCount int `json:"boardCount"`
+
+	// The number of cards in the archive
+	// required: true
+	CardCount int `json:"cardCount"`
+
+	// The number of files attached to the boards of the archive
+	// required: true
+	AttachmentCount int `json:"attachmentCount"`
+
+	// The issues that would make the import fail
+	// required: true
+	Errors []string `json:"errors"`
+
+	// The issues that wouldn't prevent the import, like images
+	// missing from the archive
+	// required: true
+	Warnings []string `json:"warnings"`
+}
+
+// IsValidIDStrategy returns true if the ID strategy is supported.
+func IsValidIDStrategy(strategy string) bool {
+	return strategy == "" || strategy == IDStrategyRandom || strategy == IDStrategyDeterministic
 }
 
 // ErrUnsupportedArchiveVersion is an error returned when trying to import an
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultInviteTTL is the time an invite can be used when no TTL
+	// is given.
+	DefaultInviteTTL = 7 * 24 * time.Hour
+
+	// MaxInviteTTL is the longest time an invite can be used.
+	MaxInviteTTL = 90 * 24 * time.Hour
+
+	maxInviteUses = 1000
+)
+
+// Invite allows new users to register and join a team. The users that
+// register with the invite join the open boards of the team with the
+// invite role.
+// swagger:model
+type Invite struct {
+	// The secret token of the invite, used in the registration link
+	// required: true
+	Token string `json:"token"`
+
+	// The team the invited users join
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The role of the invited users in the open boards of the team
+	// required: true
+	Role BoardRole `json:"role"`
+
+	// The number of registrations allowed with the invite
+	// required: true
+	MaxUses int `json:"maxUses"`
+
+	// The number of registrations done with the invite
+	// required: true
+	UseCount int `json:"useCount"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// Expiration time in miliseconds since the current epoch
+	// required: true
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// IsUsable checks that the invite has not expired and has uses left.
+func (i *Invite) IsUsable(now int64) bool {
+	return i.UseCount < i.MaxUses && now < i.ExpiresAt
+}
+
+// IsValid checks the role and the limits of a new invite.
+func (i *Invite) IsValid() error {
+	if i.TeamID == "" {
+		return NewErrInvalidField("teamId", "cannot be empty")
+	}
+
+	if i.Role == BoardRoleNone || !IsBoardMinimumRoleValid(i.Role) {
+		return NewErrInvalidField("role", fmt.Sprintf("unknown role %s", i.Role))
+	}
+
+	if i.MaxUses < 1 || i.MaxUses > maxInviteUses {
+		return NewErrInvalidField("maxUses", fmt.Sprintf("must be between 1 and %d", maxInviteUses))
+	}
+
+	if i.ExpiresAt <= i.CreateAt || i.ExpiresAt-i.CreateAt > MaxInviteTTL.Milliseconds() {
+		return NewErrInvalidField("ttl", fmt.Sprintf("must be positive and at most %s", MaxInviteTTL))
+	}
+
+	return nil
+}
+
+// CreateInviteRequest is the request to create an invite.
+// swagger:model
+type CreateInviteRequest struct {
+	// The role of the invited users in the open boards of the team
+	// required: true
+	Role BoardRole `json:"role"`
+
+	// The number of seconds the invite can be used, 7 days by default
+	// required: false
+	TTLSeconds int64 `json:"ttlSeconds"`
+
+	// The number of registrations allowed with the invite, 1 by default
+	// required: false
+	MaxUses int `json:"maxUses"`
+}
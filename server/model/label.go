@@ -0,0 +1,190 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PropertyTypeLabel is the type of the card property that holds the
+	// board labels. Its options are the labels and the card values are
+	// lists of label IDs.
+	PropertyTypeLabel = "label"
+
+	// LabelPropertyName is the name given to the label property when a
+	// board gets its first label.
+	LabelPropertyName = "Labels"
+
+	labelNameMaxLength = 100
+)
+
+// labelColors are the colors supported by the clients for the property
+// options.
+var labelColors = map[string]bool{
+	"propColorDefault": true,
+	"propColorGray":    true,
+	"propColorBrown":   true,
+	"propColorOrange":  true,
+	"propColorYellow":  true,
+	"propColorGreen":   true,
+	"propColorBlue":    true,
+	"propColorPurple":  true,
+	"propColorPink":    true,
+	"propColorRed":     true,
+}
+
+// Label is a board-wide label that can be applied to the board cards.
+// swagger:model
+type Label struct {
+	// The label ID
+	// required: true
+	ID string `json:"id"`
+
+	// The label name
+	// required: true
+	Name string `json:"name"`
+
+	// The label color
+	// required: true
+	Color string `json:"color"`
+}
+
+// IsValid checks that the label name and color are valid.
+func (l *Label) IsValid() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return NewErrInvalidField("name", "cannot be empty")
+	}
+
+	if len(l.Name) > labelNameMaxLength {
+		return NewErrInvalidField("name", fmt.Sprintf("cannot be longer than %d characters", labelNameMaxLength))
+	}
+
+	if !labelColors[l.Color] {
+		return NewErrInvalidField("color", fmt.Sprintf("unknown color %s", l.Color))
+	}
+
+	return nil
+}
+
+// LabelPatch is a patch to rename or recolor a label.
+// swagger:model
+type LabelPatch struct {
+	// The label name
+	// required: false
+	Name *string `json:"name"`
+
+	// The label color
+	// required: false
+	Color *string `json:"color"`
+}
+
+// Patch returns an updated version of the label.
+func (p *LabelPatch) Patch(label *Label) *Label {
+	if p.Name != nil {
+		label.Name = *p.Name
+	}
+
+	if p.Color != nil {
+		label.Color = *p.Color
+	}
+
+	return label
+}
+
+// GetLabelProperty returns the card property that holds the board
+// labels, or nil if the board has no labels yet.
+func (b *Board) GetLabelProperty() map[string]interface{} {
+	for _, prop := range b.CardProperties {
+		if propType, _ := prop["type"].(string); propType == PropertyTypeLabel {
+			return prop
+		}
+	}
+	return nil
+}
+
+// GetLabels returns the labels defined in the board, in order.
+func (b *Board) GetLabels() []Label {
+	labels := []Label{}
+
+	prop := b.GetLabelProperty()
+	if prop == nil {
+		return labels
+	}
+
+	options, _ := prop["options"].([]interface{})
+	for _, optionIface := range options {
+		option, ok := optionIface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		labels = append(labels, Label{
+			ID:    getMapString("id", option),
+			Name:  getMapString("value", option),
+			Color: getMapString("color", option),
+		})
+	}
+	return labels
+}
+
+// NewLabelProperty builds the label card property with the given
+// labels as options.
+func NewLabelProperty(propertyID string, labels []Label) map[string]interface{} {
+	options := make([]interface{}, 0, len(labels))
+	for _, label := range labels {
+		options = append(options, map[string]interface{}{
+			"id":    label.ID,
+			"value": label.Name,
+			"color": label.Color,
+		})
+	}
+
+	return map[string]interface{}{
+		"id":      propertyID,
+		"name":    LabelPropertyName,
+		"type":    PropertyTypeLabel,
+		"options": options,
+	}
+}
+
+// ValidateCardLabels checks that the values of the label properties of
+// a card only reference labels defined in the board.
+func ValidateCardLabels(board *Board, block *Block) error {
+	if block.Type != TypeCard {
+		return nil
+	}
+
+	props, ok := block.Fields["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	for propID, value := range props {
+		def, ok := schema[propID]
+		if !ok || def.Type != PropertyTypeLabel {
+			continue
+		}
+
+		labelIDs, ok := value.([]interface{})
+		if !ok {
+			return NewErrInvalidField("properties."+propID, "must be a list of label IDs")
+		}
+
+		for _, labelIDIface := range labelIDs {
+			labelID, ok := labelIDIface.(string)
+			if !ok {
+				return NewErrInvalidField("properties."+propID, "must be a list of label IDs")
+			}
+			if _, ok := def.Options[labelID]; !ok {
+				return NewErrInvalidField("properties."+propID, fmt.Sprintf("label %s does not exist", labelID))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelIsValid(t *testing.T) {
+	require.NoError(t, (&Label{Name: "urgent", Color: "propColorRed"}).IsValid())
+
+	var invalidField *ErrInvalidField
+	require.ErrorAs(t, (&Label{Name: " ", Color: "propColorRed"}).IsValid(), &invalidField)
+	require.Equal(t, "name", invalidField.Field)
+
+	require.ErrorAs(t, (&Label{Name: "urgent", Color: "red"}).IsValid(), &invalidField)
+	require.Equal(t, "color", invalidField.Field)
+}
+
+func TestValidateCardLabels(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			NewLabelProperty("labels", []Label{{ID: "urgent", Name: "Urgent", Color: "propColorRed"}}),
+			{"id": "status", "type": "select", "options": []interface{}{}},
+		},
+	}
+
+	newCard := func(props map[string]interface{}) *Block {
+		return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	t.Run("existing label", func(t *testing.T) {
+		card := newCard(map[string]interface{}{"labels": []interface{}{"urgent"}})
+		require.NoError(t, ValidateCardLabels(board, card))
+	})
+
+	t.Run("unknown label", func(t *testing.T) {
+		card := newCard(map[string]interface{}{"labels": []interface{}{"missing"}})
+		require.True(t, IsErrBadRequest(ValidateCardLabels(board, card)))
+	})
+
+	t.Run("wrong value type", func(t *testing.T) {
+		card := newCard(map[string]interface{}{"labels": "urgent"})
+		require.True(t, IsErrBadRequest(ValidateCardLabels(board, card)))
+	})
+
+	t.Run("other properties are not checked", func(t *testing.T) {
+		card := newCard(map[string]interface{}{"status": "any-value"})
+		require.NoError(t, ValidateCardLabels(board, card))
+	})
+
+	t.Run("labels are resolved to their names", func(t *testing.T) {
+		schema, err := ParsePropertySchema(board)
+		require.NoError(t, err)
+
+		value, err := schema["labels"].GetValue([]interface{}{"urgent"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "URGENT", value)
+	})
+}
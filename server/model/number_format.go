@@ -0,0 +1,207 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PropertyTypeNumber is the type of the numeric card properties. The
+	// card values are numbers, or strings holding a number.
+	PropertyTypeNumber = "number"
+
+	// Formats of the number properties, stored in the numberFormat key
+	// of the property definition.
+	NumberFormatPlain    = ""
+	NumberFormatInteger  = "integer"
+	NumberFormatDecimal  = "decimal"
+	NumberFormatPercent  = "percent"
+	NumberFormatCurrency = "currency"
+
+	defaultNumberDecimals = 2
+	maxNumberDecimals     = 10
+	defaultCurrency       = "$"
+)
+
+// NumberFormat is the format spec of a number property.
+type NumberFormat struct {
+	// Format is one of the NumberFormat constants
+	Format string `json:"format"`
+
+	// Decimals is the number of digits after the decimal point
+	Decimals int `json:"decimals"`
+
+	// Currency is the symbol or code shown before the currency values
+	Currency string `json:"currency"`
+}
+
+// parseNumberFormat reads the format spec from a property definition.
+// Missing or invalid settings fall back to their defaults.
+func parseNumberFormat(prop map[string]interface{}) *NumberFormat {
+	nf := &NumberFormat{Format: getMapString("numberFormat", prop)}
+
+	switch nf.Format {
+	case NumberFormatInteger:
+		nf.Decimals = 0
+	case NumberFormatDecimal, NumberFormatPercent, NumberFormatCurrency:
+		nf.Decimals = defaultNumberDecimals
+	default:
+		nf.Format = NumberFormatPlain
+	}
+
+	if decimals, ok := prop["decimals"].(float64); ok && nf.Format != NumberFormatInteger {
+		if decimals >= 0 && decimals <= maxNumberDecimals && decimals == math.Trunc(decimals) {
+			nf.Decimals = int(decimals)
+		}
+	}
+
+	if nf.Format == NumberFormatCurrency {
+		nf.Currency = getMapString("currency", prop)
+		if nf.Currency == "" {
+			nf.Currency = defaultCurrency
+		}
+	}
+
+	return nf
+}
+
+// ParseNumberValue returns the number stored in a card value. Empty
+// strings mean the value was cleared and are reported as not set.
+func ParseNumberValue(v interface{}) (value float64, isSet bool, err error) {
+	switch n := v.(type) {
+	case float64:
+		value = n
+	case string:
+		s := strings.TrimSpace(n)
+		if s == "" {
+			return 0, false, nil
+		}
+		value, err = strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, false, ErrInvalidPropertyValue
+		}
+	default:
+		return 0, false, ErrInvalidPropertyValueType
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false, ErrInvalidPropertyValue
+	}
+	return value, true, nil
+}
+
+// FormatValue returns the value formatted following the spec. Percent values
+// are shown as they are stored, so 12.5 is formatted as 12.50%.
+func (nf *NumberFormat) FormatValue(value float64) string {
+	if nf == nil || nf.Format == NumberFormatPlain {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	formatted := groupThousands(strconv.FormatFloat(math.Abs(value), 'f', nf.Decimals, 64))
+	sign := ""
+	if value < 0 && strings.Trim(formatted, "0.,") != "" {
+		sign = "-"
+	}
+
+	switch nf.Format {
+	case NumberFormatPercent:
+		return sign + formatted + "%"
+	case NumberFormatCurrency:
+		return sign + nf.Currency + formatted
+	default:
+		return sign + formatted
+	}
+}
+
+// groupThousands adds thousands separators to the integer part of a
+// non-negative formatted number.
+func groupThousands(s string) string {
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i:]
+	}
+
+	var sb strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(digit)
+	}
+	return sb.String() + fracPart
+}
+
+// ValidateCardNumbers checks that the values of the number properties of
+// a card are numeric.
+func ValidateCardNumbers(board *Board, block *Block) error {
+	if block.Type != TypeCard {
+		return nil
+	}
+
+	props, ok := block.Fields["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	for propID, value := range props {
+		def, ok := schema[propID]
+		if !ok || def.Type != PropertyTypeNumber {
+			continue
+		}
+
+		if _, _, err := ParseNumberValue(value); err != nil {
+			return NewErrInvalidField("properties."+propID, fmt.Sprintf("%v is not a number", value))
+		}
+	}
+
+	return nil
+}
+
+// ValidateCardProperties checks the values of the typed properties of a
+// card against the board property definitions. Only the values that
+// differ from the ones of oldBlock are checked, so the values set before
+// a property changed don't prevent the card from being edited. oldBlock
+// is nil for new cards.
+func ValidateCardProperties(board *Board, block, oldBlock *Block) error {
+	if block.Type != TypeCard {
+		return nil
+	}
+
+	changed := changedCardProperties(block, oldBlock)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	card := &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": changed}}
+	if err := ValidateCardLabels(board, card); err != nil {
+		return err
+	}
+	return ValidateCardNumbers(board, card)
+}
+
+// changedCardProperties returns the property values of a card that are
+// new or differ from the ones of oldBlock.
+func changedCardProperties(block, oldBlock *Block) map[string]interface{} {
+	props, _ := block.Fields["properties"].(map[string]interface{})
+	if oldBlock == nil {
+		return props
+	}
+
+	oldProps, _ := oldBlock.Fields["properties"].(map[string]interface{})
+	changed := make(map[string]interface{}, len(props))
+	for propID, value := range props {
+		if oldValue, ok := oldProps[propID]; ok && reflect.DeepEqual(oldValue, value) {
+			continue
+		}
+		changed[propID] = value
+	}
+	return changed
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberFormat(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Prop     map[string]interface{}
+		Value    float64
+		Expected string
+	}{
+		{"plain", map[string]interface{}{}, 1234.5, "1234.5"},
+		{"integer", map[string]interface{}{"numberFormat": "integer"}, 1234567.6, "1,234,568"},
+		{"decimal", map[string]interface{}{"numberFormat": "decimal"}, 1234.5, "1,234.50"},
+		{"decimal with precision", map[string]interface{}{"numberFormat": "decimal", "decimals": float64(3)}, 0.5, "0.500"},
+		{"percent", map[string]interface{}{"numberFormat": "percent", "decimals": float64(0)}, 12.5, "12%"},
+		{"currency", map[string]interface{}{"numberFormat": "currency"}, -1500, "-$1,500.00"},
+		{"currency with code", map[string]interface{}{"numberFormat": "currency", "currency": "€"}, 99.999, "€100.00"},
+		{"negative zero", map[string]interface{}{"numberFormat": "decimal", "decimals": float64(0)}, -0.2, "0"},
+		{"unknown format", map[string]interface{}{"numberFormat": "roman"}, 12, "12"},
+		{"invalid decimals", map[string]interface{}{"numberFormat": "decimal", "decimals": float64(42)}, 1, "1.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			require.Equal(t, tc.Expected, parseNumberFormat(tc.Prop).FormatValue(tc.Value))
+		})
+	}
+}
+
+func TestParseNumberValue(t *testing.T) {
+	value, isSet, err := ParseNumberValue(" 12.5 ")
+	require.NoError(t, err)
+	require.True(t, isSet)
+	require.Equal(t, 12.5, value)
+
+	value, isSet, err = ParseNumberValue(float64(3))
+	require.NoError(t, err)
+	require.True(t, isSet)
+	require.Equal(t, float64(3), value)
+
+	_, isSet, err = ParseNumberValue("")
+	require.NoError(t, err)
+	require.False(t, isSet)
+
+	_, _, err = ParseNumberValue("twelve")
+	require.ErrorIs(t, err, ErrInvalidPropertyValue)
+
+	_, _, err = ParseNumberValue("NaN")
+	require.ErrorIs(t, err, ErrInvalidPropertyValue)
+
+	_, _, err = ParseNumberValue([]interface{}{"12"})
+	require.ErrorIs(t, err, ErrInvalidPropertyValueType)
+}
+
+func TestValidateCardNumbers(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "budget", "name": "Budget", "type": PropertyTypeNumber, "numberFormat": NumberFormatCurrency},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+
+	newCard := func(props map[string]interface{}) *Block {
+		return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": props}}
+	}
+
+	require.NoError(t, ValidateCardNumbers(board, newCard(map[string]interface{}{"budget": "1500.25"})))
+	require.NoError(t, ValidateCardNumbers(board, newCard(map[string]interface{}{"budget": ""})))
+	require.NoError(t, ValidateCardNumbers(board, newCard(map[string]interface{}{"notes": "not a number"})))
+
+	err := ValidateCardNumbers(board, newCard(map[string]interface{}{"budget": "a lot"}))
+	var invalidField *ErrInvalidField
+	require.ErrorAs(t, err, &invalidField)
+	require.Equal(t, "properties.budget", invalidField.Field)
+
+	t.Run("only the changed values are validated", func(t *testing.T) {
+		oldCard := newCard(map[string]interface{}{"budget": "a lot", "notes": "old"})
+
+		// the legacy value is kept, so the card can still be edited
+		require.NoError(t, ValidateCardProperties(board, newCard(map[string]interface{}{"budget": "a lot", "notes": "new"}), oldCard))
+		require.Error(t, ValidateCardProperties(board, newCard(map[string]interface{}{"budget": "even more"}), oldCard))
+		require.Error(t, ValidateCardProperties(board, newCard(map[string]interface{}{"budget": "a lot"}), nil))
+	})
+
+	t.Run("values are formatted following the property format", func(t *testing.T) {
+		schema, err := ParsePropertySchema(board)
+		require.NoError(t, err)
+
+		props, err := ParseProperties(newCard(map[string]interface{}{"budget": "1500.25"}), schema, nil)
+		require.NoError(t, err)
+		require.Equal(t, "$1,500.25", props["budget"].Value)
+	})
+}
@@ -54,6 +54,8 @@ type PropDef struct {
 	Name    string                   `json:"name"`
 	Type    string                   `json:"type"`
 	Options map[string]PropDefOption `json:"options"`
+
+	NumberFormat *NumberFormat `json:"numberFormat,omitempty"`
 }
 
 // GetValue resolves the value of a property if the passed value is an ID for an option,
@@ -98,7 +100,23 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 		}
 		return userID, nil
 
-	case "multiSelect":
+	case PropertyTypeNumber:
+		// v is a number or a string holding a number. Values without a
+		// format, or stored before the validation existed, are returned
+		// as they are.
+		if pd.NumberFormat == nil || pd.NumberFormat.Format == NumberFormatPlain {
+			return fmt.Sprintf("%v", v), nil
+		}
+		value, isSet, err := ParseNumberValue(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v), nil
+		}
+		if !isSet {
+			return "", nil
+		}
+		return pd.NumberFormat.FormatValue(value), nil
+
+	case "multiSelect", PropertyTypeLabel:
 		// v is a slice of strings containing option ids
 		ms, ok := v.([]interface{})
 		if !ok {
@@ -143,6 +161,20 @@ func (pd PropDef) ParseDate(s string) (string, error) {
 	return date, nil
 }
 
+// ParseDateValue returns the start and end of the value of a date
+// property, in milliseconds. The end is zero if the value is not a range.
+func ParseDateValue(s string) (from, to int64, err error) {
+	var m map[string]int64
+	if err = json.Unmarshal([]byte(s), &m); err != nil {
+		return 0, 0, err
+	}
+	from, ok := m["from"]
+	if !ok {
+		return 0, 0, ErrInvalidDate
+	}
+	return from, m["to"], nil
+}
+
 // ParsePropertySchema parses a board block's `Fields` to extract the properties
 // schema for all cards within the board.
 // The result is provided as a map for quick lookup, and the original order is
@@ -158,6 +190,9 @@ func ParsePropertySchema(board *Board) (PropSchema, error) {
 			Type:    getMapString("type", prop),
 			Options: make(map[string]PropDefOption),
 		}
+		if pd.Type == PropertyTypeNumber {
+			pd.NumberFormat = parseNumberFormat(prop)
+		}
 		optsIface, ok := prop["options"]
 		if ok {
 			opts, ok := optsIface.([]interface{})
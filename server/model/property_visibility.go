@@ -0,0 +1,186 @@
+package model
+
+import (
+	"fmt"
+)
+
+// PropertyVisibilityRuleKey is the key of the card property definition
+// that holds its visibility rule.
+const PropertyVisibilityRuleKey = "visibilityRule"
+
+// PropertyVisibilityRule makes a card property visible only when
+// another property of the card has one of the given values. The rules
+// are stored in the board property definitions and applied by the
+// clients.
+// swagger:model
+type PropertyVisibilityRule struct {
+	// The ID of the property the rule applies to
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The ID of the property whose value is checked
+	// required: true
+	DependsOn string `json:"dependsOn"`
+
+	// The values of the checked property that make the property visible.
+	// For properties with options, these are the option IDs
+	// required: true
+	Values []string `json:"values"`
+}
+
+// ruleMap returns the rule as it's stored in the property definition.
+func (r *PropertyVisibilityRule) ruleMap() map[string]interface{} {
+	values := make([]interface{}, 0, len(r.Values))
+	for _, value := range r.Values {
+		values = append(values, value)
+	}
+	return map[string]interface{}{
+		"dependsOn": r.DependsOn,
+		"values":    values,
+	}
+}
+
+// parseVisibilityRule reads the visibility rule of a property
+// definition, returning nil if it has none.
+func parseVisibilityRule(prop map[string]interface{}) (*PropertyVisibilityRule, error) {
+	ruleIface, ok := prop[PropertyVisibilityRuleKey]
+	if !ok || ruleIface == nil {
+		return nil, nil
+	}
+
+	propertyID := getMapString("id", prop)
+	invalidRule := NewErrInvalidField("cardProperties."+propertyID+"."+PropertyVisibilityRuleKey, "must have a dependsOn property ID and a list of values")
+
+	ruleMap, ok := ruleIface.(map[string]interface{})
+	if !ok {
+		return nil, invalidRule
+	}
+
+	rule := &PropertyVisibilityRule{
+		PropertyID: propertyID,
+		DependsOn:  getMapString("dependsOn", ruleMap),
+		Values:     []string{},
+	}
+
+	values, ok := ruleMap["values"].([]interface{})
+	if !ok {
+		return nil, invalidRule
+	}
+	for _, valueIface := range values {
+		value, ok := valueIface.(string)
+		if !ok {
+			return nil, invalidRule
+		}
+		rule.Values = append(rule.Values, value)
+	}
+
+	return rule, nil
+}
+
+// GetPropertyVisibilityRules returns the visibility rules of the board
+// properties. Malformed rules are skipped.
+func (b *Board) GetPropertyVisibilityRules() []PropertyVisibilityRule {
+	rules := []PropertyVisibilityRule{}
+	for _, prop := range b.CardProperties {
+		rule, err := parseVisibilityRule(prop)
+		if err != nil || rule == nil {
+			continue
+		}
+		rules = append(rules, *rule)
+	}
+	return rules
+}
+
+// SetPropertyVisibilityRule returns a copy of the property definition
+// with the rule set, or removed if the rule is nil.
+func SetPropertyVisibilityRule(prop map[string]interface{}, rule *PropertyVisibilityRule) map[string]interface{} {
+	newProp := make(map[string]interface{}, len(prop)+1)
+	for key, value := range prop {
+		newProp[key] = value
+	}
+
+	if rule == nil {
+		delete(newProp, PropertyVisibilityRuleKey)
+	} else {
+		newProp[PropertyVisibilityRuleKey] = rule.ruleMap()
+	}
+	return newProp
+}
+
+// ValidatePropertyVisibilityRules checks that the visibility rules of
+// the board reference existing properties and options, and that they
+// don't depend on each other in a cycle.
+func ValidatePropertyVisibilityRules(board *Board) error {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	dependsOn := map[string]string{}
+	for _, prop := range board.CardProperties {
+		rule, ruleErr := parseVisibilityRule(prop)
+		if ruleErr != nil {
+			return ruleErr
+		}
+		if rule == nil {
+			continue
+		}
+
+		field := "cardProperties." + rule.PropertyID + "." + PropertyVisibilityRuleKey
+
+		def, ok := schema[rule.DependsOn]
+		if !ok {
+			return NewErrInvalidField(field, fmt.Sprintf("property %s does not exist", rule.DependsOn))
+		}
+
+		if rule.DependsOn == rule.PropertyID {
+			return NewErrInvalidField(field, "a property cannot depend on itself")
+		}
+
+		if len(rule.Values) == 0 {
+			return NewErrInvalidField(field, "must have at least one value")
+		}
+
+		if len(def.Options) > 0 {
+			for _, value := range rule.Values {
+				if _, ok := def.Options[value]; !ok {
+					return NewErrInvalidField(field, fmt.Sprintf("option %s does not exist", value))
+				}
+			}
+		}
+
+		dependsOn[rule.PropertyID] = rule.DependsOn
+	}
+
+	// following the dependencies from any property must not lead back
+	// to it, or the properties could never be shown
+	for propertyID := range dependsOn {
+		visited := map[string]bool{propertyID: true}
+		for next, ok := dependsOn[propertyID]; ok; next, ok = dependsOn[next] {
+			if visited[next] {
+				return NewErrInvalidField("cardProperties."+propertyID+"."+PropertyVisibilityRuleKey, "the visibility rules have a cycle")
+			}
+			visited[next] = true
+		}
+	}
+
+	return nil
+}
+
+// removeVisibilityRulesDependingOn removes the visibility rules that
+// depend on any of the given properties.
+func removeVisibilityRulesDependingOn(props []map[string]interface{}, propertyIDs []string) []map[string]interface{} {
+	removed := make(map[string]bool, len(propertyIDs))
+	for _, propertyID := range propertyIDs {
+		removed[propertyID] = true
+	}
+
+	for i, prop := range props {
+		rule, err := parseVisibilityRule(prop)
+		if err != nil || rule == nil || !removed[rule.DependsOn] {
+			continue
+		}
+		props[i] = SetPropertyVisibilityRule(prop, nil)
+	}
+	return props
+}
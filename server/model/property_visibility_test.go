@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePropertyVisibilityRules(t *testing.T) {
+	newBoard := func(rules ...*PropertyVisibilityRule) *Board {
+		props := []map[string]interface{}{
+			{"id": "status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "closed", "value": "Closed"},
+			}},
+			{"id": "resolution", "type": "text"},
+			{"id": "notes", "type": "text"},
+		}
+		for _, rule := range rules {
+			for i, prop := range props {
+				if prop["id"] == rule.PropertyID {
+					props[i] = SetPropertyVisibilityRule(prop, rule)
+				}
+			}
+		}
+		return &Board{CardProperties: props}
+	}
+
+	t.Run("valid rules", func(t *testing.T) {
+		board := newBoard(
+			&PropertyVisibilityRule{PropertyID: "resolution", DependsOn: "status", Values: []string{"closed"}},
+			&PropertyVisibilityRule{PropertyID: "notes", DependsOn: "resolution", Values: []string{"fixed"}},
+		)
+		require.NoError(t, ValidatePropertyVisibilityRules(board))
+		require.Len(t, board.GetPropertyVisibilityRules(), 2)
+	})
+
+	testCases := []struct {
+		name  string
+		rules []*PropertyVisibilityRule
+	}{
+		{"unknown property", []*PropertyVisibilityRule{{PropertyID: "notes", DependsOn: "missing", Values: []string{"a"}}}},
+		{"unknown option", []*PropertyVisibilityRule{{PropertyID: "notes", DependsOn: "status", Values: []string{"missing"}}}},
+		{"no values", []*PropertyVisibilityRule{{PropertyID: "notes", DependsOn: "status", Values: []string{}}}},
+		{"depends on itself", []*PropertyVisibilityRule{{PropertyID: "notes", DependsOn: "notes", Values: []string{"a"}}}},
+		{"cycle", []*PropertyVisibilityRule{
+			{PropertyID: "notes", DependsOn: "resolution", Values: []string{"a"}},
+			{PropertyID: "resolution", DependsOn: "notes", Values: []string{"b"}},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.True(t, IsErrBadRequest(ValidatePropertyVisibilityRules(newBoard(tc.rules...))))
+		})
+	}
+
+	t.Run("malformed rule", func(t *testing.T) {
+		board := newBoard()
+		board.CardProperties[2][PropertyVisibilityRuleKey] = "status"
+		require.True(t, IsErrBadRequest(ValidatePropertyVisibilityRules(board)))
+		require.Empty(t, board.GetPropertyVisibilityRules())
+	})
+}
+
+func TestBoardPatchRemovesVisibilityRules(t *testing.T) {
+	rule := &PropertyVisibilityRule{PropertyID: "notes", DependsOn: "resolution", Values: []string{"fixed"}}
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "resolution", "type": "text"},
+			SetPropertyVisibilityRule(map[string]interface{}{"id": "notes", "type": "text"}, rule),
+		},
+	}
+
+	patch := &BoardPatch{DeletedCardProperties: []string{"resolution"}}
+	patched := patch.Patch(board)
+	require.Len(t, patched.CardProperties, 1)
+	require.NotContains(t, patched.CardProperties[0], PropertyVisibilityRuleKey)
+}
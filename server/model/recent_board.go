@@ -0,0 +1,18 @@
+package model
+
+// RecentBoard is the last time a user opened a board. Recent boards are
+// private to the user, the other board members don't see them.
+// swagger:model
+type RecentBoard struct {
+	// The user ID
+	// required: true
+	UserID string `json:"userId"`
+
+	// The board ID
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The time the user last opened the board in miliseconds since the current epoch
+	// required: true
+	ViewedAt int64 `json:"viewedAt"`
+}
@@ -0,0 +1,36 @@
+package model
+
+// Aggregations supported by the board rollups.
+const (
+	RollupCount = "count"
+	RollupSum   = "sum"
+	RollupAvg   = "avg"
+)
+
+// QueryRollupOptions are the options of a board rollup.
+type QueryRollupOptions struct {
+	GroupBy     string // if not empty, the ID of the property used to group the cards
+	Aggregation string // one of the Rollup constants
+	Of          string // the ID of the number property aggregated by sum and avg
+}
+
+// RollupGroup is the aggregation of the cards of a board that share the
+// same value of the group-by property.
+// swagger:model
+type RollupGroup struct {
+	// The value of the group-by property, empty for the cards without value
+	// required: true
+	Value string `json:"value"`
+
+	// The number of cards in the group
+	// required: true
+	Count int64 `json:"count"`
+
+	// The result of the aggregation
+	// required: true
+	Result float64 `json:"result"`
+
+	// The result formatted with the number format of the aggregated property
+	// required: false
+	FormattedResult string `json:"formattedResult,omitempty"`
+}
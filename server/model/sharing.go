@@ -27,6 +27,11 @@ type Sharing struct {
 	// Updated time in miliseconds since the current epoch
 	// required: true
 	UpdateAt int64 `json:"update_at,omitempty"`
+
+	// IDs of the card properties visible through the shared link. If
+	// null, all the card properties are visible
+	// required: false
+	VisibleProperties []string `json:"visibleProperties"`
 }
 
 func SharingFromJSON(data io.Reader) Sharing {
@@ -34,3 +39,78 @@ func SharingFromJSON(data io.Reader) Sharing {
 	_ = json.NewDecoder(data).Decode(&sharing)
 	return sharing
 }
+
+// RestrictsProperties returns true if the shared link only exposes a
+// subset of the card properties.
+func (s *Sharing) RestrictsProperties() bool {
+	return s != nil && s.VisibleProperties != nil
+}
+
+func (s *Sharing) isPropertyVisible(propertyID string) bool {
+	for _, id := range s.VisibleProperties {
+		if id == propertyID {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterBoard returns a copy of the board whose card properties only
+// include the ones visible through the shared link.
+func (s *Sharing) FilterBoard(board *Board) *Board {
+	if !s.RestrictsProperties() {
+		return board
+	}
+
+	filtered := *board
+	filtered.CardProperties = []map[string]interface{}{}
+	for _, prop := range board.CardProperties {
+		if propertyID, _ := prop["id"].(string); s.isPropertyVisible(propertyID) {
+			filtered.CardProperties = append(filtered.CardProperties, prop)
+		}
+	}
+	return &filtered
+}
+
+// FilterBlock returns a copy of the block whose property values only
+// include the ones visible through the shared link. Blocks that are not
+// cards are returned as they are.
+func (s *Sharing) FilterBlock(block Block) Block {
+	if !s.RestrictsProperties() || block.Type != TypeCard {
+		return block
+	}
+
+	props, ok := block.Fields["properties"].(map[string]interface{})
+	if !ok {
+		return block
+	}
+
+	filteredProps := map[string]interface{}{}
+	for propertyID, value := range props {
+		if s.isPropertyVisible(propertyID) {
+			filteredProps[propertyID] = value
+		}
+	}
+
+	fields := make(map[string]interface{}, len(block.Fields))
+	for key, value := range block.Fields {
+		fields[key] = value
+	}
+	fields["properties"] = filteredProps
+	block.Fields = fields
+
+	return block
+}
+
+// FilterBlocks applies FilterBlock to a list of blocks.
+func (s *Sharing) FilterBlocks(blocks []Block) []Block {
+	if !s.RestrictsProperties() {
+		return blocks
+	}
+
+	filtered := make([]Block, 0, len(blocks))
+	for _, block := range blocks {
+		filtered = append(filtered, s.FilterBlock(block))
+	}
+	return filtered
+}
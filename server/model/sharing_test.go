@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharingFilter(t *testing.T) {
+	board := &Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "type": "select"},
+			{"id": "cost", "type": "number"},
+		},
+	}
+	card := Block{
+		Type:   TypeCard,
+		Fields: map[string]interface{}{"icon": "x", "properties": map[string]interface{}{"status": "done", "cost": "10"}},
+	}
+
+	t.Run("no restriction", func(t *testing.T) {
+		var sharing *Sharing
+		require.False(t, sharing.RestrictsProperties())
+		require.Equal(t, board, sharing.FilterBoard(board))
+		require.Equal(t, card, sharing.FilterBlock(card))
+
+		sharing = &Sharing{}
+		require.False(t, sharing.RestrictsProperties())
+		require.Equal(t, card, sharing.FilterBlock(card))
+	})
+
+	t.Run("visible properties", func(t *testing.T) {
+		sharing := &Sharing{VisibleProperties: []string{"status"}}
+
+		filteredBoard := sharing.FilterBoard(board)
+		require.Len(t, filteredBoard.CardProperties, 1)
+		require.Equal(t, "status", filteredBoard.CardProperties[0]["id"])
+		require.Len(t, board.CardProperties, 2)
+
+		filteredCard := sharing.FilterBlock(card)
+		require.Equal(t, map[string]interface{}{"status": "done"}, filteredCard.Fields["properties"])
+		require.Equal(t, "x", filteredCard.Fields["icon"])
+		require.Len(t, card.Fields["properties"], 2)
+	})
+
+	t.Run("no visible properties", func(t *testing.T) {
+		sharing := &Sharing{VisibleProperties: []string{}}
+
+		require.Empty(t, sharing.FilterBoard(board).CardProperties)
+		require.Empty(t, sharing.FilterBlock(card).Fields["properties"])
+
+		view := Block{Type: TypeView, Fields: map[string]interface{}{"visiblePropertyIds": []interface{}{"cost"}}}
+		require.Equal(t, view, sharing.FilterBlock(view))
+	})
+}
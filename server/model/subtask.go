@@ -0,0 +1,194 @@
+package model
+
+// SubtaskRuleKey is the key of the board properties that holds its
+// subtask rule.
+const SubtaskRuleKey = "subtaskRule"
+
+// Modes to handle the subtasks of a deleted card.
+const (
+	// SubtaskDeleteCascade deletes the subtasks with their parent card.
+	SubtaskDeleteCascade = "cascade"
+
+	// SubtaskDeleteDetach keeps the subtasks as cards of the board.
+	SubtaskDeleteDetach = "detach"
+)
+
+// IsValidSubtaskDeleteMode checks that a mode is one of the subtask
+// delete modes. An empty mode is valid and deletes the subtasks.
+func IsValidSubtaskDeleteMode(mode string) bool {
+	switch mode {
+	case "", SubtaskDeleteCascade, SubtaskDeleteDetach:
+		return true
+	}
+	return false
+}
+
+// SubtaskRule defines when the subtasks of a board are completed and,
+// optionally, the value set on a parent card once all of its subtasks
+// are completed. The rule is stored in the board properties.
+// swagger:model
+type SubtaskRule struct {
+	// The ID of the property that marks a subtask as completed
+	// required: true
+	CompletedPropertyID string `json:"completedPropertyId"`
+
+	// The value of the property of the completed subtasks. For
+	// properties with options, this is the option ID
+	// required: true
+	CompletedValue string `json:"completedValue"`
+
+	// The ID of the property set on the parent card when all of its
+	// subtasks are completed
+	// required: false
+	ParentPropertyID string `json:"parentPropertyId,omitempty"`
+
+	// The value set on the parent card property
+	// required: false
+	ParentValue string `json:"parentValue,omitempty"`
+}
+
+// ruleMap returns the rule as it's stored in the board properties.
+func (r *SubtaskRule) ruleMap() map[string]interface{} {
+	rule := map[string]interface{}{
+		"completedPropertyId": r.CompletedPropertyID,
+		"completedValue":      r.CompletedValue,
+	}
+	if r.ParentPropertyID != "" {
+		rule["parentPropertyId"] = r.ParentPropertyID
+		rule["parentValue"] = r.ParentValue
+	}
+	return rule
+}
+
+// IsValid checks that the rule references properties of the board and
+// that its values can be set on them.
+func (r *SubtaskRule) IsValid(board *Board) error {
+	if r.CompletedPropertyID == "" || r.CompletedValue == "" {
+		return NewErrInvalidField("completedPropertyId", "must have a property ID and a value")
+	}
+	if err := ValidateCardPropertyValue(board, r.CompletedPropertyID, r.completedValue(board)); err != nil {
+		return NewErrInvalidField("completedValue", err.Error())
+	}
+
+	if r.ParentPropertyID == "" {
+		if r.ParentValue != "" {
+			return NewErrInvalidField("parentPropertyId", "cannot be empty if there is a parent value")
+		}
+		return nil
+	}
+	if r.ParentValue == "" {
+		return NewErrInvalidField("parentValue", "cannot be empty if there is a parent property")
+	}
+	if err := ValidateCardPropertyValue(board, r.ParentPropertyID, r.ParentCardValue(board)); err != nil {
+		return NewErrInvalidField("parentValue", err.Error())
+	}
+	return nil
+}
+
+// completedValue returns the completed value as it's stored on the cards.
+func (r *SubtaskRule) completedValue(board *Board) interface{} {
+	return subtaskRuleValue(board, r.CompletedPropertyID, r.CompletedValue)
+}
+
+// ParentCardValue returns the parent value as it's stored on the cards.
+func (r *SubtaskRule) ParentCardValue(board *Board) interface{} {
+	return subtaskRuleValue(board, r.ParentPropertyID, r.ParentValue)
+}
+
+// subtaskRuleValue returns a value of the rule as it's stored on the
+// cards, which for the properties with several options is a list.
+func subtaskRuleValue(board *Board, propertyID, value string) interface{} {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return value
+	}
+	if def, ok := schema[propertyID]; ok && (def.Type == "multiSelect" || def.Type == PropertyTypeLabel) {
+		return []interface{}{value}
+	}
+	return value
+}
+
+// IsCompleted checks whether a subtask has the completed value. For the
+// properties with several values, the completed value must be one of
+// them.
+func (r *SubtaskRule) IsCompleted(subtask *Block) bool {
+	properties, _ := subtask.Fields["properties"].(map[string]interface{})
+	switch value := properties[r.CompletedPropertyID].(type) {
+	case string:
+		return value == r.CompletedValue
+	case []interface{}:
+		for _, v := range value {
+			if v == r.CompletedValue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetSubtaskRule returns the subtask rule of the board, or nil if it has
+// none or it's malformed.
+func (b *Board) GetSubtaskRule() *SubtaskRule {
+	ruleMap, ok := b.Properties[SubtaskRuleKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	rule := &SubtaskRule{
+		CompletedPropertyID: getMapString("completedPropertyId", ruleMap),
+		CompletedValue:      getMapString("completedValue", ruleMap),
+		ParentPropertyID:    getMapString("parentPropertyId", ruleMap),
+		ParentValue:         getMapString("parentValue", ruleMap),
+	}
+	if rule.CompletedPropertyID == "" {
+		return nil
+	}
+	return rule
+}
+
+// SubtaskRuleBoardPatch returns the board patch that sets the rule, or
+// removes it if the rule is nil.
+func SubtaskRuleBoardPatch(rule *SubtaskRule) *BoardPatch {
+	if rule == nil {
+		return &BoardPatch{DeletedProperties: []string{SubtaskRuleKey}}
+	}
+	return &BoardPatch{UpdatedProperties: map[string]interface{}{SubtaskRuleKey: rule.ruleMap()}}
+}
+
+// IsSubtask checks whether a block is a card parented to another card
+// instead of to its board.
+func IsSubtask(block *Block) bool {
+	return block.Type == TypeCard && block.ParentID != "" && block.ParentID != block.BoardID
+}
+
+// SubtaskRollup reports how many of the subtasks of a card are
+// completed, following the subtask rule of its board.
+// swagger:model
+type SubtaskRollup struct {
+	// The id for the parent card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ids of the subtasks of the card
+	// required: true
+	SubtaskIDs []string `json:"subtaskIds"`
+
+	// The ids of the completed subtasks. Without a subtask rule in the
+	// board, no subtask is completed
+	// required: true
+	CompletedIDs []string `json:"completedIds"`
+
+	// The number of completed subtasks
+	// required: true
+	Completed int `json:"completed"`
+
+	// The total number of subtasks
+	// required: true
+	Total int `json:"total"`
+}
+
+// AllCompleted checks whether the card has subtasks and all of them are
+// completed.
+func (r *SubtaskRollup) AllCompleted() bool {
+	return r.Total > 0 && r.Completed == r.Total
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtaskRule(t *testing.T) {
+	board := &Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "open", "value": "Open"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "tags", "name": "Tags", "type": "multiSelect", "options": []interface{}{
+				map[string]interface{}{"id": "complete", "value": "Complete"},
+			}},
+		},
+		Properties: map[string]interface{}{},
+	}
+
+	t.Run("valid rules", func(t *testing.T) {
+		rule := &SubtaskRule{CompletedPropertyID: "status", CompletedValue: "done"}
+		require.NoError(t, rule.IsValid(board))
+
+		rule = &SubtaskRule{CompletedPropertyID: "status", CompletedValue: "done", ParentPropertyID: "tags", ParentValue: "complete"}
+		require.NoError(t, rule.IsValid(board))
+		require.Equal(t, []interface{}{"complete"}, rule.ParentCardValue(board))
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		rules := []*SubtaskRule{
+			{},
+			{CompletedPropertyID: "missing", CompletedValue: "done"},
+			{CompletedPropertyID: "status", CompletedValue: "missing"},
+			{CompletedPropertyID: "status", CompletedValue: "done", ParentPropertyID: "status"},
+			{CompletedPropertyID: "status", CompletedValue: "done", ParentValue: "done"},
+			{CompletedPropertyID: "status", CompletedValue: "done", ParentPropertyID: "tags", ParentValue: "missing"},
+		}
+		for _, rule := range rules {
+			require.True(t, IsErrBadRequest(rule.IsValid(board)), "rule %+v", rule)
+		}
+	})
+
+	t.Run("completed subtasks", func(t *testing.T) {
+		rule := &SubtaskRule{CompletedPropertyID: "tags", CompletedValue: "complete"}
+		subtask := func(value interface{}) *Block {
+			return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{"tags": value}}}
+		}
+
+		require.True(t, rule.IsCompleted(subtask([]interface{}{"other", "complete"})))
+		require.True(t, rule.IsCompleted(subtask("complete")))
+		require.False(t, rule.IsCompleted(subtask([]interface{}{"other"})))
+		require.False(t, rule.IsCompleted(&Block{Type: TypeCard}))
+	})
+
+	t.Run("stored in the board properties", func(t *testing.T) {
+		require.Nil(t, board.GetSubtaskRule())
+
+		rule := &SubtaskRule{CompletedPropertyID: "status", CompletedValue: "done", ParentPropertyID: "status", ParentValue: "done"}
+		SubtaskRuleBoardPatch(rule).Patch(board)
+		require.Equal(t, rule, board.GetSubtaskRule())
+
+		SubtaskRuleBoardPatch(nil).Patch(board)
+		require.Nil(t, board.GetSubtaskRule())
+	})
+}
+
+func TestIsSubtask(t *testing.T) {
+	require.True(t, IsSubtask(&Block{Type: TypeCard, BoardID: "board-id", ParentID: "card-id"}))
+	require.False(t, IsSubtask(&Block{Type: TypeCard, BoardID: "board-id", ParentID: "board-id"}))
+	require.False(t, IsSubtask(&Block{Type: TypeCard, BoardID: "board-id"}))
+	require.False(t, IsSubtask(&Block{Type: TypeText, BoardID: "board-id", ParentID: "card-id"}))
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"fmt"
+)
+
+// SyncBlockChange is a block changed by a client while offline
+// swagger:model
+type SyncBlockChange struct {
+	// The block as modified by the client
+	// required: true
+	Block Block `json:"block"`
+
+	// The updateAt of the block the client based its change on, 0 for new blocks
+	// required: true
+	BaseUpdateAt int64 `json:"baseUpdateAt"`
+}
+
+// SyncBlocksRequest contains the changes a client needs to reconcile
+// swagger:model
+type SyncBlocksRequest struct {
+	// The changed blocks
+	// required: true
+	Changes []SyncBlockChange `json:"changes"`
+}
+
+// SyncBlockConflict describes a client change that was not applied
+// because the block was modified on the server after the client's base version
+// swagger:model
+type SyncBlockConflict struct {
+	// The ID of the conflicting block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The updateAt the client based its change on
+	// required: true
+	BaseUpdateAt int64 `json:"baseUpdateAt"`
+
+	// The current server version of the block, nil if it was deleted
+	// required: false
+	ServerBlock *Block `json:"serverBlock"`
+}
+
+// SyncBlocksResult is the outcome of a sync reconciliation
+// swagger:model
+type SyncBlocksResult struct {
+	// The IDs of the blocks that were applied
+	// required: true
+	Applied []string `json:"applied"`
+
+	// The changes that were rejected because of a conflict
+	// required: true
+	Conflicts []SyncBlockConflict `json:"conflicts"`
+
+	// The authoritative server state of the board blocks after the sync
+	// required: true
+	Blocks []Block `json:"blocks"`
+}
+
+// IsValid checks that all the changes target the given board.
+func (r *SyncBlocksRequest) IsValid(boardID string) error {
+	for _, change := range r.Changes {
+		if change.Block.ID == "" {
+			return NewErrBadRequest("missing ID for synced block")
+		}
+		if change.Block.BoardID != boardID {
+			return NewErrBadRequest(fmt.Sprintf("invalid BoardID for block id %s", change.Block.ID))
+		}
+		if change.Block.Type == "" {
+			return NewErrBadRequest(fmt.Sprintf("missing type for block id %s", change.Block.ID))
+		}
+		if change.BaseUpdateAt < 0 {
+			return NewErrBadRequest(fmt.Sprintf("invalid baseUpdateAt for block id %s", change.Block.ID))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"fmt"
+)
+
+// Block events the webhooks are called for.
+const (
+	WebhookEventCreate = "create"
+	WebhookEventUpdate = "update"
+)
+
+// TeamWebhook is a webhook called for the block events of all the boards
+// of a team.
+// swagger:model
+type TeamWebhook struct {
+	// The webhook ID
+	// required: true
+	ID string `json:"id"`
+
+	// The team of the boards the webhook is called for
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The URL the events are posted to
+	// required: true
+	URL string `json:"url"`
+
+	// The events the webhook is called for, all of them if empty
+	// required: true
+	EventTypes []string `json:"eventTypes"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// Updated time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// HasEventType checks if the webhook is called for an event.
+func (w *TeamWebhook) HasEventType(event string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range w.EventTypes {
+		if eventType == event {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid checks the team, the URL and the event types of a webhook.
+// The URL is checked against the webhook hosts policy separately.
+func (w *TeamWebhook) IsValid() error {
+	if w.TeamID == "" {
+		return NewErrInvalidField("teamId", "cannot be empty")
+	}
+
+	if w.URL == "" {
+		return NewErrInvalidField("url", "cannot be empty")
+	}
+
+	for _, eventType := range w.EventTypes {
+		if eventType != WebhookEventCreate && eventType != WebhookEventUpdate {
+			return NewErrInvalidField("eventTypes", fmt.Sprintf("unknown event type %s", eventType))
+		}
+	}
+
+	return nil
+}
+
+// TeamWebhookRequest is the request to create or update a team webhook.
+// swagger:model
+type TeamWebhookRequest struct {
+	// The URL the events are posted to
+	// required: true
+	URL string `json:"url"`
+
+	// The events the webhook is called for, all of them if empty
+	// required: false
+	EventTypes []string `json:"eventTypes"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamWebhookHasEventType(t *testing.T) {
+	webhook := &TeamWebhook{}
+	require.True(t, webhook.HasEventType(WebhookEventCreate))
+	require.True(t, webhook.HasEventType(WebhookEventUpdate))
+
+	webhook.EventTypes = []string{WebhookEventUpdate}
+	require.False(t, webhook.HasEventType(WebhookEventCreate))
+	require.True(t, webhook.HasEventType(WebhookEventUpdate))
+}
+
+func TestTeamWebhookIsValid(t *testing.T) {
+	webhook := &TeamWebhook{TeamID: "team-id", URL: "https://example.com/hook"}
+	require.NoError(t, webhook.IsValid())
+
+	webhook.EventTypes = []string{WebhookEventCreate, "delete"}
+	require.True(t, IsErrBadRequest(webhook.IsValid()))
+
+	webhook.EventTypes = nil
+	webhook.URL = ""
+	require.True(t, IsErrBadRequest(webhook.IsValid()))
+}
@@ -67,6 +67,12 @@ type User struct {
 	IsGuest bool `json:"is_guest"`
 
 	Roles string `json:"roles"`
+
+	// The user's preferred locale for the content generated by the
+	// server, such as the notifications. If empty, the server default
+	// locale is used
+	// required: false
+	Locale string `json:"locale"`
 }
 
 // UserPreferencesPatch is a user property patch
@@ -91,6 +97,54 @@ type Session struct {
 	UpdateAt    int64                  `json:"update_at,omitempty"`
 }
 
+// Props of the sessions created to impersonate a user.
+const (
+	SessionPropImpersonatedBy = "impersonatedBy"
+	SessionPropExpiresAt      = "expiresAt"
+)
+
+// ImpersonatedBy returns who impersonates the user of the session, or
+// an empty string if it's a regular session.
+func (s *Session) ImpersonatedBy() string {
+	impersonatedBy, _ := s.Props[SessionPropImpersonatedBy].(string)
+	return impersonatedBy
+}
+
+// ExpiresAt returns the time the session expires regardless of its
+// activity, or 0 if it only expires when unused.
+func (s *Session) ExpiresAt() int64 {
+	// once stored, the numeric props are read back as float64
+	switch expiresAt := s.Props[SessionPropExpiresAt].(type) {
+	case int64:
+		return expiresAt
+	case float64:
+		return int64(expiresAt)
+	default:
+		return 0
+	}
+}
+
+// ActiveUsersStats contains the number of users active in the last
+// day, week and month.
+// swagger:model
+type ActiveUsersStats struct {
+	// The number of users active in the last day
+	// required: true
+	DailyActiveUsers int `json:"dailyActiveUsers"`
+
+	// The number of users active in the last week
+	// required: true
+	WeeklyActiveUsers int `json:"weeklyActiveUsers"`
+
+	// The number of users active in the last month
+	// required: true
+	MonthlyActiveUsers int `json:"monthlyActiveUsers"`
+
+	// The time the stats were computed in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
 func UserFromJSON(data io.Reader) (*User, error) {
 	var user User
 	if err := json.NewDecoder(data).Decode(&user); err != nil {
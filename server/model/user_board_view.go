@@ -0,0 +1,47 @@
+package model
+
+import "fmt"
+
+const userBoardViewMaxCollapsedGroups = 1000
+
+// UserBoardView is the state of a board for a user: the active view, the
+// sort and filter applied to it and the collapsed groups. It is a user
+// preference, so it doesn't change the board for the other users.
+// swagger:model
+type UserBoardView struct {
+	// The user ID
+	// required: true
+	UserID string `json:"userId"`
+
+	// The board ID
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the view the user has open
+	// required: false
+	ActiveViewID string `json:"activeViewId"`
+
+	// The sort options of the user, with the format of the view ones
+	// required: false
+	SortOptions []interface{} `json:"sortOptions"`
+
+	// The filter of the user, with the format of the view one
+	// required: false
+	Filter map[string]interface{} `json:"filter"`
+
+	// The IDs of the groups the user has collapsed
+	// required: false
+	CollapsedGroups []string `json:"collapsedGroups"`
+
+	// Updated time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// IsValid checks the view state set by a user.
+func (v *UserBoardView) IsValid() error {
+	if len(v.CollapsedGroups) > userBoardViewMaxCollapsedGroups {
+		return NewErrInvalidField("collapsedGroups", fmt.Sprintf("cannot have more than %d groups", userBoardViewMaxCollapsedGroups))
+	}
+	return nil
+}
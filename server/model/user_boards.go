@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+)
+
+const (
+	// UserBoardsFilterFavorites returns the favorite boards of the user.
+	UserBoardsFilterFavorites = "favorites"
+
+	// UserBoardsFilterRecent returns the most recently updated boards.
+	UserBoardsFilterRecent = "recent"
+
+	DefaultRecentUserBoards = 20
+	MaxRecentUserBoards     = 100
+)
+
+// UserBoard is a board the user can access, tagged with its team.
+// swagger:model
+type UserBoard struct {
+	*Board
+
+	// The title of the team of the board, empty if the team isn't known
+	// required: true
+	TeamTitle string `json:"teamTitle"`
+}
+
+// IsValidUserBoardsFilter checks that the filter is empty or one of
+// the supported ones.
+func IsValidUserBoardsFilter(filter string) error {
+	switch filter {
+	case "", UserBoardsFilterFavorites, UserBoardsFilterRecent:
+		return nil
+	default:
+		return NewErrBadRequest(fmt.Sprintf("invalid filter %q, must be %s or %s", filter, UserBoardsFilterFavorites, UserBoardsFilterRecent))
+	}
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// BoardPermissions are the permissions that apply to a board, from the
+// most to the least privileged role that has them.
+var BoardPermissions = []*mmModel.Permission{
+	PermissionManageBoardType,
+	PermissionDeleteBoard,
+	PermissionManageBoardRoles,
+	PermissionShareBoard,
+	PermissionDeleteOthersComments,
+	PermissionManageBoardCards,
+	PermissionManageBoardProperties,
+	PermissionCommentBoardCards,
+	PermissionViewBoard,
+}
+
+// UserWithPermissions is the current user with their teams and,
+// optionally, what they can do on a board.
+// swagger:model
+type UserWithPermissions struct {
+	*User
+
+	// The teams the user is a member of
+	// required: true
+	Teams []*Team `json:"teams"`
+
+	// The effective permissions of the user on the requested board
+	// required: false
+	Board *UserBoardPermissions `json:"board,omitempty"`
+}
+
+// UserBoardPermissions are the effective role and permissions of a user
+// on a board, taking into account their membership, the board minimum
+// role and the system roles.
+// swagger:model
+type UserBoardPermissions struct {
+	// The board ID
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The effective role of the user: admin, editor, commenter, viewer,
+	// or empty if the user can't access the board
+	// required: true
+	Role BoardRole `json:"role"`
+
+	// The IDs of the permissions the user has on the board
+	// required: true
+	Permissions []string `json:"permissions"`
+}
+
+// NewUserBoardPermissions returns the permissions of a user on a board
+// from the check of each board permission. The role is the least
+// privileged one that grants them.
+func NewUserBoardPermissions(boardID string, hasPermission func(permission *mmModel.Permission) bool) *UserBoardPermissions {
+	perms := &UserBoardPermissions{
+		BoardID:     boardID,
+		Role:        BoardRoleNone,
+		Permissions: []string{},
+	}
+
+	granted := map[*mmModel.Permission]bool{}
+	for _, permission := range BoardPermissions {
+		if hasPermission(permission) {
+			granted[permission] = true
+			perms.Permissions = append(perms.Permissions, permission.Id)
+		}
+	}
+
+	switch {
+	case granted[PermissionManageBoardRoles]:
+		perms.Role = BoardRoleAdmin
+	case granted[PermissionManageBoardCards]:
+		perms.Role = BoardRoleEditor
+	case granted[PermissionCommentBoardCards]:
+		perms.Role = BoardRoleCommenter
+	case granted[PermissionViewBoard]:
+		perms.Role = BoardRoleViewer
+	}
+
+	return perms
+}
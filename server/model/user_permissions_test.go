@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestNewUserBoardPermissions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		granted  []*mmModel.Permission
+		role     BoardRole
+		expected []string
+	}{
+		{"no access", nil, BoardRoleNone, []string{}},
+		{"viewer", []*mmModel.Permission{PermissionViewBoard}, BoardRoleViewer, []string{"view_board"}},
+		{
+			"commenter",
+			[]*mmModel.Permission{PermissionViewBoard, PermissionCommentBoardCards},
+			BoardRoleCommenter,
+			[]string{"comment_board_cards", "view_board"},
+		},
+		{
+			"editor",
+			[]*mmModel.Permission{PermissionViewBoard, PermissionCommentBoardCards, PermissionManageBoardCards, PermissionManageBoardProperties},
+			BoardRoleEditor,
+			[]string{"manage_board_cards", "manage_board_properties", "comment_board_cards", "view_board"},
+		},
+		{"admin", BoardPermissions, BoardRoleAdmin, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			granted := map[*mmModel.Permission]bool{}
+			for _, permission := range tc.granted {
+				granted[permission] = true
+			}
+
+			perms := NewUserBoardPermissions("board-id", func(permission *mmModel.Permission) bool {
+				return granted[permission]
+			})
+			require.Equal(t, "board-id", perms.BoardID)
+			require.Equal(t, tc.role, perms.Role)
+			if tc.expected != nil {
+				require.Equal(t, tc.expected, perms.Permissions)
+			} else {
+				require.Len(t, perms.Permissions, len(BoardPermissions))
+			}
+		})
+	}
+}
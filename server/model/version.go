@@ -47,6 +47,36 @@ var (
 	Edition        string
 )
 
+// VersionInfo describes the build of the running server.
+// swagger:model
+type VersionInfo struct {
+	// The server version
+	// required: true
+	Version string `json:"version"`
+
+	// The build number
+	// required: true
+	BuildNumber string `json:"buildNumber"`
+
+	// The hash of the commit the server was built from
+	// required: true
+	BuildHash string `json:"buildHash"`
+
+	// The server edition
+	// required: true
+	Edition string `json:"edition"`
+}
+
+// GetVersionInfo returns the build information of the server.
+func GetVersionInfo() *VersionInfo {
+	return &VersionInfo{
+		Version:     CurrentVersion,
+		BuildNumber: BuildNumber,
+		BuildHash:   BuildHash,
+		Edition:     Edition,
+	}
+}
+
 // LogServerInfo logs information about the server instance.
 func LogServerInfo(logger mlog.LoggerIFace) {
 	logger.Info("FocalBoard Server",
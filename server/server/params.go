@@ -2,12 +2,20 @@ package server
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"time"
 
+	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/i18n"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/webhook"
+	"github.com/mattermost/focalboard/server/web"
 	"github.com/mattermost/focalboard/server/ws"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -21,6 +29,7 @@ type Params struct {
 	ServerID           string
 	WSAdapter          ws.Adapter
 	NotifyBackends     []notify.Backend
+	BlockHooks         []app.BlockHook
 	PermissionsService permissions.PermissionsService
 	ServicesAPI        model.ServicesAPI
 	IsPlugin           bool
@@ -42,6 +51,190 @@ func (p Params) CheckValid() error {
 	if p.PermissionsService == nil {
 		return ErrServerParam{name: "Permissions", issue: "cannot be nil"}
 	}
+
+	if !config.IsValidSameSite(p.Cfg.SessionCookieSameSite) {
+		return ErrServerParam{name: "Cfg.SessionCookieSameSite", issue: "must be one of lax, strict or none"}
+	}
+
+	if _, err := time.LoadLocation(p.Cfg.ServerTimezone); err != nil {
+		return ErrServerParam{name: "Cfg.ServerTimezone", issue: "must be a valid timezone name"}
+	}
+
+	webTimeouts := map[string]int{
+		"Cfg.WebReadTimeout":       p.Cfg.WebReadTimeout,
+		"Cfg.WebReadHeaderTimeout": p.Cfg.WebReadHeaderTimeout,
+		"Cfg.WebWriteTimeout":      p.Cfg.WebWriteTimeout,
+		"Cfg.WebIdleTimeout":       p.Cfg.WebIdleTimeout,
+		"Cfg.RequestTimeout":       p.Cfg.RequestTimeout,
+	}
+	for name, timeout := range webTimeouts {
+		if timeout < 0 {
+			return ErrServerParam{name: name, issue: "cannot be negative"}
+		}
+	}
+
+	if p.Cfg.WebsocketBroadcastWorkers < 0 {
+		return ErrServerParam{name: "Cfg.WebsocketBroadcastWorkers", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.WebsocketSubscriptionTTL < 0 {
+		return ErrServerParam{name: "Cfg.WebsocketSubscriptionTTL", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.TelemetryConcurrency < 0 {
+		return ErrServerParam{name: "Cfg.TelemetryConcurrency", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.TelemetryTrackerTimeout < 0 {
+		return ErrServerParam{name: "Cfg.TelemetryTrackerTimeout", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.TelemetryEventFlushInterval < 0 {
+		return ErrServerParam{name: "Cfg.TelemetryEventFlushInterval", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.TelemetryEventBatchSize < 0 {
+		return ErrServerParam{name: "Cfg.TelemetryEventBatchSize", issue: "cannot be negative"}
+	}
+
+	if !config.IsValidSessionStore(p.Cfg.SessionStore) {
+		return ErrServerParam{name: "Cfg.SessionStore", issue: "must be one of database or memory"}
+	}
+
+	if p.Cfg.SessionMaxLifetime < 0 {
+		return ErrServerParam{name: "Cfg.SessionMaxLifetime", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.DBTransactionRetries < 0 {
+		return ErrServerParam{name: "Cfg.DBTransactionRetries", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.MaxBlockTreeDepth < 0 {
+		return ErrServerParam{name: "Cfg.MaxBlockTreeDepth", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.ImpersonationLifetime < 0 {
+		return ErrServerParam{name: "Cfg.ImpersonationLifetime", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.TrustedAuthHeader != "" && len(p.Cfg.TrustedProxies) == 0 {
+		return ErrServerParam{name: "Cfg.TrustedProxies", issue: "cannot be empty when TrustedAuthHeader is set"}
+	}
+
+	if _, err := auth.ParseTrustedProxies(p.Cfg.TrustedProxies); err != nil {
+		return ErrServerParam{name: "Cfg.TrustedProxies", issue: err.Error()}
+	}
+
+	if p.Cfg.UseSSL {
+		if _, err := web.NewTLSConfig(p.Cfg.MinTLSVersion, nil); err != nil {
+			return ErrServerParam{name: "Cfg.MinTLSVersion", issue: err.Error()}
+		}
+		if _, err := web.NewTLSConfig(p.Cfg.MinTLSVersion, p.Cfg.TLSCipherSuites); err != nil {
+			return ErrServerParam{name: "Cfg.TLSCipherSuites", issue: err.Error()}
+		}
+	}
+
+	if p.Cfg.WebhookUpdateDebounceMillis < 0 {
+		return ErrServerParam{name: "Cfg.WebhookUpdateDebounceMillis", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.UserBoardViewDebounceMillis < 0 {
+		return ErrServerParam{name: "Cfg.UserBoardViewDebounceMillis", issue: "cannot be negative"}
+	}
+
+	if _, err := webhook.ParsePayloadTemplate(p.Cfg.WebhookUpdateTemplate); err != nil {
+		return ErrServerParam{name: "Cfg.WebhookUpdateTemplate", issue: err.Error()}
+	}
+
+	for _, url := range p.Cfg.WebhookUpdate {
+		if err := webhook.ValidateURL(p.Cfg, url); err != nil {
+			return ErrServerParam{name: "Cfg.WebhookUpdate", issue: err.Error()}
+		}
+	}
+
+	if p.Cfg.MaxPropertiesPerBoard < 0 {
+		return ErrServerParam{name: "Cfg.MaxPropertiesPerBoard", issue: "cannot be negative"}
+	}
+
+	if err := app.IsValidImageTranscodeFormat(p.Cfg.ImageTranscodeFormat); err != nil {
+		return ErrServerParam{name: "Cfg.ImageTranscodeFormat", issue: err.Error()}
+	}
+
+	if p.Cfg.MaxBoardsPerTeam < 0 {
+		return ErrServerParam{name: "Cfg.MaxBoardsPerTeam", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.MaxCommentLength < 0 {
+		return ErrServerParam{name: "Cfg.MaxCommentLength", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.CommentRateLimit < 0 {
+		return ErrServerParam{name: "Cfg.CommentRateLimit", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.MaxRecentBoards < 0 {
+		return ErrServerParam{name: "Cfg.MaxRecentBoards", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.MaxBulkItems < 0 {
+		return ErrServerParam{name: "Cfg.MaxBulkItems", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.MaxBulkBodySize < 0 {
+		return ErrServerParam{name: "Cfg.MaxBulkBodySize", issue: "cannot be negative"}
+	}
+
+	for blockType, size := range p.Cfg.MaxBlockSizes {
+		if size < 0 {
+			return ErrServerParam{name: "Cfg.MaxBlockSizes." + blockType, issue: "cannot be negative"}
+		}
+	}
+
+	if !model.IsBoardVisibilityValid(model.BoardVisibility(p.Cfg.DefaultBoardVisibility)) {
+		return ErrServerParam{name: "Cfg.DefaultBoardVisibility", issue: "must be one of private, team or public"}
+	}
+
+	if err := model.ValidateCardPropertySchema(p.Cfg.DefaultCardProperties); err != nil {
+		return ErrServerParam{name: "Cfg.DefaultCardProperties", issue: err.Error()}
+	}
+
+	if !model.IsValidSubtaskDeleteMode(p.Cfg.SubtaskDeleteMode) {
+		return ErrServerParam{name: "Cfg.SubtaskDeleteMode", issue: "must be cascade or detach"}
+	}
+
+	if p.Cfg.PreShutdownDelay < 0 {
+		return ErrServerParam{name: "Cfg.PreShutdownDelay", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.FilesBackendCheckInterval < 0 {
+		return ErrServerParam{name: "Cfg.FilesBackendCheckInterval", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.EmptyTeamCleanupDays < 0 {
+		return ErrServerParam{name: "Cfg.EmptyTeamCleanupDays", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.BlockHistoryKeepAllHours < 0 {
+		return ErrServerParam{name: "Cfg.BlockHistoryKeepAllHours", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.BlockHistoryKeepHourlyDays < 0 {
+		return ErrServerParam{name: "Cfg.BlockHistoryKeepHourlyDays", issue: "cannot be negative"}
+	}
+
+	if p.Cfg.DefaultLocale != "" && !i18n.IsSupportedLocale(p.Cfg.DefaultLocale) {
+		return ErrServerParam{name: "Cfg.DefaultLocale", issue: "unsupported locale"}
+	}
+
+	if p.Cfg.EnableProfiler {
+		_, port, err := net.SplitHostPort(p.Cfg.ProfilerAddress)
+		if err != nil {
+			return ErrServerParam{name: "Cfg.ProfilerAddress", issue: "must be a valid host:port address"}
+		}
+		if port == strconv.Itoa(p.Cfg.Port) {
+			return ErrServerParam{name: "Cfg.ProfilerAddress", issue: "cannot use the same port as the public server"}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// startProfilerServer starts a debug listener with the pprof handlers.
+// It is kept separate from the public web server so the profiler is
+// never exposed on the public port.
+func (s *Server) startProfilerServer() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	listener, err := net.Listen("tcp", s.config.ProfilerAddress)
+	if err != nil {
+		return err
+	}
+
+	if host, _, splitErr := net.SplitHostPort(s.config.ProfilerAddress); splitErr == nil && !isLoopbackHost(host) {
+		s.logger.Warn("The profiler is not bound to localhost, make sure it is not reachable from the internet",
+			mlog.String("address", s.config.ProfilerAddress),
+		)
+	}
+
+	s.profilerServer = &http.Server{Handler: mux}
+
+	go func() {
+		s.logger.Info("Starting profiler server", mlog.String("address", listener.Addr().String()))
+		if err := s.profilerServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Error starting profiler server", mlog.Err(err))
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) stopProfilerServer() {
+	if s.profilerServer != nil {
+		_ = s.profilerServer.Close()
+		s.profilerServer = nil
+	}
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
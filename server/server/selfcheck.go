@@ -0,0 +1,265 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/store/sqlstore"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+)
+
+const selfCheckDBTimeout = 10 * time.Second
+
+var errSelfCheckEmptyPath = errors.New("the path is empty")
+
+// SelfCheckStatus is the outcome of a startup self-check.
+type SelfCheckStatus string
+
+const (
+	SelfCheckPass SelfCheckStatus = "PASS"
+	SelfCheckWarn SelfCheckStatus = "WARN"
+	SelfCheckFail SelfCheckStatus = "FAIL"
+)
+
+// SelfCheckResult is the result of probing one part of a subsystem.
+type SelfCheckResult struct {
+	Group  string
+	Name   string
+	Status SelfCheckStatus
+	Detail string
+	// Hint tells how to fix a failed or warned check
+	Hint string
+}
+
+// SelfCheckReport holds the results of the startup self-check, in the
+// order the checks ran.
+type SelfCheckReport struct {
+	Results []SelfCheckResult
+}
+
+func (r *SelfCheckReport) add(group, name string, status SelfCheckStatus, detail, hint string) {
+	r.Results = append(r.Results, SelfCheckResult{
+		Group:  group,
+		Name:   name,
+		Status: status,
+		Detail: detail,
+		Hint:   hint,
+	})
+}
+
+// HasFailures returns true if any of the checks failed. Warnings don't
+// prevent the server from starting.
+func (r *SelfCheckReport) HasFailures() bool {
+	for _, result := range r.Results {
+		if result.Status == SelfCheckFail {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the summary of the checks grouped by subsystem, with
+// the remediation hints of the checks that didn't pass.
+func (r *SelfCheckReport) String() string {
+	var sb strings.Builder
+	sb.WriteString("Focalboard startup self-check\n")
+
+	counts := map[SelfCheckStatus]int{}
+	group := ""
+	for _, result := range r.Results {
+		if result.Group != group {
+			group = result.Group
+			fmt.Fprintf(&sb, "\n%s\n", group)
+		}
+		counts[result.Status]++
+
+		fmt.Fprintf(&sb, "  [%s] %s", result.Status, result.Name)
+		if result.Detail != "" {
+			fmt.Fprintf(&sb, ": %s", result.Detail)
+		}
+		sb.WriteString("\n")
+		if result.Status != SelfCheckPass && result.Hint != "" {
+			fmt.Fprintf(&sb, "         Hint: %s\n", result.Hint)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n%d passed, %d warnings, %d failed\n", counts[SelfCheckPass], counts[SelfCheckWarn], counts[SelfCheckFail])
+	return sb.String()
+}
+
+// RunSelfCheck probes the subsystems the server needs to start with the
+// given configuration. Besides the files directory and the SQLite
+// database file, that the server would create anyway, it doesn't change
+// anything, so it can run before the server starts or on its own.
+func RunSelfCheck(cfg *config.Configuration) *SelfCheckReport {
+	report := &SelfCheckReport{}
+	checkSelfConfig(report, cfg)
+	checkSelfDatabase(report, cfg)
+	checkSelfFiles(report, cfg)
+	checkSelfNetwork(report, cfg)
+	checkSelfWebClient(report, cfg)
+	return report
+}
+
+func checkSelfConfig(report *SelfCheckReport, cfg *config.Configuration) {
+	const group = "Configuration"
+
+	switch cfg.DBType {
+	case model.SqliteDBType, model.PostgresDBType, model.MysqlDBType:
+		report.add(group, "Database type", SelfCheckPass, cfg.DBType, "")
+	default:
+		report.add(group, "Database type", SelfCheckFail, fmt.Sprintf("unsupported database type %q", cfg.DBType),
+			"set dbtype to sqlite3, postgres or mysql in the config file or with -dbtype")
+	}
+
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		report.add(group, "Port", SelfCheckFail, fmt.Sprintf("invalid port %d", cfg.Port),
+			"set port to a number between 1 and 65535 in the config file or with -port")
+	} else {
+		report.add(group, "Port", SelfCheckPass, fmt.Sprintf("%d", cfg.Port), "")
+	}
+}
+
+func checkSelfDatabase(report *SelfCheckReport, cfg *config.Configuration) {
+	const group = "Database"
+
+	hint := "check the dbconfig connection string: the host, port, credentials and database name"
+	if cfg.DBType == model.SqliteDBType {
+		hint = "check that the directory of the dbconfig file exists and is writable by the user running the server"
+	}
+
+	if cfg.DBConfigString == "" {
+		report.add(group, "Connection", SelfCheckFail, "the connection string is empty", hint)
+		return
+	}
+
+	connectionString := cfg.DBConfigString
+	if cfg.DBUseTLS {
+		var err error
+		connectionString, err = sqlstore.ApplyDBTLSConfig(cfg.DBType, connectionString, sqlstore.DBTLSConfig{
+			CACertFile:     cfg.DBTLSCACert,
+			ClientCertFile: cfg.DBTLSClientCert,
+			ClientKeyFile:  cfg.DBTLSClientKey,
+		})
+		if err != nil {
+			report.add(group, "TLS", SelfCheckFail, err.Error(),
+				"check that dbtlscacert, dbtlsclientcert and dbtlsclientkey point to readable PEM files")
+			return
+		}
+		report.add(group, "TLS", SelfCheckPass, "certificates loaded", "")
+	}
+
+	// the connection string isn't included in the output as it may
+	// contain passwords
+	db, err := sql.Open(cfg.DBType, connectionString)
+	if err != nil {
+		report.add(group, "Connection", SelfCheckFail, err.Error(), hint)
+		return
+	}
+	defer func() { _ = db.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), selfCheckDBTimeout)
+	defer cancel()
+	if pingErr := db.PingContext(ctx); pingErr != nil {
+		report.add(group, "Connection", SelfCheckFail, pingErr.Error(), hint)
+		return
+	}
+	report.add(group, "Connection", SelfCheckPass, fmt.Sprintf("connected to %s", cfg.DBType), "")
+}
+
+func checkSelfFiles(report *SelfCheckReport, cfg *config.Configuration) {
+	const group = "Files storage"
+
+	// the server can start without the files storage unless it's
+	// required, in which case a problem prevents it from starting
+	status := SelfCheckWarn
+	if cfg.FilesBackendRequired {
+		status = SelfCheckFail
+	}
+
+	if cfg.FilesDriver == "local" {
+		if err := checkWritableDir(cfg.FilesPath); err != nil {
+			report.add(group, "Files path", status, err.Error(),
+				fmt.Sprintf("create the directory %q and make it writable by the user running the server, or change filespath", cfg.FilesPath))
+			return
+		}
+		report.add(group, "Files path", SelfCheckPass, fmt.Sprintf("%q is writable", cfg.FilesPath), "")
+		return
+	}
+
+	backend, err := filestore.NewFileBackend(newFilesBackendSettings(cfg))
+	if err != nil {
+		report.add(group, "Files driver", status, err.Error(), "set filesdriver to local or amazons3 and check the filess3config settings")
+		return
+	}
+	if connErr := backend.TestConnection(); connErr != nil {
+		report.add(group, "Connection", status, connErr.Error(), "check the bucket, region, endpoint and credentials in filess3config")
+		return
+	}
+	report.add(group, "Connection", SelfCheckPass, fmt.Sprintf("connected to %s", cfg.FilesDriver), "")
+}
+
+// checkWritableDir creates the directory if needed and checks that
+// files can be written to it.
+func checkWritableDir(dir string) error {
+	if dir == "" {
+		return errSelfCheckEmptyPath
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, ".focalboard-self-check-")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name)
+}
+
+func checkSelfNetwork(report *SelfCheckReport, cfg *config.Configuration) {
+	const group = "Network"
+
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		// already reported as a configuration problem
+		return
+	}
+
+	addr := fmt.Sprintf(":%d", cfg.Port)
+	if cfg.LocalOnly {
+		addr = fmt.Sprintf("localhost:%d", cfg.Port)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		report.add(group, "Port", SelfCheckFail, err.Error(),
+			fmt.Sprintf("stop the process using port %d or set a different port in the config file or with -port", cfg.Port))
+		return
+	}
+	_ = listener.Close()
+	report.add(group, "Port", SelfCheckPass, fmt.Sprintf("%s is available", addr), "")
+}
+
+func checkSelfWebClient(report *SelfCheckReport, cfg *config.Configuration) {
+	const group = "Web client"
+
+	index := filepath.Join(cfg.WebPath, "index.html")
+	if _, err := os.Stat(index); err != nil {
+		// the API still works without the web client
+		report.add(group, "Web path", SelfCheckWarn, fmt.Sprintf("%s not found", index),
+			"set webpath to the directory of the built web app, or build it with make webapp")
+		return
+	}
+	report.add(group, "Web path", SelfCheckPass, fmt.Sprintf("%q", cfg.WebPath), "")
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSelfCheck(t *testing.T) {
+	dir := t.TempDir()
+	webPath := filepath.Join(dir, "pack")
+	require.NoError(t, os.MkdirAll(webPath, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(webPath, "index.html"), []byte("<html></html>"), 0600))
+
+	newConfig := func() *config.Configuration {
+		return &config.Configuration{
+			Port:           freePort(t),
+			DBType:         model.SqliteDBType,
+			DBConfigString: filepath.Join(dir, "focalboard.db"),
+			FilesDriver:    "local",
+			FilesPath:      filepath.Join(dir, "files"),
+			WebPath:        webPath,
+		}
+	}
+
+	t.Run("everything passes", func(t *testing.T) {
+		report := RunSelfCheck(newConfig())
+		require.False(t, report.HasFailures(), report.String())
+		for _, result := range report.Results {
+			require.Equal(t, SelfCheckPass, result.Status, result.Name)
+		}
+	})
+
+	t.Run("port in use", func(t *testing.T) {
+		cfg := newConfig()
+		listener, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		defer listener.Close()
+		cfg.Port = listener.Addr().(*net.TCPAddr).Port
+
+		report := RunSelfCheck(cfg)
+		require.True(t, report.HasFailures())
+		require.Contains(t, report.String(), "stop the process using port")
+	})
+
+	t.Run("unsupported database type", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.DBType = "oracle"
+
+		report := RunSelfCheck(cfg)
+		require.True(t, report.HasFailures())
+		require.Contains(t, report.String(), "set dbtype to sqlite3, postgres or mysql")
+	})
+
+	t.Run("unwritable files path only warns unless required", func(t *testing.T) {
+		filesPath := filepath.Join(dir, "not-a-dir")
+		require.NoError(t, os.WriteFile(filesPath, []byte{}, 0600))
+
+		cfg := newConfig()
+		cfg.FilesPath = filesPath
+		report := RunSelfCheck(cfg)
+		require.False(t, report.HasFailures())
+		require.Contains(t, report.String(), "[WARN] Files path")
+
+		cfg.FilesBackendRequired = true
+		report = RunSelfCheck(cfg)
+		require.True(t, report.HasFailures())
+		require.Contains(t, report.String(), "[FAIL] Files path")
+	})
+
+	t.Run("missing web client only warns", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.WebPath = filepath.Join(dir, "missing")
+
+		report := RunSelfCheck(cfg)
+		require.False(t, report.HasFailures())
+		require.Contains(t, report.String(), "[WARN] Web path")
+	})
+}
+
+// freePort returns a port that was free when the function ran.
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/mattermost/focalboard/server/services/notify/notifylogger"
 	"github.com/mattermost/focalboard/server/services/scheduler"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/store/sessionlayer"
 	"github.com/mattermost/focalboard/server/services/store/sqlstore"
 	"github.com/mattermost/focalboard/server/services/telemetry"
 	"github.com/mattermost/focalboard/server/services/webhook"
@@ -38,8 +39,10 @@ import (
 )
 
 const (
-	cleanupSessionTaskFrequency = 10 * time.Minute
-	updateMetricsTaskFrequency  = 15 * time.Minute
+	cleanupSessionTaskFrequency      = 10 * time.Minute
+	cleanupEmptyTeamsTaskFrequency   = 1 * time.Hour
+	compactBlockHistoryTaskFrequency = 1 * time.Hour
+	updateMetricsTaskFrequency       = 15 * time.Minute
 
 	minSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
 
@@ -51,10 +54,13 @@ type Server struct {
 	wsAdapter              ws.Adapter
 	webServer              *web.Server
 	store                  store.Store
-	filesBackend           filestore.FileBackend
+	filesBackend           *app.FilesBackend
 	telemetry              *telemetry.Service
 	logger                 mlog.LoggerIFace
 	cleanUpSessionsTask    *scheduler.ScheduledTask
+	cleanUpEmptyTeamsTask  *scheduler.ScheduledTask
+	compactHistoryTask     *scheduler.ScheduledTask
+	filesBackendCheckTask  *scheduler.ScheduledTask
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
@@ -64,6 +70,7 @@ type Server struct {
 
 	localRouter     *mux.Router
 	localModeServer *http.Server
+	profilerServer  *http.Server
 	api             *api.API
 	app             *app.App
 }
@@ -78,32 +85,22 @@ func New(params Params) (*Server, error) {
 	// if no ws adapter is provided, we spin up a websocket server
 	wsAdapter := params.WSAdapter
 	if wsAdapter == nil {
-		wsAdapter = ws.NewServer(authenticator, params.SingleUserToken, params.Cfg.AuthMode == MattermostAuthMod, params.Logger, params.DBStore)
+		wsAdapter = ws.NewServer(authenticator, params.SingleUserToken, params.Cfg.AuthMode == MattermostAuthMod, params.Logger, params.DBStore, params.Cfg.WebsocketBroadcastWorkers, time.Duration(params.Cfg.WebsocketSubscriptionTTL)*time.Second)
 	}
 
-	filesBackendSettings := filestore.FileBackendSettings{}
-	filesBackendSettings.DriverName = params.Cfg.FilesDriver
-	filesBackendSettings.Directory = params.Cfg.FilesPath
-	filesBackendSettings.AmazonS3AccessKeyId = params.Cfg.FilesS3Config.AccessKeyID
-	filesBackendSettings.AmazonS3SecretAccessKey = params.Cfg.FilesS3Config.SecretAccessKey
-	filesBackendSettings.AmazonS3Bucket = params.Cfg.FilesS3Config.Bucket
-	filesBackendSettings.AmazonS3PathPrefix = params.Cfg.FilesS3Config.PathPrefix
-	filesBackendSettings.AmazonS3Region = params.Cfg.FilesS3Config.Region
-	filesBackendSettings.AmazonS3Endpoint = params.Cfg.FilesS3Config.Endpoint
-	filesBackendSettings.AmazonS3SSL = params.Cfg.FilesS3Config.SSL
-	filesBackendSettings.AmazonS3SignV2 = params.Cfg.FilesS3Config.SignV2
-	filesBackendSettings.AmazonS3SSE = params.Cfg.FilesS3Config.SSE
-	filesBackendSettings.AmazonS3Trace = params.Cfg.FilesS3Config.Trace
-	filesBackendSettings.AmazonS3RequestTimeoutMilliseconds = params.Cfg.FilesS3Config.Timeout
-
-	filesBackend, appErr := filestore.NewFileBackend(filesBackendSettings)
-	if appErr != nil {
+	filesBackendSettings := newFilesBackendSettings(params.Cfg)
+
+	// the server keeps running when the files storage is unreachable,
+	// only the file endpoints are unavailable until it's back
+	filesBackend, appErr := app.NewFilesBackend(filesBackendSettings, params.Logger)
+	if appErr != nil && params.Cfg.FilesBackendRequired {
 		params.Logger.Error("Unable to initialize the files storage", mlog.Err(appErr))
 
 		return nil, errors.New("unable to initialize the files storage")
 	}
 
 	webhookClient := webhook.NewClient(params.Cfg, params.Logger)
+	webhookClient.SetTeamWebhookStore(params.DBStore)
 
 	// Init metrics
 	instanceInfo := metrics.InstanceInfo{
@@ -139,24 +136,50 @@ func New(params Params) (*Server, error) {
 		Logger:           params.Logger,
 		Permissions:      params.PermissionsService,
 		ServicesAPI:      params.ServicesAPI,
+		BlockHooks:       params.BlockHooks,
 		SkipTemplateInit: utils.IsRunningUnitTests(),
 	}
 	app := app.New(params.Cfg, wsAdapter, appServices)
 
+	// the users authenticated by a trusted proxy have no stored session
+	// to authenticate the websocket with, so the upgrade request is
+	// authenticated with the proxy header instead
+	if wsServer, ok := wsAdapter.(*ws.Server); ok {
+		wsServer.SetRequestAuthenticator(app.GetTrustedAuthUserID)
+	}
+
 	focalboardAPI := api.NewAPI(app, params.SingleUserToken, params.Cfg.AuthMode, params.PermissionsService, params.Logger, auditService, params.IsPlugin)
 
 	// Local router for admin APIs
 	localRouter := mux.NewRouter()
 	focalboardAPI.RegisterAdminRoutes(localRouter)
 
-	// Init team
-	if _, err := app.GetRootTeam(); err != nil {
-		params.Logger.Error("Unable to get root team", mlog.Err(err))
-		return nil, err
+	// In single user mode there is only ever the root team, so it is
+	// initialized eagerly. Otherwise the standalone teams are created
+	// lazily through app.EnsureTeam when their first board is created,
+	// imported, duplicated or moved to them.
+	if len(params.SingleUserToken) > 0 {
+		if _, err := app.GetRootTeam(); err != nil {
+			params.Logger.Error("Unable to get root team", mlog.Err(err))
+			return nil, err
+		}
 	}
 
+	webTimeouts := web.Timeouts{
+		Read:       time.Duration(params.Cfg.WebReadTimeout) * time.Second,
+		ReadHeader: time.Duration(params.Cfg.WebReadHeaderTimeout) * time.Second,
+		Write:      time.Duration(params.Cfg.WebWriteTimeout) * time.Second,
+		Idle:       time.Duration(params.Cfg.WebIdleTimeout) * time.Second,
+	}
 	webServer := web.NewServer(params.Cfg.WebPath, params.Cfg.ServerRoot, params.Cfg.Port,
-		params.Cfg.UseSSL, params.Cfg.LocalOnly, params.Logger)
+		params.Cfg.UseSSL, params.Cfg.LocalOnly, params.Cfg.StaticCacheMaxAge, webTimeouts, params.Logger)
+	// the API clears the timeouts of the long-lived routes through the
+	// connection of their requests
+	webServer.ConnContext = api.SetContextConn
+	if params.Cfg.UseSSL {
+		// the TLS settings were validated with the params
+		webServer.TLSConfig, _ = web.NewTLSConfig(params.Cfg.MinTLSVersion, params.Cfg.TLSCipherSuites)
+	}
 	// if the adapter is a routed service, register it before the API
 	if routedService, ok := wsAdapter.(web.RoutedService); ok {
 		webServer.AddRoutes(routedService)
@@ -208,8 +231,42 @@ func New(params Params) (*Server, error) {
 	return &server, nil
 }
 
+// newFilesBackendSettings returns the files storage settings of the
+// configuration.
+func newFilesBackendSettings(cfg *config.Configuration) filestore.FileBackendSettings {
+	filesBackendSettings := filestore.FileBackendSettings{}
+	filesBackendSettings.DriverName = cfg.FilesDriver
+	filesBackendSettings.Directory = cfg.FilesPath
+	filesBackendSettings.AmazonS3AccessKeyId = cfg.FilesS3Config.AccessKeyID
+	filesBackendSettings.AmazonS3SecretAccessKey = cfg.FilesS3Config.SecretAccessKey
+	filesBackendSettings.AmazonS3Bucket = cfg.FilesS3Config.Bucket
+	filesBackendSettings.AmazonS3PathPrefix = cfg.FilesS3Config.PathPrefix
+	filesBackendSettings.AmazonS3Region = cfg.FilesS3Config.Region
+	filesBackendSettings.AmazonS3Endpoint = cfg.FilesS3Config.Endpoint
+	filesBackendSettings.AmazonS3SSL = cfg.FilesS3Config.SSL
+	filesBackendSettings.AmazonS3SignV2 = cfg.FilesS3Config.SignV2
+	filesBackendSettings.AmazonS3SSE = cfg.FilesS3Config.SSE
+	filesBackendSettings.AmazonS3Trace = cfg.FilesS3Config.Trace
+	filesBackendSettings.AmazonS3RequestTimeoutMilliseconds = cfg.FilesS3Config.Timeout
+	return filesBackendSettings
+}
+
 func NewStore(config *config.Configuration, isSingleUser bool, logger mlog.LoggerIFace) (store.Store, error) {
-	sqlDB, err := sql.Open(config.DBType, config.DBConfigString)
+	connectionString := config.DBConfigString
+	if config.DBUseTLS {
+		var err error
+		connectionString, err = sqlstore.ApplyDBTLSConfig(config.DBType, connectionString, sqlstore.DBTLSConfig{
+			CACertFile:     config.DBTLSCACert,
+			ClientCertFile: config.DBTLSClientCert,
+			ClientKeyFile:  config.DBTLSClientKey,
+		})
+		if err != nil {
+			logger.Error("cannot configure the database TLS", mlog.Err(err))
+			return nil, err
+		}
+	}
+
+	sqlDB, err := sql.Open(config.DBType, connectionString)
 	if err != nil {
 		logger.Error("connectDatabase failed", mlog.Err(err))
 		return nil, err
@@ -223,12 +280,21 @@ func NewStore(config *config.Configuration, isSingleUser bool, logger mlog.Logge
 
 	storeParams := sqlstore.Params{
 		DBType:           config.DBType,
-		ConnectionString: config.DBConfigString,
+		ConnectionString: connectionString,
 		TablePrefix:      config.DBTablePrefix,
 		Logger:           logger,
 		DB:               sqlDB,
 		IsPlugin:         false,
 		IsSingleUser:     isSingleUser,
+		SkipMigrations:   !config.RunMigrations,
+
+		// when the migrations run separately, the server should
+		// not start against an outdated schema.
+		CheckSchemaVersion: !config.RunMigrations,
+		SlowQueryThreshold: time.Duration(config.SlowQueryThreshold) * time.Millisecond,
+		JSONBFields:        config.PostgresJSONBFields,
+		TransactionRetries: config.DBTransactionRetries,
+		MaxBlockTreeDepth:  config.MaxBlockTreeDepth,
 	}
 
 	var db store.Store
@@ -236,7 +302,16 @@ func NewStore(config *config.Configuration, isSingleUser bool, logger mlog.Logge
 	if err != nil {
 		return nil, err
 	}
-	return db, nil
+	return withSessionStore(db, config.SessionStore), nil
+}
+
+// withSessionStore wraps the store to keep the sessions in memory when
+// the session store setting asks for it.
+func withSessionStore(db store.Store, sessionStore string) store.Store {
+	if sessionStore == config.SessionStoreMemory {
+		return sessionlayer.New(db, sessionlayer.NewMemoryStore())
+	}
+	return db
 }
 
 func (s *Server) Start() error {
@@ -253,6 +328,12 @@ func (s *Server) Start() error {
 		}
 	}
 
+	if s.config.EnableProfiler {
+		if err := s.startProfilerServer(); err != nil {
+			return err
+		}
+	}
+
 	if s.config.AuthMode != MattermostAuthMod {
 		s.cleanUpSessionsTask = scheduler.CreateRecurringTask("cleanUpSessions", func() {
 			secondsAgo := minSessionExpiryTime
@@ -264,6 +345,28 @@ func (s *Server) Start() error {
 				s.logger.Error("Unable to clean up the sessions", mlog.Err(err))
 			}
 		}, cleanupSessionTaskFrequency)
+
+		if s.config.EmptyTeamCleanupDays > 0 {
+			s.cleanUpEmptyTeamsTask = scheduler.CreateRecurringTask("cleanUpEmptyTeams", func() {
+				if _, err := s.app.CleanUpEmptyTeams(); err != nil {
+					s.logger.Error("Unable to clean up the empty teams", mlog.Err(err))
+				}
+			}, cleanupEmptyTeamsTaskFrequency)
+		}
+	}
+
+	if s.config.EnableBlockHistoryCompaction {
+		s.compactHistoryTask = scheduler.CreateRecurringTask("compactBlockHistory", func() {
+			if _, err := s.app.CompactBlockHistory(); err != nil {
+				s.logger.Error("Unable to compact the block history", mlog.Err(err))
+			}
+		}, compactBlockHistoryTaskFrequency)
+	}
+
+	if s.config.FilesBackendCheckInterval > 0 {
+		s.filesBackendCheckTask = scheduler.CreateRecurringTask("checkFilesBackend", func() {
+			_ = s.filesBackend.CheckConnection()
+		}, time.Duration(s.config.FilesBackendCheckInterval)*time.Second)
 	}
 
 	metricsUpdater := func() {
@@ -317,12 +420,34 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// PrepareShutdown marks the server as draining, which makes the
+// readiness endpoint fail, and waits for the configured delay so the load
+// balancers stop routing requests to the server before it shuts down.
+func (s *Server) PrepareShutdown() {
+	s.webServer.SetDraining()
+
+	delay := time.Duration(s.config.PreShutdownDelay) * time.Second
+	if delay <= 0 {
+		return
+	}
+
+	s.logger.Info("Waiting before shutting down the server", mlog.Int("delay_seconds", s.config.PreShutdownDelay))
+	time.Sleep(delay)
+}
+
 func (s *Server) Shutdown() error {
+	// the websocket connections are hijacked from the web server, so
+	// they need to be closed separately
+	if wsServer, ok := s.wsAdapter.(*ws.Server); ok {
+		wsServer.Shutdown()
+	}
+
 	if err := s.webServer.Shutdown(); err != nil {
 		return err
 	}
 
 	s.stopLocalModeServer()
+	s.stopProfilerServer()
 
 	s.servicesStartStopMutex.Lock()
 	defer s.servicesStartStopMutex.Unlock()
@@ -331,10 +456,22 @@ func (s *Server) Shutdown() error {
 		s.cleanUpSessionsTask.Cancel()
 	}
 
+	if s.cleanUpEmptyTeamsTask != nil {
+		s.cleanUpEmptyTeamsTask.Cancel()
+	}
+
+	if s.compactHistoryTask != nil {
+		s.compactHistoryTask.Cancel()
+	}
+
 	if s.metricsUpdaterTask != nil {
 		s.metricsUpdaterTask.Cancel()
 	}
 
+	if s.filesBackendCheckTask != nil {
+		s.filesBackendCheckTask.Cancel()
+	}
+
 	if err := s.telemetry.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
 	}
@@ -432,6 +569,8 @@ type telemetryOptions struct {
 
 func initTelemetry(opts telemetryOptions) *telemetry.Service {
 	telemetryService := telemetry.New(opts.telemetryID, opts.logger)
+	telemetryService.SetTrackerLimits(opts.cfg.TelemetryConcurrency, time.Duration(opts.cfg.TelemetryTrackerTimeout)*time.Second)
+	telemetryService.SetEventQueueLimits(time.Duration(opts.cfg.TelemetryEventFlushInterval)*time.Second, opts.cfg.TelemetryEventBatchSize)
 
 	telemetryService.RegisterTracker("server", func() (telemetry.Tracker, error) {
 		return map[string]interface{}{
@@ -462,20 +601,13 @@ func initTelemetry(opts telemetryOptions) *telemetry.Service {
 		}
 		m["registered_users"] = count
 
-		if count, err = opts.app.GetDailyActiveUsers(); err != nil {
-			return nil, err
-		}
-		m["daily_active_users"] = count
-
-		if count, err = opts.app.GetWeeklyActiveUsers(); err != nil {
-			return nil, err
-		}
-		m["weekly_active_users"] = count
-
-		if count, err = opts.app.GetMonthlyActiveUsers(); err != nil {
+		activeUsers, err := opts.app.GetActiveUsersStats()
+		if err != nil {
 			return nil, err
 		}
-		m["monthly_active_users"] = count
+		m["daily_active_users"] = activeUsers.DailyActiveUsers
+		m["weekly_active_users"] = activeUsers.WeeklyActiveUsers
+		m["monthly_active_users"] = activeUsers.MonthlyActiveUsers
 		return m, nil
 	})
 	telemetryService.RegisterTracker("blocks", func() (telemetry.Tracker, error) {
@@ -17,6 +17,8 @@ const (
 	KeyClusterID = "cluster_id"
 	KeyTeamID    = "team_id"
 
+	KeyImpersonatedBy = "impersonated_by"
+
 	Success = "success"
 	Attempt = "attempt"
 	Fail    = "fail"
@@ -1,6 +1,9 @@
 package auth
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 var emailRegex = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 
@@ -11,3 +14,25 @@ func IsEmailValid(e string) bool {
 	}
 	return emailRegex.MatchString(e)
 }
+
+// IsEmailDomainAllowed checks if the domain of the email is one of the
+// allowed domains. The comparison is case insensitive and an empty list
+// allows every domain.
+func IsEmailDomainAllowed(e string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(e, "@")
+	if at < 0 {
+		return false
+	}
+	domain := e[at+1:]
+
+	for _, allowed := range allowedDomains {
+		if strings.EqualFold(domain, strings.TrimPrefix(strings.TrimSpace(allowed), "@")) {
+			return true
+		}
+	}
+	return false
+}
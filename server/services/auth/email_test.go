@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEmailDomainAllowed(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Email          string
+		AllowedDomains []string
+		Expected       bool
+	}{
+		"No restriction": {
+			Email:    "user@example.org",
+			Expected: true,
+		},
+		"Allowed domain": {
+			Email:          "user@example.com",
+			AllowedDomains: []string{"example.org", "example.com"},
+			Expected:       true,
+		},
+		"Case insensitive": {
+			Email:          "user@Example.COM",
+			AllowedDomains: []string{"example.com"},
+			Expected:       true,
+		},
+		"Domain with leading at sign": {
+			Email:          "user@example.com",
+			AllowedDomains: []string{"@example.com"},
+			Expected:       true,
+		},
+		"Other domain": {
+			Email:          "user@example.org",
+			AllowedDomains: []string{"example.com"},
+			Expected:       false,
+		},
+		"Subdomain": {
+			Email:          "user@mail.example.com",
+			AllowedDomains: []string{"example.com"},
+			Expected:       false,
+		},
+		"No domain": {
+			Email:          "user",
+			AllowedDomains: []string{"example.com"},
+			Expected:       false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, IsEmailDomainAllowed(tc.Email, tc.AllowedDomains))
+		})
+	}
+}
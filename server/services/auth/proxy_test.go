@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	t.Run("valid proxies", func(t *testing.T) {
+		networks, err := ParseTrustedProxies([]string{"10.0.0.0/8", " 192.168.1.10 ", "::1", "fd00::/8"})
+		require.NoError(t, err)
+		require.Len(t, networks, 4)
+		assert.Equal(t, "192.168.1.10/32", networks[1].String())
+		assert.Equal(t, "::1/128", networks[2].String())
+	})
+
+	t.Run("no proxies", func(t *testing.T) {
+		networks, err := ParseTrustedProxies(nil)
+		require.NoError(t, err)
+		require.Empty(t, networks)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := ParseTrustedProxies([]string{"10.0.0.0/8", "proxy.example.com"})
+		require.ErrorIs(t, err, ErrInvalidTrustedProxy)
+	})
+
+	t.Run("invalid network", func(t *testing.T) {
+		_, err := ParseTrustedProxies([]string{"10.0.0.0/33"})
+		require.ErrorIs(t, err, ErrInvalidTrustedProxy)
+	})
+}
+
+func TestIsTrustedProxy(t *testing.T) {
+	networks, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.10", "::1"})
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		RemoteAddr string
+		Expected   bool
+	}{
+		"Address in network":     {RemoteAddr: "10.1.2.3:51234", Expected: true},
+		"Single address":         {RemoteAddr: "192.168.1.10:443", Expected: true},
+		"IPv6 address":           {RemoteAddr: "[::1]:8000", Expected: true},
+		"Address without port":   {RemoteAddr: "10.1.2.3", Expected: true},
+		"Address out of network": {RemoteAddr: "192.168.1.11:443", Expected: false},
+		"Unix socket":            {RemoteAddr: "@", Expected: false},
+		"Empty address":          {RemoteAddr: "", Expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, IsTrustedProxy(tc.RemoteAddr, networks))
+		})
+	}
+
+	t.Run("no trusted proxies", func(t *testing.T) {
+		assert.False(t, IsTrustedProxy("10.1.2.3:51234", nil))
+	})
+}
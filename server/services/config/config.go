@@ -1,16 +1,41 @@
 package config
 
 import (
+	"fmt"
 	"log"
+	"os"
+	"regexp"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 const (
-	DefaultServerRoot = "http://localhost:8000"
-	DefaultPort       = 8000
+	DefaultServerRoot      = "http://localhost:8000"
+	DefaultPort            = 8000
+	DefaultProfilerAddress = "localhost:6060"
 )
 
+// Valid values for the SessionCookieSameSite setting.
+const (
+	SameSiteLax    = "lax"
+	SameSiteStrict = "strict"
+	SameSiteNone   = "none"
+)
+
+// Valid values for the SessionStore setting.
+const (
+	SessionStoreDatabase = "database"
+	SessionStoreMemory   = "memory"
+)
+
+// BlockTypeConfig registers a custom block type. RequiredFields lists
+// the keys that must be present in the fields of the blocks of this type.
+type BlockTypeConfig struct {
+	Type           string   `json:"type" mapstructure:"type"`
+	RequiredFields []string `json:"requiredFields" mapstructure:"requiredFields"`
+}
+
 type AmazonS3Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
@@ -27,33 +52,115 @@ type AmazonS3Config struct {
 
 // Configuration is the app configuration stored in a json file.
 type Configuration struct {
-	ServerRoot               string            `json:"serverRoot" mapstructure:"serverRoot"`
-	Port                     int               `json:"port" mapstructure:"port"`
-	DBType                   string            `json:"dbtype" mapstructure:"dbtype"`
-	DBConfigString           string            `json:"dbconfig" mapstructure:"dbconfig"`
-	DBTablePrefix            string            `json:"dbtableprefix" mapstructure:"dbtableprefix"`
-	UseSSL                   bool              `json:"useSSL" mapstructure:"useSSL"`
-	SecureCookie             bool              `json:"secureCookie" mapstructure:"secureCookie"`
-	WebPath                  string            `json:"webpath" mapstructure:"webpath"`
-	FilesDriver              string            `json:"filesdriver" mapstructure:"filesdriver"`
-	FilesS3Config            AmazonS3Config    `json:"filess3config" mapstructure:"filess3config"`
-	FilesPath                string            `json:"filespath" mapstructure:"filespath"`
-	MaxFileSize              int64             `json:"maxfilesize" mapstructure:"mafilesize"`
-	Telemetry                bool              `json:"telemetry" mapstructure:"telemetry"`
-	TelemetryID              string            `json:"telemetryid" mapstructure:"telemetryid"`
-	PrometheusAddress        string            `json:"prometheusaddress" mapstructure:"prometheusaddress"`
-	WebhookUpdate            []string          `json:"webhook_update" mapstructure:"webhook_update"`
-	Secret                   string            `json:"secret" mapstructure:"secret"`
-	SessionExpireTime        int64             `json:"session_expire_time" mapstructure:"session_expire_time"`
-	SessionRefreshTime       int64             `json:"session_refresh_time" mapstructure:"session_refresh_time"`
-	LocalOnly                bool              `json:"localonly" mapstructure:"localonly"`
-	EnableLocalMode          bool              `json:"enableLocalMode" mapstructure:"enableLocalMode"`
-	LocalModeSocketLocation  string            `json:"localModeSocketLocation" mapstructure:"localModeSocketLocation"`
-	EnablePublicSharedBoards bool              `json:"enablePublicSharedBoards" mapstructure:"enablePublicSharedBoards"`
-	FeatureFlags             map[string]string `json:"featureFlags" mapstructure:"featureFlags"`
-	EnableDataRetention      bool              `json:"enable_data_retention" mapstructure:"enable_data_retention"`
-	DataRetentionDays        int               `json:"data_retention_days" mapstructure:"data_retention_days"`
-	TeammateNameDisplay      string            `json:"teammate_name_display" mapstructure:"teammateNameDisplay"`
+	ServerRoot                  string            `json:"serverRoot" mapstructure:"serverRoot"`
+	Port                        int               `json:"port" mapstructure:"port"`
+	DBType                      string            `json:"dbtype" mapstructure:"dbtype"`
+	DBConfigString              string            `json:"dbconfig" mapstructure:"dbconfig"`
+	DBTablePrefix               string            `json:"dbtableprefix" mapstructure:"dbtableprefix"`
+	DBUseTLS                    bool              `json:"dbusetls" mapstructure:"dbusetls"`
+	DBTLSCACert                 string            `json:"dbtlscacert" mapstructure:"dbtlscacert"`
+	DBTLSClientCert             string            `json:"dbtlsclientcert" mapstructure:"dbtlsclientcert"`
+	DBTLSClientKey              string            `json:"dbtlsclientkey" mapstructure:"dbtlsclientkey"`
+	UseSSL                      bool              `json:"useSSL" mapstructure:"useSSL"`
+	MinTLSVersion               string            `json:"min_tls_version" mapstructure:"min_tls_version"`
+	TLSCipherSuites             []string          `json:"tls_cipher_suites" mapstructure:"tls_cipher_suites"`
+	SecureCookie                bool              `json:"secureCookie" mapstructure:"secureCookie"`
+	WebPath                     string            `json:"webpath" mapstructure:"webpath"`
+	FilesDriver                 string            `json:"filesdriver" mapstructure:"filesdriver"`
+	FilesS3Config               AmazonS3Config    `json:"filess3config" mapstructure:"filess3config"`
+	FilesPath                   string            `json:"filespath" mapstructure:"filespath"`
+	MaxFileSize                 int64             `json:"maxfilesize" mapstructure:"mafilesize"`
+	Telemetry                   bool              `json:"telemetry" mapstructure:"telemetry"`
+	TelemetryID                 string            `json:"telemetryid" mapstructure:"telemetryid"`
+	TelemetryConcurrency        int               `json:"telemetry_concurrency" mapstructure:"telemetry_concurrency"`
+	TelemetryTrackerTimeout     int               `json:"telemetry_tracker_timeout" mapstructure:"telemetry_tracker_timeout"`
+	TelemetryEventFlushInterval int               `json:"telemetry_event_flush_interval" mapstructure:"telemetry_event_flush_interval"`
+	TelemetryEventBatchSize     int               `json:"telemetry_event_batch_size" mapstructure:"telemetry_event_batch_size"`
+	PrometheusAddress           string            `json:"prometheusaddress" mapstructure:"prometheusaddress"`
+	WebhookUpdate               []string          `json:"webhook_update" mapstructure:"webhook_update"`
+	Secret                      string            `json:"secret" mapstructure:"secret"`
+	SessionExpireTime           int64             `json:"session_expire_time" mapstructure:"session_expire_time"`
+	SessionRefreshTime          int64             `json:"session_refresh_time" mapstructure:"session_refresh_time"`
+	SessionMaxLifetime          int64             `json:"session_max_lifetime" mapstructure:"session_max_lifetime"`
+	ImpersonationLifetime       int64             `json:"impersonation_lifetime" mapstructure:"impersonation_lifetime"`
+	TrustedAuthHeader           string            `json:"trusted_auth_header" mapstructure:"trusted_auth_header"`
+	TrustedAuthEmailHeader      string            `json:"trusted_auth_email_header" mapstructure:"trusted_auth_email_header"`
+	TrustedProxies              []string          `json:"trusted_proxies" mapstructure:"trusted_proxies"`
+	LocalOnly                   bool              `json:"localonly" mapstructure:"localonly"`
+	EnableLocalMode             bool              `json:"enableLocalMode" mapstructure:"enableLocalMode"`
+	LocalModeSocketLocation     string            `json:"localModeSocketLocation" mapstructure:"localModeSocketLocation"`
+	EnablePublicSharedBoards    bool              `json:"enablePublicSharedBoards" mapstructure:"enablePublicSharedBoards"`
+	FeatureFlags                map[string]string `json:"featureFlags" mapstructure:"featureFlags"`
+	EnableDataRetention         bool              `json:"enable_data_retention" mapstructure:"enable_data_retention"`
+	DataRetentionDays           int               `json:"data_retention_days" mapstructure:"data_retention_days"`
+	TeammateNameDisplay         string            `json:"teammate_name_display" mapstructure:"teammateNameDisplay"`
+	ReadOnlyMode                bool              `json:"readonly_mode" mapstructure:"readonly_mode"`
+	ServerTimezone              string            `json:"server_timezone" mapstructure:"server_timezone"`
+	SlowQueryThreshold          int64             `json:"slow_query_threshold" mapstructure:"slow_query_threshold"`
+	PostgresJSONBFields         bool              `json:"postgres_jsonb_fields" mapstructure:"postgres_jsonb_fields"`
+	DBTransactionRetries        int               `json:"db_transaction_retries" mapstructure:"db_transaction_retries"`
+	MaxBlockTreeDepth           int               `json:"max_block_tree_depth" mapstructure:"max_block_tree_depth"`
+	EnableChannelBoardAccess    bool              `json:"enable_channel_board_access" mapstructure:"enable_channel_board_access"`
+	SessionCookieSameSite       string            `json:"session_cookie_samesite" mapstructure:"session_cookie_samesite"`
+	MaxConcurrentUploads        int               `json:"max_concurrent_uploads" mapstructure:"max_concurrent_uploads"`
+	StaticCacheMaxAge           int               `json:"static_cache_max_age" mapstructure:"static_cache_max_age"`
+	EnableProfiler              bool              `json:"enable_profiler" mapstructure:"enable_profiler"`
+	ProfilerAddress             string            `json:"profiler_address" mapstructure:"profiler_address"`
+	CustomBlockTypes            []BlockTypeConfig `json:"custom_block_types" mapstructure:"custom_block_types"`
+	RunMigrations               bool              `json:"run_migrations" mapstructure:"run_migrations"`
+	WebReadTimeout              int               `json:"web_read_timeout" mapstructure:"web_read_timeout"`
+	WebReadHeaderTimeout        int               `json:"web_read_header_timeout" mapstructure:"web_read_header_timeout"`
+	WebWriteTimeout             int               `json:"web_write_timeout" mapstructure:"web_write_timeout"`
+	WebIdleTimeout              int               `json:"web_idle_timeout" mapstructure:"web_idle_timeout"`
+	RequestTimeout              int               `json:"request_timeout" mapstructure:"request_timeout"`
+	WebsocketBroadcastWorkers   int               `json:"websocket_broadcast_workers" mapstructure:"websocket_broadcast_workers"`
+	WebsocketSubscriptionTTL    int               `json:"websocket_subscription_ttl" mapstructure:"websocket_subscription_ttl"`
+
+	ActiveUsersStatsRefreshInterval int `json:"active_users_stats_refresh_interval" mapstructure:"active_users_stats_refresh_interval"`
+
+	WebhookUpdateDebounceMillis  int      `json:"webhook_update_debounce_millis" mapstructure:"webhook_update_debounce_millis"`
+	UserBoardViewDebounceMillis  int      `json:"user_board_view_debounce_millis" mapstructure:"user_board_view_debounce_millis"`
+	WebhookUpdateTemplate        string   `json:"webhook_update_template" mapstructure:"webhook_update_template"`
+	WebhookAllowedHosts          []string `json:"webhook_allowed_hosts" mapstructure:"webhook_allowed_hosts"`
+	WebhookAllowPrivateAddresses bool     `json:"webhook_allow_private_addresses" mapstructure:"webhook_allow_private_addresses"`
+
+	ImageTranscodeFormat       string `json:"image_transcode_format" mapstructure:"image_transcode_format"`
+	ImageTranscodeKeepOriginal bool   `json:"image_transcode_keep_original" mapstructure:"image_transcode_keep_original"`
+
+	DeduplicateUploads bool `json:"deduplicate_uploads" mapstructure:"deduplicate_uploads"`
+
+	MaxPropertiesPerBoard int `json:"max_properties_per_board" mapstructure:"max_properties_per_board"`
+	MaxBoardsPerTeam      int `json:"max_boards_per_team" mapstructure:"max_boards_per_team"`
+	MaxCommentLength      int `json:"max_comment_length" mapstructure:"max_comment_length"`
+	CommentRateLimit      int `json:"comment_rate_limit" mapstructure:"comment_rate_limit"`
+	MaxRecentBoards       int `json:"max_recent_boards" mapstructure:"max_recent_boards"`
+
+	MaxBulkItems    int   `json:"max_bulk_items" mapstructure:"max_bulk_items"`
+	MaxBulkBodySize int64 `json:"max_bulk_body_size" mapstructure:"max_bulk_body_size"`
+
+	MaxBlockSizes map[string]int `json:"max_block_sizes" mapstructure:"max_block_sizes"`
+
+	DefaultBoardVisibility string                   `json:"default_board_visibility" mapstructure:"default_board_visibility"`
+	DefaultCardProperties  []map[string]interface{} `json:"default_card_properties" mapstructure:"default_card_properties"`
+
+	SubtaskDeleteMode string `json:"subtask_delete_mode" mapstructure:"subtask_delete_mode"`
+
+	DefaultLocale string `json:"default_locale" mapstructure:"default_locale"`
+
+	SessionStore string `json:"session_store" mapstructure:"session_store"`
+
+	FilesBackendRequired      bool `json:"files_backend_required" mapstructure:"files_backend_required"`
+	FilesBackendCheckInterval int  `json:"files_backend_check_interval" mapstructure:"files_backend_check_interval"`
+
+	EmptyTeamCleanupDays int `json:"empty_team_cleanup_days" mapstructure:"empty_team_cleanup_days"`
+
+	EnableBlockHistoryCompaction bool `json:"enable_block_history_compaction" mapstructure:"enable_block_history_compaction"`
+	BlockHistoryKeepAllHours     int  `json:"block_history_keep_all_hours" mapstructure:"block_history_keep_all_hours"`
+	BlockHistoryKeepHourlyDays   int  `json:"block_history_keep_hourly_days" mapstructure:"block_history_keep_hourly_days"`
+
+	PreShutdownDelay int `json:"pre_shutdown_delay" mapstructure:"pre_shutdown_delay"`
+
+	AllowedRegistrationDomains []string `json:"allowed_registration_domains" mapstructure:"allowed_registration_domains"`
 
 	AuthMode string `json:"authMode" mapstructure:"authMode"`
 
@@ -82,15 +189,25 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("DBType", "sqlite3")
 	viper.SetDefault("DBConfigString", "./focalboard.db")
 	viper.SetDefault("DBTablePrefix", "")
+	viper.SetDefault("DBUseTLS", false)
 	viper.SetDefault("SecureCookie", false)
 	viper.SetDefault("WebPath", "./pack")
 	viper.SetDefault("FilesPath", "./files")
 	viper.SetDefault("FilesDriver", "local")
 	viper.SetDefault("Telemetry", true)
 	viper.SetDefault("TelemetryID", "")
+	viper.SetDefault("TelemetryConcurrency", 4)         // trackers gathered in parallel
+	viper.SetDefault("TelemetryTrackerTimeout", 30)     // in seconds
+	viper.SetDefault("TelemetryEventFlushInterval", 60) // in seconds
+	viper.SetDefault("TelemetryEventBatchSize", 100)    // events sent right away once queued
 	viper.SetDefault("WebhookUpdate", nil)
 	viper.SetDefault("SessionExpireTime", 60*60*24*30) // 30 days session lifetime
 	viper.SetDefault("SessionRefreshTime", 60*60*5)    // 5 minutes session refresh
+	viper.SetDefault("SessionMaxLifetime", 0)          // 0 means no absolute lifetime
+	viper.SetDefault("ImpersonationLifetime", 30*60)   // 30 minutes, 0 disables the impersonation
+	viper.SetDefault("TrustedAuthHeader", "")          // empty disables the header-based authentication
+	viper.SetDefault("TrustedAuthEmailHeader", "")
+	viper.SetDefault("TrustedProxies", []string{})
 	viper.SetDefault("LocalOnly", false)
 	viper.SetDefault("EnableLocalMode", false)
 	viper.SetDefault("LocalModeSocketLocation", "/var/tmp/focalboard_local.socket")
@@ -103,6 +220,64 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("DataRetentionDays", 365) // 1 year is default
 	viper.SetDefault("PrometheusAddress", "")
 	viper.SetDefault("TeammateNameDisplay", "username")
+	viper.SetDefault("ReadOnlyMode", false)
+	viper.SetDefault("ServerTimezone", "UTC")
+	viper.SetDefault("SlowQueryThreshold", 0) // in milliseconds, 0 disables the slow query log
+	viper.SetDefault("PostgresJSONBFields", false)
+	viper.SetDefault("DBTransactionRetries", 3)         // 0 disables the retries
+	viper.SetDefault("MaxBlockTreeDepth", 100)          // levels walked by the recursive block queries
+	viper.SetDefault("EnableChannelBoardAccess", false) // only applies when integrated with Mattermost
+	viper.SetDefault("SessionCookieSameSite", SameSiteLax)
+	viper.SetDefault("MaxConcurrentUploads", 0)         // 0 means no limit
+	viper.SetDefault("StaticCacheMaxAge", 60*60*24*365) // 1 year for hashed static assets
+	viper.SetDefault("EnableProfiler", false)
+	viper.SetDefault("ProfilerAddress", DefaultProfilerAddress)
+	viper.SetDefault("CustomBlockTypes", []BlockTypeConfig{})
+	viper.SetDefault("RunMigrations", true) // false expects the migrations to be run with the -migrate flag
+	viper.SetDefault("WebReadTimeout", 300) // in seconds, long enough for the file uploads
+	viper.SetDefault("WebReadHeaderTimeout", 10)
+	viper.SetDefault("WebWriteTimeout", 300)
+	viper.SetDefault("WebIdleTimeout", 60)
+	viper.SetDefault("RequestTimeout", 120)                    // in seconds, below WebWriteTimeout so the 503 can still be written
+	viper.SetDefault("ActiveUsersStatsRefreshInterval", 60*60) // in seconds, 0 disables the cache
+	viper.SetDefault("WebsocketBroadcastWorkers", 4)           // 0 sends the messages from the broadcasting goroutine
+	viper.SetDefault("WebsocketSubscriptionTTL", 30)           // in seconds, 0 doesn't restore the subscriptions on reconnect
+	viper.SetDefault("WebhookUpdateDebounceMillis", 2000)      // 0 disables the debouncing
+	viper.SetDefault("UserBoardViewDebounceMillis", 2000)      // 0 saves every view state change
+	viper.SetDefault("WebhookUpdateTemplate", "")              // empty sends the block as JSON
+	viper.SetDefault("MaxPropertiesPerBoard", 500)             // 0 disables the limit
+	viper.SetDefault("MaxBoardsPerTeam", 0)                    // 0 disables the limit
+	viper.SetDefault("MaxCommentLength", 10000)                // in characters, 0 disables the limit
+	viper.SetDefault("CommentRateLimit", 30)                   // comments per minute of a user on a board, 0 disables the limit
+	viper.SetDefault("MaxRecentBoards", 20)                    // recently opened boards kept per user, 0 disables them
+	viper.SetDefault("MaxBulkItems", 5000)                     // boards and blocks per bulk request, 0 disables the limit
+	viper.SetDefault("MaxBulkBodySize", 50*1024*1024)          // in bytes, 0 disables the limit
+	viper.SetDefault("MaxBlockSizes", map[string]int{          // in bytes by block type, types without a size have no limit
+		"card":     64 * 1024,
+		"checkbox": 64 * 1024,
+		"text":     256 * 1024,
+		"view":     1024 * 1024,
+	})
+	viper.SetDefault("DefaultBoardVisibility", "private")                 // visibility of the boards created without a type
+	viper.SetDefault("DefaultCardProperties", []map[string]interface{}{}) // card properties of the boards created without any
+	viper.SetDefault("AllowedRegistrationDomains", []string{})            // empty allows every domain
+	viper.SetDefault("WebhookAllowedHosts", []string{})                   // empty allows every host
+	viper.SetDefault("WebhookAllowPrivateAddresses", false)
+	viper.SetDefault("SubtaskDeleteMode", "cascade") // or detach to keep the subtasks of the deleted cards
+	viper.SetDefault("ImageTranscodeFormat", "")     // empty stores the images as uploaded
+	viper.SetDefault("ImageTranscodeKeepOriginal", false)
+	viper.SetDefault("DeduplicateUploads", false)
+	viper.SetDefault("DefaultLocale", "en") // locale of the content generated by the server
+	viper.SetDefault("SessionStore", SessionStoreDatabase)
+	viper.SetDefault("FilesBackendRequired", false)
+	viper.SetDefault("FilesBackendCheckInterval", 60) // in seconds, 0 disables the checks
+	viper.SetDefault("EmptyTeamCleanupDays", 0)       // 0 disables the cleanup
+	viper.SetDefault("EnableBlockHistoryCompaction", false)
+	viper.SetDefault("BlockHistoryKeepAllHours", 24)  // every version of the last hours is kept
+	viper.SetDefault("BlockHistoryKeepHourlyDays", 7) // then one version per hour, and one per day after it
+	viper.SetDefault("PreShutdownDelay", 0)           // in seconds, 0 shuts down right away
+	viper.SetDefault("MinTLSVersion", "1.2")
+	viper.SetDefault("TLSCipherSuites", []string{}) // empty uses the Go defaults
 
 	err := viper.ReadInConfig() // Find and read the config file
 	if err != nil {             // Handle errors reading the config file
@@ -119,9 +294,73 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	log.Println("readConfigFile")
 	log.Printf("%+v", removeSecurityData(configuration))
 
+	// secrets are resolved after logging the configuration so they are
+	// never printed
+	configuration.DBConfigString, err = ResolveSecrets(configuration.DBConfigString)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve the secrets of dbconfig: %w", err)
+	}
+
 	return &configuration, nil
 }
 
+var secretReferenceRegexp = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// ResolveSecrets expands the references to environment variables, like
+// ${DB_PASSWORD}, and to files, like ${file:/run/secrets/db_password},
+// so the credentials don't need to be stored in the config file. It
+// fails if a referenced variable is not set or a file cannot be read.
+func ResolveSecrets(value string) (string, error) {
+	var resolveErr error
+	resolved := secretReferenceRegexp.ReplaceAllStringFunc(value, func(ref string) string {
+		if resolveErr != nil {
+			return ref
+		}
+
+		name := secretReferenceRegexp.FindStringSubmatch(ref)[1]
+		if path := strings.TrimPrefix(name, "file:"); path != name {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				resolveErr = fmt.Errorf("cannot read secret file %s: %w", path, err)
+				return ref
+			}
+			return strings.TrimRight(string(data), "\r\n")
+		}
+
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			resolveErr = fmt.Errorf("environment variable %s is not set", name)
+			return ref
+		}
+		return secret
+	})
+
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+// IsValidSameSite returns true if the value is a supported SameSite
+// setting for the session cookie. An empty value means the default.
+func IsValidSameSite(value string) bool {
+	switch strings.ToLower(value) {
+	case "", SameSiteLax, SameSiteStrict, SameSiteNone:
+		return true
+	}
+	return false
+}
+
+// IsValidSessionStore returns true if the value is a supported session
+// store. An empty value means the database.
+func IsValidSessionStore(value string) bool {
+	switch value {
+	case "", SessionStoreDatabase, SessionStoreMemory:
+		return true
+	}
+	return false
+}
+
 func removeSecurityData(config Configuration) Configuration {
 	clean := config
 	return clean
@@ -0,0 +1,168 @@
+// Package i18n provides the translations of the content generated by the
+// server, such as the notifications, independently of the translations
+// of the webapp.
+package i18n
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// DefaultLocale is the locale used when no other locale is configured,
+// and the last fallback for missing translations.
+const DefaultLocale = "en"
+
+//go:embed translations
+var translations embed.FS
+
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// Bundle holds the messages of all the supported locales. The messages
+// are text templates whose params are referenced as {{.Name}}.
+type Bundle struct {
+	defaultLocale string
+	messages      map[string]map[string]string
+}
+
+// NewBundle loads the embedded message bundles. The default locale is
+// used for the missing translations and for the users without a
+// preferred locale.
+func NewBundle(defaultLocale string) (*Bundle, error) {
+	messages, err := loadMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{messages: messages}
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+
+	locale, ok := b.resolveLocale(defaultLocale)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLocale, defaultLocale)
+	}
+	b.defaultLocale = locale
+
+	return b, nil
+}
+
+func loadMessages() (map[string]map[string]string, error) {
+	entries, err := translations.ReadDir("translations")
+	if err != nil {
+		return nil, err
+	}
+
+	messages := map[string]map[string]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || path.Ext(name) != ".json" {
+			continue
+		}
+
+		data, readErr := translations.ReadFile(path.Join("translations", name))
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		localeMessages := map[string]string{}
+		if err = json.Unmarshal(data, &localeMessages); err != nil {
+			return nil, fmt.Errorf("cannot parse translation file %s: %w", name, err)
+		}
+		messages[normalizeLocale(strings.TrimSuffix(name, ".json"))] = localeMessages
+	}
+
+	return messages, nil
+}
+
+// IsSupportedLocale returns true if there is a message bundle for the
+// locale or for its language.
+func IsSupportedLocale(locale string) bool {
+	b, err := NewBundle(DefaultLocale)
+	if err != nil {
+		return false
+	}
+	_, ok := b.resolveLocale(locale)
+	return ok
+}
+
+// Locales returns the supported locales, sorted.
+func (b *Bundle) Locales() []string {
+	locales := make([]string, 0, len(b.messages))
+	for locale := range b.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// DefaultLocale returns the locale used for the missing translations.
+func (b *Bundle) DefaultLocale() string {
+	return b.defaultLocale
+}
+
+// Message returns the raw message for the locale. If the locale has no
+// translation for it, the language of the locale and then the default
+// locale are tried. Returns false if no bundle has the message.
+func (b *Bundle) Message(locale, id string) (string, bool) {
+	candidates := []string{b.defaultLocale, DefaultLocale}
+	if resolved, ok := b.resolveLocale(locale); ok {
+		candidates = append([]string{resolved}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		if message, ok := b.messages[candidate][id]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// T returns the message for the locale with its params replaced. If no
+// bundle has the message, its ID is returned.
+func (b *Bundle) T(locale, id string, params map[string]interface{}) string {
+	message, ok := b.Message(locale, id)
+	if !ok {
+		return id
+	}
+
+	tmpl, err := template.New(id).Option("missingkey=zero").Parse(message)
+	if err != nil {
+		return message
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, params); err != nil {
+		return message
+	}
+	return buf.String()
+}
+
+// resolveLocale returns the bundle locale for the locale, which is
+// either the locale itself or its language.
+func (b *Bundle) resolveLocale(locale string) (string, bool) {
+	locale = normalizeLocale(locale)
+	if _, ok := b.messages[locale]; ok {
+		return locale, true
+	}
+
+	if language, _, found := strings.Cut(locale, "_"); found {
+		if _, ok := b.messages[language]; ok {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// normalizeLocale converts the locale to the form used by the bundle
+// files, so pt-BR, pt_BR and pt_br are the same locale.
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "-", "_")
+}
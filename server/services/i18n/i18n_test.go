@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBundle(t *testing.T) {
+	t.Run("default locale", func(t *testing.T) {
+		b, err := NewBundle("")
+		require.NoError(t, err)
+		require.Equal(t, DefaultLocale, b.DefaultLocale())
+		require.Contains(t, b.Locales(), "es")
+	})
+
+	t.Run("regional variant of a supported language", func(t *testing.T) {
+		b, err := NewBundle("es-MX")
+		require.NoError(t, err)
+		require.Equal(t, "es", b.DefaultLocale())
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		_, err := NewBundle("xx")
+		require.ErrorIs(t, err, ErrUnsupportedLocale)
+	})
+}
+
+func TestBundleT(t *testing.T) {
+	b, err := NewBundle(DefaultLocale)
+	require.NoError(t, err)
+	params := map[string]interface{}{"Authors": "@jane"}
+
+	testCases := []struct {
+		name     string
+		locale   string
+		id       string
+		expected string
+	}{
+		{"english", "en", "notify.subscription.comment_by", "Comment by @jane"},
+		{"spanish", "es", "notify.subscription.comment_by", "Comentario de @jane"},
+		{"regional variant", "es_ES", "notify.subscription.title", "Título"},
+		{"unsupported locale falls back", "fr", "notify.subscription.title", "Title"},
+		{"empty locale falls back", "", "notify.subscription.description", "Description"},
+		{"unknown message", "es", "notify.unknown", "notify.unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, b.T(tc.locale, tc.id, params))
+		})
+	}
+
+	t.Run("default locale is used before english", func(t *testing.T) {
+		esBundle, esErr := NewBundle("es")
+		require.NoError(t, esErr)
+		require.Equal(t, "Título", esBundle.T("fr", "notify.subscription.title", nil))
+		require.Equal(t, "Title", esBundle.T("en", "notify.subscription.title", nil))
+	})
+}
+
+func TestIsSupportedLocale(t *testing.T) {
+	for _, locale := range []string{"en", "es", "ES", "es-AR", "en_US"} {
+		require.True(t, IsSupportedLocale(locale), locale)
+	}
+
+	for _, locale := range []string{"", "xx", "fr"} {
+		require.False(t, IsSupportedLocale(locale), locale)
+	}
+}
@@ -13,6 +13,7 @@ const (
 	MetricsSubsystemBoards = "boards"
 	MetricsSubsystemTeams  = "teams"
 	MetricsSubsystemSystem = "system"
+	MetricsSubsystemFiles  = "files"
 
 	MetricsCloudInstallationLabel = "installationId"
 )
@@ -44,6 +45,8 @@ type Metrics struct {
 	teamCount  prometheus.Gauge
 
 	blockLastActivity prometheus.Gauge
+
+	uploadsInFlight prometheus.Gauge
 }
 
 // NewMetrics Factory method to create a new metrics collector.
@@ -172,6 +175,15 @@ func NewMetrics(info InstanceInfo) *Metrics {
 	})
 	m.registry.MustRegister(m.blockLastActivity)
 
+	m.uploadsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemFiles,
+		Name:        "uploads_in_flight",
+		Help:        "Number of file uploads currently being processed.",
+		ConstLabels: additionalLabels,
+	})
+	m.registry.MustRegister(m.uploadsInFlight)
+
 	return m
 }
 
@@ -214,6 +226,18 @@ func (m *Metrics) IncrementBlocksDeleted(num int) {
 	}
 }
 
+func (m *Metrics) IncrementUploadsInFlight() {
+	if m != nil {
+		m.uploadsInFlight.Inc()
+	}
+}
+
+func (m *Metrics) DecrementUploadsInFlight() {
+	if m != nil {
+		m.uploadsInFlight.Dec()
+	}
+}
+
 func (m *Metrics) ObserveBlockCount(blockType string, count int64) {
 	if m != nil {
 		m.blockCount.WithLabelValues(blockType).Set(float64(count))
@@ -12,6 +12,7 @@ import (
 	"text/template"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/i18n"
 	"github.com/wiggin77/merror"
 
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
@@ -23,6 +24,15 @@ const (
 	defAddCardNotify    = "{{.Authors | printAuthors \"unknown_user\" }} has added the card {{. | makeLink}}\n"
 	defModifyCardNotify = "###### {{.Authors | printAuthors \"unknown_user\" }} has modified the card {{. | makeLink}} on the board {{. | makeBoardLink}}\n"
 	defDeleteCardNotify = "{{.Authors | printAuthors \"unknown_user\" }} has deleted the card {{. | makeLink}}\n"
+
+	// message IDs of the translations.
+	addCardNotifyMessageID    = "notify.subscription.add_card"
+	modifyCardNotifyMessageID = "notify.subscription.modify_card"
+	deleteCardNotifyMessageID = "notify.subscription.delete_card"
+	titleFieldMessageID       = "notify.subscription.title"
+	descriptionFieldMessageID = "notify.subscription.description"
+	commentByFieldMessageID   = "notify.subscription.comment_by"
+	unknownUserMessageID      = "notify.unknown_user"
 )
 
 var (
@@ -34,11 +44,21 @@ var (
 // DiffConvOpts provides options when converting diffs to slack attachments.
 type DiffConvOpts struct {
 	Language      string
+	Bundle        *i18n.Bundle
 	MakeCardLink  func(block *model.Block, board *model.Board, card *model.Block) string
 	MakeBoardLink func(board *model.Board) string
 	Logger        mlog.LoggerIFace
 }
 
+// translate returns the message translated to the language of the
+// options, or the default message if there are no translations.
+func (opts DiffConvOpts) translate(messageID string, def string, params map[string]interface{}) string {
+	if opts.Bundle == nil {
+		return def
+	}
+	return opts.Bundle.T(opts.Language, messageID, params)
+}
+
 // getTemplate returns a new or cached named template based on the language specified.
 func getTemplate(name string, opts DiffConvOpts, def string) (*template.Template, error) {
 	templateCacheMux.Lock()
@@ -77,7 +97,12 @@ func getTemplate(name string, opts DiffConvOpts, def string) (*template.Template
 		}
 		t.Funcs(myFuncs)
 
-		s := def // TODO: lookup i18n string when supported on server
+		s := def
+		if opts.Bundle != nil {
+			if message, ok := opts.Bundle.Message(opts.Language, name); ok {
+				s = message
+			}
+		}
 		t2, err := t.Parse(s)
 		if err != nil {
 			return nil, fmt.Errorf("cannot parse markdown template '%s' for notifications: %w", key, err)
@@ -144,7 +169,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 
 	// card added
 	if cardDiff.NewBlock != nil && cardDiff.OldBlock == nil {
-		if err := execTemplate(buf, "AddCardNotify", opts, defAddCardNotify, cardDiff); err != nil {
+		if err := execTemplate(buf, addCardNotifyMessageID, opts, defAddCardNotify, cardDiff); err != nil {
 			return nil, err
 		}
 		attachment.Pretext = buf.String()
@@ -155,7 +180,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 	// card deleted
 	if (cardDiff.NewBlock == nil || cardDiff.NewBlock.DeleteAt != 0) && cardDiff.OldBlock != nil {
 		buf.Reset()
-		if err := execTemplate(buf, "DeleteCardNotify", opts, defDeleteCardNotify, cardDiff); err != nil {
+		if err := execTemplate(buf, deleteCardNotifyMessageID, opts, defDeleteCardNotify, cardDiff); err != nil {
 			return nil, err
 		}
 		attachment.Pretext = buf.String()
@@ -174,7 +199,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 	)
 
 	buf.Reset()
-	if err := execTemplate(buf, "ModifyCardNotify", opts, defModifyCardNotify, cardDiff); err != nil {
+	if err := execTemplate(buf, modifyCardNotifyMessageID, opts, defModifyCardNotify, cardDiff); err != nil {
 		return nil, fmt.Errorf("cannot write notification for card %s: %w", cardDiff.NewBlock.ID, err)
 	}
 	attachment.Pretext = buf.String()
@@ -184,7 +209,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 	if cardDiff.NewBlock.Title != cardDiff.OldBlock.Title {
 		attachment.Fields = append(attachment.Fields, &mm_model.SlackAttachmentField{
 			Short: false,
-			Title: "Title",
+			Title: opts.translate(titleFieldMessageID, "Title", nil),
 			Value: fmt.Sprintf("%s  ~~`%s`~~", stripNewlines(cardDiff.NewBlock.Title), stripNewlines(cardDiff.OldBlock.Title)),
 		})
 	}
@@ -229,9 +254,10 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 			}
 
 			if format != "" {
+				authors := makeAuthorsList(child.Authors, opts.translate(unknownUserMessageID, "unknown_user", nil))
 				attachment.Fields = append(attachment.Fields, &mm_model.SlackAttachmentField{
 					Short: false,
-					Title: "Comment by " + makeAuthorsList(child.Authors, "unknown_user"), // todo:  localize this when server has i18n
+					Title: opts.translate(commentByFieldMessageID, "Comment by "+authors, map[string]interface{}{"Authors": authors}),
 					Value: fmt.Sprintf(format, stripNewlines(block.Title)),
 				})
 			}
@@ -260,7 +286,7 @@ func cardDiff2SlackAttachment(cardDiff *Diff, opts DiffConvOpts) (*mm_model.Slac
 
 			attachment.Fields = append(attachment.Fields, &mm_model.SlackAttachmentField{
 				Short: false,
-				Title: "Description",
+				Title: opts.translate(descriptionFieldMessageID, "Description", nil),
 				Value: markdown,
 			})
 		}
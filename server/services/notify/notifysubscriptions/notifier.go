@@ -10,10 +10,12 @@ import (
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/i18n"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/wiggin77/merror"
 
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -35,6 +37,7 @@ type notifier struct {
 	store       AppAPI
 	permissions permissions.PermissionsService
 	delivery    SubscriptionDelivery
+	bundle      *i18n.Bundle
 	logger      mlog.LoggerIFace
 
 	hints chan *model.NotificationHint
@@ -49,6 +52,7 @@ func newNotifier(params BackendParams) *notifier {
 		store:       params.AppAPI,
 		permissions: params.Permissions,
 		delivery:    params.Delivery,
+		bundle:      params.Bundle,
 		logger:      params.Logger,
 		done:        nil,
 		hints:       make(chan *model.NotificationHint, hintQueueSize),
@@ -200,7 +204,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 	}
 
 	opts := DiffConvOpts{
-		Language: "en", // TODO: use correct language with i18n available on server.
+		Bundle: n.bundle,
 		MakeCardLink: func(block *model.Block, board *model.Board, card *model.Block) string {
 			return fmt.Sprintf("[%s](%s)", block.Title, utils.MakeCardLink(n.serverRoot, board.TeamID, board.ID, card.ID))
 		},
@@ -210,10 +214,13 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 		Logger: n.logger,
 	}
 
+	// the attachments are generated in the default locale first, and
+	// then for each of the other locales the subscribers prefer
 	attachments, err := Diffs2SlackAttachments(diffs, opts)
 	if err != nil {
 		return err
 	}
+	attachmentsByLocale := map[string][]*mm_model.SlackAttachment{"": attachments}
 
 	merr := merror.New()
 	if len(attachments) > 0 {
@@ -246,7 +253,19 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				mlog.String("subscriber_type", string(sub.SubscriberType)),
 			)
 
-			if err = n.delivery.SubscriptionDeliverSlackAttachments(board.TeamID, sub.SubscriberID, sub.SubscriberType, attachments); err != nil {
+			locale := n.getSubscriberLocale(sub)
+			localeAttachments, ok := attachmentsByLocale[locale]
+			if !ok {
+				opts.Language = locale
+				localeAttachments, err = Diffs2SlackAttachments(diffs, opts)
+				if err != nil {
+					merr.Append(fmt.Errorf("cannot generate notification for locale %s: %w", locale, err))
+					continue
+				}
+				attachmentsByLocale[locale] = localeAttachments
+			}
+
+			if err = n.delivery.SubscriptionDeliverSlackAttachments(board.TeamID, sub.SubscriberID, sub.SubscriberType, localeAttachments); err != nil {
 				merr.Append(fmt.Errorf("cannot deliver notification to subscriber %s [%s]: %w",
 					sub.SubscriberID, sub.SubscriberType, err))
 			}
@@ -279,3 +298,21 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 
 	return merr.ErrorOrNil()
 }
+
+// getSubscriberLocale returns the preferred locale of the subscriber, or
+// an empty string for the default locale.
+func (n *notifier) getSubscriberLocale(sub *model.Subscriber) string {
+	if sub.SubscriberType != model.SubTypeUser {
+		return ""
+	}
+
+	user, err := n.store.GetUserByID(sub.SubscriberID)
+	if err != nil {
+		n.logger.Warn("notifySubscribers - cannot get the subscriber locale",
+			mlog.String("subscriber_id", sub.SubscriberID),
+			mlog.Err(err),
+		)
+		return ""
+	}
+	return user.Locale
+}
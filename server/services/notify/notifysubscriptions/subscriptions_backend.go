@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/i18n"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/wiggin77/merror"
@@ -24,6 +25,7 @@ type BackendParams struct {
 	AppAPI                 AppAPI
 	Permissions            permissions.PermissionsService
 	Delivery               SubscriptionDelivery
+	Bundle                 *i18n.Bundle
 	Logger                 mlog.LoggerIFace
 	NotifyFreqCardSeconds  int
 	NotifyFreqBoardSeconds int
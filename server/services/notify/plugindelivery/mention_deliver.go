@@ -29,7 +29,7 @@ func (pd *PluginDelivery) MentionDeliver(mentionedUser *mm_model.User, extract s
 	post := &mm_model.Post{
 		UserId:    pd.botID,
 		ChannelId: channel.Id,
-		Message:   formatMessage(author.Username, extract, evt.Card.Title, link, evt.BlockChanged, boardLink, evt.Board.Title),
+		Message:   pd.formatMessage(mentionedUser.Locale, author.Username, extract, evt.Card.Title, link, evt.BlockChanged, boardLink, evt.Board.Title),
 	}
 
 	if _, err := pd.api.CreatePost(post); err != nil {
@@ -4,21 +4,26 @@
 package plugindelivery
 
 import (
-	"fmt"
-
 	"github.com/mattermost/focalboard/server/model"
 )
 
 const (
-	// TODO: localize these when i18n is available.
-	defCommentTemplate     = "@%s mentioned you in a comment on the card [%s](%s) in board [%s](%s)\n> %s"
-	defDescriptionTemplate = "@%s mentioned you in the card [%s](%s) in board [%s](%s)\n> %s"
+	mentionCommentMessageID     = "notify.mention.comment"
+	mentionDescriptionMessageID = "notify.mention.description"
 )
 
-func formatMessage(author string, extract string, card string, link string, block *model.Block, boardLink string, board string) string {
-	template := defDescriptionTemplate
+func (pd *PluginDelivery) formatMessage(locale string, author string, extract string, card string, link string, block *model.Block, boardLink string, board string) string {
+	messageID := mentionDescriptionMessageID
 	if block.Type == model.TypeComment {
-		template = defCommentTemplate
+		messageID = mentionCommentMessageID
 	}
-	return fmt.Sprintf(template, author, card, link, board, boardLink, extract)
+
+	return pd.bundle.T(locale, messageID, map[string]interface{}{
+		"Author":    author,
+		"Card":      card,
+		"CardLink":  link,
+		"Board":     board,
+		"BoardLink": boardLink,
+		"Extract":   extract,
+	})
 }
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMessage(t *testing.T) {
+	bundle, err := i18n.NewBundle(i18n.DefaultLocale)
+	require.NoError(t, err)
+	delivery := New("bot_id", "server_root", nil, bundle)
+
+	comment := &model.Block{Type: model.TypeComment}
+	text := &model.Block{Type: model.TypeText}
+
+	t.Run("english", func(t *testing.T) {
+		message := delivery.formatMessage("en", "jane", "hi @john", "Card", "http://card", comment, "http://board", "Board")
+		require.Equal(t, "@jane mentioned you in a comment on the card [Card](http://card) in board [Board](http://board)\n> hi @john", message)
+
+		message = delivery.formatMessage("en", "jane", "hi @john", "Card", "http://card", text, "http://board", "Board")
+		require.Equal(t, "@jane mentioned you in the card [Card](http://card) in board [Board](http://board)\n> hi @john", message)
+	})
+
+	t.Run("user locale", func(t *testing.T) {
+		message := delivery.formatMessage("es", "jane", "hola @john", "Card", "http://card", text, "http://board", "Board")
+		require.Equal(t, "@jane te ha mencionado en la tarjeta [Card](http://card) del tablero [Board](http://board)\n> hola @john", message)
+	})
+
+	t.Run("unsupported locale falls back to the default one", func(t *testing.T) {
+		message := delivery.formatMessage("fr", "jane", "hi @john", "Card", "http://card", text, "http://board", "Board")
+		require.Equal(t, "@jane mentioned you in the card [Card](http://card) in board [Board](http://board)\n> hi @john", message)
+	})
+}
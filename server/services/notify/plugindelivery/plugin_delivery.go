@@ -4,6 +4,8 @@
 package plugindelivery
 
 import (
+	"github.com/mattermost/focalboard/server/services/i18n"
+
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
 )
 
@@ -40,13 +42,16 @@ type PluginDelivery struct {
 	botID      string
 	serverRoot string
 	api        servicesAPI
+	bundle     *i18n.Bundle
 }
 
-// New creates a PluginDelivery instance.
-func New(botID string, serverRoot string, api servicesAPI) *PluginDelivery {
+// New creates a PluginDelivery instance. The messages are translated to
+// the locale of their recipients with the bundle.
+func New(botID string, serverRoot string, api servicesAPI, bundle *i18n.Bundle) *PluginDelivery {
 	return &PluginDelivery{
 		botID:      botID,
 		serverRoot: serverRoot,
 		api:        api,
+		bundle:     bundle,
 	}
 }
@@ -46,7 +46,7 @@ var (
 
 func Test_userByUsername(t *testing.T) {
 	servicesAPI := newServicesAPIMock(mockUsers)
-	delivery := New("bot_id", "server_root", servicesAPI)
+	delivery := New("bot_id", "server_root", servicesAPI, nil)
 
 	tests := []struct {
 		name    string
@@ -27,6 +27,10 @@ func (s *Service) HasPermissionToTeam(userID, teamID string, permission *mmModel
 	if userID == "" || teamID == "" || permission == nil {
 		return false
 	}
+	// there are no team admins on standalone installations
+	if permission == model.PermissionManageTeam {
+		return false
+	}
 	return true
 }
 
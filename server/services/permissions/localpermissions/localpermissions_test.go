@@ -27,6 +27,11 @@ func TestHasPermissionToTeam(t *testing.T) {
 		hasPermission := th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageBoardCards)
 		assert.True(t, hasPermission)
 	})
+
+	t.Run("no user can manage the team", func(t *testing.T) {
+		hasPermission := th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageTeam)
+		assert.False(t, hasPermission)
+	})
 }
 
 func TestHasPermissionToBoard(t *testing.T) {
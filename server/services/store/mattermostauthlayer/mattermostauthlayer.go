@@ -51,17 +51,22 @@ type MattermostAuthLayer struct {
 	logger      mlog.LoggerIFace
 	servicesAPI servicesAPI
 	tablePrefix string
+
+	// enableChannelBoardAccess gives the members of the channel a
+	// board is linked to access to the board
+	enableChannelBoardAccess bool
 }
 
 // New creates a new SQL implementation of the store.
-func New(dbType string, db *sql.DB, store store.Store, logger mlog.LoggerIFace, api servicesAPI, tablePrefix string) (*MattermostAuthLayer, error) {
+func New(dbType string, db *sql.DB, store store.Store, logger mlog.LoggerIFace, api servicesAPI, tablePrefix string, enableChannelBoardAccess bool) (*MattermostAuthLayer, error) {
 	layer := &MattermostAuthLayer{
-		Store:       store,
-		dbType:      dbType,
-		mmDB:        db,
-		logger:      logger,
-		servicesAPI: api,
-		tablePrefix: tablePrefix,
+		Store:                    store,
+		dbType:                   dbType,
+		mmDB:                     db,
+		logger:                   logger,
+		servicesAPI:              api,
+		tablePrefix:              tablePrefix,
+		enableChannelBoardAccess: enableChannelBoardAccess,
 	}
 
 	return layer, nil
@@ -236,6 +241,40 @@ func (s *MattermostAuthLayer) GetActiveUserCount(updatedSecondsAgo int64) (int,
 	return count, nil
 }
 
+// GetActiveUserCounts returns the number of users with active sessions
+// within each of the periods of N seconds ago.
+func (s *MattermostAuthLayer) GetActiveUserCounts(updatedSecondsAgo []int64) ([]int, error) {
+	if len(updatedSecondsAgo) == 0 {
+		return []int{}, nil
+	}
+
+	now := utils.GetMillis()
+	oldest := now
+	query := s.getQueryBuilder().Select()
+	for _, secondsAgo := range updatedSecondsAgo {
+		since := now - utils.SecondsToMillis(secondsAgo)
+		if since < oldest {
+			oldest = since
+		}
+		query = query.Column(sq.Expr("count(distinct case when LastActivityAt > ? then UserId end)", since))
+	}
+	query = query.
+		From("Sessions").
+		Where(sq.Gt{"LastActivityAt": oldest})
+
+	counts := make([]int, len(updatedSecondsAgo))
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+
+	if err := query.QueryRow().Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 func (s *MattermostAuthLayer) GetSession(token string, expireTime int64) (*model.Session, error) {
 	return nil, store.NewNotSupportedError("sessions not used when using mattermost")
 }
@@ -289,6 +328,10 @@ func (s *MattermostAuthLayer) GetTeam(id string) (*model.Team, error) {
 	return &model.Team{ID: id, Title: displayName}, nil
 }
 
+func (s *MattermostAuthLayer) DeleteEmptyTeams(updatedBefore int64) ([]string, error) {
+	return nil, store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
 // GetTeamsForUser retrieves all the teams that the user is a member of.
 func (s *MattermostAuthLayer) GetTeamsForUser(userID string) ([]*model.Team, error) {
 	query := s.getQueryBuilder().
@@ -520,6 +563,7 @@ func mmUserToFbUser(mmUser *mmModel.User) model.User {
 		IsBot:       mmUser.IsBot,
 		IsGuest:     mmUser.IsGuest(),
 		Roles:       mmUser.Roles,
+		Locale:      mmUser.Locale,
 	}
 }
 
@@ -627,6 +671,8 @@ func boardFields(prefix string) []string {
 		"template_version",
 		"COALESCE(properties, '{}')",
 		"COALESCE(card_properties, '[]')",
+		"COALESCE(card_template_id, '')",
+		"last_activity_at",
 		"create_at",
 		"update_at",
 		"delete_at",
@@ -725,6 +771,8 @@ func (s *MattermostAuthLayer) boardsFromRows(rows *sql.Rows) ([]*model.Board, er
 			&board.TemplateVersion,
 			&propertiesBytes,
 			&cardPropertiesBytes,
+			&board.CardTemplateID,
+			&board.LastActivityAt,
 			&board.CreateAt,
 			&board.UpdateAt,
 			&board.DeleteAt,
@@ -778,6 +826,11 @@ func (s *MattermostAuthLayer) implicitBoardMembershipsFromRows(rows *sql.Rows) (
 	return boardMembers, nil
 }
 
+// GetMemberForBoard returns the membership of a user to a board. If the
+// user is not an explicit member, the membership is resolved from the
+// channel the board is linked to, when channel board access is enabled,
+// and from the team for the open templates. The permissions services
+// check the board access through it.
 func (s *MattermostAuthLayer) GetMemberForBoard(boardID, userID string) (*model.BoardMember, error) {
 	bm, err := s.Store.GetMemberForBoard(boardID, userID)
 	if model.IsErrNotFound(err) {
@@ -785,7 +838,7 @@ func (s *MattermostAuthLayer) GetMemberForBoard(boardID, userID string) (*model.
 		if boardErr != nil {
 			return nil, boardErr
 		}
-		if b.ChannelID != "" {
+		if b.ChannelID != "" && s.enableChannelBoardAccess {
 			_, memberErr := s.servicesAPI.GetChannelMember(b.ChannelID, userID)
 			if memberErr != nil {
 				var appErr *mmModel.AppError
@@ -0,0 +1,84 @@
+package mattermostauthlayer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	mockservicesapi "github.com/mattermost/focalboard/server/model/mocks"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func setupAuthLayer(t *testing.T, enableChannelBoardAccess bool) (*MattermostAuthLayer, *mockstore.MockStore, *mockservicesapi.MockServicesAPI) {
+	ctrl := gomock.NewController(t)
+	mockStore := mockstore.NewMockStore(ctrl)
+	servicesAPI := mockservicesapi.NewMockServicesAPI(ctrl)
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	t.Cleanup(func() { _ = logger.Shutdown() })
+
+	layer, err := New("postgres", nil, mockStore, logger, servicesAPI, "focalboard_", enableChannelBoardAccess)
+	require.NoError(t, err)
+	return layer, mockStore, servicesAPI
+}
+
+func TestGetMemberForBoard(t *testing.T) {
+	const boardID = "board_id_1"
+	const userID = "user_id_1"
+	const channelID = "channel_id_1"
+
+	board := &model.Board{ID: boardID, TeamID: "team_id_1", ChannelID: channelID, Type: model.BoardTypePrivate}
+
+	t.Run("explicit member", func(t *testing.T) {
+		layer, mockStore, _ := setupAuthLayer(t, false)
+
+		boardMember := &model.BoardMember{BoardID: boardID, UserID: userID, SchemeAdmin: true}
+		mockStore.EXPECT().GetMemberForBoard(boardID, userID).Return(boardMember, nil)
+
+		member, err := layer.GetMemberForBoard(boardID, userID)
+		require.NoError(t, err)
+		require.Equal(t, boardMember, member)
+	})
+
+	t.Run("channel board access disabled", func(t *testing.T) {
+		layer, mockStore, _ := setupAuthLayer(t, false)
+
+		mockStore.EXPECT().GetMemberForBoard(boardID, userID).Return(nil, model.NewErrNotFound("member"))
+		mockStore.EXPECT().GetBoard(boardID).Return(board, nil)
+
+		member, err := layer.GetMemberForBoard(boardID, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, member)
+	})
+
+	t.Run("channel member", func(t *testing.T) {
+		layer, mockStore, servicesAPI := setupAuthLayer(t, true)
+
+		mockStore.EXPECT().GetMemberForBoard(boardID, userID).Return(nil, model.NewErrNotFound("member"))
+		mockStore.EXPECT().GetBoard(boardID).Return(board, nil)
+		servicesAPI.EXPECT().GetChannelMember(channelID, userID).Return(&mmModel.ChannelMember{}, nil)
+
+		member, err := layer.GetMemberForBoard(boardID, userID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeEditor)
+		require.True(t, member.Synthetic)
+	})
+
+	t.Run("not a channel member", func(t *testing.T) {
+		layer, mockStore, servicesAPI := setupAuthLayer(t, true)
+
+		mockStore.EXPECT().GetMemberForBoard(boardID, userID).Return(nil, model.NewErrNotFound("member"))
+		mockStore.EXPECT().GetBoard(boardID).Return(board, nil)
+		appErr := mmModel.NewAppError("GetChannelMember", "not_found", nil, "", http.StatusNotFound)
+		servicesAPI.EXPECT().GetChannelMember(channelID, userID).Return(nil, appErr)
+
+		member, err := layer.GetMemberForBoard(boardID, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, member)
+	})
+}
@@ -36,6 +36,20 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddBoardFavorite mocks base method.
+func (m *MockStore) AddBoardFavorite(arg0 *model.BoardFavorite) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBoardFavorite", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBoardFavorite indicates an expected call of AddBoardFavorite.
+func (mr *MockStoreMockRecorder) AddBoardFavorite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBoardFavorite", reflect.TypeOf((*MockStore)(nil).AddBoardFavorite), arg0)
+}
+
 // AddUpdateCategoryBoard mocks base method.
 func (m *MockStore) AddUpdateCategoryBoard(arg0, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -79,6 +93,35 @@ func (mr *MockStoreMockRecorder) CleanUpSessions(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpSessions", reflect.TypeOf((*MockStore)(nil).CleanUpSessions), arg0)
 }
 
+// CompactBlockHistory mocks base method.
+func (m *MockStore) CompactBlockHistory(arg0 *model.BlockHistoryCompactionPolicy, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactBlockHistory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompactBlockHistory indicates an expected call of CompactBlockHistory.
+func (mr *MockStoreMockRecorder) CompactBlockHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactBlockHistory", reflect.TypeOf((*MockStore)(nil).CompactBlockHistory), arg0, arg1)
+}
+
+// CreateBoardAPIKey mocks base method.
+func (m *MockStore) CreateBoardAPIKey(arg0 *model.BoardAPIKey, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBoardAPIKey indicates an expected call of CreateBoardAPIKey.
+func (mr *MockStoreMockRecorder) CreateBoardAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardAPIKey", reflect.TypeOf((*MockStore)(nil).CreateBoardAPIKey), arg0, arg1)
+}
+
 // CreateBoardsAndBlocks mocks base method.
 func (m *MockStore) CreateBoardsAndBlocks(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +167,20 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0)
 }
 
+// CreateInvite mocks base method.
+func (m *MockStore) CreateInvite(arg0 *model.Invite) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvite", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvite indicates an expected call of CreateInvite.
+func (mr *MockStoreMockRecorder) CreateInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvite", reflect.TypeOf((*MockStore)(nil).CreateInvite), arg0)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -153,6 +210,20 @@ func (mr *MockStoreMockRecorder) CreateSubscription(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStore)(nil).CreateSubscription), arg0)
 }
 
+// CreateTeamWebhook mocks base method.
+func (m *MockStore) CreateTeamWebhook(arg0 *model.TeamWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeamWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTeamWebhook indicates an expected call of CreateTeamWebhook.
+func (mr *MockStoreMockRecorder) CreateTeamWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamWebhook", reflect.TypeOf((*MockStore)(nil).CreateTeamWebhook), arg0)
+}
+
 // CreateUser mocks base method.
 func (m *MockStore) CreateUser(arg0 *model.User) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -168,6 +239,21 @@ func (mr *MockStoreMockRecorder) CreateUser(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0)
 }
 
+// CreateUserWithInvite mocks base method.
+func (m *MockStore) CreateUserWithInvite(arg0 *model.User, arg1 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserWithInvite", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserWithInvite indicates an expected call of CreateUserWithInvite.
+func (mr *MockStoreMockRecorder) CreateUserWithInvite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserWithInvite", reflect.TypeOf((*MockStore)(nil).CreateUserWithInvite), arg0, arg1)
+}
+
 // DBType mocks base method.
 func (m *MockStore) DBType() string {
 	m.ctrl.T.Helper()
@@ -196,6 +282,21 @@ func (mr *MockStoreMockRecorder) DeleteBlock(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockStore)(nil).DeleteBlock), arg0, arg1)
 }
 
+// DeleteBlocks mocks base method.
+func (m *MockStore) DeleteBlocks(arg0 []string, arg1 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlocks", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBlocks indicates an expected call of DeleteBlocks.
+func (mr *MockStoreMockRecorder) DeleteBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBlocks), arg0, arg1)
+}
+
 // DeleteBoard mocks base method.
 func (m *MockStore) DeleteBoard(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -210,6 +311,34 @@ func (mr *MockStoreMockRecorder) DeleteBoard(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockStore)(nil).DeleteBoard), arg0, arg1)
 }
 
+// DeleteBoardAPIKey mocks base method.
+func (m *MockStore) DeleteBoardAPIKey(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardAPIKey indicates an expected call of DeleteBoardAPIKey.
+func (mr *MockStoreMockRecorder) DeleteBoardAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardAPIKey", reflect.TypeOf((*MockStore)(nil).DeleteBoardAPIKey), arg0, arg1)
+}
+
+// DeleteBoardFavorite mocks base method.
+func (m *MockStore) DeleteBoardFavorite(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardFavorite", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardFavorite indicates an expected call of DeleteBoardFavorite.
+func (mr *MockStoreMockRecorder) DeleteBoardFavorite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardFavorite", reflect.TypeOf((*MockStore)(nil).DeleteBoardFavorite), arg0, arg1)
+}
+
 // DeleteBoardsAndBlocks mocks base method.
 func (m *MockStore) DeleteBoardsAndBlocks(arg0 *model.DeleteBoardsAndBlocks, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -238,6 +367,50 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2)
 }
 
+// DeleteEmptyTeams mocks base method.
+func (m *MockStore) DeleteEmptyTeams(arg0 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmptyTeams", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmptyTeams indicates an expected call of DeleteEmptyTeams.
+func (mr *MockStoreMockRecorder) DeleteEmptyTeams(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmptyTeams", reflect.TypeOf((*MockStore)(nil).DeleteEmptyTeams), arg0)
+}
+
+// DeleteFileReference mocks base method.
+func (m *MockStore) DeleteFileReference(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFileReference", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFileReference indicates an expected call of DeleteFileReference.
+func (mr *MockStoreMockRecorder) DeleteFileReference(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileReference", reflect.TypeOf((*MockStore)(nil).DeleteFileReference), arg0)
+}
+
+// DeleteInvite mocks base method.
+func (m *MockStore) DeleteInvite(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvite", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvite indicates an expected call of DeleteInvite.
+func (mr *MockStoreMockRecorder) DeleteInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvite", reflect.TypeOf((*MockStore)(nil).DeleteInvite), arg0)
+}
+
 // DeleteMember mocks base method.
 func (m *MockStore) DeleteMember(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -294,6 +467,34 @@ func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
 }
 
+// DeleteTeamFeatureFlag mocks base method.
+func (m *MockStore) DeleteTeamFeatureFlag(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeamFeatureFlag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeamFeatureFlag indicates an expected call of DeleteTeamFeatureFlag.
+func (mr *MockStoreMockRecorder) DeleteTeamFeatureFlag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeamFeatureFlag", reflect.TypeOf((*MockStore)(nil).DeleteTeamFeatureFlag), arg0, arg1)
+}
+
+// DeleteTeamWebhook mocks base method.
+func (m *MockStore) DeleteTeamWebhook(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeamWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeamWebhook indicates an expected call of DeleteTeamWebhook.
+func (mr *MockStoreMockRecorder) DeleteTeamWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeamWebhook", reflect.TypeOf((*MockStore)(nil).DeleteTeamWebhook), arg0)
+}
+
 // DuplicateBlock mocks base method.
 func (m *MockStore) DuplicateBlock(arg0, arg1, arg2 string, arg3 bool) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -340,6 +541,36 @@ func (mr *MockStoreMockRecorder) GetActiveUserCount(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCount", reflect.TypeOf((*MockStore)(nil).GetActiveUserCount), arg0)
 }
 
+// GetActiveUserCounts mocks base method.
+func (m *MockStore) GetActiveUserCounts(arg0 []int64) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveUserCounts", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveUserCounts indicates an expected call of GetActiveUserCounts.
+func (mr *MockStoreMockRecorder) GetActiveUserCounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCounts", reflect.TypeOf((*MockStore)(nil).GetActiveUserCounts), arg0)
+}
+
+// GetAdminAuditEntries mocks base method.
+func (m *MockStore) GetAdminAuditEntries(arg0 model.QueryAdminAuditOptions) ([]*model.AdminAuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAuditEntries", arg0)
+	ret0, _ := ret[0].([]*model.AdminAuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAuditEntries indicates an expected call of GetAdminAuditEntries.
+func (mr *MockStoreMockRecorder) GetAdminAuditEntries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAuditEntries", reflect.TypeOf((*MockStore)(nil).GetAdminAuditEntries), arg0)
+}
+
 // GetAllTeams mocks base method.
 func (m *MockStore) GetAllTeams() ([]*model.Team, error) {
 	m.ctrl.T.Helper()
@@ -415,6 +646,21 @@ func (mr *MockStoreMockRecorder) GetBlockHistoryDescendants(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistoryDescendants", reflect.TypeOf((*MockStore)(nil).GetBlockHistoryDescendants), arg0, arg1)
 }
 
+// GetBlockManifest mocks base method.
+func (m *MockStore) GetBlockManifest(arg0 string) ([]model.BlockManifestEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockManifest", arg0)
+	ret0, _ := ret[0].([]model.BlockManifestEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockManifest indicates an expected call of GetBlockManifest.
+func (mr *MockStoreMockRecorder) GetBlockManifest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockManifest", reflect.TypeOf((*MockStore)(nil).GetBlockManifest), arg0)
+}
+
 // GetBlocks mocks base method.
 func (m *MockStore) GetBlocks(arg0 model.QueryBlocksOptions) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -520,6 +766,36 @@ func (mr *MockStoreMockRecorder) GetBoard(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStore)(nil).GetBoard), arg0)
 }
 
+// GetBoardAPIKeyByHash mocks base method.
+func (m *MockStore) GetBoardAPIKeyByHash(arg0 string) (*model.BoardAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAPIKeyByHash", arg0)
+	ret0, _ := ret[0].(*model.BoardAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardAPIKeyByHash indicates an expected call of GetBoardAPIKeyByHash.
+func (mr *MockStoreMockRecorder) GetBoardAPIKeyByHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAPIKeyByHash", reflect.TypeOf((*MockStore)(nil).GetBoardAPIKeyByHash), arg0)
+}
+
+// GetBoardAPIKeys mocks base method.
+func (m *MockStore) GetBoardAPIKeys(arg0 string) ([]*model.BoardAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAPIKeys", arg0)
+	ret0, _ := ret[0].([]*model.BoardAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardAPIKeys indicates an expected call of GetBoardAPIKeys.
+func (mr *MockStoreMockRecorder) GetBoardAPIKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAPIKeys", reflect.TypeOf((*MockStore)(nil).GetBoardAPIKeys), arg0)
+}
+
 // GetBoardAndCard mocks base method.
 func (m *MockStore) GetBoardAndCard(arg0 *model.Block) (*model.Board, *model.Block, error) {
 	m.ctrl.T.Helper()
@@ -552,6 +828,21 @@ func (mr *MockStoreMockRecorder) GetBoardAndCardByID(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCardByID", reflect.TypeOf((*MockStore)(nil).GetBoardAndCardByID), arg0)
 }
 
+// GetBoardBlocksPage mocks base method.
+func (m *MockStore) GetBoardBlocksPage(arg0, arg1 string, arg2 uint64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardBlocksPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardBlocksPage indicates an expected call of GetBoardBlocksPage.
+func (mr *MockStoreMockRecorder) GetBoardBlocksPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardBlocksPage", reflect.TypeOf((*MockStore)(nil).GetBoardBlocksPage), arg0, arg1, arg2)
+}
+
 // GetBoardCount mocks base method.
 func (m *MockStore) GetBoardCount() (int64, error) {
 	m.ctrl.T.Helper()
@@ -582,6 +873,21 @@ func (mr *MockStoreMockRecorder) GetBoardHistory(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardHistory", reflect.TypeOf((*MockStore)(nil).GetBoardHistory), arg0, arg1)
 }
 
+// GetBoardIDBySlug mocks base method.
+func (m *MockStore) GetBoardIDBySlug(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardIDBySlug", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardIDBySlug indicates an expected call of GetBoardIDBySlug.
+func (mr *MockStoreMockRecorder) GetBoardIDBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardIDBySlug", reflect.TypeOf((*MockStore)(nil).GetBoardIDBySlug), arg0, arg1)
+}
+
 // GetBoardMemberHistory mocks base method.
 func (m *MockStore) GetBoardMemberHistory(arg0, arg1 string, arg2 uint64) ([]*model.BoardMemberHistoryEntry, error) {
 	m.ctrl.T.Helper()
@@ -597,6 +903,81 @@ func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
 }
 
+// GetBoardRollup mocks base method.
+func (m *MockStore) GetBoardRollup(arg0 string, arg1 model.QueryRollupOptions) ([]*model.RollupGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardRollup", arg0, arg1)
+	ret0, _ := ret[0].([]*model.RollupGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardRollup indicates an expected call of GetBoardRollup.
+func (mr *MockStoreMockRecorder) GetBoardRollup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardRollup", reflect.TypeOf((*MockStore)(nil).GetBoardRollup), arg0, arg1)
+}
+
+// GetBoardSlug mocks base method.
+func (m *MockStore) GetBoardSlug(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSlug", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSlug indicates an expected call of GetBoardSlug.
+func (mr *MockStoreMockRecorder) GetBoardSlug(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSlug", reflect.TypeOf((*MockStore)(nil).GetBoardSlug), arg0)
+}
+
+// GetBoardStats mocks base method.
+func (m *MockStore) GetBoardStats(arg0 string, arg1 model.QueryBoardStatsOptions) (*model.BoardStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardStats", arg0, arg1)
+	ret0, _ := ret[0].(*model.BoardStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardStats indicates an expected call of GetBoardStats.
+func (mr *MockStoreMockRecorder) GetBoardStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardStats", reflect.TypeOf((*MockStore)(nil).GetBoardStats), arg0, arg1)
+}
+
+// GetBoardUnreadCounts mocks base method.
+func (m *MockStore) GetBoardUnreadCounts(arg0 string, arg1 []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardUnreadCounts", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardUnreadCounts indicates an expected call of GetBoardUnreadCounts.
+func (mr *MockStoreMockRecorder) GetBoardUnreadCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardUnreadCounts", reflect.TypeOf((*MockStore)(nil).GetBoardUnreadCounts), arg0, arg1)
+}
+
+// GetBoardsForTeam mocks base method.
+func (m *MockStore) GetBoardsForTeam(arg0 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsForTeam", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsForTeam indicates an expected call of GetBoardsForTeam.
+func (mr *MockStoreMockRecorder) GetBoardsForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForTeam", reflect.TypeOf((*MockStore)(nil).GetBoardsForTeam), arg0)
+}
+
 // GetBoardsForUserAndTeam mocks base method.
 func (m *MockStore) GetBoardsForUserAndTeam(arg0, arg1 string, arg2 bool) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -642,6 +1023,21 @@ func (mr *MockStoreMockRecorder) GetCardLimitTimestamp() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp))
 }
 
+// GetCardProgress mocks base method.
+func (m *MockStore) GetCardProgress(arg0, arg1 string) (*model.CardProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardProgress", arg0, arg1)
+	ret0, _ := ret[0].(*model.CardProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardProgress indicates an expected call of GetCardProgress.
+func (mr *MockStoreMockRecorder) GetCardProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardProgress", reflect.TypeOf((*MockStore)(nil).GetCardProgress), arg0, arg1)
+}
+
 // GetCategory mocks base method.
 func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
 	m.ctrl.T.Helper()
@@ -687,6 +1083,51 @@ func (mr *MockStoreMockRecorder) GetCloudLimits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudLimits", reflect.TypeOf((*MockStore)(nil).GetCloudLimits))
 }
 
+// GetDeletedBlocksForBoard mocks base method.
+func (m *MockStore) GetDeletedBlocksForBoard(arg0 string, arg1 int64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedBlocksForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedBlocksForBoard indicates an expected call of GetDeletedBlocksForBoard.
+func (mr *MockStoreMockRecorder) GetDeletedBlocksForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedBlocksForBoard", reflect.TypeOf((*MockStore)(nil).GetDeletedBlocksForBoard), arg0, arg1)
+}
+
+// GetFavoriteBoardIDs mocks base method.
+func (m *MockStore) GetFavoriteBoardIDs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavoriteBoardIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavoriteBoardIDs indicates an expected call of GetFavoriteBoardIDs.
+func (mr *MockStoreMockRecorder) GetFavoriteBoardIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavoriteBoardIDs", reflect.TypeOf((*MockStore)(nil).GetFavoriteBoardIDs), arg0)
+}
+
+// GetFavoriteBoards mocks base method.
+func (m *MockStore) GetFavoriteBoards(arg0 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavoriteBoards", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavoriteBoards indicates an expected call of GetFavoriteBoards.
+func (mr *MockStoreMockRecorder) GetFavoriteBoards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavoriteBoards", reflect.TypeOf((*MockStore)(nil).GetFavoriteBoards), arg0)
+}
+
 // GetFileInfo mocks base method.
 func (m *MockStore) GetFileInfo(arg0 string) (*model0.FileInfo, error) {
 	m.ctrl.T.Helper()
@@ -702,6 +1143,51 @@ func (mr *MockStoreMockRecorder) GetFileInfo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockStore)(nil).GetFileInfo), arg0)
 }
 
+// GetFileReference mocks base method.
+func (m *MockStore) GetFileReference(arg0 string) (*model.FileReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileReference", arg0)
+	ret0, _ := ret[0].(*model.FileReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileReference indicates an expected call of GetFileReference.
+func (mr *MockStoreMockRecorder) GetFileReference(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileReference", reflect.TypeOf((*MockStore)(nil).GetFileReference), arg0)
+}
+
+// GetInvite mocks base method.
+func (m *MockStore) GetInvite(arg0 string) (*model.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvite", arg0)
+	ret0, _ := ret[0].(*model.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvite indicates an expected call of GetInvite.
+func (mr *MockStoreMockRecorder) GetInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvite", reflect.TypeOf((*MockStore)(nil).GetInvite), arg0)
+}
+
+// GetInvitesForTeam mocks base method.
+func (m *MockStore) GetInvitesForTeam(arg0 string) ([]*model.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitesForTeam", arg0)
+	ret0, _ := ret[0].([]*model.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitesForTeam indicates an expected call of GetInvitesForTeam.
+func (mr *MockStoreMockRecorder) GetInvitesForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitesForTeam", reflect.TypeOf((*MockStore)(nil).GetInvitesForTeam), arg0)
+}
+
 // GetLicense mocks base method.
 func (m *MockStore) GetLicense() *model0.License {
 	m.ctrl.T.Helper()
@@ -791,6 +1277,21 @@ func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
 }
 
+// GetRecentBoards mocks base method.
+func (m *MockStore) GetRecentBoards(arg0 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentBoards", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentBoards indicates an expected call of GetRecentBoards.
+func (mr *MockStoreMockRecorder) GetRecentBoards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentBoards", reflect.TypeOf((*MockStore)(nil).GetRecentBoards), arg0)
+}
+
 // GetRegisteredUserCount mocks base method.
 func (m *MockStore) GetRegisteredUserCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -956,6 +1457,21 @@ func (mr *MockStoreMockRecorder) GetTeam(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), arg0)
 }
 
+// GetTeamBoardCount mocks base method.
+func (m *MockStore) GetTeamBoardCount(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamBoardCount", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamBoardCount indicates an expected call of GetTeamBoardCount.
+func (mr *MockStoreMockRecorder) GetTeamBoardCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamBoardCount", reflect.TypeOf((*MockStore)(nil).GetTeamBoardCount), arg0)
+}
+
 // GetTeamBoardsInsights mocks base method.
 func (m *MockStore) GetTeamBoardsInsights(arg0, arg1 string, arg2 int64, arg3, arg4 int, arg5 []string) (*model.BoardInsightsList, error) {
 	m.ctrl.T.Helper()
@@ -986,6 +1502,51 @@ func (mr *MockStoreMockRecorder) GetTeamCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamCount", reflect.TypeOf((*MockStore)(nil).GetTeamCount))
 }
 
+// GetTeamFeatureFlags mocks base method.
+func (m *MockStore) GetTeamFeatureFlags(arg0 string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamFeatureFlags", arg0)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamFeatureFlags indicates an expected call of GetTeamFeatureFlags.
+func (mr *MockStoreMockRecorder) GetTeamFeatureFlags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamFeatureFlags", reflect.TypeOf((*MockStore)(nil).GetTeamFeatureFlags), arg0)
+}
+
+// GetTeamWebhook mocks base method.
+func (m *MockStore) GetTeamWebhook(arg0 string) (*model.TeamWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamWebhook", arg0)
+	ret0, _ := ret[0].(*model.TeamWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamWebhook indicates an expected call of GetTeamWebhook.
+func (mr *MockStoreMockRecorder) GetTeamWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamWebhook", reflect.TypeOf((*MockStore)(nil).GetTeamWebhook), arg0)
+}
+
+// GetTeamWebhooks mocks base method.
+func (m *MockStore) GetTeamWebhooks(arg0 string) ([]*model.TeamWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamWebhooks", arg0)
+	ret0, _ := ret[0].([]*model.TeamWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamWebhooks indicates an expected call of GetTeamWebhooks.
+func (mr *MockStoreMockRecorder) GetTeamWebhooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamWebhooks", reflect.TypeOf((*MockStore)(nil).GetTeamWebhooks), arg0)
+}
+
 // GetTeamsForUser mocks base method.
 func (m *MockStore) GetTeamsForUser(arg0 string) ([]*model.Team, error) {
 	m.ctrl.T.Helper()
@@ -1031,6 +1592,21 @@ func (mr *MockStoreMockRecorder) GetUsedCardsCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedCardsCount", reflect.TypeOf((*MockStore)(nil).GetUsedCardsCount))
 }
 
+// GetUserBoardView mocks base method.
+func (m *MockStore) GetUserBoardView(arg0, arg1 string) (*model.UserBoardView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBoardView", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserBoardView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBoardView indicates an expected call of GetUserBoardView.
+func (mr *MockStoreMockRecorder) GetUserBoardView(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBoardView", reflect.TypeOf((*MockStore)(nil).GetUserBoardView), arg0, arg1)
+}
+
 // GetUserBoardsInsights mocks base method.
 func (m *MockStore) GetUserBoardsInsights(arg0, arg1 string, arg2 int64, arg3, arg4 int, arg5 []string) (*model.BoardInsightsList, error) {
 	m.ctrl.T.Helper()
@@ -1166,6 +1742,20 @@ func (mr *MockStoreMockRecorder) GetUsersList(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersList", reflect.TypeOf((*MockStore)(nil).GetUsersList), arg0)
 }
 
+// InsertAdminAuditEntry mocks base method.
+func (m *MockStore) InsertAdminAuditEntry(arg0 *model.AdminAuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAdminAuditEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAdminAuditEntry indicates an expected call of InsertAdminAuditEntry.
+func (mr *MockStoreMockRecorder) InsertAdminAuditEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAdminAuditEntry", reflect.TypeOf((*MockStore)(nil).InsertAdminAuditEntry), arg0)
+}
+
 // InsertBlock mocks base method.
 func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1225,6 +1815,36 @@ func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
 }
 
+// MoveBoard mocks base method.
+func (m *MockStore) MoveBoard(arg0, arg1, arg2 string) (*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveBoard indicates an expected call of MoveBoard.
+func (mr *MockStoreMockRecorder) MoveBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBoard", reflect.TypeOf((*MockStore)(nil).MoveBoard), arg0, arg1, arg2)
+}
+
+// NextBoardSequence mocks base method.
+func (m *MockStore) NextBoardSequence(arg0 string, arg1 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextBoardSequence", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextBoardSequence indicates an expected call of NextBoardSequence.
+func (mr *MockStoreMockRecorder) NextBoardSequence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextBoardSequence", reflect.TypeOf((*MockStore)(nil).NextBoardSequence), arg0, arg1)
+}
+
 // PatchBlock mocks base method.
 func (m *MockStore) PatchBlock(arg0 string, arg1 *model.BlockPatch, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1355,6 +1975,20 @@ func (mr *MockStoreMockRecorder) RunDataRetention(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDataRetention", reflect.TypeOf((*MockStore)(nil).RunDataRetention), arg0, arg1)
 }
 
+// SaveBoardLastSeen mocks base method.
+func (m *MockStore) SaveBoardLastSeen(arg0 *model.BoardLastSeen) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBoardLastSeen", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBoardLastSeen indicates an expected call of SaveBoardLastSeen.
+func (mr *MockStoreMockRecorder) SaveBoardLastSeen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBoardLastSeen", reflect.TypeOf((*MockStore)(nil).SaveBoardLastSeen), arg0)
+}
+
 // SaveFileInfo mocks base method.
 func (m *MockStore) SaveFileInfo(arg0 *model0.FileInfo) error {
 	m.ctrl.T.Helper()
@@ -1369,6 +2003,20 @@ func (mr *MockStoreMockRecorder) SaveFileInfo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFileInfo", reflect.TypeOf((*MockStore)(nil).SaveFileInfo), arg0)
 }
 
+// SaveFileReference mocks base method.
+func (m *MockStore) SaveFileReference(arg0 *model.FileReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFileReference", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFileReference indicates an expected call of SaveFileReference.
+func (mr *MockStoreMockRecorder) SaveFileReference(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFileReference", reflect.TypeOf((*MockStore)(nil).SaveFileReference), arg0)
+}
+
 // SaveMember mocks base method.
 func (m *MockStore) SaveMember(arg0 *model.BoardMember) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
@@ -1384,6 +2032,34 @@ func (mr *MockStoreMockRecorder) SaveMember(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockStore)(nil).SaveMember), arg0)
 }
 
+// SaveRecentBoard mocks base method.
+func (m *MockStore) SaveRecentBoard(arg0 *model.RecentBoard, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRecentBoard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRecentBoard indicates an expected call of SaveRecentBoard.
+func (mr *MockStoreMockRecorder) SaveRecentBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecentBoard", reflect.TypeOf((*MockStore)(nil).SaveRecentBoard), arg0, arg1)
+}
+
+// SaveUserBoardView mocks base method.
+func (m *MockStore) SaveUserBoardView(arg0 *model.UserBoardView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserBoardView", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserBoardView indicates an expected call of SaveUserBoardView.
+func (mr *MockStoreMockRecorder) SaveUserBoardView(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserBoardView", reflect.TypeOf((*MockStore)(nil).SaveUserBoardView), arg0)
+}
+
 // SearchBoardsForUser mocks base method.
 func (m *MockStore) SearchBoardsForUser(arg0, arg1 string, arg2 bool) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -1458,6 +2134,20 @@ func (mr *MockStoreMockRecorder) SendMessage(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockStore)(nil).SendMessage), arg0, arg1, arg2)
 }
 
+// SetBoardSlug mocks base method.
+func (m *MockStore) SetBoardSlug(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBoardSlug", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBoardSlug indicates an expected call of SetBoardSlug.
+func (mr *MockStoreMockRecorder) SetBoardSlug(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardSlug", reflect.TypeOf((*MockStore)(nil).SetBoardSlug), arg0, arg1, arg2)
+}
+
 // SetSystemSetting mocks base method.
 func (m *MockStore) SetSystemSetting(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1472,6 +2162,20 @@ func (mr *MockStoreMockRecorder) SetSystemSetting(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSystemSetting", reflect.TypeOf((*MockStore)(nil).SetSystemSetting), arg0, arg1)
 }
 
+// SetTeamFeatureFlag mocks base method.
+func (m *MockStore) SetTeamFeatureFlag(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTeamFeatureFlag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTeamFeatureFlag indicates an expected call of SetTeamFeatureFlag.
+func (mr *MockStoreMockRecorder) SetTeamFeatureFlag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTeamFeatureFlag", reflect.TypeOf((*MockStore)(nil).SetTeamFeatureFlag), arg0, arg1, arg2)
+}
+
 // Shutdown mocks base method.
 func (m *MockStore) Shutdown() error {
 	m.ctrl.T.Helper()
@@ -1486,6 +2190,36 @@ func (mr *MockStoreMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockStore)(nil).Shutdown))
 }
 
+// SyncBlocks mocks base method.
+func (m *MockStore) SyncBlocks(arg0 string, arg1 []model.SyncBlockChange, arg2 string, arg3 bool) (*model.SyncBlocksResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncBlocks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.SyncBlocksResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncBlocks indicates an expected call of SyncBlocks.
+func (mr *MockStoreMockRecorder) SyncBlocks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncBlocks", reflect.TypeOf((*MockStore)(nil).SyncBlocks), arg0, arg1, arg2, arg3)
+}
+
+// TransferBoardOwnership mocks base method.
+func (m *MockStore) TransferBoardOwnership(arg0, arg1, arg2 string) (*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferBoardOwnership", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferBoardOwnership indicates an expected call of TransferBoardOwnership.
+func (mr *MockStoreMockRecorder) TransferBoardOwnership(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferBoardOwnership", reflect.TypeOf((*MockStore)(nil).TransferBoardOwnership), arg0, arg1, arg2)
+}
+
 // UndeleteBlock mocks base method.
 func (m *MockStore) UndeleteBlock(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1571,6 +2305,20 @@ func (mr *MockStoreMockRecorder) UpdateSubscribersNotifiedAt(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscribersNotifiedAt", reflect.TypeOf((*MockStore)(nil).UpdateSubscribersNotifiedAt), arg0, arg1)
 }
 
+// UpdateTeamWebhook mocks base method.
+func (m *MockStore) UpdateTeamWebhook(arg0 *model.TeamWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTeamWebhook indicates an expected call of UpdateTeamWebhook.
+func (mr *MockStoreMockRecorder) UpdateTeamWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamWebhook", reflect.TypeOf((*MockStore)(nil).UpdateTeamWebhook), arg0)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 *model.User) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,148 @@
+package sessionlayer
+
+import (
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// MemoryStore is a SessionStore that keeps the sessions in memory, so
+// they are lost when the server restarts.
+type MemoryStore struct {
+	mux      sync.RWMutex
+	sessions map[string]model.Session // keyed by token
+}
+
+// NewMemoryStore creates an empty in-memory session store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: map[string]model.Session{},
+	}
+}
+
+func (m *MemoryStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
+	counts, err := m.GetActiveUserCounts([]int64{updatedSecondsAgo})
+	if err != nil {
+		return 0, err
+	}
+	return counts[0], nil
+}
+
+func (m *MemoryStore) GetActiveUserCounts(updatedSecondsAgo []int64) ([]int, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	now := utils.GetMillis()
+	counts := make([]int, len(updatedSecondsAgo))
+	for i, secondsAgo := range updatedSecondsAgo {
+		since := now - utils.SecondsToMillis(secondsAgo)
+		userIDs := map[string]bool{}
+		for _, session := range m.sessions {
+			if session.UpdateAt > since {
+				userIDs[session.UserID] = true
+			}
+		}
+		counts[i] = len(userIDs)
+	}
+	return counts, nil
+}
+
+func (m *MemoryStore) GetSession(token string, expireTime int64) (*model.Session, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	session, ok := m.sessions[token]
+	if !ok || session.UpdateAt <= utils.GetMillis()-utils.SecondsToMillis(expireTime) {
+		return nil, model.NewErrNotFound("session")
+	}
+
+	// only the fields read by the database store are returned
+	return &model.Session{
+		ID:          session.ID,
+		Token:       session.Token,
+		UserID:      session.UserID,
+		AuthService: session.AuthService,
+		Props:       copyProps(session.Props),
+		CreateAt:    session.CreateAt,
+		UpdateAt:    session.UpdateAt,
+	}, nil
+}
+
+func (m *MemoryStore) CreateSession(session *model.Session) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	now := utils.GetMillis()
+	stored := *session
+	stored.Props = copyProps(session.Props)
+	stored.CreateAt = now
+	stored.UpdateAt = now
+	m.sessions[session.Token] = stored
+
+	session.CreateAt = now
+	session.UpdateAt = now
+	return nil
+}
+
+func (m *MemoryStore) RefreshSession(session *model.Session) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if stored, ok := m.sessions[session.Token]; ok {
+		stored.UpdateAt = utils.GetMillis()
+		m.sessions[session.Token] = stored
+	}
+	return nil
+}
+
+func (m *MemoryStore) UpdateSession(session *model.Session) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if stored, ok := m.sessions[session.Token]; ok {
+		stored.UpdateAt = utils.GetMillis()
+		stored.Props = copyProps(session.Props)
+		m.sessions[session.Token] = stored
+		session.UpdateAt = stored.UpdateAt
+	}
+	return nil
+}
+
+func (m *MemoryStore) DeleteSession(sessionID string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for token, session := range m.sessions {
+		if session.ID == sessionID {
+			delete(m.sessions, token)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) CleanUpSessions(expireTime int64) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	expiredBefore := utils.GetMillis() - utils.SecondsToMillis(expireTime)
+	for token, session := range m.sessions {
+		if session.UpdateAt < expiredBefore {
+			delete(m.sessions, token)
+		}
+	}
+	return nil
+}
+
+// copyProps copies the session props so the stored sessions can't be
+// modified by the callers.
+func copyProps(props map[string]interface{}) map[string]interface{} {
+	if props == nil {
+		return nil
+	}
+	c := make(map[string]interface{}, len(props))
+	for key, value := range props {
+		c[key] = value
+	}
+	return c
+}
@@ -0,0 +1,54 @@
+package sessionlayer
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// SessionLayer is a store layer that keeps the user sessions in a
+// separate SessionStore instead of the database.
+type SessionLayer struct {
+	store.Store
+	sessions store.SessionStore
+}
+
+// New creates a layer that stores the sessions in the session store
+// and everything else in the wrapped store.
+func New(store store.Store, sessions store.SessionStore) *SessionLayer {
+	return &SessionLayer{
+		Store:    store,
+		sessions: sessions,
+	}
+}
+
+func (l *SessionLayer) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
+	return l.sessions.GetActiveUserCount(updatedSecondsAgo)
+}
+
+func (l *SessionLayer) GetActiveUserCounts(updatedSecondsAgo []int64) ([]int, error) {
+	return l.sessions.GetActiveUserCounts(updatedSecondsAgo)
+}
+
+func (l *SessionLayer) GetSession(token string, expireTime int64) (*model.Session, error) {
+	return l.sessions.GetSession(token, expireTime)
+}
+
+func (l *SessionLayer) CreateSession(session *model.Session) error {
+	return l.sessions.CreateSession(session)
+}
+
+func (l *SessionLayer) RefreshSession(session *model.Session) error {
+	return l.sessions.RefreshSession(session)
+}
+
+func (l *SessionLayer) UpdateSession(session *model.Session) error {
+	return l.sessions.UpdateSession(session)
+}
+
+func (l *SessionLayer) DeleteSession(sessionID string) error {
+	return l.sessions.DeleteSession(sessionID)
+}
+
+func (l *SessionLayer) CleanUpSessions(expireTime int64) error {
+	return l.sessions.CleanUpSessions(expireTime)
+}
@@ -0,0 +1,47 @@
+package sessionlayer
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/store/storetests"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTests(t *testing.T) (store.Store, func()) {
+	// the session tests only use the session methods, so there is no
+	// need for an underlying store
+	return New(nil, NewMemoryStore()), func() {}
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	t.Run("SessionStore", func(t *testing.T) { storetests.StoreTestSessionStore(t, setupTests) })
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	memoryStore := NewMemoryStore()
+
+	require.NoError(t, memoryStore.CreateSession(&model.Session{ID: "active", Token: "active-token", UserID: "user-1"}))
+	require.NoError(t, memoryStore.CreateSession(&model.Session{ID: "expired", Token: "expired-token", UserID: "user-2"}))
+
+	// make the second session look unused for an hour
+	expired := memoryStore.sessions["expired-token"]
+	expired.UpdateAt = utils.GetMillis() - utils.SecondsToMillis(60*60)
+	memoryStore.sessions["expired-token"] = expired
+
+	t.Run("expired sessions are not returned", func(t *testing.T) {
+		_, err := memoryStore.GetSession("active-token", 60)
+		require.NoError(t, err)
+
+		_, err = memoryStore.GetSession("expired-token", 60)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("expired sessions are cleaned up", func(t *testing.T) {
+		require.NoError(t, memoryStore.CleanUpSessions(60))
+		require.Len(t, memoryStore.sessions, 1)
+		require.Contains(t, memoryStore.sessions, "active-token")
+	})
+}
@@ -0,0 +1,70 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *SQLStore) insertAdminAuditEntry(db sq.BaseRunner, entry *model.AdminAuditEntry) error {
+	_, err := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"admin_audit_log").
+		Columns("id", "actor", "action", "target", "create_at").
+		Values(entry.ID, entry.Actor, entry.Action, entry.Target, entry.CreateAt).
+		Exec()
+	return err
+}
+
+func (s *SQLStore) getAdminAuditEntries(db sq.BaseRunner, opts model.QueryAdminAuditOptions) ([]*model.AdminAuditEntry, error) {
+	order := "create_at DESC"
+	if opts.Ascending {
+		order = "create_at ASC"
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("id", "actor", "action", "target", "create_at").
+		From(s.tablePrefix+"admin_audit_log").
+		OrderBy(order, "id")
+
+	if opts.Actor != "" {
+		query = query.Where(sq.Eq{"actor": opts.Actor})
+	}
+
+	if opts.Action != "" {
+		query = query.Where(sq.Eq{"action": opts.Action})
+	}
+
+	if opts.AfterCreateAt != 0 {
+		query = query.Where(sq.Gt{"create_at": opts.AfterCreateAt})
+	}
+
+	if opts.BeforeCreateAt != 0 {
+		query = query.Where(sq.Lt{"create_at": opts.BeforeCreateAt})
+	}
+
+	if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+		if opts.Offset != 0 {
+			query = query.Offset(opts.Offset)
+		}
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getAdminAuditEntries ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	entries := []*model.AdminAuditEntry{}
+	for rows.Next() {
+		var entry model.AdminAuditEntry
+		if err := rows.Scan(&entry.ID, &entry.Actor, &entry.Action, &entry.Target, &entry.CreateAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,117 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// blockHistoryCompactionBatchSize is the number of blocks whose history
+// is read at once by the compaction.
+const blockHistoryCompactionBatchSize = 100
+
+// compactBlockHistory removes the versions of the blocks history the
+// policy doesn't keep, returning the number of versions removed. Each
+// block is compacted with a single statement, so the history of a block
+// is never left half compacted.
+func (s *SQLStore) compactBlockHistory(db sq.BaseRunner, policy *model.BlockHistoryCompactionPolicy, now int64) (int64, error) {
+	// the versions newer than KeepAll are all kept, so only the blocks
+	// with more than one older version can be compacted
+	keepAllAfter := now - policy.KeepAll.Milliseconds()
+
+	var deleted int64
+	lastBlockID := ""
+	for {
+		blockIDs, err := s.getBlockIDsWithOldHistory(db, keepAllAfter, lastBlockID)
+		if err != nil {
+			return deleted, err
+		}
+
+		for _, blockID := range blockIDs {
+			var count int64
+			count, err = s.compactHistoryOfBlock(db, policy, blockID, now)
+			if err != nil {
+				return deleted, err
+			}
+			deleted += count
+		}
+
+		if len(blockIDs) < blockHistoryCompactionBatchSize {
+			return deleted, nil
+		}
+		lastBlockID = blockIDs[len(blockIDs)-1]
+	}
+}
+
+// getBlockIDsWithOldHistory returns the next batch of blocks, ordered by
+// ID after afterID, that have more than one version older than before.
+func (s *SQLStore) getBlockIDsWithOldHistory(db sq.BaseRunner, before int64, afterID string) ([]string, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Lt{"update_at": before}).
+		Where(sq.Gt{"id": afterID}).
+		GroupBy("id").
+		Having("COUNT(*) > 1").
+		OrderBy("id").
+		Limit(blockHistoryCompactionBatchSize).
+		Query()
+	if err != nil {
+		s.logger.Error(`getBlockIDsWithOldHistory ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	blockIDs := []string{}
+	for rows.Next() {
+		var blockID string
+		if err = rows.Scan(&blockID); err != nil {
+			return nil, err
+		}
+		blockIDs = append(blockIDs, blockID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return blockIDs, nil
+}
+
+func (s *SQLStore) compactHistoryOfBlock(db sq.BaseRunner, policy *model.BlockHistoryCompactionPolicy, blockID string, now int64) (int64, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("update_at", "COALESCE(delete_at, 0)").
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": blockID}).
+		Query()
+	if err != nil {
+		s.logger.Error(`compactHistoryOfBlock ERROR`, mlog.Err(err))
+		return 0, err
+	}
+	defer s.CloseRows(rows)
+
+	versions := []model.BlockVersion{}
+	for rows.Next() {
+		var version model.BlockVersion
+		if err = rows.Scan(&version.UpdateAt, &version.DeleteAt); err != nil {
+			return 0, err
+		}
+		versions = append(versions, version)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	toDelete := policy.VersionsToDelete(versions, now)
+	if len(toDelete) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": blockID, "update_at": toDelete}).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -16,8 +16,7 @@ import (
 )
 
 const (
-	maxSearchDepth = 50
-	descClause     = " DESC "
+	descClause = " DESC "
 )
 
 type BoardIDNilError struct{}
@@ -181,6 +180,76 @@ func (s *SQLStore) getBlocksForBoard(db sq.BaseRunner, boardID string) ([]model.
 	return s.getBlocks(db, opts)
 }
 
+// getBlockManifest returns the ID, type and update time of the blocks
+// of a board, sorted by ID.
+func (s *SQLStore) getBlockManifest(db sq.BaseRunner, boardID string) ([]model.BlockManifestEntry, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("id", "update_at", "type").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("id").
+		Query()
+	if err != nil {
+		s.logger.Error(`getBlockManifest ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	manifest := []model.BlockManifestEntry{}
+	for rows.Next() {
+		var entry model.BlockManifestEntry
+		if err = rows.Scan(&entry.ID, &entry.UpdateAt, &entry.Type); err != nil {
+			return nil, err
+		}
+		manifest = append(manifest, entry)
+	}
+
+	return manifest, rows.Err()
+}
+
+// getBoardBlocksPage returns up to limit blocks of a board sorted by ID,
+// starting after afterID. It allows iterating over large boards without
+// loading all their blocks at once.
+func (s *SQLStore) getBoardBlocksPage(db sq.BaseRunner, boardID, afterID string, limit uint64) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Gt{"id": afterID}).
+		OrderBy("id").
+		Limit(limit)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardBlocksPage ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// getCardProgress counts the checkbox blocks of a card and how many of
+// them are checked.
+func (s *SQLStore) getCardProgress(db sq.BaseRunner, boardID, cardID string) (*model.CardProgress, error) {
+	checkedSelector := fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0)", s.jsonFieldIsTrue("fields", "value"))
+
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)", checkedSelector).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"parent_id": cardID}).
+		Where(sq.Eq{"type": model.TypeCheckbox})
+
+	progress := &model.CardProgress{CardID: cardID}
+	if err := query.QueryRow().Scan(&progress.Total, &progress.Checked); err != nil {
+		s.logger.Error(`getCardProgress ERROR`, mlog.Err(err))
+		return nil, err
+	}
+
+	return progress, nil
+}
+
 func (s *SQLStore) blocksFromRows(rows *sql.Rows) ([]model.Block, error) {
 	results := []model.Block{}
 
@@ -280,6 +349,10 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 		"board_id":              block.BoardID,
 	}
 
+	if existingBlock != nil && existingBlock.BoardID != block.BoardID {
+		return model.NewErrBadRequest(fmt.Sprintf("block id %s belongs to another board", block.ID))
+	}
+
 	if existingBlock != nil {
 		// block with ID exists, so this is an update operation
 		query := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks").
@@ -294,11 +367,22 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 			Set("update_at", block.UpdateAt).
 			Set("delete_at", block.DeleteAt)
 
-		if _, err := query.Exec(); err != nil {
+		result, err := query.Exec()
+		if err != nil {
 			s.logger.Error(`InsertBlock error occurred while updating existing block`, mlog.String("blockID", block.ID), mlog.Err(err))
 
 			return err
 		}
+
+		// the block was deleted meanwhile, or, on MySQL, nothing
+		// changed; either way there is no new version to record
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return nil
+		}
 	} else {
 		block.CreatedBy = userID
 		query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "blocks")
@@ -313,19 +397,69 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 		return err
 	}
 
-	return nil
+	return s.updateBoardLastActivity(db, block.BoardID, block.UpdateAt)
+}
+
+// updateBoardLastActivity moves forward the last activity time of the
+// board of a changed block, so the boards can be sorted by activity
+// without reading their blocks.
+func (s *SQLStore) updateBoardLastActivity(db sq.BaseRunner, boardID string, activityAt int64) error {
+	_, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"boards").
+		Set("last_activity_at", activityAt).
+		Where(sq.Eq{"id": boardID}).
+		Where(sq.Lt{"last_activity_at": activityAt}).
+		Exec()
+	if err != nil {
+		s.logger.Error("updateBoardLastActivity error", mlog.String("boardID", boardID), mlog.Err(err))
+	}
+	return err
 }
 
 func (s *SQLStore) patchBlock(db sq.BaseRunner, blockID string, blockPatch *model.BlockPatch, userID string) error {
+	// the block can't change between the version check and the write:
+	// SQLite runs the store methods without a transaction, so its
+	// patches are serialized, and the other databases lock the row
+	// until the transaction ends
+	if s.dbType == model.SqliteDBType {
+		s.patchBlockMux.Lock()
+		defer s.patchBlockMux.Unlock()
+	} else if blockPatch.BaseUpdateAt != nil {
+		if err := s.lockBlock(db, blockID); err != nil {
+			return err
+		}
+	}
+
 	existingBlock, err := s.getBlock(db, blockID)
 	if err != nil {
 		return err
 	}
 
+	if blockPatch.BaseUpdateAt != nil && existingBlock.UpdateAt > *blockPatch.BaseUpdateAt {
+		return model.NewErrBlockConflict(*blockPatch.BaseUpdateAt, existingBlock)
+	}
+
 	block := blockPatch.Patch(existingBlock)
 	return s.insertBlock(db, block, userID)
 }
 
+// lockBlock locks the row of the block until the end of the current
+// transaction.
+func (s *SQLStore) lockBlock(db sq.BaseRunner, blockID string) error {
+	rows, err := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"id": blockID}).
+		Suffix("FOR UPDATE").
+		Query()
+	if err != nil {
+		s.logger.Error(`lockBlock ERROR`, mlog.Err(err))
+		return err
+	}
+	s.CloseRows(rows)
+	return nil
+}
+
 func (s *SQLStore) patchBlocks(db sq.BaseRunner, blockPatches *model.BlockPatchBatch, userID string) error {
 	for i, blockID := range blockPatches.BlockIDs {
 		err := s.patchBlock(db, blockID, &blockPatches.BlockPatches[i], userID)
@@ -409,7 +543,65 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy stri
 		return err
 	}
 
-	return nil
+	return s.updateBoardLastActivity(db, block.BoardID, now)
+}
+
+// deleteBlocks deletes the blocks and all their descendants, returning
+// the deleted blocks. Blocks that don't exist are skipped.
+func (s *SQLStore) deleteBlocks(db sq.BaseRunner, blockIDs []string, modifiedBy string) ([]model.Block, error) {
+	type queuedBlock struct {
+		block model.Block
+		depth int
+	}
+
+	queue := []queuedBlock{}
+	for _, blockID := range blockIDs {
+		block, err := s.getBlock(db, blockID)
+		if model.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, queuedBlock{block: *block})
+	}
+
+	deleted := []model.Block{}
+	seen := map[string]bool{}
+	for len(queue) > 0 {
+		block := queue[0].block
+		depth := queue[0].depth
+		queue = queue[1:]
+
+		if seen[block.ID] {
+			continue
+		}
+		seen[block.ID] = true
+
+		if depth > s.maxBlockTreeDepth {
+			s.logger.Error("deleteBlocks block tree too deep",
+				mlog.String("board_id", block.BoardID),
+				mlog.String("block_id", block.ID),
+				mlog.Int("max_depth", s.maxBlockTreeDepth),
+			)
+			return nil, fmt.Errorf("cannot delete the descendants of block %s: %w", block.ID, model.ErrBlockTreeTooDeep)
+		}
+
+		children, err := s.getBlocksWithParent(db, block.BoardID, block.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			queue = append(queue, queuedBlock{block: child, depth: depth + 1})
+		}
+
+		if err := s.deleteBlock(db, block.ID, modifiedBy); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, block)
+	}
+
+	return deleted, nil
 }
 
 func (s *SQLStore) undeleteBlock(db sq.BaseRunner, blockID string, modifiedBy string) error {
@@ -481,7 +673,7 @@ func (s *SQLStore) undeleteBlock(db sq.BaseRunner, blockID string, modifiedBy st
 		return err
 	}
 
-	return nil
+	return s.updateBoardLastActivity(db, block.BoardID, now)
 }
 
 func (s *SQLStore) getBlockCountsByType(db sq.BaseRunner) (map[string]int64, error) {
@@ -534,6 +726,24 @@ func (s *SQLStore) getBoardCount(db sq.BaseRunner) (int64, error) {
 	return count, nil
 }
 
+// getTeamBoardCount returns the number of boards of a team, without
+// counting the templates.
+func (s *SQLStore) getTeamBoardCount(db sq.BaseRunner, teamID string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*) AS count").
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"is_template": false}).
+		Where(sq.Eq{"delete_at": 0})
+
+	var count int64
+	if err := query.QueryRow().Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (s *SQLStore) getBlock(db sq.BaseRunner, blockID string) (*model.Block, error) {
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
@@ -627,6 +837,29 @@ func (s *SQLStore) getBlockHistoryDescendants(db sq.BaseRunner, boardID string,
 	return s.blocksFromRows(rows)
 }
 
+// getDeletedBlocksForBoard returns the blocks of a board that are
+// currently deleted, as they were when they were deleted, most recently
+// deleted first. Only the blocks deleted after deletedAfter are returned.
+func (s *SQLStore) getDeletedBlocksForBoard(db sq.BaseRunner, boardID string, deletedAfter int64) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix+"blocks_history AS bh").
+		Where(sq.Eq{"bh.board_id": boardID}).
+		Where(sq.Gt{"bh.delete_at": deletedAfter}).
+		Where("NOT EXISTS (SELECT 1 FROM "+s.tablePrefix+"blocks AS b WHERE b.id = bh.id)").
+		Where("bh.delete_at = (SELECT MAX(h.delete_at) FROM "+s.tablePrefix+"blocks_history AS h WHERE h.id = bh.id)").
+		OrderBy("bh.delete_at DESC", "bh.id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getDeletedBlocksForBoard ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
 // getBoardAndCardByID returns the first parent of type `card` and first parent of type `board` for the block specified by ID.
 // `board` and/or `card` may return nil without error if the block does not belong to a board or card.
 func (s *SQLStore) getBoardAndCardByID(db sq.BaseRunner, blockID string) (board *model.Board, card *model.Block, err error) {
@@ -666,10 +899,19 @@ func (s *SQLStore) getBoardAndCard(db sq.BaseRunner, block *model.Block) (board
 			card = iter
 		}
 
-		if iter.ParentID == "" || card != nil || count > maxSearchDepth {
+		if iter.ParentID == "" || card != nil {
 			break
 		}
 
+		if count > s.maxBlockTreeDepth {
+			s.logger.Error("getBoardAndCard block tree too deep",
+				mlog.String("board_id", block.BoardID),
+				mlog.String("block_id", block.ID),
+				mlog.Int("max_depth", s.maxBlockTreeDepth),
+			)
+			return nil, nil, fmt.Errorf("cannot get the card of block %s: %w", block.ID, model.ErrBlockTreeTooDeep)
+		}
+
 		blocks, err2 := s.getBlockHistory(db, iter.ParentID, opts)
 		if err2 != nil {
 			return nil, nil, err2
@@ -34,6 +34,8 @@ func boardFields(prefix string) []string {
 		"template_version",
 		"COALESCE(properties, '{}')",
 		"COALESCE(card_properties, '[]')",
+		"COALESCE(card_template_id, '')",
+		"last_activity_at",
 		"create_at",
 		"update_at",
 		"delete_at",
@@ -71,6 +73,8 @@ func boardHistoryFields() []string {
 		"template_version",
 		"COALESCE(properties, '{}')",
 		"COALESCE(card_properties, '[]')",
+		"COALESCE(card_template_id, '')",
+		"COALESCE(last_activity_at, 0)",
 		"COALESCE(create_at, 0)",
 		"COALESCE(update_at, 0)",
 		"COALESCE(delete_at, 0)",
@@ -114,6 +118,8 @@ func (s *SQLStore) boardsFromRows(rows *sql.Rows) ([]*model.Board, error) {
 			&board.TemplateVersion,
 			&propertiesBytes,
 			&cardPropertiesBytes,
+			&board.CardTemplateID,
+			&board.LastActivityAt,
 			&board.CreateAt,
 			&board.UpdateAt,
 			&board.DeleteAt,
@@ -304,6 +310,16 @@ func (s *SQLStore) getBoardsInTeamByIds(db sq.BaseRunner, boardIDs []string, tea
 	return boards, nil
 }
 
+// getBoardsForTeam returns all the boards of a team, including the
+// templates.
+func (s *SQLStore) getBoardsForTeam(db sq.BaseRunner, teamID string) ([]*model.Board, error) {
+	boards, err := s.getBoardsByCondition(db, sq.Eq{"team_id": teamID})
+	if model.IsErrNotFound(err) {
+		return []*model.Board{}, nil
+	}
+	return boards, err
+}
+
 func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, error) {
 	// Generate tracking IDs for in-built templates
 	if board.IsTemplate && board.TeamID == model.GlobalTeamID {
@@ -345,6 +361,7 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 	now := utils.GetMillis()
 	board.ModifiedBy = userID
 	board.UpdateAt = now
+	board.LastActivityAt = now
 
 	insertQueryValues := map[string]interface{}{
 		"id":               board.ID,
@@ -362,6 +379,8 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 		"template_version": board.TemplateVersion,
 		"properties":       propertiesBytes,
 		"card_properties":  cardPropertiesBytes,
+		"card_template_id": board.CardTemplateID,
+		"last_activity_at": board.LastActivityAt,
 		"create_at":        board.CreateAt,
 		"update_at":        board.UpdateAt,
 		"delete_at":        board.DeleteAt,
@@ -382,6 +401,8 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 			Set("template_version", board.TemplateVersion).
 			Set("properties", propertiesBytes).
 			Set("card_properties", cardPropertiesBytes).
+			Set("card_template_id", board.CardTemplateID).
+			Set("last_activity_at", board.LastActivityAt).
 			Set("update_at", board.UpdateAt).
 			Set("delete_at", board.DeleteAt)
 
@@ -421,6 +442,49 @@ func (s *SQLStore) patchBoard(db sq.BaseRunner, boardID string, boardPatch *mode
 	return s.insertBoard(db, board, userID)
 }
 
+// moveBoard reparents a board to another team. The blocks of the board
+// are not team scoped, so only the board needs to be updated. The
+// channel link and the sidebar categories belong to the old team, so
+// they are removed too.
+func (s *SQLStore) moveBoard(db sq.BaseRunner, boardID, toTeamID, userID string) (*model.Board, error) {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.TeamID == toTeamID {
+		return board, nil
+	}
+
+	board.TeamID = toTeamID
+	board.ChannelID = ""
+
+	movedBoard, err := s.insertBoard(db, board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.getQueryBuilder(db).
+		Update(s.tablePrefix+"category_boards").
+		Set("delete_at", utils.GetMillis()).
+		Where(sq.Eq{
+			"board_id":  boardID,
+			"delete_at": 0,
+		}).Exec()
+	if err != nil {
+		s.logger.Error("moveBoard failed to remove board from categories", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, err
+	}
+
+	// the slugs are unique within a team, so the slug of the board
+	// could be taken in the new one
+	if err = s.deleteBoardSlugs(db, sq.Eq{"board_id": boardID}); err != nil {
+		return nil, err
+	}
+
+	return movedBoard, nil
+}
+
 func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
 	now := utils.GetMillis()
 
@@ -454,6 +518,8 @@ func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
 		"template_version": board.TemplateVersion,
 		"properties":       propertiesBytes,
 		"card_properties":  cardPropertiesBytes,
+		"card_template_id": board.CardTemplateID,
+		"last_activity_at": board.LastActivityAt,
 		"create_at":        board.CreateAt,
 		"update_at":        now,
 		"delete_at":        now,
@@ -477,7 +543,74 @@ func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
 		return err
 	}
 
-	return nil
+	if err := s.deleteUserBoardViews(db, sq.Eq{"board_id": boardID}); err != nil {
+		return err
+	}
+	if err := s.deleteBoardFavorites(db, sq.Eq{"board_id": boardID}); err != nil {
+		return err
+	}
+	if err := s.deleteBoardSlugs(db, sq.Eq{"board_id": boardID}); err != nil {
+		return err
+	}
+	if err := s.deleteRecentBoards(db, sq.Eq{"board_id": boardID}); err != nil {
+		return err
+	}
+	return s.deleteBoardLastSeen(db, sq.Eq{"board_id": boardID})
+}
+
+// transferBoardOwnership makes newOwnerID the owner of the board. The
+// new owner becomes an admin of the board and the previous owner, if
+// still a member, is demoted to editor.
+func (s *SQLStore) transferBoardOwnership(db sq.BaseRunner, boardID, newOwnerID, userID string) (*model.Board, error) {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldOwnerID := board.CreatedBy
+	if oldOwnerID == newOwnerID {
+		return board, nil
+	}
+
+	newOwner := &model.BoardMember{
+		BoardID:      boardID,
+		UserID:       newOwnerID,
+		SchemeAdmin:  true,
+		SchemeEditor: true,
+	}
+	if _, err = s.saveMember(db, newOwner); err != nil {
+		return nil, fmt.Errorf("cannot save new owner %s of board %s: %w", newOwnerID, boardID, err)
+	}
+
+	oldOwner, err := s.getMemberForBoard(db, boardID, oldOwnerID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	if oldOwner != nil && oldOwner.SchemeAdmin {
+		oldOwner.SchemeAdmin = false
+		oldOwner.SchemeEditor = true
+		if _, err = s.saveMember(db, oldOwner); err != nil {
+			return nil, fmt.Errorf("cannot demote previous owner %s of board %s: %w", oldOwnerID, boardID, err)
+		}
+	}
+
+	board.CreatedBy = newOwnerID
+	transferredBoard, err := s.insertBoard(db, board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// insertBoard doesn't update the creator of existing boards
+	_, err = s.getQueryBuilder(db).
+		Update(s.tablePrefix+"boards").
+		Set("created_by", newOwnerID).
+		Where(sq.Eq{"id": boardID}).
+		Exec()
+	if err != nil {
+		return nil, fmt.Errorf("cannot update owner of board %s: %w", boardID, err)
+	}
+
+	return transferredBoard, nil
 }
 
 func (s *SQLStore) insertBoardWithAdmin(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
@@ -578,7 +711,16 @@ func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error
 		}
 	}
 
-	return nil
+	if err := s.deleteUserBoardViews(db, sq.Eq{"board_id": boardID, "user_id": userID}); err != nil {
+		return err
+	}
+	if err := s.deleteBoardFavorites(db, sq.Eq{"board_id": boardID, "user_id": userID}); err != nil {
+		return err
+	}
+	if err := s.deleteRecentBoards(db, sq.Eq{"board_id": boardID, "user_id": userID}); err != nil {
+		return err
+	}
+	return s.deleteBoardLastSeen(db, sq.Eq{"board_id": boardID, "user_id": userID})
 }
 
 func (s *SQLStore) getMemberForBoard(db sq.BaseRunner, boardID, userID string) (*model.BoardMember, error) {
@@ -821,6 +963,8 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		"template_version",
 		"properties",
 		"card_properties",
+		"card_template_id",
+		"last_activity_at",
 		"create_at",
 		"update_at",
 		"delete_at",
@@ -842,6 +986,8 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		board.TemplateVersion,
 		propertiesJSON,
 		cardPropertiesJSON,
+		board.CardTemplateID,
+		now,
 		board.CreateAt,
 		now,
 		0,
@@ -0,0 +1,109 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func boardAPIKeyFields() []string {
+	return []string{
+		"id",
+		"board_id",
+		"name",
+		"permission",
+		"created_by",
+		"create_at",
+	}
+}
+
+func (s *SQLStore) boardAPIKeysFromRows(rows *sql.Rows) ([]*model.BoardAPIKey, error) {
+	keys := []*model.BoardAPIKey{}
+	for rows.Next() {
+		var key model.BoardAPIKey
+		err := rows.Scan(
+			&key.ID,
+			&key.BoardID,
+			&key.Name,
+			&key.Permission,
+			&key.CreatedBy,
+			&key.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, &key)
+	}
+	return keys, nil
+}
+
+func (s *SQLStore) createBoardAPIKey(db sq.BaseRunner, key *model.BoardAPIKey, keyHash string) error {
+	_, err := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_api_keys").
+		Columns("id", "board_id", "name", "key_hash", "permission", "created_by", "create_at").
+		Values(key.ID, key.BoardID, key.Name, keyHash, key.Permission, key.CreatedBy, key.CreateAt).
+		Exec()
+	return err
+}
+
+func (s *SQLStore) getBoardAPIKeyByHash(db sq.BaseRunner, keyHash string) (*model.BoardAPIKey, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(boardAPIKeyFields()...).
+		From(s.tablePrefix + "board_api_keys").
+		Where(sq.Eq{"key_hash": keyHash}).
+		Query()
+	if err != nil {
+		s.logger.Error(`getBoardAPIKeyByHash ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	keys, err := s.boardAPIKeysFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, model.NewErrNotFound("board API key")
+	}
+	return keys[0], nil
+}
+
+func (s *SQLStore) getBoardAPIKeys(db sq.BaseRunner, boardID string) ([]*model.BoardAPIKey, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(boardAPIKeyFields()...).
+		From(s.tablePrefix+"board_api_keys").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id").
+		Query()
+	if err != nil {
+		s.logger.Error(`getBoardAPIKeys ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardAPIKeysFromRows(rows)
+}
+
+func (s *SQLStore) deleteBoardAPIKey(db sq.BaseRunner, boardID, keyID string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_api_keys").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"id": keyID}).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("board API key ID=" + keyID)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *SQLStore) addBoardFavorite(db sq.BaseRunner, favorite *model.BoardFavorite) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_favorites").
+		Columns("user_id", "board_id", "create_at").
+		Values(favorite.UserID, favorite.BoardID, favorite.CreateAt)
+
+	// adding a favorite twice keeps the original one
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE create_at = create_at")
+	} else {
+		query = query.Suffix("ON CONFLICT (user_id, board_id) DO NOTHING")
+	}
+
+	_, err := query.Exec()
+	return err
+}
+
+func (s *SQLStore) deleteBoardFavorite(db sq.BaseRunner, userID, boardID string) error {
+	return s.deleteBoardFavorites(db, sq.Eq{"user_id": userID, "board_id": boardID})
+}
+
+// deleteBoardFavorites deletes the favorites that match the condition,
+// used when a board is deleted or a user leaves it.
+func (s *SQLStore) deleteBoardFavorites(db sq.BaseRunner, condition sq.Eq) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_favorites").
+		Where(condition).
+		Exec()
+	return err
+}
+
+func (s *SQLStore) getFavoriteBoardIDs(db sq.BaseRunner, userID string) ([]string, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("board_id").
+		From(s.tablePrefix + "board_favorites").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("create_at").
+		Query()
+	if err != nil {
+		s.logger.Error(`getFavoriteBoardIDs ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boardIDs := []string{}
+	for rows.Next() {
+		var boardID string
+		if err = rows.Scan(&boardID); err != nil {
+			return nil, err
+		}
+		boardIDs = append(boardIDs, boardID)
+	}
+	return boardIDs, rows.Err()
+}
+
+// getFavoriteBoards returns the favorite boards of the user, in the order
+// they were added.
+func (s *SQLStore) getFavoriteBoards(db sq.BaseRunner, userID string) ([]*model.Board, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		From(s.tablePrefix + "board_favorites as f").
+		Join(s.tablePrefix + "boards as b on b.id=f.board_id").
+		Where(sq.Eq{"f.user_id": userID}).
+		OrderBy("f.create_at").
+		Query()
+	if err != nil {
+		s.logger.Error(`getFavoriteBoards ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
@@ -0,0 +1,73 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// saveBoardLastSeen records the last time the user viewed the board.
+func (s *SQLStore) saveBoardLastSeen(db sq.BaseRunner, seen *model.BoardLastSeen) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_last_seen").
+		Columns("user_id", "board_id", "seen_at").
+		Values(seen.UserID, seen.BoardID, seen.SeenAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE seen_at = ?", seen.SeenAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (user_id, board_id) DO UPDATE SET seen_at = EXCLUDED.seen_at")
+	}
+
+	_, err := query.Exec()
+	return err
+}
+
+// deleteBoardLastSeen deletes the last seen times that match the
+// condition, used when a board is deleted or a user leaves it.
+func (s *SQLStore) deleteBoardLastSeen(db sq.BaseRunner, condition sq.Eq) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_last_seen").
+		Where(condition).
+		Exec()
+	return err
+}
+
+// getBoardUnreadCounts returns, by board ID, the number of blocks of the
+// boards changed by other users since the user last viewed each board.
+// The boards without unread changes are not included.
+func (s *SQLStore) getBoardUnreadCounts(db sq.BaseRunner, userID string, boardIDs []string) (map[string]int64, error) {
+	counts := map[string]int64{}
+	if len(boardIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("b.board_id", "COUNT(b.id)").
+		From(s.tablePrefix+"blocks as b").
+		LeftJoin(s.tablePrefix+"board_last_seen as ls on ls.board_id=b.board_id and ls.user_id=?", userID).
+		Where(sq.Eq{"b.board_id": boardIDs, "b.delete_at": 0}).
+		Where(sq.NotEq{"b.modified_by": userID}).
+		Where("b.update_at > COALESCE(ls.seen_at, 0)").
+		GroupBy("b.board_id").
+		Query()
+	if err != nil {
+		s.logger.Error(`getBoardUnreadCounts ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var boardID string
+		var count int64
+		if err = rows.Scan(&boardID, &count); err != nil {
+			return nil, err
+		}
+		counts[boardID] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
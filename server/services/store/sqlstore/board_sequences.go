@@ -0,0 +1,48 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// nextBoardSequence allocates count consecutive values of the board
+// sequence and returns the last one. The sequence row is updated before
+// it's read, so the row lock held by the transaction keeps concurrent
+// allocations from getting the same values.
+func (s *SQLStore) nextBoardSequence(db sq.BaseRunner, boardID string, count int) (int64, error) {
+	if count < 1 {
+		return 0, model.NewErrInvalidField("count", fmt.Sprintf("must be positive, got %d", count))
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_sequences").
+		Columns("board_id", "value").
+		Values(boardID, count)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE value = value + ?", count)
+	} else {
+		query = query.Suffix(
+			fmt.Sprintf(`ON CONFLICT (board_id)
+			 DO UPDATE SET value = %sboard_sequences.value + EXCLUDED.value`, s.tablePrefix),
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		return 0, err
+	}
+
+	row := s.getQueryBuilder(db).
+		Select("value").
+		From(s.tablePrefix + "board_sequences").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryRow()
+
+	var value int64
+	if err := row.Scan(&value); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
@@ -0,0 +1,85 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// setBoardSlug replaces the slug of the board, or removes it if slug is
+// empty. The slugs are unique within a team.
+func (s *SQLStore) setBoardSlug(db sq.BaseRunner, boardID, teamID, slug string) error {
+	if slug != "" {
+		existingBoardID, err := s.getBoardIDBySlug(db, teamID, slug)
+		if err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+		if existingBoardID == boardID {
+			return nil
+		}
+		if existingBoardID != "" {
+			return model.NewErrInvalidField("slug", "is already used by another board of the team")
+		}
+	}
+
+	if err := s.deleteBoardSlugs(db, sq.Eq{"board_id": boardID}); err != nil {
+		return err
+	}
+
+	if slug == "" {
+		return nil
+	}
+
+	_, err := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_slugs").
+		Columns("team_id", "slug", "board_id").
+		Values(teamID, slug, boardID).
+		Exec()
+	return err
+}
+
+// deleteBoardSlugs deletes the slugs that match the condition, used
+// when a board is deleted or moved to another team.
+func (s *SQLStore) deleteBoardSlugs(db sq.BaseRunner, condition sq.Eq) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_slugs").
+		Where(condition).
+		Exec()
+	return err
+}
+
+func (s *SQLStore) getBoardSlug(db sq.BaseRunner, boardID string) (string, error) {
+	row := s.getQueryBuilder(db).
+		Select("slug").
+		From(s.tablePrefix + "board_slugs").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryRow()
+
+	var slug string
+	if err := row.Scan(&slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", model.NewErrNotFound("slug of board ID=" + boardID)
+		}
+		return "", err
+	}
+	return slug, nil
+}
+
+func (s *SQLStore) getBoardIDBySlug(db sq.BaseRunner, teamID, slug string) (string, error) {
+	row := s.getQueryBuilder(db).
+		Select("board_id").
+		From(s.tablePrefix + "board_slugs").
+		Where(sq.Eq{"team_id": teamID, "slug": slug}).
+		QueryRow()
+
+	var boardID string
+	if err := row.Scan(&boardID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", model.NewErrNotFound("board slug=" + slug)
+		}
+		return "", err
+	}
+	return boardID, nil
+}
@@ -0,0 +1,81 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// getBoardStats counts the cards of a board by status, and the cards
+// completed and overdue. Only the status and due date of the cards are
+// read, as the due dates are stored as JSON and can't be compared by
+// the database.
+func (s *SQLStore) getBoardStats(db sq.BaseRunner, boardID string, opts model.QueryBoardStatsOptions) (*model.BoardStats, error) {
+	query := s.getQueryBuilder(db).
+		Select().
+		Column(s.cardPropertyColumn(opts.StatusProperty, "status_value")).
+		Column(s.cardPropertyColumn(opts.DueDateProperty, "due_value")).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(fmt.Sprintf("(CASE WHEN %s THEN 1 ELSE 0 END) = 0", s.jsonFieldIsTrue("fields", "isTemplate")))
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardStats ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	done := make(map[string]bool, len(opts.DoneValues))
+	for _, value := range opts.DoneValues {
+		done[value] = true
+	}
+
+	stats := &model.BoardStats{
+		BoardID:       boardID,
+		CardsByStatus: []*model.BoardStatusCount{},
+	}
+	countsByValue := map[string]*model.BoardStatusCount{}
+	for rows.Next() {
+		var statusValue, dueValue sql.NullString
+		if err := rows.Scan(&statusValue, &dueValue); err != nil {
+			return nil, err
+		}
+		stats.TotalCards++
+
+		count, ok := countsByValue[statusValue.String]
+		if !ok {
+			count = &model.BoardStatusCount{Value: statusValue.String, Completed: done[statusValue.String]}
+			countsByValue[statusValue.String] = count
+			stats.CardsByStatus = append(stats.CardsByStatus, count)
+		}
+		count.Count++
+
+		if count.Completed {
+			stats.CompletedCards++
+			continue
+		}
+
+		// the due dates that can't be parsed don't make the card overdue
+		if from, to, err := model.ParseDateValue(dueValue.String); err == nil {
+			due := from
+			if to != 0 {
+				due = to
+			}
+			if due < opts.OverdueBefore {
+				stats.OverdueCards++
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -54,6 +54,7 @@ func (s *SQLStore) createBoardsAndBlocks(db sq.BaseRunner, bab *model.BoardsAndB
 		boards = append(boards, newBoard)
 	}
 
+	lastActivity := map[string]int64{}
 	for _, block := range bab.Blocks {
 		b := block
 		err := s.insertBlock(db, &b, userID)
@@ -62,7 +63,11 @@ func (s *SQLStore) createBoardsAndBlocks(db sq.BaseRunner, bab *model.BoardsAndB
 		}
 
 		blocks = append(blocks, block)
+		if b.UpdateAt > lastActivity[b.BoardID] {
+			lastActivity[b.BoardID] = b.UpdateAt
+		}
 	}
+	setBoardsLastActivity(boards, lastActivity)
 
 	newBab := &model.BoardsAndBlocks{
 		Boards: boards,
@@ -72,6 +77,16 @@ func (s *SQLStore) createBoardsAndBlocks(db sq.BaseRunner, bab *model.BoardsAndB
 	return newBab, nil
 }
 
+// setBoardsLastActivity updates the last activity of the boards returned
+// to the caller with the one stored when their blocks were written.
+func setBoardsLastActivity(boards []*model.Board, lastActivity map[string]int64) {
+	for _, board := range boards {
+		if activityAt := lastActivity[board.ID]; activityAt > board.LastActivityAt {
+			board.LastActivityAt = activityAt
+		}
+	}
+}
+
 func (s *SQLStore) patchBoardsAndBlocks(db sq.BaseRunner, pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
 	bab := &model.BoardsAndBlocks{}
 	for i, boardID := range pbab.BoardIDs {
@@ -82,6 +97,7 @@ func (s *SQLStore) patchBoardsAndBlocks(db sq.BaseRunner, pbab *model.PatchBoard
 		bab.Boards = append(bab.Boards, board)
 	}
 
+	lastActivity := map[string]int64{}
 	for i, blockID := range pbab.BlockIDs {
 		if err := s.patchBlock(db, blockID, pbab.BlockPatches[i], userID); err != nil {
 			return nil, err
@@ -91,7 +107,11 @@ func (s *SQLStore) patchBoardsAndBlocks(db sq.BaseRunner, pbab *model.PatchBoard
 			return nil, err
 		}
 		bab.Blocks = append(bab.Blocks, *block)
+		if block.UpdateAt > lastActivity[block.BoardID] {
+			lastActivity[block.BoardID] = block.UpdateAt
+		}
 	}
+	setBoardsLastActivity(bab.Boards, lastActivity)
 
 	return bab, nil
 }
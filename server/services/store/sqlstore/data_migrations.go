@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -24,6 +25,11 @@ const (
 	CategoryUUIDIDMigrationKey          = "CategoryUuidIdMigrationComplete"
 	TeamLessBoardsMigrationKey          = "TeamLessBoardsMigrationComplete"
 	DeletedMembershipBoardsMigrationKey = "DeletedMembershipBoardsMigrationComplete"
+	JSONBFieldsMigrationKey             = "JSONBFieldsMigrationComplete"
+
+	// jsonbFieldsMigrationLockTimeout is how long the jsonb fields
+	// migration waits to lock a table before failing the startup.
+	jsonbFieldsMigrationLockTimeout = "30s"
 )
 
 func (s *SQLStore) getBlocksWithSameID(db sq.BaseRunner) ([]model.Block, error) {
@@ -645,3 +651,98 @@ func (s *SQLStore) getDeletedMembershipBoards(tx sq.BaseRunner) ([]*model.Board,
 
 	return boards, err
 }
+
+// RunJSONBFieldsMigration converts the fields of the blocks from json to
+// jsonb on PostgreSQL, if enabled, and adds a GIN index on the card
+// properties so they can be filtered by value with the containment
+// operator. The other databases keep storing the fields as text.
+//
+// Changing the type rewrites the table while holding an ACCESS EXCLUSIVE
+// lock on it, so each table is converted in its own transaction, which
+// holds the lock only for the rewrite of that table. Waiting for the lock
+// is bounded by jsonbFieldsMigrationLockTimeout, so the server doesn't
+// hang on startup behind another session. A table already converted by
+// an interrupted run is skipped.
+func (s *SQLStore) RunJSONBFieldsMigration() error {
+	if s.dbType != model.PostgresDBType || !s.jsonbFields {
+		return nil
+	}
+
+	setting, err := s.GetSystemSetting(JSONBFieldsMigrationKey)
+	if err != nil {
+		return fmt.Errorf("cannot get jsonb fields migration state: %w", err)
+	}
+
+	// If the migration is already completed, do not run it again.
+	if hasAlreadyRun, _ := strconv.ParseBool(setting); hasAlreadyRun {
+		return nil
+	}
+
+	s.logger.Info("Converting the block fields to jsonb, this can take a while on large databases")
+
+	// the GIN index is built while the blocks table is locked anyway
+	indexStatement := fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%sblocks_fields_properties ON %sblocks USING GIN ((fields->'properties') jsonb_path_ops)",
+		s.tablePrefix, s.tablePrefix)
+
+	if err := s.convertFieldsToJSONB("blocks_history"); err != nil {
+		return err
+	}
+	if err := s.convertFieldsToJSONB("blocks", indexStatement); err != nil {
+		return err
+	}
+
+	if err := s.setSystemSetting(s.db, JSONBFieldsMigrationKey, strconv.FormatBool(true)); err != nil {
+		return fmt.Errorf("cannot mark migration as completed: %w", err)
+	}
+
+	s.logger.Info("Block fields converted to jsonb")
+	return nil
+}
+
+// convertFieldsToJSONB changes the type of the fields column of the table
+// to jsonb, and runs the extra statements in the same transaction.
+func (s *SQLStore) convertFieldsToJSONB(table string, extraStatements ...string) error {
+	var dataType string
+	err := s.db.QueryRow(
+		"SELECT data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 AND column_name = 'fields'",
+		s.tablePrefix+table,
+	).Scan(&dataType)
+	if err != nil {
+		return fmt.Errorf("cannot get the type of the %s fields: %w", table, err)
+	}
+
+	statements := []string{
+		fmt.Sprintf("SET LOCAL lock_timeout = '%s'", jsonbFieldsMigrationLockTimeout),
+	}
+	if dataType != "jsonb" {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s%s ALTER COLUMN fields TYPE jsonb USING fields::jsonb", s.tablePrefix, table))
+	}
+	statements = append(statements, extraStatements...)
+
+	start := time.Now()
+	tx, err := s.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		s.logger.Error("error starting transaction in convertFieldsToJSONB", mlog.Err(err))
+		return err
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "convertFieldsToJSONB"))
+			}
+			return fmt.Errorf("cannot convert the %s fields to jsonb: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("failed to commit convertFieldsToJSONB transaction", mlog.Err(err))
+		return err
+	}
+
+	s.logger.Info("Converted the fields to jsonb",
+		mlog.String("table", s.tablePrefix+table),
+		mlog.Int64("locked_ms", time.Since(start).Milliseconds()),
+	)
+	return nil
+}
@@ -234,3 +234,60 @@ func TestRunUniqueIDsMigration(t *testing.T) {
 	require.NotEqual(t, block4.ID, newBlock4.BoardID)
 	require.NotEqual(t, block5.ID, newBlock5.ParentID)
 }
+
+//nolint:gosec
+func TestRunJSONBFieldsMigration(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	if sqlStore.dbType != model.PostgresDBType {
+		t.Skip("the block fields are only converted to jsonb on PostgreSQL")
+	}
+
+	block := &model.Block{
+		ID:      "block-id-jsonb",
+		BoardID: "board-id",
+		Type:    model.TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"property-id": "option-id"},
+		},
+	}
+	require.NoError(t, sqlStore.InsertBlock(block, "user-id"))
+
+	sqlStore.jsonbFields = true
+	defer func() { sqlStore.jsonbFields = false }()
+	require.NoError(t, sqlStore.SetSystemSetting(JSONBFieldsMigrationKey, "false"))
+
+	// an interrupted run converted the history only
+	_, err := sqlStore.db.Exec("ALTER TABLE " + sqlStore.tablePrefix + "blocks_history ALTER COLUMN fields TYPE jsonb USING fields::jsonb")
+	require.NoError(t, err)
+
+	require.NoError(t, sqlStore.RunJSONBFieldsMigration())
+
+	for _, table := range []string{"blocks", "blocks_history"} {
+		var dataType string
+		err := sqlStore.db.QueryRow(
+			"SELECT data_type FROM information_schema.columns WHERE table_name = $1 AND column_name = 'fields'",
+			sqlStore.tablePrefix+table,
+		).Scan(&dataType)
+		require.NoError(t, err)
+		require.Equal(t, "jsonb", dataType)
+	}
+
+	// the existing blocks are kept and can be queried by property value
+	got, err := sqlStore.GetBlock(block.ID)
+	require.NoError(t, err)
+	require.Equal(t, block.Fields, got.Fields)
+
+	var count int
+	err = sqlStore.db.QueryRow(
+		"SELECT COUNT(*) FROM "+sqlStore.tablePrefix+"blocks WHERE fields->'properties' @> $1::jsonb",
+		`{"property-id": "option-id"}`,
+	).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	// running it again does nothing
+	require.NoError(t, sqlStore.RunJSONBFieldsMigration())
+}
@@ -0,0 +1,143 @@
+package sqlstore
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// mysqlTLSConfigName is the name the database TLS settings are
+// registered with in the MySQL driver.
+const mysqlTLSConfigName = "focalboard-db"
+
+var ErrDBTLSClientCertAndKey = errors.New("the database TLS client certificate and key must be set together")
+
+// DBTLSConfig contains the TLS settings of the database connection.
+type DBTLSConfig struct {
+	// CACertFile is the path of the PEM file with the CA certificates
+	// used to verify the database server. If empty, the system
+	// certificates are used.
+	CACertFile string
+
+	// ClientCertFile and ClientKeyFile are the paths of the PEM files
+	// with the client certificate and key, for databases that require
+	// client authentication.
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// ApplyDBTLSConfig returns the connection string with the TLS settings
+// set in the format of the database driver. The certificate files are
+// loaded upfront so a missing or malformed file is reported before
+// connecting.
+func ApplyDBTLSConfig(dbType, connectionString string, cfg DBTLSConfig) (string, error) {
+	tlsConfig, err := cfg.load()
+	if err != nil {
+		return "", err
+	}
+
+	switch dbType {
+	case model.PostgresDBType:
+		return cfg.applyToPostgres(connectionString)
+	case model.MysqlDBType:
+		if err = mysql.RegisterTLSConfig(mysqlTLSConfigName, tlsConfig); err != nil {
+			return "", fmt.Errorf("cannot register the database TLS config: %w", err)
+		}
+
+		var dsn *mysql.Config
+		dsn, err = mysql.ParseDSN(connectionString)
+		if err != nil {
+			return "", fmt.Errorf("cannot parse the database connection string: %w", err)
+		}
+		dsn.TLSConfig = mysqlTLSConfigName
+		return dsn.FormatDSN(), nil
+	default:
+		return "", fmt.Errorf("database TLS is not supported for %s databases", dbType)
+	}
+}
+
+func (c DBTLSConfig) load() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.CACertFile != "" {
+		caCert, err := os.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the database CA certificate %s: %w", c.CACertFile, err)
+		}
+
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("the database CA certificate %s contains no valid PEM certificates", c.CACertFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		return nil, ErrDBTLSClientCertAndKey
+	}
+
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the database client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// applyToPostgres sets the lib/pq ssl parameters, supporting both the
+// URL and the key/value connection string formats.
+func (c DBTLSConfig) applyToPostgres(connectionString string) (string, error) {
+	// without a CA the server certificate can't be verified, so only
+	// the encryption is required.
+	sslMode := "require"
+	if c.CACertFile != "" {
+		sslMode = "verify-full"
+	}
+
+	params := [][2]string{{"sslmode", sslMode}}
+	if c.CACertFile != "" {
+		params = append(params, [2]string{"sslrootcert", c.CACertFile})
+	}
+	if c.ClientCertFile != "" {
+		params = append(params,
+			[2]string{"sslcert", c.ClientCertFile},
+			[2]string{"sslkey", c.ClientKeyFile},
+		)
+	}
+
+	if strings.HasPrefix(connectionString, "postgres://") || strings.HasPrefix(connectionString, "postgresql://") {
+		u, err := url.Parse(connectionString)
+		if err != nil {
+			return "", fmt.Errorf("cannot parse the database connection string: %w", err)
+		}
+
+		query := u.Query()
+		for _, param := range params {
+			query.Set(param[0], param[1])
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	// in the key/value format, the last occurrence of a key wins.
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSpace(connectionString))
+	for _, param := range params {
+		value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(param[1])
+		fmt.Fprintf(&sb, " %s='%s'", param[0], value)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
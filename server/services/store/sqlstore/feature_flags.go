@@ -0,0 +1,62 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (s *SQLStore) getTeamFeatureFlags(db sq.BaseRunner, teamID string) (map[string]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("name", "value").
+		From(s.tablePrefix + "feature_flags").
+		Where(sq.Eq{"team_id": teamID})
+
+	rows, err := query.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	results := map[string]string{}
+
+	for rows.Next() {
+		var name string
+		var value string
+
+		err := rows.Scan(&name, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		results[name] = value
+	}
+
+	return results, nil
+}
+
+func (s *SQLStore) setTeamFeatureFlag(db sq.BaseRunner, teamID, name, value string) error {
+	now := utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"feature_flags").
+		Columns("team_id", "name", "value", "update_at").
+		Values(teamID, name, value, now)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE value = ?, update_at = ?", value, now)
+	} else {
+		query = query.Suffix("ON CONFLICT (team_id, name) DO UPDATE SET value = EXCLUDED.value, update_at = EXCLUDED.update_at")
+	}
+
+	_, err := query.Exec()
+	return err
+}
+
+func (s *SQLStore) deleteTeamFeatureFlag(db sq.BaseRunner, teamID, name string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "feature_flags").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"name": name}).
+		Exec()
+	return err
+}
@@ -0,0 +1,126 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *SQLStore) saveFileReference(db sq.BaseRunner, ref *model.FileReference) error {
+	// the reference can't be added while the last reference of the same
+	// content is being deleted, so the deletion counts it
+	if s.dbType == model.SqliteDBType {
+		s.fileReferencesMux.Lock()
+		defer s.fileReferencesMux.Unlock()
+	} else if _, err := s.lockFileContentReferences(db, ref.TeamID, ref.ContentHash); err != nil {
+		return err
+	}
+
+	_, err := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"file_references").
+		Columns("file_id", "team_id", "content_hash", "create_at").
+		Values(ref.FileID, ref.TeamID, ref.ContentHash, ref.CreateAt).
+		Exec()
+	return err
+}
+
+func (s *SQLStore) getFileReference(db sq.BaseRunner, fileID string) (*model.FileReference, error) {
+	row := s.getQueryBuilder(db).
+		Select("file_id", "team_id", "content_hash", "create_at").
+		From(s.tablePrefix + "file_references").
+		Where(sq.Eq{"file_id": fileID}).
+		QueryRow()
+
+	var ref model.FileReference
+	err := row.Scan(&ref.FileID, &ref.TeamID, &ref.ContentHash, &ref.CreateAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewErrNotFound("file reference ID=" + fileID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// lockFileContentReferences locks the references of a content until
+// the end of the current transaction, and returns the IDs of their
+// files. The locking read returns the latest committed references.
+func (s *SQLStore) lockFileContentReferences(db sq.BaseRunner, teamID, contentHash string) ([]string, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select("file_id").
+		From(s.tablePrefix + "file_references").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"content_hash": contentHash}).
+		Suffix("FOR UPDATE").
+		Query()
+	if err != nil {
+		s.logger.Error(`lockFileContentReferences ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	fileIDs := []string{}
+	for rows.Next() {
+		var fileID string
+		if err = rows.Scan(&fileID); err != nil {
+			return nil, err
+		}
+		fileIDs = append(fileIDs, fileID)
+	}
+	return fileIDs, rows.Err()
+}
+
+// deleteFileReference deletes the reference of a file and returns how
+// many files still reference the same content. The references of the
+// content are locked while counting, so the deletions of its last
+// references can't both see the other one, and a reference can't be
+// added in between.
+func (s *SQLStore) deleteFileReference(db sq.BaseRunner, fileID string) (int64, error) {
+	if s.dbType == model.SqliteDBType {
+		s.fileReferencesMux.Lock()
+		defer s.fileReferencesMux.Unlock()
+	}
+
+	ref, err := s.getFileReference(db, fileID)
+	if err != nil {
+		return 0, err
+	}
+
+	var remaining int64
+	if s.dbType == model.SqliteDBType {
+		err = s.getQueryBuilder(db).
+			Select("COUNT(*)").
+			From(s.tablePrefix + "file_references").
+			Where(sq.Eq{"team_id": ref.TeamID}).
+			Where(sq.Eq{"content_hash": ref.ContentHash}).
+			Where(sq.NotEq{"file_id": fileID}).
+			QueryRow().
+			Scan(&remaining)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		fileIDs, lockErr := s.lockFileContentReferences(db, ref.TeamID, ref.ContentHash)
+		if lockErr != nil {
+			return 0, lockErr
+		}
+		for _, id := range fileIDs {
+			if id != fileID {
+				remaining++
+			}
+		}
+	}
+
+	if _, err = s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "file_references").
+		Where(sq.Eq{"file_id": fileID}).
+		Exec(); err != nil {
+		return 0, err
+	}
+	return remaining, nil
+}
@@ -12,6 +12,16 @@ import (
 )
 
 func SetupTests(t *testing.T) (store.Store, func()) {
+	return setupTests(t, false)
+}
+
+// SetupTestsWithJSONBFields works as SetupTests, with the block fields
+// converted to jsonb on PostgreSQL.
+func SetupTestsWithJSONBFields(t *testing.T) (store.Store, func()) {
+	return setupTests(t, true)
+}
+
+func setupTests(t *testing.T, jsonbFields bool) (store.Store, func()) {
 	dbType, connectionString, err := PrepareNewTestDatabase()
 	require.NoError(t, err)
 
@@ -29,6 +39,7 @@ func SetupTests(t *testing.T) (store.Store, func()) {
 		Logger:           logger,
 		DB:               sqlDB,
 		IsPlugin:         false,
+		JSONBFields:      jsonbFields,
 	}
 	store, err := New(storeParams)
 	require.NoError(t, err)
@@ -0,0 +1,145 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func inviteFields() []string {
+	return []string{
+		"token",
+		"team_id",
+		"role",
+		"max_uses",
+		"use_count",
+		"create_at",
+		"expires_at",
+	}
+}
+
+func (s *SQLStore) invitesFromRows(rows *sql.Rows) ([]*model.Invite, error) {
+	invites := []*model.Invite{}
+	for rows.Next() {
+		var invite model.Invite
+		err := rows.Scan(
+			&invite.Token,
+			&invite.TeamID,
+			&invite.Role,
+			&invite.MaxUses,
+			&invite.UseCount,
+			&invite.CreateAt,
+			&invite.ExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		invites = append(invites, &invite)
+	}
+	return invites, nil
+}
+
+func (s *SQLStore) createInvite(db sq.BaseRunner, invite *model.Invite) error {
+	_, err := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"invites").
+		Columns(inviteFields()...).
+		Values(
+			invite.Token,
+			invite.TeamID,
+			invite.Role,
+			invite.MaxUses,
+			invite.UseCount,
+			invite.CreateAt,
+			invite.ExpiresAt,
+		).
+		Exec()
+	return err
+}
+
+func (s *SQLStore) getInvite(db sq.BaseRunner, token string) (*model.Invite, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(inviteFields()...).
+		From(s.tablePrefix + "invites").
+		Where(sq.Eq{"token": token}).
+		Query()
+	if err != nil {
+		s.logger.Error(`getInvite ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	invites, err := s.invitesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invites) == 0 {
+		return nil, model.NewErrNotFound("invite")
+	}
+	return invites[0], nil
+}
+
+func (s *SQLStore) getInvitesForTeam(db sq.BaseRunner, teamID string) ([]*model.Invite, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(inviteFields()...).
+		From(s.tablePrefix+"invites").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("create_at", "token").
+		Query()
+	if err != nil {
+		s.logger.Error(`getInvitesForTeam ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.invitesFromRows(rows)
+}
+
+func (s *SQLStore) deleteInvite(db sq.BaseRunner, token string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "invites").
+		Where(sq.Eq{"token": token}).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("invite")
+	}
+	return nil
+}
+
+// createUserWithInvite uses the invite and creates the user. The use
+// count is only increased if the invite is still usable, so concurrent
+// registrations can't exceed its limit.
+func (s *SQLStore) createUserWithInvite(db sq.BaseRunner, user *model.User, token string) (*model.User, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"invites").
+		Set("use_count", sq.Expr("use_count + 1")).
+		Where(sq.Eq{"token": token}).
+		Where("use_count < max_uses").
+		Where(sq.Gt{"expires_at": utils.GetMillis()}).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrNotFound("invite")
+	}
+
+	return s.createUser(db, user)
+}
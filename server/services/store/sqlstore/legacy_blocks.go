@@ -33,6 +33,8 @@ func legacyBoardFields(prefix string) []string {
 		"template_version",
 		"COALESCE(properties, '{}')",
 		"COALESCE(card_properties, '[]')",
+		"''", // substitute for card_template_id column.
+		"0",  // substitute for last_activity_at column.
 		"create_at",
 		"update_at",
 		"delete_at",
@@ -47,7 +49,7 @@ func legacyBoardFields(prefix string) []string {
 		switch {
 		case strings.HasPrefix(field, "COALESCE("):
 			prefixedFields[i] = strings.Replace(field, "COALESCE(", "COALESCE("+prefix, 1)
-		case field == "''":
+		case field == "''", field == "0":
 			prefixedFields[i] = field
 		default:
 			prefixedFields[i] = prefix + field
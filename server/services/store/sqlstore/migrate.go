@@ -7,6 +7,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"strings"
 
 	"text/template"
 
@@ -38,6 +39,10 @@ const (
 
 var errChannelCreatorNotInTeam = errors.New("channel creator not found in user teams")
 
+// ErrSchemaOutdated is returned when the database has not been
+// migrated to the version expected by the server.
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
 // migrations in MySQL need to run with the multiStatements flag
 // enabled, so this method creates a new connection ensuring that it's
 // enabled.
@@ -244,7 +249,14 @@ func (s *SQLStore) runMigrationSequence(engine *morph.Morph, driver drivers.Driv
 	s.logger.Debug("== Applying all remaining migrations ====================",
 		mlog.Int("current_version", len(appliedMigrations)))
 
-	return engine.ApplyAll()
+	if err = engine.ApplyAll(); err != nil {
+		return err
+	}
+
+	if mErr := s.RunJSONBFieldsMigration(); mErr != nil {
+		return fmt.Errorf("error running jsonb fields migration: %w", mErr)
+	}
+	return nil
 }
 
 func (s *SQLStore) ensureMigrationsAppliedUpToVersion(engine *morph.Morph, driver drivers.Driver, version int) error {
@@ -276,3 +288,52 @@ func (s *SQLStore) ensureMigrationsAppliedUpToVersion(engine *morph.Morph, drive
 
 	return nil
 }
+
+// expectedSchemaVersion returns the version the database has once all
+// the migrations bundled with the server have been applied.
+func expectedSchemaVersion() (int, error) {
+	assetsList, err := Assets.ReadDir("migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	for _, dirEntry := range assetsList {
+		if strings.HasSuffix(dirEntry.Name(), ".up.sql") {
+			version++
+		}
+	}
+	return version, nil
+}
+
+// CheckSchemaVersion verifies that all the migrations bundled with the
+// server have been applied. It is used instead of Migrate when the
+// migrations are run separately from the server startup.
+func (s *SQLStore) CheckSchemaVersion() error {
+	expectedVersion, err := expectedSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(s.db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "schema_migrations")
+
+	var currentVersion int
+	if err := query.QueryRow().Scan(&currentVersion); err != nil {
+		return fmt.Errorf("cannot read the schema version: %w", err)
+	}
+
+	if currentVersion < expectedVersion {
+		return fmt.Errorf("%w: current version is %d, expected %d", ErrSchemaOutdated, currentVersion, expectedVersion)
+	}
+
+	if currentVersion > expectedVersion {
+		s.logger.Warn("Database schema is newer than the server",
+			mlog.Int("current_version", currentVersion),
+			mlog.Int("expected_version", expectedVersion),
+		)
+	}
+
+	return nil
+}
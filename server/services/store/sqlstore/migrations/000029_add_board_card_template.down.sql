@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}boards DROP COLUMN card_template_id;
+ALTER TABLE {{.prefix}}boards_history DROP COLUMN card_template_id;
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}boards ADD COLUMN card_template_id VARCHAR(36) NOT NULL DEFAULT '';
+ALTER TABLE {{.prefix}}boards_history ADD COLUMN card_template_id VARCHAR(36) NOT NULL DEFAULT '';
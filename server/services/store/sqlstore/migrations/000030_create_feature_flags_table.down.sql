@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}feature_flags;
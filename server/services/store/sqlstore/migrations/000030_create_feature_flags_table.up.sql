@@ -0,0 +1,8 @@
+create table {{.prefix}}feature_flags
+(
+    team_id   varchar(36) not null,
+    name      varchar(64) not null,
+    value     text        null,
+    update_at bigint      not null,
+    primary key (team_id, name)
+    );
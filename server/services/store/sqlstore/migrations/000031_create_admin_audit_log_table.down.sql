@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}admin_audit_log;
@@ -0,0 +1,15 @@
+create table {{.prefix}}admin_audit_log
+(
+    id        varchar(36)  not null,
+    actor     varchar(64)  not null,
+    action    varchar(64)  not null,
+    target    varchar(255) not null,
+    create_at bigint       not null,
+    primary key (id)
+    );
+
+create index idx_{{.prefix}}admin_audit_log_create_at
+    on {{.prefix}}admin_audit_log (create_at);
+
+create index idx_{{.prefix}}admin_audit_log_actor_action
+    on {{.prefix}}admin_audit_log (actor, action);
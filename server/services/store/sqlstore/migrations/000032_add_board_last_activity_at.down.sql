@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}boards DROP COLUMN last_activity_at;
+ALTER TABLE {{.prefix}}boards_history DROP COLUMN last_activity_at;
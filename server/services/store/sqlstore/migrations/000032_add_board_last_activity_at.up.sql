@@ -0,0 +1,8 @@
+ALTER TABLE {{.prefix}}boards ADD COLUMN last_activity_at BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE {{.prefix}}boards_history ADD COLUMN last_activity_at BIGINT NOT NULL DEFAULT 0;
+
+UPDATE {{.prefix}}boards SET last_activity_at = COALESCE(
+    (SELECT MAX(update_at) FROM {{.prefix}}blocks WHERE {{.prefix}}blocks.board_id = {{.prefix}}boards.id),
+    0
+);
+UPDATE {{.prefix}}boards SET last_activity_at = update_at WHERE update_at > last_activity_at;
@@ -0,0 +1,14 @@
+create table {{.prefix}}invites
+(
+    token      varchar(36) not null,
+    team_id    varchar(36) not null,
+    role       varchar(16) not null,
+    max_uses   int         not null,
+    use_count  int         not null,
+    create_at  bigint      not null,
+    expires_at bigint      not null,
+    primary key (token)
+    );
+
+create index idx_{{.prefix}}invites_team_id
+    on {{.prefix}}invites (team_id);
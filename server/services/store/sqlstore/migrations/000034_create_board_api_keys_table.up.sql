@@ -0,0 +1,17 @@
+create table {{.prefix}}board_api_keys
+(
+    id         varchar(36)  not null,
+    board_id   varchar(36)  not null,
+    name       varchar(100) not null,
+    key_hash   varchar(64)  not null,
+    permission varchar(16)  not null,
+    created_by varchar(36)  not null,
+    create_at  bigint       not null,
+    primary key (id)
+    );
+
+create unique index idx_{{.prefix}}board_api_keys_key_hash
+    on {{.prefix}}board_api_keys (key_hash);
+
+create index idx_{{.prefix}}board_api_keys_board_id
+    on {{.prefix}}board_api_keys (board_id);
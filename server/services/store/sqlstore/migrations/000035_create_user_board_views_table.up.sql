@@ -0,0 +1,14 @@
+create table {{.prefix}}user_board_views
+(
+    user_id          varchar(36) not null,
+    board_id         varchar(36) not null,
+    active_view_id   varchar(36),
+    sort_options     {{if .postgres}}JSON{{else}}TEXT{{end}},
+    filter_group     {{if .postgres}}JSON{{else}}TEXT{{end}},
+    collapsed_groups {{if .postgres}}JSON{{else}}TEXT{{end}},
+    update_at        bigint      not null,
+    primary key (user_id, board_id)
+    );
+
+create index idx_{{.prefix}}user_board_views_board_id
+    on {{.prefix}}user_board_views (board_id);
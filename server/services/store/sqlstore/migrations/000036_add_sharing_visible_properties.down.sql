@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}sharing
+DROP COLUMN visible_properties;
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}sharing
+ADD COLUMN visible_properties {{if .postgres}}JSON{{else}}TEXT{{end}};
@@ -0,0 +1,6 @@
+create table {{.prefix}}board_sequences
+(
+    board_id varchar(36) not null,
+    value    bigint      not null,
+    primary key (board_id)
+    );
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}file_references;
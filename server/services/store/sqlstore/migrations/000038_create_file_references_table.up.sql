@@ -0,0 +1,11 @@
+create table {{.prefix}}file_references
+(
+    file_id      varchar(26) not null,
+    team_id      varchar(36) not null,
+    content_hash varchar(64) not null,
+    create_at    bigint      not null,
+    primary key (file_id)
+    ) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+create index idx_{{.prefix}}file_references_team_id_content_hash
+    on {{.prefix}}file_references (team_id, content_hash);
@@ -0,0 +1,10 @@
+create table {{.prefix}}board_favorites
+(
+    user_id   varchar(36) not null,
+    board_id  varchar(36) not null,
+    create_at bigint      not null,
+    primary key (user_id, board_id)
+    );
+
+create index idx_{{.prefix}}board_favorites_board_id
+    on {{.prefix}}board_favorites (board_id);
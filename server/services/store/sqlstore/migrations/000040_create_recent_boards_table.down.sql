@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}recent_boards;
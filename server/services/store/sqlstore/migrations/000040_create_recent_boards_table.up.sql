@@ -0,0 +1,10 @@
+create table {{.prefix}}recent_boards
+(
+    user_id   varchar(36) not null,
+    board_id  varchar(36) not null,
+    viewed_at bigint      not null,
+    primary key (user_id, board_id)
+    );
+
+create index idx_{{.prefix}}recent_boards_board_id
+    on {{.prefix}}recent_boards (board_id);
@@ -0,0 +1,10 @@
+create table {{.prefix}}board_slugs
+(
+    team_id  varchar(36)  not null,
+    slug     varchar(64)  not null,
+    board_id varchar(36)  not null,
+    primary key (team_id, slug)
+    );
+
+create unique index idx_{{.prefix}}board_slugs_board_id
+    on {{.prefix}}board_slugs (board_id);
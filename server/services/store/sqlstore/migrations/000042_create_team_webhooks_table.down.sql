@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}team_webhooks;
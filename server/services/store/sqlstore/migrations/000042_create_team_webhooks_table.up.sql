@@ -0,0 +1,13 @@
+create table {{.prefix}}team_webhooks
+(
+    id          varchar(36)  not null,
+    team_id     varchar(36)  not null,
+    url         text         not null,
+    event_types varchar(255) not null,
+    create_at   bigint       not null,
+    update_at   bigint       not null,
+    primary key (id)
+    );
+
+create index idx_{{.prefix}}team_webhooks_team_id
+    on {{.prefix}}team_webhooks (team_id);
@@ -0,0 +1,10 @@
+create table {{.prefix}}board_last_seen
+(
+    user_id  varchar(36) not null,
+    board_id varchar(36) not null,
+    seen_at  bigint      not null,
+    primary key (user_id, board_id)
+    );
+
+create index idx_{{.prefix}}board_last_seen_board_id
+    on {{.prefix}}board_last_seen (board_id);
@@ -3,6 +3,7 @@ package sqlstore
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 
@@ -26,6 +27,27 @@ type Params struct {
 	NewMutexFn       MutexFactory
 	ServicesAPI      servicesAPI
 	SkipMigrations   bool
+
+	// CheckSchemaVersion makes the store refuse to start if the
+	// migrations were skipped and the database schema is outdated.
+	CheckSchemaVersion bool
+
+	// SlowQueryThreshold is the duration after which a query is
+	// logged as slow. Zero disables the slow query log.
+	SlowQueryThreshold time.Duration
+
+	// JSONBFields converts the fields of the blocks to jsonb on
+	// PostgreSQL, so the card properties can be indexed.
+	JSONBFields bool
+
+	// TransactionRetries is the number of times the transactional
+	// methods are run again after a deadlock or a serialization
+	// failure. Zero disables the retries.
+	TransactionRetries int
+
+	// MaxBlockTreeDepth is the number of levels the recursive block
+	// queries walk before failing. Zero uses the default depth.
+	MaxBlockTreeDepth int
 }
 
 func (p Params) CheckValid() error {
@@ -22,27 +22,40 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+func (s *SQLStore) AddBoardFavorite(favorite *model.BoardFavorite) error {
+	return s.addBoardFavorite(s.db, favorite)
+
+}
+
 func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, blockID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.addUpdateCategoryBoard(s.db, userID, categoryID, blockID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.addUpdateCategoryBoard(tx, userID, categoryID, blockID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "AddUpdateCategoryBoard"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.addUpdateCategoryBoard(tx, userID, categoryID, blockID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "AddUpdateCategoryBoard"))
+			}
+			if s.retryTransaction("AddUpdateCategoryBoard", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("AddUpdateCategoryBoard", attempt, err) {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
@@ -56,27 +69,45 @@ func (s *SQLStore) CleanUpSessions(expireTime int64) error {
 
 }
 
+func (s *SQLStore) CompactBlockHistory(policy *model.BlockHistoryCompactionPolicy, now int64) (int64, error) {
+	return s.compactBlockHistory(s.db, policy, now)
+
+}
+
+func (s *SQLStore) CreateBoardAPIKey(key *model.BoardAPIKey, keyHash string) error {
+	return s.createBoardAPIKey(s.db, key, keyHash)
+
+}
+
 func (s *SQLStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.createBoardsAndBlocks(s.db, bab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
-	}
-	result, err := s.createBoardsAndBlocks(tx, bab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocks"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.createBoardsAndBlocks(tx, bab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocks"))
+			}
+			if s.retryTransaction("CreateBoardsAndBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
 		}
-		return nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("CreateBoardsAndBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
 
-	return result, nil
+		return result, nil
+	}
 
 }
 
@@ -84,23 +115,31 @@ func (s *SQLStore) CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, us
 	if s.dbType == model.SqliteDBType {
 		return s.createBoardsAndBlocksWithAdmin(s.db, bab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, nil, txErr
-	}
-	result, resultVar1, err := s.createBoardsAndBlocksWithAdmin(tx, bab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocksWithAdmin"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, nil, txErr
+		}
+		result, resultVar1, err := s.createBoardsAndBlocksWithAdmin(tx, bab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateBoardsAndBlocksWithAdmin"))
+			}
+			if s.retryTransaction("CreateBoardsAndBlocksWithAdmin", attempt, err) {
+				continue
+			}
+			return nil, nil, err
 		}
-		return nil, nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, nil, err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("CreateBoardsAndBlocksWithAdmin", attempt, err) {
+				continue
+			}
+			return nil, nil, err
+		}
 
-	return result, resultVar1, nil
+		return result, resultVar1, nil
+	}
 
 }
 
@@ -109,6 +148,11 @@ func (s *SQLStore) CreateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) CreateInvite(invite *model.Invite) error {
+	return s.createInvite(s.db, invite)
+
+}
+
 func (s *SQLStore) CreateSession(session *model.Session) error {
 	return s.createSession(s.db, session)
 
@@ -119,32 +163,109 @@ func (s *SQLStore) CreateSubscription(sub *model.Subscription) (*model.Subscript
 
 }
 
+func (s *SQLStore) CreateTeamWebhook(webhook *model.TeamWebhook) error {
+	return s.createTeamWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) CreateUser(user *model.User) (*model.User, error) {
 	return s.createUser(s.db, user)
 
 }
 
+func (s *SQLStore) CreateUserWithInvite(user *model.User, token string) (*model.User, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.createUserWithInvite(s.db, user, token)
+	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.createUserWithInvite(tx, user, token)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateUserWithInvite"))
+			}
+			if s.retryTransaction("CreateUserWithInvite", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("CreateUserWithInvite", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+}
+
 func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBlock(s.db, blockID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.deleteBlock(tx, blockID, modifiedBy)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlock"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.deleteBlock(tx, blockID, modifiedBy)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlock"))
+			}
+			if s.retryTransaction("DeleteBlock", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DeleteBlock", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
+
+		return nil
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+}
+
+func (s *SQLStore) DeleteBlocks(blockIDs []string, modifiedBy string) ([]model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBlocks(s.db, blockIDs, modifiedBy)
 	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.deleteBlocks(tx, blockIDs, modifiedBy)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlocks"))
+			}
+			if s.retryTransaction("DeleteBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
 
-	return nil
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DeleteBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
 
 }
 
@@ -152,23 +273,41 @@ func (s *SQLStore) DeleteBoard(boardID string, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBoard(s.db, boardID, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.deleteBoard(tx, boardID, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoard"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.deleteBoard(tx, boardID, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoard"))
+			}
+			if s.retryTransaction("DeleteBoard", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DeleteBoard", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		return nil
 	}
 
-	return nil
+}
+
+func (s *SQLStore) DeleteBoardAPIKey(boardID string, keyID string) error {
+	return s.deleteBoardAPIKey(s.db, boardID, keyID)
+
+}
+
+func (s *SQLStore) DeleteBoardFavorite(userID string, boardID string) error {
+	return s.deleteBoardFavorite(s.db, userID, boardID)
 
 }
 
@@ -176,28 +315,105 @@ func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, user
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBoardsAndBlocks(s.db, dbab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.deleteBoardsAndBlocks(tx, dbab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardsAndBlocks"))
+			}
+			if s.retryTransaction("DeleteBoardsAndBlocks", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DeleteBoardsAndBlocks", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
+	return s.deleteCategory(s.db, categoryID, userID, teamID)
+
+}
+
+func (s *SQLStore) DeleteEmptyTeams(updatedBefore int64) ([]string, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteEmptyTeams(s.db, updatedBefore)
 	}
-	err := s.deleteBoardsAndBlocks(tx, dbab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardsAndBlocks"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.deleteEmptyTeams(tx, updatedBefore)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteEmptyTeams"))
+			}
+			if s.retryTransaction("DeleteEmptyTeams", attempt, err) {
+				continue
+			}
+			return nil, err
 		}
-		return err
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DeleteEmptyTeams", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+}
+
+func (s *SQLStore) DeleteFileReference(fileID string) (int64, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteFileReference(s.db, fileID)
 	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return 0, txErr
+		}
+		result, err := s.deleteFileReference(tx, fileID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteFileReference"))
+			}
+			if s.retryTransaction("DeleteFileReference", attempt, err) {
+				continue
+			}
+			return 0, err
+		}
 
-	return nil
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DeleteFileReference", attempt, err) {
+				continue
+			}
+			return 0, err
+		}
+
+		return result, nil
+	}
 
 }
 
-func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
-	return s.deleteCategory(s.db, categoryID, userID, teamID)
+func (s *SQLStore) DeleteInvite(token string) error {
+	return s.deleteInvite(s.db, token)
 
 }
 
@@ -221,27 +437,45 @@ func (s *SQLStore) DeleteSubscription(blockID string, subscriberID string) error
 
 }
 
+func (s *SQLStore) DeleteTeamFeatureFlag(teamID string, name string) error {
+	return s.deleteTeamFeatureFlag(s.db, teamID, name)
+
+}
+
+func (s *SQLStore) DeleteTeamWebhook(webhookID string) error {
+	return s.deleteTeamWebhook(s.db, webhookID)
+
+}
+
 func (s *SQLStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.duplicateBlock(s.db, boardID, blockID, userID, asTemplate)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
-	}
-	result, err := s.duplicateBlock(tx, boardID, blockID, userID, asTemplate)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBlock"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.duplicateBlock(tx, boardID, blockID, userID, asTemplate)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBlock"))
+			}
+			if s.retryTransaction("DuplicateBlock", attempt, err) {
+				continue
+			}
+			return nil, err
 		}
-		return nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DuplicateBlock", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
 
-	return result, nil
+		return result, nil
+	}
 
 }
 
@@ -249,23 +483,31 @@ func (s *SQLStore) DuplicateBoard(boardID string, userID string, toTeam string,
 	if s.dbType == model.SqliteDBType {
 		return s.duplicateBoard(s.db, boardID, userID, toTeam, asTemplate)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, nil, txErr
-	}
-	result, resultVar1, err := s.duplicateBoard(tx, boardID, userID, toTeam, asTemplate)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBoard"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, nil, txErr
+		}
+		result, resultVar1, err := s.duplicateBoard(tx, boardID, userID, toTeam, asTemplate)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DuplicateBoard"))
+			}
+			if s.retryTransaction("DuplicateBoard", attempt, err) {
+				continue
+			}
+			return nil, nil, err
 		}
-		return nil, nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, nil, err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("DuplicateBoard", attempt, err) {
+				continue
+			}
+			return nil, nil, err
+		}
 
-	return result, resultVar1, nil
+		return result, resultVar1, nil
+	}
 
 }
 
@@ -274,6 +516,16 @@ func (s *SQLStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
 
 }
 
+func (s *SQLStore) GetActiveUserCounts(updatedSecondsAgo []int64) ([]int, error) {
+	return s.getActiveUserCounts(s.db, updatedSecondsAgo)
+
+}
+
+func (s *SQLStore) GetAdminAuditEntries(opts model.QueryAdminAuditOptions) ([]*model.AdminAuditEntry, error) {
+	return s.getAdminAuditEntries(s.db, opts)
+
+}
+
 func (s *SQLStore) GetAllTeams() ([]*model.Team, error) {
 	return s.getAllTeams(s.db)
 
@@ -299,6 +551,11 @@ func (s *SQLStore) GetBlockHistoryDescendants(boardID string, opts model.QueryBl
 
 }
 
+func (s *SQLStore) GetBlockManifest(boardID string) ([]model.BlockManifestEntry, error) {
+	return s.getBlockManifest(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetBlocks(opts model.QueryBlocksOptions) ([]model.Block, error) {
 	return s.getBlocks(s.db, opts)
 
@@ -334,6 +591,16 @@ func (s *SQLStore) GetBoard(id string) (*model.Board, error) {
 
 }
 
+func (s *SQLStore) GetBoardAPIKeyByHash(keyHash string) (*model.BoardAPIKey, error) {
+	return s.getBoardAPIKeyByHash(s.db, keyHash)
+
+}
+
+func (s *SQLStore) GetBoardAPIKeys(boardID string) ([]*model.BoardAPIKey, error) {
+	return s.getBoardAPIKeys(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetBoardAndCard(block *model.Block) (*model.Board, *model.Block, error) {
 	return s.getBoardAndCard(s.db, block)
 
@@ -344,6 +611,11 @@ func (s *SQLStore) GetBoardAndCardByID(blockID string) (*model.Board, *model.Blo
 
 }
 
+func (s *SQLStore) GetBoardBlocksPage(boardID string, afterID string, limit uint64) ([]model.Block, error) {
+	return s.getBoardBlocksPage(s.db, boardID, afterID, limit)
+
+}
+
 func (s *SQLStore) GetBoardCount() (int64, error) {
 	return s.getBoardCount(s.db)
 
@@ -354,11 +626,41 @@ func (s *SQLStore) GetBoardHistory(boardID string, opts model.QueryBoardHistoryO
 
 }
 
+func (s *SQLStore) GetBoardIDBySlug(teamID string, slug string) (string, error) {
+	return s.getBoardIDBySlug(s.db, teamID, slug)
+
+}
+
 func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
 	return s.getBoardMemberHistory(s.db, boardID, userID, limit)
 
 }
 
+func (s *SQLStore) GetBoardRollup(boardID string, opts model.QueryRollupOptions) ([]*model.RollupGroup, error) {
+	return s.getBoardRollup(s.db, boardID, opts)
+
+}
+
+func (s *SQLStore) GetBoardSlug(boardID string) (string, error) {
+	return s.getBoardSlug(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetBoardStats(boardID string, opts model.QueryBoardStatsOptions) (*model.BoardStats, error) {
+	return s.getBoardStats(s.db, boardID, opts)
+
+}
+
+func (s *SQLStore) GetBoardUnreadCounts(userID string, boardIDs []string) (map[string]int64, error) {
+	return s.getBoardUnreadCounts(s.db, userID, boardIDs)
+
+}
+
+func (s *SQLStore) GetBoardsForTeam(teamID string) ([]*model.Board, error) {
+	return s.getBoardsForTeam(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetBoardsForUserAndTeam(userID string, teamID string, includePublicBoards bool) ([]*model.Board, error) {
 	return s.getBoardsForUserAndTeam(s.db, userID, teamID, includePublicBoards)
 
@@ -374,6 +676,11 @@ func (s *SQLStore) GetCardLimitTimestamp() (int64, error) {
 
 }
 
+func (s *SQLStore) GetCardProgress(boardID string, cardID string) (*model.CardProgress, error) {
+	return s.getCardProgress(s.db, boardID, cardID)
+
+}
+
 func (s *SQLStore) GetCategory(id string) (*model.Category, error) {
 	return s.getCategory(s.db, id)
 
@@ -389,11 +696,41 @@ func (s *SQLStore) GetCloudLimits() (*mmModel.ProductLimits, error) {
 
 }
 
+func (s *SQLStore) GetDeletedBlocksForBoard(boardID string, deletedAfter int64) ([]model.Block, error) {
+	return s.getDeletedBlocksForBoard(s.db, boardID, deletedAfter)
+
+}
+
+func (s *SQLStore) GetFavoriteBoardIDs(userID string) ([]string, error) {
+	return s.getFavoriteBoardIDs(s.db, userID)
+
+}
+
+func (s *SQLStore) GetFavoriteBoards(userID string) ([]*model.Board, error) {
+	return s.getFavoriteBoards(s.db, userID)
+
+}
+
 func (s *SQLStore) GetFileInfo(id string) (*mmModel.FileInfo, error) {
 	return s.getFileInfo(s.db, id)
 
 }
 
+func (s *SQLStore) GetFileReference(fileID string) (*model.FileReference, error) {
+	return s.getFileReference(s.db, fileID)
+
+}
+
+func (s *SQLStore) GetInvite(token string) (*model.Invite, error) {
+	return s.getInvite(s.db, token)
+
+}
+
+func (s *SQLStore) GetInvitesForTeam(teamID string) ([]*model.Invite, error) {
+	return s.getInvitesForTeam(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetLicense() *mmModel.License {
 	return s.getLicense(s.db)
 
@@ -424,6 +761,11 @@ func (s *SQLStore) GetNotificationHint(blockID string) (*model.NotificationHint,
 
 }
 
+func (s *SQLStore) GetRecentBoards(userID string) ([]*model.Board, error) {
+	return s.getRecentBoards(s.db, userID)
+
+}
+
 func (s *SQLStore) GetRegisteredUserCount() (int, error) {
 	return s.getRegisteredUserCount(s.db)
 
@@ -479,6 +821,11 @@ func (s *SQLStore) GetTeam(ID string) (*model.Team, error) {
 
 }
 
+func (s *SQLStore) GetTeamBoardCount(teamID string) (int64, error) {
+	return s.getTeamBoardCount(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetTeamBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
 	return s.getTeamBoardsInsights(s.db, teamID, userID, since, offset, limit, boardIDs)
 
@@ -489,6 +836,21 @@ func (s *SQLStore) GetTeamCount() (int64, error) {
 
 }
 
+func (s *SQLStore) GetTeamFeatureFlags(teamID string) (map[string]string, error) {
+	return s.getTeamFeatureFlags(s.db, teamID)
+
+}
+
+func (s *SQLStore) GetTeamWebhook(webhookID string) (*model.TeamWebhook, error) {
+	return s.getTeamWebhook(s.db, webhookID)
+
+}
+
+func (s *SQLStore) GetTeamWebhooks(teamID string) ([]*model.TeamWebhook, error) {
+	return s.getTeamWebhooks(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
 	return s.getTeamsForUser(s.db, userID)
 
@@ -504,6 +866,11 @@ func (s *SQLStore) GetUsedCardsCount() (int, error) {
 
 }
 
+func (s *SQLStore) GetUserBoardView(userID string, boardID string) (*model.UserBoardView, error) {
+	return s.getUserBoardView(s.db, userID, boardID)
+
+}
+
 func (s *SQLStore) GetUserBoardsInsights(teamID string, userID string, since int64, offset int, limit int, boardIDs []string) (*model.BoardInsightsList, error) {
 	return s.getUserBoardsInsights(s.db, teamID, userID, since, offset, limit, boardIDs)
 
@@ -549,27 +916,40 @@ func (s *SQLStore) GetUsersList(userIDs []string) ([]*model.User, error) {
 
 }
 
+func (s *SQLStore) InsertAdminAuditEntry(entry *model.AdminAuditEntry) error {
+	return s.insertAdminAuditEntry(s.db, entry)
+
+}
+
 func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.insertBlock(s.db, block, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.insertBlock(tx, block, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlock"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.insertBlock(tx, block, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlock"))
+			}
+			if s.retryTransaction("InsertBlock", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("InsertBlock", attempt, err) {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
@@ -577,23 +957,31 @@ func (s *SQLStore) InsertBlocks(blocks []model.Block, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.insertBlocks(s.db, blocks, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.insertBlocks(tx, blocks, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlocks"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.insertBlocks(tx, blocks, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBlocks"))
+			}
+			if s.retryTransaction("InsertBlocks", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("InsertBlocks", attempt, err) {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
@@ -606,23 +994,95 @@ func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*mod
 	if s.dbType == model.SqliteDBType {
 		return s.insertBoardWithAdmin(s.db, board, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, nil, txErr
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, nil, txErr
+		}
+		result, resultVar1, err := s.insertBoardWithAdmin(tx, board, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBoardWithAdmin"))
+			}
+			if s.retryTransaction("InsertBoardWithAdmin", attempt, err) {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("InsertBoardWithAdmin", attempt, err) {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		return result, resultVar1, nil
+	}
+
+}
+
+func (s *SQLStore) MoveBoard(boardID string, toTeamID string, userID string) (*model.Board, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.moveBoard(s.db, boardID, toTeamID, userID)
 	}
-	result, resultVar1, err := s.insertBoardWithAdmin(tx, board, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertBoardWithAdmin"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
 		}
-		return nil, nil, err
+		result, err := s.moveBoard(tx, boardID, toTeamID, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveBoard"))
+			}
+			if s.retryTransaction("MoveBoard", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("MoveBoard", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, nil, err
+}
+
+func (s *SQLStore) NextBoardSequence(boardID string, count int) (int64, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.nextBoardSequence(s.db, boardID, count)
 	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return 0, txErr
+		}
+		result, err := s.nextBoardSequence(tx, boardID, count)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "NextBoardSequence"))
+			}
+			if s.retryTransaction("NextBoardSequence", attempt, err) {
+				continue
+			}
+			return 0, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("NextBoardSequence", attempt, err) {
+				continue
+			}
+			return 0, err
+		}
 
-	return result, resultVar1, nil
+		return result, nil
+	}
 
 }
 
@@ -630,23 +1090,31 @@ func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, user
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlock(s.db, blockID, blockPatch, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.patchBlock(tx, blockID, blockPatch, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlock"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.patchBlock(tx, blockID, blockPatch, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlock"))
+			}
+			if s.retryTransaction("PatchBlock", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("PatchBlock", attempt, err) {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
@@ -654,23 +1122,31 @@ func (s *SQLStore) PatchBlocks(blockPatches *model.BlockPatchBatch, userID strin
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlocks(s.db, blockPatches, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.patchBlocks(tx, blockPatches, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlocks"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.patchBlocks(tx, blockPatches, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlocks"))
+			}
+			if s.retryTransaction("PatchBlocks", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("PatchBlocks", attempt, err) {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
@@ -678,23 +1154,31 @@ func (s *SQLStore) PatchBoard(boardID string, boardPatch *model.BoardPatch, user
 	if s.dbType == model.SqliteDBType {
 		return s.patchBoard(s.db, boardID, boardPatch, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
-	}
-	result, err := s.patchBoard(tx, boardID, boardPatch, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoard"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.patchBoard(tx, boardID, boardPatch, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoard"))
+			}
+			if s.retryTransaction("PatchBoard", attempt, err) {
+				continue
+			}
+			return nil, err
 		}
-		return nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("PatchBoard", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
 
-	return result, nil
+		return result, nil
+	}
 
 }
 
@@ -702,23 +1186,31 @@ func (s *SQLStore) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID
 	if s.dbType == model.SqliteDBType {
 		return s.patchBoardsAndBlocks(s.db, pbab, userID)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return nil, txErr
-	}
-	result, err := s.patchBoardsAndBlocks(tx, pbab, userID)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoardsAndBlocks"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.patchBoardsAndBlocks(tx, pbab, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBoardsAndBlocks"))
+			}
+			if s.retryTransaction("PatchBoardsAndBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
 		}
-		return nil, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("PatchBoardsAndBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
 
-	return result, nil
+		return result, nil
+	}
 
 }
 
@@ -746,23 +1238,36 @@ func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64)
 	if s.dbType == model.SqliteDBType {
 		return s.runDataRetention(s.db, globalRetentionDate, batchSize)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return 0, txErr
-	}
-	result, err := s.runDataRetention(tx, globalRetentionDate, batchSize)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunDataRetention"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return 0, txErr
+		}
+		result, err := s.runDataRetention(tx, globalRetentionDate, batchSize)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunDataRetention"))
+			}
+			if s.retryTransaction("RunDataRetention", attempt, err) {
+				continue
+			}
+			return 0, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("RunDataRetention", attempt, err) {
+				continue
+			}
+			return 0, err
 		}
-		return 0, err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, err
+		return result, nil
 	}
 
-	return result, nil
+}
+
+func (s *SQLStore) SaveBoardLastSeen(seen *model.BoardLastSeen) error {
+	return s.saveBoardLastSeen(s.db, seen)
 
 }
 
@@ -771,11 +1276,80 @@ func (s *SQLStore) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
 
 }
 
+func (s *SQLStore) SaveFileReference(ref *model.FileReference) error {
+	if s.dbType == model.SqliteDBType {
+		return s.saveFileReference(s.db, ref)
+	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.saveFileReference(tx, ref)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveFileReference"))
+			}
+			if s.retryTransaction("SaveFileReference", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("SaveFileReference", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
 func (s *SQLStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error) {
 	return s.saveMember(s.db, bm)
 
 }
 
+func (s *SQLStore) SaveRecentBoard(recent *model.RecentBoard, maxRecentBoards int) error {
+	if s.dbType == model.SqliteDBType {
+		return s.saveRecentBoard(s.db, recent, maxRecentBoards)
+	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.saveRecentBoard(tx, recent, maxRecentBoards)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveRecentBoard"))
+			}
+			if s.retryTransaction("SaveRecentBoard", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("SaveRecentBoard", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
+func (s *SQLStore) SaveUserBoardView(view *model.UserBoardView) error {
+	return s.saveUserBoardView(s.db, view)
+
+}
+
 func (s *SQLStore) SearchBoardsForUser(term string, userID string, includePublicBoards bool) ([]*model.Board, error) {
 	return s.searchBoardsForUser(s.db, term, userID, includePublicBoards)
 
@@ -801,32 +1375,141 @@ func (s *SQLStore) SendMessage(message string, postType string, receipts []strin
 
 }
 
+func (s *SQLStore) SetBoardSlug(boardID string, teamID string, slug string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.setBoardSlug(s.db, boardID, teamID, slug)
+	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.setBoardSlug(tx, boardID, teamID, slug)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SetBoardSlug"))
+			}
+			if s.retryTransaction("SetBoardSlug", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("SetBoardSlug", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+}
+
 func (s *SQLStore) SetSystemSetting(key string, value string) error {
 	return s.setSystemSetting(s.db, key, value)
 
 }
 
-func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
+func (s *SQLStore) SetTeamFeatureFlag(teamID string, name string, value string) error {
+	return s.setTeamFeatureFlag(s.db, teamID, name, value)
+
+}
+
+func (s *SQLStore) SyncBlocks(boardID string, changes []model.SyncBlockChange, userID string, onlyComments bool) (*model.SyncBlocksResult, error) {
 	if s.dbType == model.SqliteDBType {
-		return s.undeleteBlock(s.db, blockID, modifiedBy)
+		return s.syncBlocks(s.db, boardID, changes, userID, onlyComments)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.syncBlocks(tx, boardID, changes, userID, onlyComments)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SyncBlocks"))
+			}
+			if s.retryTransaction("SyncBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("SyncBlocks", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+}
+
+func (s *SQLStore) TransferBoardOwnership(boardID string, newOwnerID string, userID string) (*model.Board, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.transferBoardOwnership(s.db, boardID, newOwnerID, userID)
 	}
-	err := s.undeleteBlock(tx, blockID, modifiedBy)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBlock"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return nil, txErr
+		}
+		result, err := s.transferBoardOwnership(tx, boardID, newOwnerID, userID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "TransferBoardOwnership"))
+			}
+			if s.retryTransaction("TransferBoardOwnership", attempt, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("TransferBoardOwnership", attempt, err) {
+				continue
+			}
+			return nil, err
 		}
-		return err
+
+		return result, nil
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+}
+
+func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.undeleteBlock(s.db, blockID, modifiedBy)
 	}
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.undeleteBlock(tx, blockID, modifiedBy)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBlock"))
+			}
+			if s.retryTransaction("UndeleteBlock", attempt, err) {
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("UndeleteBlock", attempt, err) {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
@@ -834,23 +1517,31 @@ func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.undeleteBoard(s.db, boardID, modifiedBy)
 	}
-	tx, txErr := s.db.BeginTx(context.Background(), nil)
-	if txErr != nil {
-		return txErr
-	}
-	err := s.undeleteBoard(tx, boardID, modifiedBy)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBoard"))
+	for attempt := 0; ; attempt++ {
+		tx, txErr := s.db.BeginTx(context.Background(), nil)
+		if txErr != nil {
+			return txErr
+		}
+		err := s.undeleteBoard(tx, boardID, modifiedBy)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBoard"))
+			}
+			if s.retryTransaction("UndeleteBoard", attempt, err) {
+				continue
+			}
+			return err
 		}
-		return err
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
+		if err := tx.Commit(); err != nil {
+			if s.retryTransaction("UndeleteBoard", attempt, err) {
+				continue
+			}
+			return err
+		}
 
-	return nil
+		return nil
+	}
 
 }
 
@@ -874,6 +1565,11 @@ func (s *SQLStore) UpdateSubscribersNotifiedAt(blockID string, notifiedAt int64)
 
 }
 
+func (s *SQLStore) UpdateTeamWebhook(webhook *model.TeamWebhook) error {
+	return s.updateTeamWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) UpdateUser(user *model.User) (*model.User, error) {
 	return s.updateUser(s.db, user)
 
@@ -0,0 +1,97 @@
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// saveRecentBoard records that the user opened the board, keeping only
+// the last maxRecentBoards boards of the user.
+func (s *SQLStore) saveRecentBoard(db sq.BaseRunner, recent *model.RecentBoard, maxRecentBoards int) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"recent_boards").
+		Columns("user_id", "board_id", "viewed_at").
+		Values(recent.UserID, recent.BoardID, recent.ViewedAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE viewed_at = ?", recent.ViewedAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (user_id, board_id) DO UPDATE SET viewed_at = EXCLUDED.viewed_at")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		return err
+	}
+
+	return s.pruneRecentBoards(db, recent.UserID, maxRecentBoards)
+}
+
+// pruneRecentBoards deletes the boards of the user that aren't among the
+// last maxRecentBoards they opened.
+func (s *SQLStore) pruneRecentBoards(db sq.BaseRunner, userID string, maxRecentBoards int) error {
+	if maxRecentBoards <= 0 {
+		return nil
+	}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("board_id").
+		From(s.tablePrefix+"recent_boards").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("viewed_at DESC", "board_id").
+		Query()
+	if err != nil {
+		s.logger.Error(`pruneRecentBoards ERROR`, mlog.Err(err))
+		return err
+	}
+	defer s.CloseRows(rows)
+
+	boardIDs := []string{}
+	for rows.Next() {
+		var boardID string
+		if err = rows.Scan(&boardID); err != nil {
+			return err
+		}
+		boardIDs = append(boardIDs, boardID)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// the boards are pruned on every write, so there are usually none or
+	// one to delete
+	if len(boardIDs) <= maxRecentBoards {
+		return nil
+	}
+	return s.deleteRecentBoards(db, sq.Eq{"user_id": userID, "board_id": boardIDs[maxRecentBoards:]})
+}
+
+// deleteRecentBoards deletes the recent boards that match the condition,
+// used when a board is deleted or a user leaves it.
+func (s *SQLStore) deleteRecentBoards(db sq.BaseRunner, condition sq.Eq) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "recent_boards").
+		Where(condition).
+		Exec()
+	return err
+}
+
+// getRecentBoards returns the boards the user opened, the most recently
+// opened first.
+func (s *SQLStore) getRecentBoards(db sq.BaseRunner, userID string) ([]*model.Board, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		From(s.tablePrefix+"recent_boards as r").
+		Join(s.tablePrefix+"boards as b on b.id=r.board_id").
+		Where(sq.Eq{"r.user_id": userID}).
+		OrderBy("r.viewed_at DESC", "b.id").
+		Query()
+	if err != nil {
+		s.logger.Error(`getRecentBoards ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
@@ -0,0 +1,65 @@
+package sqlstore
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// transactionRetryBackoff is the wait before the first retry of a
+// transaction, doubled on each of the following ones.
+const transactionRetryBackoff = 50 * time.Millisecond
+
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+
+	mysqlLockWaitTimeout = 1205
+	mysqlDeadlock        = 1213
+)
+
+// isRetryableError returns true if the error is caused by the
+// contention with other transactions, so running the transaction again
+// can succeed.
+func isRetryableError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDeadlock || mysqlErr.Number == mysqlLockWaitTimeout
+	}
+
+	return false
+}
+
+// retryTransaction is called after a transaction failed and was rolled
+// back. If the error is retryable and the transaction has retries left,
+// it waits for the backoff and returns true so the transaction is run
+// again.
+func (s *SQLStore) retryTransaction(methodName string, attempt int, err error) bool {
+	if attempt >= s.transactionRetries || !isRetryableError(err) {
+		return false
+	}
+
+	backoff := transactionRetryBackoff << attempt
+	//nolint:gosec
+	// the jitter doesn't need a secure random number
+	backoff += time.Duration(rand.Int63n(int64(backoff)))
+
+	s.logger.Warn("Retrying transaction after a transient error",
+		mlog.String("methodName", methodName),
+		mlog.Int("attempt", attempt+1),
+		mlog.Duration("backoff", backoff),
+		mlog.Err(err),
+	)
+	time.Sleep(backoff)
+	return true
+}
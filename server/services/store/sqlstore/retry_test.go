@@ -0,0 +1,34 @@
+package sqlstore
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"postgres serialization failure", &pq.Error{Code: pqSerializationFailure}, true},
+		{"postgres deadlock", &pq.Error{Code: pqDeadlockDetected}, true},
+		{"postgres unique violation", &pq.Error{Code: "23505"}, false},
+		{"mysql deadlock", &mysql.MySQLError{Number: mysqlDeadlock}, true},
+		{"mysql lock wait timeout", &mysql.MySQLError{Number: mysqlLockWaitTimeout}, true},
+		{"mysql duplicate entry", &mysql.MySQLError{Number: 1062}, false},
+		{"wrapped deadlock", fmt.Errorf("cannot save block: %w", &pq.Error{Code: pqDeadlockDetected}), true},
+		{"other error", errors.New("connection refused"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.retryable, isRetryableError(tc.err))
+		})
+	}
+}
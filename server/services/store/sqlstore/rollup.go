@@ -0,0 +1,127 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// cardPropertyValue returns an expression that extracts the value of a
+// card property as text, and the argument it needs. The property ID is
+// passed as an argument so it never becomes part of the query.
+func (s *SQLStore) cardPropertyValue(propertyID string) (string, interface{}) {
+	if propertyID == "" {
+		return "''", nil
+	}
+
+	if s.dbType == model.PostgresDBType {
+		return "(fields->'properties'->>CAST(? AS TEXT))", propertyID
+	}
+
+	path := fmt.Sprintf(`$.properties."%s"`, propertyID)
+	if s.dbType == model.MysqlDBType {
+		return "JSON_UNQUOTE(JSON_EXTRACT(fields, ?))", path
+	}
+	return "json_extract(fields, ?)", path
+}
+
+func (s *SQLStore) cardPropertyColumn(propertyID, alias string) sq.Sqlizer {
+	expr, arg := s.cardPropertyValue(propertyID)
+	if arg == nil {
+		return sq.Expr(expr + " AS " + alias)
+	}
+	return sq.Expr(expr+" AS "+alias, arg)
+}
+
+// getBoardRollup aggregates the cards of a board grouped by the value of
+// a property. The counts are computed by the database, while the sums
+// and averages are computed from the values of the aggregated property,
+// as the cards can hold them both as numbers and as strings.
+func (s *SQLStore) getBoardRollup(db sq.BaseRunner, boardID string, opts model.QueryRollupOptions) ([]*model.RollupGroup, error) {
+	cards := s.getQueryBuilder(db).
+		Select().
+		Column(s.cardPropertyColumn(opts.GroupBy, "group_value")).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where(sq.Eq{"delete_at": 0}).
+		Where(fmt.Sprintf("(CASE WHEN %s THEN 1 ELSE 0 END) = 0", s.jsonFieldIsTrue("fields", "isTemplate")))
+
+	if opts.Aggregation == model.RollupCount {
+		query := s.getQueryBuilder(db).
+			Select("group_value", "COUNT(*)").
+			FromSelect(cards, "cards").
+			GroupBy("group_value").
+			OrderBy("group_value")
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error(`getBoardRollup ERROR`, mlog.Err(err))
+			return nil, err
+		}
+		defer s.CloseRows(rows)
+
+		groups := []*model.RollupGroup{}
+		for rows.Next() {
+			var value sql.NullString
+			group := &model.RollupGroup{}
+			if err := rows.Scan(&value, &group.Count); err != nil {
+				return nil, err
+			}
+			group.Value = value.String
+			group.Result = float64(group.Count)
+			groups = append(groups, group)
+		}
+		return groups, nil
+	}
+
+	query := cards.
+		Column(s.cardPropertyColumn(opts.Of, "rollup_value")).
+		OrderBy("group_value")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardRollup ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	groups := []*model.RollupGroup{}
+	groupsByValue := map[string]*model.RollupGroup{}
+	numericCounts := map[string]int64{}
+	for rows.Next() {
+		var groupValue, rollupValue sql.NullString
+		if err := rows.Scan(&groupValue, &rollupValue); err != nil {
+			return nil, err
+		}
+
+		group, ok := groupsByValue[groupValue.String]
+		if !ok {
+			group = &model.RollupGroup{Value: groupValue.String}
+			groupsByValue[groupValue.String] = group
+			groups = append(groups, group)
+		}
+		group.Count++
+
+		// values that aren't numbers, like the ones stored before the
+		// number validation existed, are left out of the aggregation
+		if number, isSet, err := model.ParseNumberValue(rollupValue.String); err == nil && isSet {
+			group.Result += number
+			numericCounts[group.Value]++
+		}
+	}
+
+	if opts.Aggregation == model.RollupAvg {
+		for _, group := range groups {
+			if n := numericCounts[group.Value]; n > 0 {
+				group.Result /= float64(n)
+			}
+		}
+	}
+
+	return groups, nil
+}
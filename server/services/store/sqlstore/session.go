@@ -26,9 +26,43 @@ func (s *SQLStore) getActiveUserCount(db sq.BaseRunner, updatedSecondsAgo int64)
 	return count, nil
 }
 
+// getActiveUserCounts returns the number of users with active sessions
+// within each of the periods of N seconds ago.
+func (s *SQLStore) getActiveUserCounts(db sq.BaseRunner, updatedSecondsAgo []int64) ([]int, error) {
+	if len(updatedSecondsAgo) == 0 {
+		return []int{}, nil
+	}
+
+	now := utils.GetMillis()
+	oldest := now
+	query := s.getQueryBuilder(db).Select()
+	for _, secondsAgo := range updatedSecondsAgo {
+		since := now - utils.SecondsToMillis(secondsAgo)
+		if since < oldest {
+			oldest = since
+		}
+		query = query.Column(sq.Expr("count(distinct case when update_at > ? then user_id end)", since))
+	}
+	query = query.
+		From(s.tablePrefix + "sessions").
+		Where(sq.Gt{"update_at": oldest})
+
+	counts := make([]int, len(updatedSecondsAgo))
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+
+	if err := query.QueryRow().Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds int64) (*model.Session, error) {
 	query := s.getQueryBuilder(db).
-		Select("id", "token", "user_id", "auth_service", "props").
+		Select("id", "token", "user_id", "auth_service", "props", "create_at", "update_at").
 		From(s.tablePrefix + "sessions").
 		Where(sq.Eq{"token": token}).
 		Where(sq.Gt{"update_at": utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)})
@@ -37,7 +71,7 @@ func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds
 	session := model.Session{}
 
 	var propsBytes []byte
-	err := row.Scan(&session.ID, &session.Token, &session.UserID, &session.AuthService, &propsBytes)
+	err := row.Scan(&session.ID, &session.Token, &session.UserID, &session.AuthService, &propsBytes, &session.CreateAt, &session.UpdateAt)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +96,13 @@ func (s *SQLStore) createSession(db sq.BaseRunner, session *model.Session) error
 		Columns("id", "token", "user_id", "auth_service", "props", "create_at", "update_at").
 		Values(session.ID, session.Token, session.UserID, session.AuthService, propsBytes, now, now)
 
-	_, err = query.Exec()
-	return err
+	if _, err = query.Exec(); err != nil {
+		return err
+	}
+
+	session.CreateAt = now
+	session.UpdateAt = now
+	return nil
 }
 
 func (s *SQLStore) refreshSession(db sq.BaseRunner, session *model.Session) error {
@@ -90,8 +129,12 @@ func (s *SQLStore) updateSession(db sq.BaseRunner, session *model.Session) error
 		Set("update_at", now).
 		Set("props", propsBytes)
 
-	_, err = query.Exec()
-	return err
+	if _, err = query.Exec(); err != nil {
+		return err
+	}
+
+	session.UpdateAt = now
+	return nil
 }
 
 func (s *SQLStore) deleteSession(db sq.BaseRunner, sessionID string) error {
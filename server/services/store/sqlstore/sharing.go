@@ -1,6 +1,8 @@
 package sqlstore
 
 import (
+	"encoding/json"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -10,6 +12,11 @@ import (
 func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error {
 	now := utils.GetMillis()
 
+	visiblePropertiesBytes, err := s.MarshalJSONB(sharing.VisibleProperties)
+	if err != nil {
+		return err
+	}
+
 	query := s.getQueryBuilder(db).
 		Insert(s.tablePrefix+"sharing").
 		Columns(
@@ -18,6 +25,7 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error
 			"token",
 			"modified_by",
 			"update_at",
+			"visible_properties",
 		).
 		Values(
 			sharing.ID,
@@ -25,18 +33,20 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error
 			sharing.Token,
 			sharing.ModifiedBy,
 			now,
+			visiblePropertiesBytes,
 		)
 	if s.dbType == model.MysqlDBType {
-		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, token = ?, modified_by = ?, update_at = ?",
-			sharing.Enabled, sharing.Token, sharing.ModifiedBy, now)
+		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, token = ?, modified_by = ?, update_at = ?, visible_properties = ?",
+			sharing.Enabled, sharing.Token, sharing.ModifiedBy, now, visiblePropertiesBytes)
 	} else {
 		query = query.Suffix(
 			`ON CONFLICT (id)
-			 DO UPDATE SET enabled = EXCLUDED.enabled, token = EXCLUDED.token, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at`,
+			 DO UPDATE SET enabled = EXCLUDED.enabled, token = EXCLUDED.token, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at,
+			   visible_properties = EXCLUDED.visible_properties`,
 		)
 	}
 
-	_, err := query.Exec()
+	_, err = query.Exec()
 	return err
 }
 
@@ -48,22 +58,31 @@ func (s *SQLStore) getSharing(db sq.BaseRunner, boardID string) (*model.Sharing,
 			"token",
 			"modified_by",
 			"update_at",
+			"visible_properties",
 		).
 		From(s.tablePrefix + "sharing").
 		Where(sq.Eq{"id": boardID})
 	row := query.QueryRow()
 	sharing := model.Sharing{}
 
+	var visiblePropertiesBytes []byte
 	err := row.Scan(
 		&sharing.ID,
 		&sharing.Enabled,
 		&sharing.Token,
 		&sharing.ModifiedBy,
 		&sharing.UpdateAt,
+		&visiblePropertiesBytes,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(visiblePropertiesBytes) > 0 {
+		if err = json.Unmarshal(visiblePropertiesBytes, &sharing.VisibleProperties); err != nil {
+			return nil, err
+		}
+	}
+
 	return &sharing, nil
 }
@@ -0,0 +1,79 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var errNotQueryRower = errors.New("cannot QueryRow; runner is not a QueryRower")
+
+// slowQueryRunner wraps a database runner and logs the statements that
+// take longer than the threshold to run. Only the SQL is logged, the
+// parameters are summarized so no user data ends up in the logs. The
+// queries run while serving an API request are logged with its ID.
+type slowQueryRunner struct {
+	db        sq.BaseRunner
+	threshold time.Duration
+	logger    mlog.LoggerIFace
+}
+
+func (r *slowQueryRunner) logIfSlow(query string, args []interface{}, start time.Time) {
+	elapsed := time.Since(start)
+	if elapsed < r.threshold {
+		return
+	}
+
+	fields := []mlog.Field{
+		mlog.String("query", query),
+		mlog.Int("args_count", len(args)),
+		mlog.Int64("duration_ms", elapsed.Milliseconds()),
+		mlog.Int64("threshold_ms", r.threshold.Milliseconds()),
+	}
+	if requestID := utils.RequestIDFromContext(utils.GoroutineContext()); requestID != "" {
+		fields = append(fields, mlog.String("request_id", requestID))
+	}
+
+	r.logger.Warn("Slow query", fields...)
+}
+
+func (r *slowQueryRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer r.logIfSlow(query, args, time.Now())
+	return r.db.Exec(query, args...)
+}
+
+func (r *slowQueryRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer r.logIfSlow(query, args, time.Now())
+	return r.db.Query(query, args...)
+}
+
+func (r *slowQueryRunner) QueryRow(query string, args ...interface{}) sq.RowScanner {
+	defer r.logIfSlow(query, args, time.Now())
+
+	switch db := r.db.(type) {
+	case sq.QueryRower:
+		return db.QueryRow(query, args...)
+	case stdQueryRower:
+		return db.QueryRow(query, args...)
+	}
+	return errRowScanner{errNotQueryRower}
+}
+
+// stdQueryRower is implemented by both sql.DB and sql.Tx.
+type stdQueryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+type errRowScanner struct {
+	err error
+}
+
+func (e errRowScanner) Scan(...interface{}) error {
+	return e.err
+}
@@ -0,0 +1,89 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type sleepingRunner struct {
+	delay time.Duration
+}
+
+func (r sleepingRunner) Exec(string, ...interface{}) (sql.Result, error) {
+	time.Sleep(r.delay)
+	return nil, nil
+}
+
+func (r sleepingRunner) Query(string, ...interface{}) (*sql.Rows, error) {
+	time.Sleep(r.delay)
+	return nil, nil
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	setup := func(t *testing.T) (*slowQueryRunner, *mlog.Buffer, *mlog.Logger) {
+		logger, err := mlog.NewLogger()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = logger.Shutdown() })
+
+		buffer := &mlog.Buffer{}
+		require.NoError(t, mlog.AddWriterTarget(logger, buffer, true, mlog.LvlWarn))
+
+		runner := &slowQueryRunner{
+			db:        sleepingRunner{delay: 2 * time.Millisecond},
+			threshold: time.Millisecond,
+			logger:    logger,
+		}
+		return runner, buffer, logger
+	}
+
+	t.Run("logs the request ID of the query", func(t *testing.T) {
+		runner, buffer, logger := setup(t)
+
+		ctx := utils.ContextWithRequestID(context.Background(), "request-id")
+		utils.RunWithGoroutineContext(ctx, func() {
+			_, err := runner.Exec("UPDATE blocks SET title = ?", "title")
+			require.NoError(t, err)
+		})
+		require.NoError(t, logger.Flush())
+
+		output := buffer.String()
+		require.Contains(t, output, `"msg":"Slow query"`)
+		require.Contains(t, output, `"query":"UPDATE blocks SET title = ?"`)
+		require.Contains(t, output, `"request_id":"request-id"`)
+		require.NotContains(t, output, `"title"`)
+	})
+
+	t.Run("omits the request ID outside of a request", func(t *testing.T) {
+		runner, buffer, logger := setup(t)
+
+		_, err := runner.Exec("DELETE FROM sessions")
+		require.NoError(t, err)
+		require.NoError(t, logger.Flush())
+
+		output := buffer.String()
+		require.Contains(t, output, `"msg":"Slow query"`)
+		require.NotContains(t, output, "request_id")
+	})
+
+	t.Run("doesn't log the fast queries", func(t *testing.T) {
+		runner, buffer, logger := setup(t)
+		runner.threshold = time.Hour
+
+		ctx := utils.ContextWithRequestID(context.Background(), "request-id")
+		utils.RunWithGoroutineContext(ctx, func() {
+			_, err := runner.Exec("SELECT 1")
+			require.NoError(t, err)
+		})
+		require.NoError(t, logger.Flush())
+
+		require.Empty(t, buffer.String())
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -32,6 +34,18 @@ type SQLStore struct {
 	NewMutexFn       MutexFactory
 	servicesAPI      servicesAPI
 	isBinaryParam    bool
+
+	slowQueryThreshold time.Duration
+	jsonbFields        bool
+	transactionRetries int
+	maxBlockTreeDepth  int
+
+	// patchBlockMux serializes the block patches and syncs on SQLite
+	patchBlockMux sync.Mutex
+
+	// fileReferencesMux serializes the changes of the file references
+	// on SQLite
+	fileReferencesMux sync.Mutex
 }
 
 // MutexFactory is used by the store in plugin mode to generate
@@ -56,6 +70,11 @@ func New(params Params) (*SQLStore, error) {
 		isSingleUser:     params.IsSingleUser,
 		NewMutexFn:       params.NewMutexFn,
 		servicesAPI:      params.ServicesAPI,
+
+		slowQueryThreshold: params.SlowQueryThreshold,
+		jsonbFields:        params.JSONBFields,
+		transactionRetries: params.TransactionRetries,
+		maxBlockTreeDepth:  model.MaxBlockTreeDepth(params.MaxBlockTreeDepth),
 	}
 
 	if store.IsMariaDB() {
@@ -75,6 +94,12 @@ func New(params Params) (*SQLStore, error) {
 
 			return nil, mErr
 		}
+	} else if params.CheckSchemaVersion {
+		if vErr := store.CheckSchemaVersion(); vErr != nil {
+			params.Logger.Error(`Database schema check failed, the migrations need to be run`, mlog.Err(vErr))
+
+			return nil, vErr
+		}
 	}
 	return store, nil
 }
@@ -132,6 +157,10 @@ func (s *SQLStore) getQueryBuilder(db sq.BaseRunner) sq.StatementBuilderType {
 		builder = builder.PlaceholderFormat(sq.Dollar)
 	}
 
+	if s.slowQueryThreshold > 0 {
+		db = &slowQueryRunner{db: db, threshold: s.slowQueryThreshold, logger: s.logger}
+	}
+
 	return builder.RunWith(db)
 }
 
@@ -168,6 +197,18 @@ func (s *SQLStore) elementInColumn(column string) string {
 	return ""
 }
 
+// jsonFieldIsTrue returns a condition that matches the rows where the
+// boolean key of a JSON column is set to true.
+func (s *SQLStore) jsonFieldIsTrue(column string, key string) string {
+	if s.dbType == model.PostgresDBType {
+		return fmt.Sprintf("(%s->>'%s') = 'true'", column, key)
+	}
+	if s.dbType == model.MysqlDBType {
+		return fmt.Sprintf("JSON_EXTRACT(%s, '$.%s') = CAST('true' AS JSON)", column, key)
+	}
+	return fmt.Sprintf("json_extract(%s, '$.%s') = 1", column, key)
+}
+
 func (s *SQLStore) getLicense(db sq.BaseRunner) *mmModel.License {
 	return nil
 }
@@ -4,6 +4,7 @@
 package sqlstore
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -15,6 +16,17 @@ func TestSQLStore(t *testing.T) {
 	t.Run("BlocksStore", func(t *testing.T) { storetests.StoreTestBlocksStore(t, SetupTests) })
 	t.Run("SharingStore", func(t *testing.T) { storetests.StoreTestSharingStore(t, SetupTests) })
 	t.Run("SystemStore", func(t *testing.T) { storetests.StoreTestSystemStore(t, SetupTests) })
+	t.Run("FeatureFlagsStore", func(t *testing.T) { storetests.StoreTestFeatureFlagsStore(t, SetupTests) })
+	t.Run("AdminAuditStore", func(t *testing.T) { storetests.StoreTestAdminAuditStore(t, SetupTests) })
+	t.Run("InvitesStore", func(t *testing.T) { storetests.StoreTestInvitesStore(t, SetupTests) })
+	t.Run("TeamWebhooksStore", func(t *testing.T) { storetests.StoreTestTeamWebhooksStore(t, SetupTests) })
+	t.Run("BoardAPIKeysStore", func(t *testing.T) { storetests.StoreTestBoardAPIKeysStore(t, SetupTests) })
+	t.Run("UserBoardViewsStore", func(t *testing.T) { storetests.StoreTestUserBoardViewsStore(t, SetupTests) })
+	t.Run("BoardFavoritesStore", func(t *testing.T) { storetests.StoreTestBoardFavoritesStore(t, SetupTests) })
+	t.Run("RecentBoardsStore", func(t *testing.T) { storetests.StoreTestRecentBoardsStore(t, SetupTests) })
+	t.Run("BoardLastSeenStore", func(t *testing.T) { storetests.StoreTestBoardLastSeenStore(t, SetupTests) })
+	t.Run("BoardSlugsStore", func(t *testing.T) { storetests.StoreTestBoardSlugsStore(t, SetupTests) })
+	t.Run("BoardSequencesStore", func(t *testing.T) { storetests.StoreTestBoardSequencesStore(t, SetupTests) })
 	t.Run("UserStore", func(t *testing.T) { storetests.StoreTestUserStore(t, SetupTests) })
 	t.Run("SessionStore", func(t *testing.T) { storetests.StoreTestSessionStore(t, SetupTests) })
 	t.Run("TeamStore", func(t *testing.T) { storetests.StoreTestTeamStore(t, SetupTests) })
@@ -25,11 +37,50 @@ func TestSQLStore(t *testing.T) {
 	t.Run("DataRetention", func(t *testing.T) { storetests.StoreTestDataRetention(t, SetupTests) })
 	t.Run("CloudStore", func(t *testing.T) { storetests.StoreTestCloudStore(t, SetupTests) })
 	t.Run("StoreTestFileStore", func(t *testing.T) { storetests.StoreTestFileStore(t, SetupTests) })
+	t.Run("StoreTestFileReferencesStore", func(t *testing.T) { storetests.StoreTestFileReferencesStore(t, SetupTests) })
 	t.Run("StoreTestCategoryStore", func(t *testing.T) { storetests.StoreTestCategoryStore(t, SetupTests) })
 	t.Run("StoreTestCategoryBoardsStore", func(t *testing.T) { storetests.StoreTestCategoryBoardsStore(t, SetupTests) })
 	t.Run("BoardsInsightsStore", func(t *testing.T) { storetests.StoreTestBoardsInsightsStore(t, SetupTests) })
 }
 
+// TestSQLStoreJSONBFields runs the tests of the stores that read or write
+// the block fields with the fields stored as jsonb.
+func TestSQLStoreJSONBFields(t *testing.T) {
+	store, tearDown := SetupTestsWithJSONBFields(t)
+	sqlStore := store.(*SQLStore)
+	if sqlStore.dbType != model.PostgresDBType {
+		tearDown()
+		t.Skip("the block fields are only converted to jsonb on PostgreSQL")
+	}
+
+	for _, table := range []string{"blocks", "blocks_history"} {
+		var dataType string
+		err := sqlStore.db.QueryRow(
+			"SELECT data_type FROM information_schema.columns WHERE table_name = $1 AND column_name = 'fields'",
+			sqlStore.tablePrefix+table,
+		).Scan(&dataType)
+		require.NoError(t, err)
+		require.Equal(t, "jsonb", dataType, "the fields of %s should be jsonb", table)
+	}
+
+	var indexCount int
+	err := sqlStore.db.QueryRow(
+		"SELECT COUNT(*) FROM pg_indexes WHERE indexname = $1",
+		"idx_"+sqlStore.tablePrefix+"blocks_fields_properties",
+	).Scan(&indexCount)
+	require.NoError(t, err)
+	require.Equal(t, 1, indexCount)
+	tearDown()
+
+	t.Run("BlocksStore", func(t *testing.T) { storetests.StoreTestBlocksStore(t, SetupTestsWithJSONBFields) })
+	t.Run("BoardStore", func(t *testing.T) { storetests.StoreTestBoardStore(t, SetupTestsWithJSONBFields) })
+	t.Run("BoardsAndBlocksStore", func(t *testing.T) { storetests.StoreTestBoardsAndBlocksStore(t, SetupTestsWithJSONBFields) })
+	t.Run("TeamStore", func(t *testing.T) { storetests.StoreTestTeamStore(t, SetupTestsWithJSONBFields) })
+	t.Run("DataRetention", func(t *testing.T) { storetests.StoreTestDataRetention(t, SetupTestsWithJSONBFields) })
+	t.Run("CloudStore", func(t *testing.T) { storetests.StoreTestCloudStore(t, SetupTestsWithJSONBFields) })
+	t.Run("BoardsInsightsStore", func(t *testing.T) { storetests.StoreTestBoardsInsightsStore(t, SetupTestsWithJSONBFields) })
+}
+
 //  tests for  utility functions inside sqlstore.go
 
 func TestConcatenationSelector(t *testing.T) {
@@ -63,3 +114,24 @@ func TestElementInColumn(t *testing.T) {
 		require.Equal(t, inLiteral, "position(? in test_column) > 0")
 	}
 }
+
+func TestCheckSchemaVersion(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	t.Run("migrated schema", func(t *testing.T) {
+		require.NoError(t, sqlStore.CheckSchemaVersion())
+	})
+
+	t.Run("outdated schema", func(t *testing.T) {
+		expectedVersion, err := expectedSchemaVersion()
+		require.NoError(t, err)
+
+		_, err = sqlStore.db.Exec("DELETE FROM " + sqlStore.tablePrefix + "schema_migrations WHERE version = " + strconv.Itoa(expectedVersion))
+		require.NoError(t, err)
+
+		err = sqlStore.CheckSchemaVersion()
+		require.ErrorIs(t, err, ErrSchemaOutdated)
+	})
+}
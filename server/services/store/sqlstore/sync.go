@@ -0,0 +1,106 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// syncBlocks applies the changes of a client that were based on a
+// version of the block that is still the latest one on the server,
+// and reports the rest as conflicts. It returns the server state of
+// the board blocks once the changes are applied. With onlyComments,
+// the user can only create comments and change the ones it created.
+func (s *SQLStore) syncBlocks(db sq.BaseRunner, boardID string, changes []model.SyncBlockChange, userID string, onlyComments bool) (*model.SyncBlocksResult, error) {
+	// the blocks can't change between the checks and the writes: SQLite
+	// runs the store methods without a transaction, so its syncs are
+	// serialized, and the other databases lock the rows until the
+	// transaction ends
+	if s.dbType == model.SqliteDBType {
+		s.patchBlockMux.Lock()
+		defer s.patchBlockMux.Unlock()
+	}
+
+	result := &model.SyncBlocksResult{
+		Applied:   []string{},
+		Conflicts: []model.SyncBlockConflict{},
+	}
+
+	for i := range changes {
+		change := changes[i]
+		block := change.Block
+
+		if block.BoardID != boardID {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("invalid BoardID for block id %s", block.ID))
+		}
+
+		if s.dbType != model.SqliteDBType {
+			if err := s.lockBlock(db, block.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		existingBlock, err := s.getBlock(db, block.ID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return nil, err
+		}
+
+		if existingBlock != nil {
+			if existingBlock.BoardID != boardID {
+				return nil, model.NewErrBadRequest(fmt.Sprintf("block id %s belongs to another board", block.ID))
+			}
+			if existingBlock.Type != block.Type {
+				return nil, model.NewErrBadRequest(fmt.Sprintf("cannot change the type of block id %s", block.ID))
+			}
+		}
+
+		if onlyComments {
+			if block.Type != model.TypeComment {
+				return nil, model.NewErrPermission("access denied to make board changes")
+			}
+			if existingBlock != nil && existingBlock.CreatedBy != userID {
+				return nil, model.NewErrPermission(fmt.Sprintf("access denied to change comment id %s", block.ID))
+			}
+		}
+
+		conflict := false
+		switch {
+		case existingBlock == nil && change.BaseUpdateAt > 0:
+			// the block was deleted on the server after the client fetched it
+			conflict = true
+		case existingBlock != nil && existingBlock.UpdateAt > change.BaseUpdateAt:
+			conflict = true
+		}
+
+		if conflict {
+			result.Conflicts = append(result.Conflicts, model.SyncBlockConflict{
+				BlockID:      block.ID,
+				BaseUpdateAt: change.BaseUpdateAt,
+				ServerBlock:  existingBlock,
+			})
+			continue
+		}
+
+		if block.DeleteAt > 0 {
+			err = s.deleteBlock(db, block.ID, userID)
+		} else {
+			err = s.insertBlock(db, &block, userID)
+		}
+		if err != nil {
+			s.logger.Error("syncBlocks failed to apply change", mlog.String("blockID", block.ID), mlog.Err(err))
+			return nil, err
+		}
+		result.Applied = append(result.Applied, block.ID)
+	}
+
+	blocks, err := s.getBlocksForBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+	result.Blocks = blocks
+
+	return result, nil
+}
@@ -156,6 +156,67 @@ func (s *SQLStore) getTeamCount(db sq.BaseRunner) (int64, error) {
 	return count, nil
 }
 
+// deleteEmptyTeams deletes the teams not updated since updatedBefore
+// that have no boards and no members, along with their feature flags,
+// invites and webhooks, and returns their IDs.
+func (s *SQLStore) deleteEmptyTeams(db sq.BaseRunner, updatedBefore int64) ([]string, error) {
+	teamIDs, err := s.getEmptyTeamIDs(db, updatedBefore)
+	if err != nil || len(teamIDs) == 0 {
+		return teamIDs, err
+	}
+
+	for _, table := range []string{"feature_flags", "invites", "team_webhooks"} {
+		if _, err = s.getQueryBuilder(db).
+			Delete(s.tablePrefix + table).
+			Where(sq.Eq{"team_id": teamIDs}).
+			Exec(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "teams").
+		Where(sq.Eq{"id": teamIDs}).
+		Exec(); err != nil {
+		return nil, err
+	}
+
+	return teamIDs, nil
+}
+
+// getEmptyTeamIDs returns the IDs of the teams not updated since
+// updatedBefore that have no boards and no members. The members of a
+// team are the users that have categories in it, as they are created
+// when a user first opens the team, and the users with a pending
+// invite. The global team is never returned.
+func (s *SQLStore) getEmptyTeamIDs(db sq.BaseRunner, updatedBefore int64) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("t.id").
+		From(s.tablePrefix+"teams AS t").
+		Where(sq.Lt{"t.update_at": updatedBefore}).
+		Where(sq.NotEq{"t.id": model.GlobalTeamID}).
+		Where("NOT EXISTS (SELECT 1 FROM "+s.tablePrefix+"boards AS b WHERE b.team_id = t.id)").
+		Where("NOT EXISTS (SELECT 1 FROM "+s.tablePrefix+"categories AS c WHERE c.team_id = t.id)").
+		Where("NOT EXISTS (SELECT 1 FROM "+s.tablePrefix+"invites AS i WHERE i.team_id = t.id AND i.expires_at > ?)", utils.GetMillis())
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("ERROR getEmptyTeamIDs", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	teamIDs := []string{}
+	for rows.Next() {
+		var teamID string
+		if err = rows.Scan(&teamID); err != nil {
+			return nil, err
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+	return teamIDs, nil
+}
+
 func (s *SQLStore) teamsFromRows(rows *sql.Rows) ([]*model.Team, error) {
 	teams := []*model.Team{}
 
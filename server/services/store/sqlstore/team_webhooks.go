@@ -0,0 +1,154 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func teamWebhookFields() []string {
+	return []string{
+		"id",
+		"team_id",
+		"url",
+		"event_types",
+		"create_at",
+		"update_at",
+	}
+}
+
+func (s *SQLStore) teamWebhooksFromRows(rows *sql.Rows) ([]*model.TeamWebhook, error) {
+	webhooks := []*model.TeamWebhook{}
+	for rows.Next() {
+		var webhook model.TeamWebhook
+		var eventTypes string
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.TeamID,
+			&webhook.URL,
+			&eventTypes,
+			&webhook.CreateAt,
+			&webhook.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal([]byte(eventTypes), &webhook.EventTypes); err != nil {
+			s.logger.Error("teamWebhooksFromRows cannot unmarshal the event types", mlog.String("webhookID", webhook.ID), mlog.Err(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+func (s *SQLStore) createTeamWebhook(db sq.BaseRunner, webhook *model.TeamWebhook) error {
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"team_webhooks").
+		Columns(teamWebhookFields()...).
+		Values(
+			webhook.ID,
+			webhook.TeamID,
+			webhook.URL,
+			string(eventTypes),
+			webhook.CreateAt,
+			webhook.UpdateAt,
+		).
+		Exec()
+	return err
+}
+
+func (s *SQLStore) getTeamWebhook(db sq.BaseRunner, webhookID string) (*model.TeamWebhook, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(teamWebhookFields()...).
+		From(s.tablePrefix + "team_webhooks").
+		Where(sq.Eq{"id": webhookID}).
+		Query()
+	if err != nil {
+		s.logger.Error(`getTeamWebhook ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	webhooks, err := s.teamWebhooksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, model.NewErrNotFound("team webhook")
+	}
+	return webhooks[0], nil
+}
+
+func (s *SQLStore) getTeamWebhooks(db sq.BaseRunner, teamID string) ([]*model.TeamWebhook, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(teamWebhookFields()...).
+		From(s.tablePrefix+"team_webhooks").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("create_at", "id").
+		Query()
+	if err != nil {
+		s.logger.Error(`getTeamWebhooks ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.teamWebhooksFromRows(rows)
+}
+
+func (s *SQLStore) updateTeamWebhook(db sq.BaseRunner, webhook *model.TeamWebhook) error {
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"team_webhooks").
+		Set("url", webhook.URL).
+		Set("event_types", string(eventTypes)).
+		Set("update_at", webhook.UpdateAt).
+		Where(sq.Eq{"id": webhook.ID}).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("team webhook")
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteTeamWebhook(db sq.BaseRunner, webhookID string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "team_webhooks").
+		Where(sq.Eq{"id": webhookID}).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("team webhook")
+	}
+	return nil
+}
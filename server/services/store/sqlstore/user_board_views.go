@@ -0,0 +1,120 @@
+package sqlstore
+
+import (
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (s *SQLStore) getUserBoardView(db sq.BaseRunner, userID, boardID string) (*model.UserBoardView, error) {
+	rows, err := s.getQueryBuilder(db).
+		Select(
+			"user_id",
+			"board_id",
+			"COALESCE(active_view_id, '')",
+			"sort_options",
+			"filter_group",
+			"collapsed_groups",
+			"update_at",
+		).
+		From(s.tablePrefix + "user_board_views").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"board_id": boardID}).
+		Query()
+	if err != nil {
+		s.logger.Error(`getUserBoardView ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, model.NewErrNotFound("user board view BoardID=" + boardID)
+	}
+
+	var view model.UserBoardView
+	var sortOptionsBytes, filterBytes, collapsedGroupsBytes []byte
+	err = rows.Scan(
+		&view.UserID,
+		&view.BoardID,
+		&view.ActiveViewID,
+		&sortOptionsBytes,
+		&filterBytes,
+		&collapsedGroupsBytes,
+		&view.UpdateAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sortOptionsBytes) > 0 {
+		if err = json.Unmarshal(sortOptionsBytes, &view.SortOptions); err != nil {
+			s.logger.Error("getUserBoardView: cannot unmarshal the sort options", mlog.Err(err))
+			return nil, err
+		}
+	}
+	if len(filterBytes) > 0 {
+		if err = json.Unmarshal(filterBytes, &view.Filter); err != nil {
+			s.logger.Error("getUserBoardView: cannot unmarshal the filter", mlog.Err(err))
+			return nil, err
+		}
+	}
+	if len(collapsedGroupsBytes) > 0 {
+		if err = json.Unmarshal(collapsedGroupsBytes, &view.CollapsedGroups); err != nil {
+			s.logger.Error("getUserBoardView: cannot unmarshal the collapsed groups", mlog.Err(err))
+			return nil, err
+		}
+	}
+
+	return &view, nil
+}
+
+func (s *SQLStore) saveUserBoardView(db sq.BaseRunner, view *model.UserBoardView) error {
+	sortOptionsBytes, err := s.MarshalJSONB(view.SortOptions)
+	if err != nil {
+		return err
+	}
+	filterBytes, err := s.MarshalJSONB(view.Filter)
+	if err != nil {
+		return err
+	}
+	collapsedGroupsBytes, err := s.MarshalJSONB(view.CollapsedGroups)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"user_board_views").
+		Columns("user_id", "board_id", "active_view_id", "sort_options", "filter_group", "collapsed_groups", "update_at").
+		Values(view.UserID, view.BoardID, view.ActiveViewID, sortOptionsBytes, filterBytes, collapsedGroupsBytes, view.UpdateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix(
+			`ON DUPLICATE KEY UPDATE active_view_id = ?, sort_options = ?, filter_group = ?, collapsed_groups = ?, update_at = ?`,
+			view.ActiveViewID, sortOptionsBytes, filterBytes, collapsedGroupsBytes, view.UpdateAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (user_id, board_id)
+             DO UPDATE SET active_view_id = EXCLUDED.active_view_id, sort_options = EXCLUDED.sort_options,
+			   filter_group = EXCLUDED.filter_group, collapsed_groups = EXCLUDED.collapsed_groups, update_at = EXCLUDED.update_at`,
+		)
+	}
+
+	_, err = query.Exec()
+	return err
+}
+
+// deleteUserBoardViews deletes the view states that match the
+// condition, used when a board is deleted or a user leaves it.
+func (s *SQLStore) deleteUserBoardViews(db sq.BaseRunner, condition sq.Eq) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "user_board_views").
+		Where(condition).
+		Exec()
+	return err
+}
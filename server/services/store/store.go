@@ -21,11 +21,18 @@ type Store interface {
 	GetBlocksWithType(boardID, blockType string) ([]model.Block, error)
 	GetSubTree2(boardID, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error)
 	GetBlocksForBoard(boardID string) ([]model.Block, error)
+	GetBoardBlocksPage(boardID, afterID string, limit uint64) ([]model.Block, error)
+	GetBlockManifest(boardID string) ([]model.BlockManifestEntry, error)
+	GetBoardRollup(boardID string, opts model.QueryRollupOptions) ([]*model.RollupGroup, error)
+	GetBoardStats(boardID string, opts model.QueryBoardStatsOptions) (*model.BoardStats, error)
+	GetCardProgress(boardID, cardID string) (*model.CardProgress, error)
 	// @withTransaction
 	InsertBlock(block *model.Block, userID string) error
 	// @withTransaction
 	DeleteBlock(blockID string, modifiedBy string) error
 	// @withTransaction
+	DeleteBlocks(blockIDs []string, modifiedBy string) ([]model.Block, error)
+	// @withTransaction
 	InsertBlocks(blocks []model.Block, userID string) error
 	// @withTransaction
 	UndeleteBlock(blockID string, modifiedBy string) error
@@ -33,11 +40,14 @@ type Store interface {
 	UndeleteBoard(boardID string, modifiedBy string) error
 	GetBlockCountsByType() (map[string]int64, error)
 	GetBoardCount() (int64, error)
+	GetTeamBoardCount(teamID string) (int64, error)
 	GetBlock(blockID string) (*model.Block, error)
 	// @withTransaction
 	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error)
+	CompactBlockHistory(policy *model.BlockHistoryCompactionPolicy, now int64) (int64, error)
 	GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error)
+	GetDeletedBlocksForBoard(boardID string, deletedAfter int64) ([]model.Block, error)
 	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
 	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
 	GetBoardAndCard(block *model.Block) (board *model.Board, card *model.Block, err error)
@@ -47,6 +57,10 @@ type Store interface {
 	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error)
 	// @withTransaction
 	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
+	// @withTransaction
+	SyncBlocks(boardID string, changes []model.SyncBlockChange, userID string, onlyComments bool) (*model.SyncBlocksResult, error)
+	// @withTransaction
+	NextBoardSequence(boardID string, count int) (int64, error)
 
 	Shutdown() error
 
@@ -54,6 +68,46 @@ type Store interface {
 	GetSystemSettings() (map[string]string, error)
 	SetSystemSetting(key, value string) error
 
+	GetTeamFeatureFlags(teamID string) (map[string]string, error)
+	SetTeamFeatureFlag(teamID, name, value string) error
+	DeleteTeamFeatureFlag(teamID, name string) error
+
+	InsertAdminAuditEntry(entry *model.AdminAuditEntry) error
+	GetAdminAuditEntries(opts model.QueryAdminAuditOptions) ([]*model.AdminAuditEntry, error)
+
+	CreateInvite(invite *model.Invite) error
+	GetInvite(token string) (*model.Invite, error)
+	GetInvitesForTeam(teamID string) ([]*model.Invite, error)
+	DeleteInvite(token string) error
+	// @withTransaction
+	CreateUserWithInvite(user *model.User, token string) (*model.User, error)
+
+	CreateTeamWebhook(webhook *model.TeamWebhook) error
+	GetTeamWebhook(webhookID string) (*model.TeamWebhook, error)
+	GetTeamWebhooks(teamID string) ([]*model.TeamWebhook, error)
+	UpdateTeamWebhook(webhook *model.TeamWebhook) error
+	DeleteTeamWebhook(webhookID string) error
+
+	GetUserBoardView(userID, boardID string) (*model.UserBoardView, error)
+	SaveUserBoardView(view *model.UserBoardView) error
+
+	AddBoardFavorite(favorite *model.BoardFavorite) error
+	DeleteBoardFavorite(userID, boardID string) error
+	GetFavoriteBoardIDs(userID string) ([]string, error)
+	GetFavoriteBoards(userID string) ([]*model.Board, error)
+
+	// @withTransaction
+	SaveRecentBoard(recent *model.RecentBoard, maxRecentBoards int) error
+	GetRecentBoards(userID string) ([]*model.Board, error)
+
+	SaveBoardLastSeen(seen *model.BoardLastSeen) error
+	GetBoardUnreadCounts(userID string, boardIDs []string) (map[string]int64, error)
+
+	CreateBoardAPIKey(key *model.BoardAPIKey, keyHash string) error
+	GetBoardAPIKeyByHash(keyHash string) (*model.BoardAPIKey, error)
+	GetBoardAPIKeys(boardID string) ([]*model.BoardAPIKey, error)
+	DeleteBoardAPIKey(boardID, keyID string) error
+
 	GetRegisteredUserCount() (int, error)
 	GetUserByID(userID string) (*model.User, error)
 	GetUsersList(userIDs []string) ([]*model.User, error)
@@ -69,6 +123,9 @@ type Store interface {
 	GetUserPreferences(userID string) (mmModel.Preferences, error)
 
 	GetActiveUserCount(updatedSecondsAgo int64) (int, error)
+	// GetActiveUserCounts returns the number of active users for each
+	// of the periods, computed with a single query.
+	GetActiveUserCounts(updatedSecondsAgo []int64) ([]int, error)
 	GetSession(token string, expireTime int64) (*model.Session, error)
 	CreateSession(session *model.Session) error
 	RefreshSession(session *model.Session) error
@@ -85,15 +142,26 @@ type Store interface {
 	GetTeamsForUser(userID string) ([]*model.Team, error)
 	GetAllTeams() ([]*model.Team, error)
 	GetTeamCount() (int64, error)
+	// @withTransaction
+	DeleteEmptyTeams(updatedBefore int64) ([]string, error)
 
 	InsertBoard(board *model.Board, userID string) (*model.Board, error)
 	// @withTransaction
 	InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error)
 	// @withTransaction
 	PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error)
+	// @withTransaction
+	MoveBoard(boardID, toTeamID, userID string) (*model.Board, error)
+	// @withTransaction
+	SetBoardSlug(boardID, teamID, slug string) error
+	GetBoardSlug(boardID string) (string, error)
+	GetBoardIDBySlug(teamID, slug string) (string, error)
+	// @withTransaction
+	TransferBoardOwnership(boardID, newOwnerID, userID string) (*model.Board, error)
 	GetBoard(id string) (*model.Board, error)
 	GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error)
 	GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*model.Board, error)
+	GetBoardsForTeam(teamID string) ([]*model.Board, error)
 	// @withTransaction
 	DeleteBoard(boardID, userID string) error
 
@@ -125,6 +193,11 @@ type Store interface {
 
 	GetFileInfo(id string) (*mmModel.FileInfo, error)
 	SaveFileInfo(fileInfo *mmModel.FileInfo) error
+	// @withTransaction
+	SaveFileReference(ref *model.FileReference) error
+	GetFileReference(fileID string) (*model.FileReference, error)
+	// @withTransaction
+	DeleteFileReference(fileID string) (int64, error)
 
 	// @withTransaction
 	AddUpdateCategoryBoard(userID, categoryID, blockID string) error
@@ -167,6 +240,20 @@ type Store interface {
 	GetUserTimezone(userID string) (string, error)
 }
 
+// SessionStore is the storage of the user sessions. The Store keeps
+// them in the database, and a different SessionStore can be plugged in
+// with the sessionlayer.
+type SessionStore interface {
+	GetActiveUserCount(updatedSecondsAgo int64) (int, error)
+	GetActiveUserCounts(updatedSecondsAgo []int64) ([]int, error)
+	GetSession(token string, expireTime int64) (*model.Session, error)
+	CreateSession(session *model.Session) error
+	RefreshSession(session *model.Session) error
+	UpdateSession(session *model.Session) error
+	DeleteSession(sessionID string) error
+	CleanUpSessions(expireTime int64) error
+}
+
 type NotSupportedError struct {
 	msg string
 }
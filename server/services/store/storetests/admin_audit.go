@@ -0,0 +1,81 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestAdminAuditStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("InsertGetAdminAuditEntries", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertGetAdminAuditEntries(t, store)
+	})
+}
+
+func testInsertGetAdminAuditEntries(t *testing.T, store store.Store) {
+	entries, err := store.GetAdminAuditEntries(model.QueryAdminAuditOptions{})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	newEntry := func(actor, action, target string, createAt int64) *model.AdminAuditEntry {
+		return &model.AdminAuditEntry{
+			ID:       utils.NewID(utils.IDTypeNone),
+			Actor:    actor,
+			Action:   action,
+			Target:   target,
+			CreateAt: createAt,
+		}
+	}
+
+	entry1 := newEntry("local", model.AdminActionSetPassword, "john", 1000)
+	entry2 := newEntry("local", model.AdminActionSetReadOnlyMode, "true", 2000)
+	entry3 := newEntry("user-id", model.AdminActionSetPassword, "jane", 3000)
+	for _, entry := range []*model.AdminAuditEntry{entry1, entry2, entry3} {
+		require.NoError(t, store.InsertAdminAuditEntry(entry))
+	}
+
+	t.Run("all entries, most recent first", func(t *testing.T) {
+		entries, err := store.GetAdminAuditEntries(model.QueryAdminAuditOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry3, entry2, entry1}, entries)
+	})
+
+	t.Run("filter by actor and action", func(t *testing.T) {
+		entries, err := store.GetAdminAuditEntries(model.QueryAdminAuditOptions{Actor: "local"})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry2, entry1}, entries)
+
+		entries, err = store.GetAdminAuditEntries(model.QueryAdminAuditOptions{Action: model.AdminActionSetPassword})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry3, entry1}, entries)
+
+		entries, err = store.GetAdminAuditEntries(model.QueryAdminAuditOptions{Actor: "local", Action: model.AdminActionSetPassword})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry1}, entries)
+	})
+
+	t.Run("filter by time and limit", func(t *testing.T) {
+		entries, err := store.GetAdminAuditEntries(model.QueryAdminAuditOptions{AfterCreateAt: 1000, BeforeCreateAt: 3000})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry2}, entries)
+
+		entries, err = store.GetAdminAuditEntries(model.QueryAdminAuditOptions{Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry3, entry2}, entries)
+	})
+
+	t.Run("oldest first with offset", func(t *testing.T) {
+		entries, err := store.GetAdminAuditEntries(model.QueryAdminAuditOptions{Ascending: true})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry1, entry2, entry3}, entries)
+
+		entries, err = store.GetAdminAuditEntries(model.QueryAdminAuditOptions{Ascending: true, Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		require.Equal(t, []*model.AdminAuditEntry{entry2, entry3}, entries)
+	})
+}
@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -44,6 +45,21 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testDeleteBlock(t, store)
 	})
+	t.Run("DeleteBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBlocks(t, store)
+	})
+	t.Run("DeleteBlocksTooDeep", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBlocksTooDeep(t, store)
+	})
+	t.Run("CompactBlockHistory", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCompactBlockHistory(t, store)
+	})
 	t.Run("UndeleteBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -74,6 +90,21 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlockMetadata(t, store)
 	})
+	t.Run("GetCardProgress", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardProgress(t, store)
+	})
+	t.Run("GetBoardBlocksPage", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardBlocksPage(t, store)
+	})
+	t.Run("GetDeletedBlocksForBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDeletedBlocksForBoard(t, store)
+	})
 }
 
 func testInsertBlock(t *testing.T, store store.Store) {
@@ -203,6 +234,35 @@ func testInsertBlock(t *testing.T, store store.Store) {
 		assert.WithinDurationf(t, expectedTime, utils.GetTimeForMillis(retrievedBlock.CreateAt), 1*time.Second, "create time should be current time")
 		assert.WithinDurationf(t, expectedTime, utils.GetTimeForMillis(retrievedBlock.UpdateAt), 1*time.Second, "update time should be current time")
 	})
+
+	t.Run("block of another board", func(t *testing.T) {
+		block := model.Block{
+			ID:      "id-11",
+			BoardID: "board-id-1",
+			Title:   "Old Title",
+		}
+		require.NoError(t, store.InsertBlock(&block, "user-id-1"))
+
+		time.Sleep(1 * time.Millisecond)
+
+		otherBoardBlock := model.Block{
+			ID:      "id-11",
+			BoardID: "board-id-2",
+			Title:   "New Title",
+		}
+		err := store.InsertBlock(&otherBoardBlock, "user-id-2")
+		require.True(t, model.IsErrBadRequest(err), "the block can't be moved to another board, got %v", err)
+
+		retrievedBlock, err := store.GetBlock("id-11")
+		require.NoError(t, err)
+		require.Equal(t, "board-id-1", retrievedBlock.BoardID)
+		require.Equal(t, "Old Title", retrievedBlock.Title)
+
+		history, err := store.GetBlockHistory("id-11", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, "board-id-1", history[0].BoardID)
+	})
 }
 
 func testInsertBlocks(t *testing.T, store store.Store) {
@@ -302,6 +362,48 @@ func testPatchBlock(t *testing.T, store store.Store) {
 		require.Equal(t, "New title", retrievedBlock.Title)
 	})
 
+	t.Run("outdated base version", func(t *testing.T) {
+		existingBlock, err := store.GetBlock("id-test")
+		require.NoError(t, err)
+
+		newTitle := "Conflicting title"
+		baseUpdateAt := existingBlock.UpdateAt - 1
+		blockPatch := model.BlockPatch{
+			Title:        &newTitle,
+			BaseUpdateAt: &baseUpdateAt,
+		}
+
+		err = store.PatchBlock("id-test", &blockPatch, "user-id-2")
+		var conflict *model.ErrBlockConflict
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, existingBlock.Title, conflict.Block.Title)
+
+		retrievedBlock, err := store.GetBlock("id-test")
+		require.NoError(t, err)
+		require.Equal(t, existingBlock.Title, retrievedBlock.Title)
+	})
+
+	t.Run("current base version", func(t *testing.T) {
+		existingBlock, err := store.GetBlock("id-test")
+		require.NoError(t, err)
+
+		newTitle := "Versioned title"
+		blockPatch := model.BlockPatch{
+			Title:        &newTitle,
+			BaseUpdateAt: &existingBlock.UpdateAt,
+		}
+
+		// Wait for not colliding the ID+insert_at key
+		time.Sleep(1 * time.Millisecond)
+
+		err = store.PatchBlock("id-test", &blockPatch, "user-id-2")
+		require.NoError(t, err)
+
+		retrievedBlock, err := store.GetBlock("id-test")
+		require.NoError(t, err)
+		require.Equal(t, "Versioned title", retrievedBlock.Title)
+	})
+
 	t.Run("update block custom fields", func(t *testing.T) {
 		blockPatch := model.BlockPatch{
 			UpdatedFields: map[string]interface{}{"test": "new test value", "test3": "new value"},
@@ -554,6 +656,120 @@ func testDeleteBlock(t *testing.T, store store.Store) {
 	})
 }
 
+func testDeleteBlocks(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	blocksToInsert := []model.Block{
+		{ID: "card1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, ModifiedBy: userID},
+		{ID: "text1", BoardID: boardID, ParentID: "card1", Type: model.TypeText, ModifiedBy: userID},
+		{ID: "card2", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, ModifiedBy: userID},
+		{ID: "card3", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, ModifiedBy: userID},
+	}
+	InsertBlocks(t, store, blocksToInsert, "user-id-1")
+
+	// Wait for not colliding the ID+insert_at key
+	time.Sleep(1 * time.Millisecond)
+
+	deleted, err := store.DeleteBlocks([]string{"card1", "card2", "not-exists"}, userID)
+	require.NoError(t, err)
+
+	deletedIDs := []string{}
+	for _, block := range deleted {
+		deletedIDs = append(deletedIDs, block.ID)
+	}
+	require.ElementsMatch(t, []string{"card1", "text1", "card2"}, deletedIDs)
+
+	blocks, err := store.GetBlocksForBoard(boardID)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, "card3", blocks[0].ID)
+}
+
+func testDeleteBlocksTooDeep(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	// a chain of text blocks nested deeper than the default depth
+	blocksToInsert := []model.Block{
+		{ID: "block0", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, ModifiedBy: userID},
+	}
+	for i := 1; i <= model.DefaultMaxBlockTreeDepth+1; i++ {
+		blocksToInsert = append(blocksToInsert, model.Block{
+			ID:         fmt.Sprintf("block%d", i),
+			BoardID:    boardID,
+			ParentID:   fmt.Sprintf("block%d", i-1),
+			Type:       model.TypeText,
+			ModifiedBy: userID,
+		})
+	}
+	InsertBlocks(t, store, blocksToInsert, userID)
+
+	_, err := store.DeleteBlocks([]string{"block0"}, userID)
+	require.ErrorIs(t, err, model.ErrBlockTreeTooDeep)
+
+	// nothing is deleted
+	blocks, err := store.GetBlocksForBoard(boardID)
+	require.NoError(t, err)
+	require.Len(t, blocks, len(blocksToInsert))
+
+	// the blocks within the depth are deleted
+	deleted, err := store.DeleteBlocks([]string{"block2"}, userID)
+	require.NoError(t, err)
+	require.Len(t, deleted, model.DefaultMaxBlockTreeDepth)
+}
+
+func testCompactBlockHistory(t *testing.T, store store.Store) {
+	userID := testUserID
+	boardID := testBoardID
+
+	InsertBlocks(t, store, []model.Block{
+		{ID: "edited", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, ModifiedBy: userID},
+		{ID: "deleted", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, ModifiedBy: userID},
+	}, userID)
+	for i := 1; i <= 3; i++ {
+		time.Sleep(2 * time.Millisecond)
+		title := fmt.Sprintf("version %d", i)
+		require.NoError(t, store.PatchBlock("edited", &model.BlockPatch{Title: &title}, userID))
+	}
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, store.DeleteBlock("deleted", userID))
+
+	history, err := store.GetBlockHistory("edited", model.QueryBlockHistoryOptions{})
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+
+	t.Run("the recent versions are kept", func(t *testing.T) {
+		policy := &model.BlockHistoryCompactionPolicy{KeepAll: time.Hour, KeepHourly: 24 * time.Hour}
+		deleted, err := store.CompactBlockHistory(policy, utils.GetMillis())
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+	})
+
+	t.Run("the old versions are thinned", func(t *testing.T) {
+		// all the versions are days old and almost surely in the same day,
+		// so only the latest version and the deletion are kept
+		policy := &model.BlockHistoryCompactionPolicy{}
+		now := utils.GetMillis() + int64(10*24*time.Hour/time.Millisecond)
+		deleted, err := store.CompactBlockHistory(policy, now)
+		require.NoError(t, err)
+		require.Positive(t, deleted)
+
+		history, err := store.GetBlockHistory("edited", model.QueryBlockHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Less(t, len(history), 4)
+		require.Equal(t, "version 3", history[0].Title)
+
+		history, err = store.GetBlockHistory("deleted", model.QueryBlockHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.NotEmpty(t, history)
+		require.NotZero(t, history[0].DeleteAt)
+
+		// the deleted block can still be restored
+		require.NoError(t, store.UndeleteBlock("deleted", userID))
+	})
+}
+
 func testUndeleteBlock(t *testing.T, store store.Store) {
 	boardID := testBoardID
 	userID := testUserID
@@ -813,6 +1029,65 @@ func testGetBlock(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetCardProgress(t *testing.T, store store.Store) {
+	blocksToInsert := []model.Block{
+		{
+			ID:         "card1",
+			BoardID:    testBoardID,
+			ModifiedBy: testUserID,
+			Type:       model.TypeCard,
+		},
+		{
+			ID:         "checkbox1",
+			BoardID:    testBoardID,
+			ParentID:   "card1",
+			ModifiedBy: testUserID,
+			Type:       model.TypeCheckbox,
+			Fields:     map[string]interface{}{"value": true},
+		},
+		{
+			ID:         "checkbox2",
+			BoardID:    testBoardID,
+			ParentID:   "card1",
+			ModifiedBy: testUserID,
+			Type:       model.TypeCheckbox,
+			Fields:     map[string]interface{}{"value": false},
+		},
+		{
+			ID:         "checkbox3",
+			BoardID:    testBoardID,
+			ParentID:   "card1",
+			ModifiedBy: testUserID,
+			Type:       model.TypeCheckbox,
+		},
+		{
+			ID:         "text1",
+			BoardID:    testBoardID,
+			ParentID:   "card1",
+			ModifiedBy: testUserID,
+			Type:       model.TypeText,
+			Fields:     map[string]interface{}{"value": true},
+		},
+	}
+	InsertBlocks(t, store, blocksToInsert, testUserID)
+	defer DeleteBlocks(t, store, blocksToInsert, "test")
+
+	t.Run("card with checkboxes", func(t *testing.T) {
+		progress, err := store.GetCardProgress(testBoardID, "card1")
+		require.NoError(t, err)
+		require.Equal(t, "card1", progress.CardID)
+		require.Equal(t, 3, progress.Total)
+		require.Equal(t, 1, progress.Checked)
+	})
+
+	t.Run("card without checkboxes", func(t *testing.T) {
+		progress, err := store.GetCardProgress(testBoardID, "not-exists")
+		require.NoError(t, err)
+		require.Equal(t, 0, progress.Total)
+		require.Equal(t, 0, progress.Checked)
+	})
+}
+
 func testDuplicateBlock(t *testing.T, store store.Store) {
 	blocksToInsert := subtreeSampleBlocks
 	blocksToInsert = append(blocksToInsert,
@@ -1061,3 +1336,94 @@ func testGetBlockMetadata(t *testing.T, store store.Store) {
 		require.Empty(t, blocks)
 	})
 }
+
+func testGetBoardBlocksPage(t *testing.T, store store.Store) {
+	blocksToInsert := []model.Block{
+		{ID: "block3", BoardID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "block1", BoardID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "block2", BoardID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "other1", BoardID: "other-board-id", ModifiedBy: testUserID, Type: model.TypeCard},
+	}
+	InsertBlocks(t, store, blocksToInsert, testUserID)
+	defer DeleteBlocks(t, store, blocksToInsert, "test")
+
+	blocks, err := store.GetBoardBlocksPage(testBoardID, "", 2)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, "block1", blocks[0].ID)
+	require.Equal(t, "block2", blocks[1].ID)
+
+	blocks, err = store.GetBoardBlocksPage(testBoardID, blocks[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, "block3", blocks[0].ID)
+
+	blocks, err = store.GetBoardBlocksPage(testBoardID, "block3", 2)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+}
+
+func testGetDeletedBlocksForBoard(t *testing.T, store store.Store) {
+	blocksToInsert := []model.Block{
+		{ID: "block1", BoardID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "block2", BoardID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "block3", BoardID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "other1", BoardID: "other-board-id", ModifiedBy: testUserID, Type: model.TypeCard},
+	}
+	InsertBlocks(t, store, blocksToInsert, testUserID)
+	defer DeleteBlocks(t, store, blocksToInsert, "test")
+
+	blocks, err := store.GetDeletedBlocksForBoard(testBoardID, 0)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+
+	// Wait for not colliding the ID+insert_at key
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.DeleteBlock("block1", testUserID))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.DeleteBlock("block2", testUserID))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.DeleteBlock("other1", testUserID))
+
+	t.Run("most recently deleted first", func(t *testing.T) {
+		blocks, err := store.GetDeletedBlocksForBoard(testBoardID, 0)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.Equal(t, "block2", blocks[0].ID)
+		require.Equal(t, "block1", blocks[1].ID)
+		require.Greater(t, blocks[1].DeleteAt, int64(0))
+		require.GreaterOrEqual(t, blocks[0].DeleteAt, blocks[1].DeleteAt)
+	})
+
+	t.Run("deleted after a date", func(t *testing.T) {
+		all, err := store.GetDeletedBlocksForBoard(testBoardID, 0)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		blocks, err := store.GetDeletedBlocksForBoard(testBoardID, all[1].DeleteAt)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, "block2", blocks[0].ID)
+	})
+
+	t.Run("undeleted blocks are not returned", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.UndeleteBlock("block1", testUserID))
+
+		blocks, err := store.GetDeletedBlocksForBoard(testBoardID, 0)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, "block2", blocks[0].ID)
+	})
+
+	t.Run("deleted again", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.DeleteBlock("block1", testUserID))
+
+		blocks, err := store.GetDeletedBlocksForBoard(testBoardID, 0)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.Equal(t, "block1", blocks[0].ID)
+		require.Equal(t, "block2", blocks[1].ID)
+	})
+}
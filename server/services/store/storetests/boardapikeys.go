@@ -0,0 +1,52 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardAPIKeysStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateGetDeleteBoardAPIKey", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateGetDeleteBoardAPIKey(t, store)
+	})
+}
+
+func newTestBoardAPIKey(boardID string) *model.BoardAPIKey {
+	return &model.BoardAPIKey{
+		ID:         utils.NewID(utils.IDTypeNone),
+		BoardID:    boardID,
+		Name:       "integration",
+		Permission: model.BoardAPIKeyPermissionRead,
+		CreatedBy:  "user-id",
+		CreateAt:   utils.GetMillis(),
+	}
+}
+
+func testCreateGetDeleteBoardAPIKey(t *testing.T, store store.Store) {
+	key := newTestBoardAPIKey("board-id")
+	require.NoError(t, store.CreateBoardAPIKey(key, "hash-1"))
+	require.NoError(t, store.CreateBoardAPIKey(newTestBoardAPIKey("other-board-id"), "hash-2"))
+
+	got, err := store.GetBoardAPIKeyByHash("hash-1")
+	require.NoError(t, err)
+	require.Equal(t, key, got)
+
+	keys, err := store.GetBoardAPIKeys("board-id")
+	require.NoError(t, err)
+	require.Equal(t, []*model.BoardAPIKey{key}, keys)
+
+	// keys can only be deleted through their board
+	err = store.DeleteBoardAPIKey("other-board-id", key.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	require.NoError(t, store.DeleteBoardAPIKey("board-id", key.ID))
+
+	_, err = store.GetBoardAPIKeyByHash("hash-1")
+	require.True(t, model.IsErrNotFound(err))
+}
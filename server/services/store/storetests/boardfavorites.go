@@ -0,0 +1,94 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardFavoritesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("AddGetBoardFavorites", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testAddGetBoardFavorites(t, store)
+	})
+	t.Run("DeleteBoardFavorites", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardFavorites(t, store)
+	})
+}
+
+func createFavoriteTestBoard(t *testing.T, store store.Store) *model.Board {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, "user-id")
+	require.NoError(t, err)
+	return board
+}
+
+func testAddGetBoardFavorites(t *testing.T, store store.Store) {
+	boardIDs, err := store.GetFavoriteBoardIDs("user-id")
+	require.NoError(t, err)
+	require.Empty(t, boardIDs)
+
+	board1 := createFavoriteTestBoard(t, store)
+	board2 := createFavoriteTestBoard(t, store)
+
+	require.NoError(t, store.AddBoardFavorite(&model.BoardFavorite{UserID: "user-id", BoardID: board2.ID, CreateAt: 1}))
+	require.NoError(t, store.AddBoardFavorite(&model.BoardFavorite{UserID: "user-id", BoardID: board1.ID, CreateAt: 2}))
+
+	// adding a favorite again keeps it in its place
+	require.NoError(t, store.AddBoardFavorite(&model.BoardFavorite{UserID: "user-id", BoardID: board2.ID, CreateAt: 3}))
+
+	boardIDs, err = store.GetFavoriteBoardIDs("user-id")
+	require.NoError(t, err)
+	require.Equal(t, []string{board2.ID, board1.ID}, boardIDs)
+
+	boards, err := store.GetFavoriteBoards("user-id")
+	require.NoError(t, err)
+	require.Len(t, boards, 2)
+	require.Equal(t, board2.ID, boards[0].ID)
+	require.Equal(t, board1.ID, boards[1].ID)
+
+	// the favorites are per user
+	boardIDs, err = store.GetFavoriteBoardIDs("other-user-id")
+	require.NoError(t, err)
+	require.Empty(t, boardIDs)
+
+	require.NoError(t, store.DeleteBoardFavorite("user-id", board2.ID))
+	boardIDs, err = store.GetFavoriteBoardIDs("user-id")
+	require.NoError(t, err)
+	require.Equal(t, []string{board1.ID}, boardIDs)
+}
+
+func testDeleteBoardFavorites(t *testing.T, store store.Store) {
+	board := createFavoriteTestBoard(t, store)
+
+	for _, userID := range []string{"user-id", "other-user-id"} {
+		_, err := store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: userID, SchemeViewer: true})
+		require.NoError(t, err)
+		require.NoError(t, store.AddBoardFavorite(&model.BoardFavorite{UserID: userID, BoardID: board.ID, CreateAt: 1}))
+	}
+
+	// leaving the board deletes the favorite of the user
+	require.NoError(t, store.DeleteMember(board.ID, "user-id"))
+	boardIDs, err := store.GetFavoriteBoardIDs("user-id")
+	require.NoError(t, err)
+	require.Empty(t, boardIDs)
+
+	boardIDs, err = store.GetFavoriteBoardIDs("other-user-id")
+	require.NoError(t, err)
+	require.Equal(t, []string{board.ID}, boardIDs)
+
+	// deleting the board deletes the favorites of every user
+	require.NoError(t, store.DeleteBoard(board.ID, "user-id"))
+	boardIDs, err = store.GetFavoriteBoardIDs("other-user-id")
+	require.NoError(t, err)
+	require.Empty(t, boardIDs)
+}
@@ -0,0 +1,95 @@
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardLastSeenStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetBoardUnreadCounts", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardUnreadCounts(t, store)
+	})
+	t.Run("DeleteBoardLastSeen", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardLastSeen(t, store)
+	})
+}
+
+func insertUnreadTestBlock(t *testing.T, store store.Store, boardID, userID string) {
+	block := &model.Block{
+		ID:        utils.NewID(utils.IDTypeBlock),
+		BoardID:   boardID,
+		ParentID:  boardID,
+		Type:      model.TypeCard,
+		CreatedBy: userID,
+	}
+	require.NoError(t, store.InsertBlock(block, userID))
+}
+
+func testGetBoardUnreadCounts(t *testing.T, store store.Store) {
+	board1 := createFavoriteTestBoard(t, store)
+	board2 := createFavoriteTestBoard(t, store)
+	boardIDs := []string{board1.ID, board2.ID}
+
+	counts, err := store.GetBoardUnreadCounts("user-id", boardIDs)
+	require.NoError(t, err)
+	require.Empty(t, counts)
+
+	// the changes of the user are never unread
+	insertUnreadTestBlock(t, store, board1.ID, "user-id")
+	insertUnreadTestBlock(t, store, board1.ID, "other-user-id")
+	insertUnreadTestBlock(t, store, board1.ID, "other-user-id")
+	insertUnreadTestBlock(t, store, board2.ID, "other-user-id")
+
+	// every change is unread on the boards never viewed
+	counts, err = store.GetBoardUnreadCounts("user-id", boardIDs)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{board1.ID: 2, board2.ID: 1}, counts)
+
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, store.SaveBoardLastSeen(&model.BoardLastSeen{UserID: "user-id", BoardID: board1.ID, SeenAt: utils.GetMillis()}))
+	time.Sleep(2 * time.Millisecond)
+
+	counts, err = store.GetBoardUnreadCounts("user-id", boardIDs)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{board2.ID: 1}, counts)
+
+	// only the changes after the board was viewed are unread
+	insertUnreadTestBlock(t, store, board1.ID, "other-user-id")
+	counts, err = store.GetBoardUnreadCounts("user-id", boardIDs)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{board1.ID: 1, board2.ID: 1}, counts)
+
+	// the last seen times are per user
+	counts, err = store.GetBoardUnreadCounts("other-user-id", boardIDs)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{board1.ID: 1}, counts)
+}
+
+func testDeleteBoardLastSeen(t *testing.T, store store.Store) {
+	board := createFavoriteTestBoard(t, store)
+	insertUnreadTestBlock(t, store, board.ID, "other-user-id")
+
+	_, err := store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: "user-id", SchemeViewer: true})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, store.SaveBoardLastSeen(&model.BoardLastSeen{UserID: "user-id", BoardID: board.ID, SeenAt: utils.GetMillis()}))
+
+	counts, err := store.GetBoardUnreadCounts("user-id", []string{board.ID})
+	require.NoError(t, err)
+	require.Empty(t, counts)
+
+	// leaving the board deletes the last seen time of the user
+	require.NoError(t, store.DeleteMember(board.ID, "user-id"))
+	counts, err = store.GetBoardUnreadCounts("user-id", []string{board.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{board.ID: 1}, counts)
+}
@@ -37,6 +37,16 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testPatchBoard(t, store)
 	})
+	t.Run("MoveBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveBoard(t, store)
+	})
+	t.Run("TransferBoardOwnership", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testTransferBoardOwnership(t, store)
+	})
 	t.Run("DeleteBoard", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -97,6 +107,11 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoardCount(t, store)
 	})
+	t.Run("BoardLastActivity", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testBoardLastActivity(t, store)
+	})
 }
 
 func testGetBoard(t *testing.T, store store.Store) {
@@ -373,6 +388,95 @@ func testInsertBoard(t *testing.T, store store.Store) {
 	})
 }
 
+func testMoveBoard(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	t.Run("should return error if the board doesn't exist", func(t *testing.T) {
+		board, err := store.MoveBoard("nonexistent-board-id", "other-team-id", userID)
+		require.Error(t, err)
+		require.Nil(t, board)
+	})
+
+	t.Run("should move the board and keep its blocks", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		board := &model.Board{
+			ID:        boardID,
+			TeamID:    testTeamID,
+			ChannelID: "channel-id",
+			Type:      model.BoardTypeOpen,
+			Title:     "A simple title",
+		}
+
+		_, err := store.InsertBoard(board, userID)
+		require.NoError(t, err)
+
+		block := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: boardID,
+			Type:    model.TypeCard,
+		}
+		require.NoError(t, store.InsertBlock(block, userID))
+
+		// wait to avoid hitting pk uniqueness constraint in history
+		time.Sleep(10 * time.Millisecond)
+
+		movedBoard, err := store.MoveBoard(boardID, "other-team-id", userID)
+		require.NoError(t, err)
+		require.Equal(t, "other-team-id", movedBoard.TeamID)
+		require.Empty(t, movedBoard.ChannelID)
+
+		dbBoard, err := store.GetBoard(boardID)
+		require.NoError(t, err)
+		require.Equal(t, "other-team-id", dbBoard.TeamID)
+
+		blocks, err := store.GetBlocksForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+	})
+}
+
+func testTransferBoardOwnership(t *testing.T, store store.Store) {
+	userID := testUserID
+
+	t.Run("should return error if the board doesn't exist", func(t *testing.T) {
+		board, err := store.TransferBoardOwnership("nonexistent-board-id", "new-owner-id", userID)
+		require.Error(t, err)
+		require.Nil(t, board)
+	})
+
+	t.Run("should make the new owner admin and demote the previous one", func(t *testing.T) {
+		board := &model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}
+
+		newBoard, _, err := store.InsertBoardWithAdmin(board, userID)
+		require.NoError(t, err)
+		require.Equal(t, userID, newBoard.CreatedBy)
+
+		// wait to avoid hitting pk uniqueness constraint in history
+		time.Sleep(10 * time.Millisecond)
+
+		transferredBoard, err := store.TransferBoardOwnership(newBoard.ID, "new-owner-id", userID)
+		require.NoError(t, err)
+		require.Equal(t, "new-owner-id", transferredBoard.CreatedBy)
+
+		dbBoard, err := store.GetBoard(newBoard.ID)
+		require.NoError(t, err)
+		require.Equal(t, "new-owner-id", dbBoard.CreatedBy)
+
+		newOwner, err := store.GetMemberForBoard(newBoard.ID, "new-owner-id")
+		require.NoError(t, err)
+		require.True(t, newOwner.SchemeAdmin)
+
+		oldOwner, err := store.GetMemberForBoard(newBoard.ID, userID)
+		require.NoError(t, err)
+		require.False(t, oldOwner.SchemeAdmin)
+		require.True(t, oldOwner.SchemeEditor)
+	})
+}
+
 func testPatchBoard(t *testing.T, store store.Store) {
 	userID := testUserID
 
@@ -1169,3 +1273,54 @@ func testGetBoardCount(t *testing.T, store store.Store) {
 		require.Equal(t, originalCount+1, newCount)
 	})
 }
+
+func testBoardLastActivity(t *testing.T, store store.Store) {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     "board-id",
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+	require.Equal(t, board.UpdateAt, board.LastActivityAt)
+
+	rBoard, err := store.GetBoard(board.ID)
+	require.NoError(t, err)
+	require.Equal(t, board.LastActivityAt, rBoard.LastActivityAt)
+
+	t.Run("inserting a block updates the board activity", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		block := &model.Block{ID: "block-id", BoardID: board.ID, Type: model.TypeCard}
+		require.NoError(t, store.InsertBlock(block, testUserID))
+
+		rBoard, err := store.GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, block.UpdateAt, rBoard.LastActivityAt)
+		require.Greater(t, rBoard.LastActivityAt, board.LastActivityAt)
+		require.Equal(t, board.UpdateAt, rBoard.UpdateAt)
+	})
+
+	t.Run("deleting a block updates the board activity", func(t *testing.T) {
+		before, err := store.GetBoard(board.ID)
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.DeleteBlock("block-id", testUserID))
+
+		rBoard, err := store.GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Greater(t, rBoard.LastActivityAt, before.LastActivityAt)
+	})
+
+	t.Run("created boards carry the activity of their blocks", func(t *testing.T) {
+		bab := &model.BoardsAndBlocks{
+			Boards: []*model.Board{{ID: "board-id-2", TeamID: testTeamID, Type: model.BoardTypeOpen}},
+			Blocks: []model.Block{{ID: "block-id-2", BoardID: "board-id-2", Type: model.TypeCard}},
+		}
+		newBab, err := store.CreateBoardsAndBlocks(bab, testUserID)
+		require.NoError(t, err)
+
+		rBoard, err := store.GetBoard("board-id-2")
+		require.NoError(t, err)
+		require.Equal(t, rBoard.LastActivityAt, newBab.Boards[0].LastActivityAt)
+	})
+}
@@ -0,0 +1,75 @@
+package storetests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardSequencesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("NextBoardSequence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testNextBoardSequence(t, store)
+	})
+	t.Run("ConcurrentNextBoardSequence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testConcurrentNextBoardSequence(t, store)
+	})
+}
+
+func testNextBoardSequence(t *testing.T, store store.Store) {
+	value, err := store.NextBoardSequence("board-id", 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, value)
+
+	// a range of values returns the last one
+	value, err = store.NextBoardSequence("board-id", 5)
+	require.NoError(t, err)
+	require.EqualValues(t, 6, value)
+
+	// the sequences are per board
+	value, err = store.NextBoardSequence("other-board-id", 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, value)
+
+	_, err = store.NextBoardSequence("board-id", 0)
+	require.Error(t, err)
+}
+
+func testConcurrentNextBoardSequence(t *testing.T, store store.Store) {
+	const allocations = 20
+
+	var wg sync.WaitGroup
+	values := make(chan int64, allocations)
+	errs := make(chan error, allocations)
+	for i := 0; i < allocations; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := store.NextBoardSequence("board-id", 1)
+			if err != nil {
+				errs <- err
+				return
+			}
+			values <- value
+		}()
+	}
+	wg.Wait()
+	close(values)
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	seen := map[int64]bool{}
+	for value := range values {
+		require.False(t, seen[value], "value %d allocated twice", value)
+		seen[value] = true
+	}
+	require.Len(t, seen, allocations)
+}
@@ -0,0 +1,87 @@
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardSlugsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SetGetBoardSlug", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetGetBoardSlug(t, store)
+	})
+	t.Run("DeleteBoardSlug", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardSlug(t, store)
+	})
+}
+
+func testSetGetBoardSlug(t *testing.T, store store.Store) {
+	board1 := createFavoriteTestBoard(t, store)
+	board2 := createFavoriteTestBoard(t, store)
+
+	_, err := store.GetBoardSlug(board1.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	t.Run("set and resolve a slug", func(t *testing.T) {
+		require.NoError(t, store.SetBoardSlug(board1.ID, testTeamID, "roadmap"))
+
+		slug, err := store.GetBoardSlug(board1.ID)
+		require.NoError(t, err)
+		require.Equal(t, "roadmap", slug)
+
+		boardID, err := store.GetBoardIDBySlug(testTeamID, "roadmap")
+		require.NoError(t, err)
+		require.Equal(t, board1.ID, boardID)
+
+		_, err = store.GetBoardIDBySlug("other-team-id", "roadmap")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("a taken slug is rejected", func(t *testing.T) {
+		err := store.SetBoardSlug(board2.ID, testTeamID, "roadmap")
+		require.True(t, model.IsErrBadRequest(err))
+
+		// setting the same slug again is a no-op
+		require.NoError(t, store.SetBoardSlug(board1.ID, testTeamID, "roadmap"))
+	})
+
+	t.Run("replace and clear a slug", func(t *testing.T) {
+		require.NoError(t, store.SetBoardSlug(board1.ID, testTeamID, "new-roadmap"))
+
+		_, err := store.GetBoardIDBySlug(testTeamID, "roadmap")
+		require.True(t, model.IsErrNotFound(err))
+
+		// the old slug is free again
+		require.NoError(t, store.SetBoardSlug(board2.ID, testTeamID, "roadmap"))
+
+		require.NoError(t, store.SetBoardSlug(board1.ID, testTeamID, ""))
+		_, err = store.GetBoardSlug(board1.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteBoardSlug(t *testing.T, store store.Store) {
+	board1 := createFavoriteTestBoard(t, store)
+	board2 := createFavoriteTestBoard(t, store)
+	require.NoError(t, store.SetBoardSlug(board1.ID, testTeamID, "deleted"))
+	require.NoError(t, store.SetBoardSlug(board2.ID, testTeamID, "moved"))
+
+	// wait to avoid hitting pk uniqueness constraint in history
+	time.Sleep(10 * time.Millisecond)
+
+	require.NoError(t, store.DeleteBoard(board1.ID, "user-id"))
+	_, err := store.GetBoardIDBySlug(testTeamID, "deleted")
+	require.True(t, model.IsErrNotFound(err))
+
+	_, err = store.MoveBoard(board2.ID, "other-team-id", "user-id")
+	require.NoError(t, err)
+	_, err = store.GetBoardIDBySlug(testTeamID, "moved")
+	require.True(t, model.IsErrNotFound(err))
+}
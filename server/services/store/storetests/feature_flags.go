@@ -0,0 +1,66 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestFeatureFlagsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SetGetTeamFeatureFlags", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetGetTeamFeatureFlags(t, store)
+	})
+	t.Run("DeleteTeamFeatureFlag", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteTeamFeatureFlag(t, store)
+	})
+}
+
+func testSetGetTeamFeatureFlags(t *testing.T, store store.Store) {
+	t.Run("Get empty flags", func(t *testing.T) {
+		flags, err := store.GetTeamFeatureFlags("team-1")
+		require.NoError(t, err)
+		require.Empty(t, flags)
+	})
+
+	t.Run("Set and get flags", func(t *testing.T) {
+		require.NoError(t, store.SetTeamFeatureFlag("team-1", "comments", "true"))
+		require.NoError(t, store.SetTeamFeatureFlag("team-1", "relations", "false"))
+		require.NoError(t, store.SetTeamFeatureFlag("team-2", "comments", "false"))
+
+		flags, err := store.GetTeamFeatureFlags("team-1")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"comments": "true", "relations": "false"}, flags)
+
+		flags, err = store.GetTeamFeatureFlags("team-2")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"comments": "false"}, flags)
+	})
+
+	t.Run("Update a flag", func(t *testing.T) {
+		require.NoError(t, store.SetTeamFeatureFlag("team-1", "comments", "false"))
+
+		flags, err := store.GetTeamFeatureFlags("team-1")
+		require.NoError(t, err)
+		require.Equal(t, "false", flags["comments"])
+	})
+}
+
+func testDeleteTeamFeatureFlag(t *testing.T, store store.Store) {
+	require.NoError(t, store.SetTeamFeatureFlag("team-1", "comments", "true"))
+	require.NoError(t, store.SetTeamFeatureFlag("team-1", "relations", "true"))
+
+	require.NoError(t, store.DeleteTeamFeatureFlag("team-1", "comments"))
+
+	flags, err := store.GetTeamFeatureFlags("team-1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"relations": "true"}, flags)
+
+	t.Run("Delete a flag that is not set", func(t *testing.T) {
+		require.NoError(t, store.DeleteTeamFeatureFlag("team-1", "comments"))
+	})
+}
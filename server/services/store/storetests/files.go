@@ -46,3 +46,45 @@ func StoreTestFileStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		require.Nil(t, fileInfo)
 	})
 }
+
+func StoreTestFileReferencesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	sqlStore, tearDown := setup(t)
+	defer tearDown()
+
+	newRef := func(fileID, teamID string) *model.FileReference {
+		return &model.FileReference{
+			FileID:      fileID,
+			TeamID:      teamID,
+			ContentHash: "content-hash",
+			CreateAt:    utils.GetMillis(),
+		}
+	}
+
+	t.Run("should save and retrieve a file reference", func(t *testing.T) {
+		ref := newRef("file_ref_1", "team_1")
+		require.NoError(t, sqlStore.SaveFileReference(ref))
+
+		retrieved, err := sqlStore.GetFileReference("file_ref_1")
+		require.NoError(t, err)
+		require.Equal(t, ref, retrieved)
+
+		_, err = sqlStore.GetFileReference("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("should count the remaining references of the team content", func(t *testing.T) {
+		require.NoError(t, sqlStore.SaveFileReference(newRef("file_ref_2", "team_1")))
+		require.NoError(t, sqlStore.SaveFileReference(newRef("file_ref_3", "team_2")))
+
+		remaining, err := sqlStore.DeleteFileReference("file_ref_1")
+		require.NoError(t, err)
+		require.EqualValues(t, 1, remaining)
+
+		remaining, err = sqlStore.DeleteFileReference("file_ref_2")
+		require.NoError(t, err)
+		require.EqualValues(t, 0, remaining)
+
+		_, err = sqlStore.DeleteFileReference("file_ref_2")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -0,0 +1,93 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestInvitesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateGetDeleteInvite", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateGetDeleteInvite(t, store)
+	})
+	t.Run("CreateUserWithInvite", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateUserWithInvite(t, store)
+	})
+}
+
+func newTestInvite(teamID string, maxUses int, expiresAt int64) *model.Invite {
+	return &model.Invite{
+		Token:     utils.NewID(utils.IDTypeToken),
+		TeamID:    teamID,
+		Role:      model.BoardRoleEditor,
+		MaxUses:   maxUses,
+		CreateAt:  utils.GetMillis(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func testCreateGetDeleteInvite(t *testing.T, store store.Store) {
+	expiresAt := utils.GetMillis() + 60*1000
+	invite := newTestInvite("team-id", 2, expiresAt)
+	require.NoError(t, store.CreateInvite(invite))
+	require.NoError(t, store.CreateInvite(newTestInvite("other-team-id", 1, expiresAt)))
+
+	got, err := store.GetInvite(invite.Token)
+	require.NoError(t, err)
+	require.Equal(t, invite, got)
+
+	invites, err := store.GetInvitesForTeam("team-id")
+	require.NoError(t, err)
+	require.Equal(t, []*model.Invite{invite}, invites)
+
+	require.NoError(t, store.DeleteInvite(invite.Token))
+
+	_, err = store.GetInvite(invite.Token)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteInvite(invite.Token)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testCreateUserWithInvite(t *testing.T, store store.Store) {
+	newUser := func(username string) *model.User {
+		return &model.User{
+			ID:       utils.NewID(utils.IDTypeUser),
+			Username: username,
+			Email:    username + "@example.com",
+		}
+	}
+
+	t.Run("use count limit", func(t *testing.T) {
+		invite := newTestInvite("team-id", 1, utils.GetMillis()+60*1000)
+		require.NoError(t, store.CreateInvite(invite))
+
+		_, err := store.CreateUserWithInvite(newUser("invited1"), invite.Token)
+		require.NoError(t, err)
+
+		_, err = store.CreateUserWithInvite(newUser("invited2"), invite.Token)
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.GetUserByUsername("invited2")
+		require.True(t, model.IsErrNotFound(err))
+
+		got, err := store.GetInvite(invite.Token)
+		require.NoError(t, err)
+		require.Equal(t, 1, got.UseCount)
+	})
+
+	t.Run("expired invite", func(t *testing.T) {
+		invite := newTestInvite("team-id", 1, utils.GetMillis()-1)
+		require.NoError(t, store.CreateInvite(invite))
+
+		_, err := store.CreateUserWithInvite(newUser("invited3"), invite.Token)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
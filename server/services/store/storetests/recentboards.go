@@ -0,0 +1,75 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestRecentBoardsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveGetRecentBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveGetRecentBoards(t, store)
+	})
+	t.Run("DeleteRecentBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteRecentBoards(t, store)
+	})
+}
+
+func recentBoardIDs(t *testing.T, store store.Store, userID string) []string {
+	boards, err := store.GetRecentBoards(userID)
+	require.NoError(t, err)
+
+	boardIDs := make([]string, 0, len(boards))
+	for _, board := range boards {
+		boardIDs = append(boardIDs, board.ID)
+	}
+	return boardIDs
+}
+
+func testSaveGetRecentBoards(t *testing.T, store store.Store) {
+	require.Empty(t, recentBoardIDs(t, store, "user-id"))
+
+	board1 := createFavoriteTestBoard(t, store)
+	board2 := createFavoriteTestBoard(t, store)
+	board3 := createFavoriteTestBoard(t, store)
+
+	require.NoError(t, store.SaveRecentBoard(&model.RecentBoard{UserID: "user-id", BoardID: board1.ID, ViewedAt: 1}, 2))
+	require.NoError(t, store.SaveRecentBoard(&model.RecentBoard{UserID: "user-id", BoardID: board2.ID, ViewedAt: 2}, 2))
+	require.Equal(t, []string{board2.ID, board1.ID}, recentBoardIDs(t, store, "user-id"))
+
+	// opening a board again updates its time
+	require.NoError(t, store.SaveRecentBoard(&model.RecentBoard{UserID: "user-id", BoardID: board1.ID, ViewedAt: 3}, 2))
+	require.Equal(t, []string{board1.ID, board2.ID}, recentBoardIDs(t, store, "user-id"))
+
+	// the oldest board is pruned
+	require.NoError(t, store.SaveRecentBoard(&model.RecentBoard{UserID: "user-id", BoardID: board3.ID, ViewedAt: 4}, 2))
+	require.Equal(t, []string{board3.ID, board1.ID}, recentBoardIDs(t, store, "user-id"))
+
+	// the recent boards are per user
+	require.Empty(t, recentBoardIDs(t, store, "other-user-id"))
+}
+
+func testDeleteRecentBoards(t *testing.T, store store.Store) {
+	board := createFavoriteTestBoard(t, store)
+
+	for _, userID := range []string{"user-id", "other-user-id"} {
+		_, err := store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: userID, SchemeViewer: true})
+		require.NoError(t, err)
+		require.NoError(t, store.SaveRecentBoard(&model.RecentBoard{UserID: userID, BoardID: board.ID, ViewedAt: 1}, 10))
+	}
+
+	// leaving the board deletes it from the recent boards of the user
+	require.NoError(t, store.DeleteMember(board.ID, "user-id"))
+	require.Empty(t, recentBoardIDs(t, store, "user-id"))
+	require.Equal(t, []string{board.ID}, recentBoardIDs(t, store, "other-user-id"))
+
+	// deleting the board deletes it from the recent boards of every user
+	require.NoError(t, store.DeleteBoard(board.ID, "user-id"))
+	require.Empty(t, recentBoardIDs(t, store, "other-user-id"))
+}
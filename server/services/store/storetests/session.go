@@ -7,6 +7,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,11 +24,23 @@ func StoreTestSessionStore(t *testing.T, setup func(t *testing.T) (store.Store,
 		testGetActiveUserCount(t, store)
 	})
 
+	t.Run("GetActiveUserCounts", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetActiveUserCounts(t, store)
+	})
+
 	t.Run("UpdateSession", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
 		testUpdateSession(t, store)
 	})
+
+	t.Run("GetSessionTimestamps", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSessionTimestamps(t, store)
+	})
 }
 
 func testCreateAndGetAndDeleteSession(t *testing.T, store store.Store) {
@@ -86,6 +99,36 @@ func testGetActiveUserCount(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetActiveUserCounts(t *testing.T, store store.Store) {
+	t.Run("no periods", func(t *testing.T) {
+		counts, err := store.GetActiveUserCounts(nil)
+		require.NoError(t, err)
+		require.Empty(t, counts)
+	})
+
+	t.Run("no active user", func(t *testing.T) {
+		counts, err := store.GetActiveUserCounts([]int64{60, 3600})
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 0}, counts)
+	})
+
+	t.Run("active users", func(t *testing.T) {
+		// two sessions for the same user should be counted once
+		for i, userID := range []string{"user-id-1", "user-id-1", "user-id-2"} {
+			session := &model.Session{
+				ID:     fmt.Sprintf("id-%d", i),
+				UserID: userID,
+				Token:  fmt.Sprintf("token-%d", i),
+			}
+			require.NoError(t, store.CreateSession(session))
+		}
+
+		counts, err := store.GetActiveUserCounts([]int64{60, 3600})
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 2}, counts)
+	})
+}
+
 func testUpdateSession(t *testing.T, store store.Store) {
 	session := &model.Session{
 		ID:    "session-id",
@@ -105,3 +148,27 @@ func testUpdateSession(t *testing.T, store store.Store) {
 	require.NoError(t, err)
 	require.Equal(t, session, got)
 }
+
+func testGetSessionTimestamps(t *testing.T, store store.Store) {
+	before := utils.GetMillis()
+	session := &model.Session{
+		ID:     "session-id",
+		Token:  "token",
+		UserID: "user-id",
+	}
+	require.NoError(t, store.CreateSession(session))
+
+	got, err := store.GetSession(session.Token, 60)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, got.CreateAt, before)
+	require.GreaterOrEqual(t, got.UpdateAt, got.CreateAt)
+
+	// refreshing the session moves only its update time
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.RefreshSession(got))
+
+	refreshed, err := store.GetSession(session.Token, 60)
+	require.NoError(t, err)
+	require.Equal(t, got.CreateAt, refreshed.CreateAt)
+	require.Greater(t, refreshed.UpdateAt, got.UpdateAt)
+}
@@ -52,6 +52,22 @@ func testUpsertSharingAndGetSharing(t *testing.T, store store.Store) {
 		newSharing.UpdateAt = 0
 		require.Equal(t, sharing, *newSharing)
 	})
+	t.Run("Upsert the sharing with visible properties and get it", func(t *testing.T) {
+		sharing := model.Sharing{
+			ID:                "sharing-id",
+			Enabled:           true,
+			Token:             "token2",
+			ModifiedBy:        "user-id2",
+			VisibleProperties: []string{"property-1", "property-2"},
+		}
+
+		err := store.UpsertSharing(sharing)
+		require.NoError(t, err)
+		newSharing, err := store.GetSharing("sharing-id")
+		require.NoError(t, err)
+		newSharing.UpdateAt = 0
+		require.Equal(t, sharing, *newSharing)
+	})
 	t.Run("Get not existing sharing", func(t *testing.T) {
 		_, err := store.GetSharing("not-existing")
 		require.Error(t, err)
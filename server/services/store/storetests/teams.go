@@ -40,6 +40,18 @@ func StoreTestTeamStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testGetAllTeams(t, store)
 	})
+
+	t.Run("DeleteEmptyTeams", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteEmptyTeams(t, store)
+	})
+
+	t.Run("RenamePopulatedTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRenamePopulatedTeam(t, store)
+	})
 }
 
 func testGetTeam(t *testing.T, store store.Store) {
@@ -155,3 +167,142 @@ func testGetAllTeams(t *testing.T, store store.Store) {
 		require.Len(t, got, teamCount)
 	})
 }
+
+func testDeleteEmptyTeams(t *testing.T, store store.Store) {
+	for _, teamID := range []string{model.GlobalTeamID, "empty", "with-board", "with-category", "with-invite", "with-expired-invite"} {
+		require.NoError(t, store.UpsertTeamSignupToken(model.Team{ID: teamID, SignupToken: utils.NewID(utils.IDTypeToken)}))
+	}
+
+	_, err := store.InsertBoard(&model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "with-board", Type: model.BoardTypeOpen}, "user-id")
+	require.NoError(t, err)
+
+	now := utils.GetMillis()
+	require.NoError(t, store.CreateCategory(model.Category{
+		ID:       utils.NewID(utils.IDTypeNone),
+		Name:     "Category",
+		UserID:   "user-id",
+		TeamID:   "with-category",
+		CreateAt: now,
+		UpdateAt: now,
+	}))
+
+	require.NoError(t, store.CreateInvite(newTestInvite("with-invite", 1, now+60*1000)))
+	require.NoError(t, store.CreateInvite(newTestInvite("with-expired-invite", 1, now-1)))
+	require.NoError(t, store.SetTeamFeatureFlag("empty", "flag", "on"))
+
+	t.Run("recently updated teams are kept", func(t *testing.T) {
+		teamIDs, err := store.DeleteEmptyTeams(now - 60*1000)
+		require.NoError(t, err)
+		require.Empty(t, teamIDs)
+	})
+
+	t.Run("empty teams are deleted", func(t *testing.T) {
+		teamIDs, err := store.DeleteEmptyTeams(utils.GetMillis() + 1)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"empty", "with-expired-invite"}, teamIDs)
+
+		for _, teamID := range teamIDs {
+			_, err = store.GetTeam(teamID)
+			require.True(t, model.IsErrNotFound(err))
+		}
+
+		invites, err := store.GetInvitesForTeam("with-expired-invite")
+		require.NoError(t, err)
+		require.Empty(t, invites)
+
+		flags, err := store.GetTeamFeatureFlags("empty")
+		require.NoError(t, err)
+		require.Empty(t, flags)
+
+		teams, err := store.GetAllTeams()
+		require.NoError(t, err)
+		require.Len(t, teams, 4)
+	})
+}
+
+// testRenamePopulatedTeam renames a team that has boards, members,
+// categories and blocks, and checks that every row referencing the
+// team, or referencing those rows, still resolves.
+func testRenamePopulatedTeam(t *testing.T, store store.Store) {
+	const teamID = "team-id"
+	const userID = "user-id"
+
+	require.NoError(t, store.UpsertTeamSignupToken(model.Team{ID: teamID, SignupToken: utils.NewID(utils.IDTypeToken)}))
+	require.NoError(t, store.UpsertTeamSettings(model.Team{ID: teamID, Settings: map[string]interface{}{"title": "Team"}}))
+
+	board, member, err := store.InsertBoardWithAdmin(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: teamID,
+		Type:   model.BoardTypeOpen,
+		Title:  "Board",
+	}, userID)
+	require.NoError(t, err)
+	require.NotNil(t, member)
+
+	card := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}
+	require.NoError(t, store.InsertBlock(card, userID))
+	text := &model.Block{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, ParentID: card.ID, Type: model.TypeText}
+	require.NoError(t, store.InsertBlock(text, userID))
+
+	now := utils.GetMillis()
+	category := model.Category{
+		ID:       utils.NewID(utils.IDTypeNone),
+		Name:     "Category",
+		UserID:   userID,
+		TeamID:   teamID,
+		CreateAt: now,
+		UpdateAt: now,
+	}
+	require.NoError(t, store.CreateCategory(category))
+	require.NoError(t, store.AddUpdateCategoryBoard(userID, category.ID, board.ID))
+
+	// rename the team
+	require.NoError(t, store.UpsertTeamSettings(model.Team{ID: teamID, Settings: map[string]interface{}{"title": "Renamed team"}}))
+
+	team, err := store.GetTeam(teamID)
+	require.NoError(t, err)
+	require.Equal(t, "Renamed team", team.Settings["title"])
+
+	boards, err := store.GetBoardsForTeam(teamID)
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	for _, b := range boards {
+		_, err = store.GetTeam(b.TeamID)
+		require.NoError(t, err, "board %s references a missing team", b.ID)
+	}
+
+	members, err := store.GetMembersForBoard(board.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	for _, m := range members {
+		_, err = store.GetBoard(m.BoardID)
+		require.NoError(t, err, "member %s references a missing board", m.UserID)
+	}
+
+	blocks, err := store.GetBlocksForBoard(board.ID)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	blocksByID := map[string]bool{}
+	for _, block := range blocks {
+		blocksByID[block.ID] = true
+	}
+	for _, block := range blocks {
+		_, err = store.GetBoard(block.BoardID)
+		require.NoError(t, err, "block %s references a missing board", block.ID)
+		require.True(t, block.ParentID == block.BoardID || blocksByID[block.ParentID],
+			"block %s references a missing parent", block.ID)
+	}
+
+	categoryBoards, err := store.GetUserCategoryBoards(userID, teamID)
+	require.NoError(t, err)
+	var boardIDs []string
+	for _, cb := range categoryBoards {
+		require.Equal(t, teamID, cb.TeamID)
+		boardIDs = append(boardIDs, cb.BoardIDs...)
+	}
+	require.Contains(t, boardIDs, board.ID)
+	for _, boardID := range boardIDs {
+		_, err = store.GetBoard(boardID)
+		require.NoError(t, err, "category references a missing board %s", boardID)
+	}
+}
@@ -0,0 +1,64 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestTeamWebhooksStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateGetUpdateDeleteTeamWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateGetUpdateDeleteTeamWebhook(t, store)
+	})
+}
+
+func newTestTeamWebhook(teamID string, eventTypes []string) *model.TeamWebhook {
+	now := utils.GetMillis()
+	return &model.TeamWebhook{
+		ID:         utils.NewID(utils.IDTypeNone),
+		TeamID:     teamID,
+		URL:        "https://example.com/" + teamID,
+		EventTypes: eventTypes,
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+}
+
+func testCreateGetUpdateDeleteTeamWebhook(t *testing.T, store store.Store) {
+	webhook := newTestTeamWebhook("team-id", []string{model.WebhookEventCreate})
+	require.NoError(t, store.CreateTeamWebhook(webhook))
+	require.NoError(t, store.CreateTeamWebhook(newTestTeamWebhook("other-team-id", []string{})))
+
+	got, err := store.GetTeamWebhook(webhook.ID)
+	require.NoError(t, err)
+	require.Equal(t, webhook, got)
+
+	webhooks, err := store.GetTeamWebhooks("team-id")
+	require.NoError(t, err)
+	require.Equal(t, []*model.TeamWebhook{webhook}, webhooks)
+
+	webhook.URL = "https://example.com/other"
+	webhook.EventTypes = []string{model.WebhookEventCreate, model.WebhookEventUpdate}
+	webhook.UpdateAt++
+	require.NoError(t, store.UpdateTeamWebhook(webhook))
+
+	got, err = store.GetTeamWebhook(webhook.ID)
+	require.NoError(t, err)
+	require.Equal(t, webhook, got)
+
+	require.NoError(t, store.DeleteTeamWebhook(webhook.ID))
+
+	_, err = store.GetTeamWebhook(webhook.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteTeamWebhook(webhook.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.UpdateTeamWebhook(webhook)
+	require.True(t, model.IsErrNotFound(err))
+}
@@ -0,0 +1,84 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestUserBoardViewsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveGetUserBoardView", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveGetUserBoardView(t, store)
+	})
+	t.Run("DeleteUserBoardViews", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteUserBoardViews(t, store)
+	})
+}
+
+func testSaveGetUserBoardView(t *testing.T, store store.Store) {
+	_, err := store.GetUserBoardView("user-id", "board-id")
+	require.True(t, model.IsErrNotFound(err))
+
+	view := &model.UserBoardView{
+		UserID:          "user-id",
+		BoardID:         "board-id",
+		ActiveViewID:    "view-id",
+		SortOptions:     []interface{}{map[string]interface{}{"propertyId": "status", "reversed": true}},
+		Filter:          map[string]interface{}{"operation": "and", "filters": []interface{}{}},
+		CollapsedGroups: []string{"done"},
+		UpdateAt:        utils.GetMillis(),
+	}
+	require.NoError(t, store.SaveUserBoardView(view))
+
+	got, err := store.GetUserBoardView("user-id", "board-id")
+	require.NoError(t, err)
+	require.Equal(t, view, got)
+
+	// saving again replaces the state
+	view.ActiveViewID = "other-view-id"
+	view.CollapsedGroups = []string{}
+	require.NoError(t, store.SaveUserBoardView(view))
+
+	got, err = store.GetUserBoardView("user-id", "board-id")
+	require.NoError(t, err)
+	require.Equal(t, view, got)
+
+	// the state is per user
+	_, err = store.GetUserBoardView("other-user-id", "board-id")
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeleteUserBoardViews(t *testing.T, store store.Store) {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, "user-id")
+	require.NoError(t, err)
+
+	for _, userID := range []string{"user-id", "other-user-id"} {
+		_, err = store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: userID, SchemeViewer: true})
+		require.NoError(t, err)
+		require.NoError(t, store.SaveUserBoardView(&model.UserBoardView{UserID: userID, BoardID: board.ID, UpdateAt: 1}))
+	}
+
+	// leaving the board deletes the state of the user
+	require.NoError(t, store.DeleteMember(board.ID, "user-id"))
+	_, err = store.GetUserBoardView("user-id", board.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	_, err = store.GetUserBoardView("other-user-id", board.ID)
+	require.NoError(t, err)
+
+	// deleting the board deletes the state of every user
+	require.NoError(t, store.DeleteBoard(board.ID, "user-id"))
+	_, err = store.GetUserBoardView("other-user-id", board.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
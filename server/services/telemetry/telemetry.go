@@ -4,8 +4,10 @@
 package telemetry
 
 import (
+	"errors"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mattermost/focalboard/server/services/scheduler"
@@ -19,18 +21,50 @@ const (
 	rudderKey                  = "placeholder_rudder_key"
 	rudderDataplaneURL         = "placeholder_rudder_dataplane_url"
 	timeBetweenTelemetryChecks = 10 * time.Minute
+
+	defaultTrackerConcurrency = 4
+	defaultTrackerTimeout     = 30 * time.Second
+
+	defaultEventFlushInterval = time.Minute
+	defaultEventBatchSize     = 100
+)
+
+var (
+	ErrTrackerTimeout = errors.New("telemetry tracker timed out")
+	ErrShutdown       = errors.New("telemetry service is shutting down")
 )
 
 type TrackerFunc func() (Tracker, error)
 
 type Tracker map[string]interface{}
 
+// Event is a telemetry event waiting in the queue to be sent.
+type Event struct {
+	Name       string
+	Properties map[string]interface{}
+}
+
 type Service struct {
 	trackers                   map[string]TrackerFunc
 	logger                     mlog.LoggerIFace
 	rudderClient               rudder.Client
+	rudderMux                  sync.Mutex
 	telemetryID                string
 	timestampLastTelemetrySent time.Time
+
+	concurrency    int
+	trackerTimeout time.Duration
+
+	eventFlushInterval time.Duration
+	eventBatchSize     int
+	eventsMux          sync.Mutex
+	events             []Event
+	eventQueueRunning  bool
+	flushEventsChan    chan struct{}
+	eventQueueDone     chan struct{}
+
+	done         chan struct{}
+	shutdownOnce sync.Once
 }
 
 type RudderConfig struct {
@@ -40,14 +74,46 @@ type RudderConfig struct {
 
 func New(telemetryID string, logger mlog.LoggerIFace) *Service {
 	service := &Service{
-		logger:      logger,
-		telemetryID: telemetryID,
-		trackers:    map[string]TrackerFunc{},
+		logger:         logger,
+		telemetryID:    telemetryID,
+		trackers:       map[string]TrackerFunc{},
+		concurrency:    defaultTrackerConcurrency,
+		trackerTimeout: defaultTrackerTimeout,
+
+		eventFlushInterval: defaultEventFlushInterval,
+		eventBatchSize:     defaultEventBatchSize,
+		flushEventsChan:    make(chan struct{}, 1),
+		eventQueueDone:     make(chan struct{}),
+
+		done: make(chan struct{}),
 	}
 
 	return service
 }
 
+// SetTrackerLimits sets how many trackers are gathered in parallel and
+// how long each of them can take. Zero keeps the default value.
+func (ts *Service) SetTrackerLimits(concurrency int, timeout time.Duration) {
+	if concurrency > 0 {
+		ts.concurrency = concurrency
+	}
+	if timeout > 0 {
+		ts.trackerTimeout = timeout
+	}
+}
+
+// SetEventQueueLimits sets how often the queued events are sent and how
+// many events are queued before they are sent right away. Zero keeps the
+// default value.
+func (ts *Service) SetEventQueueLimits(flushInterval time.Duration, batchSize int) {
+	if flushInterval > 0 {
+		ts.eventFlushInterval = flushInterval
+	}
+	if batchSize > 0 {
+		ts.eventBatchSize = batchSize
+	}
+}
+
 func (ts *Service) RegisterTracker(name string, f TrackerFunc) {
 	ts.trackers[name] = f
 }
@@ -62,32 +128,175 @@ func (ts *Service) getRudderConfig() RudderConfig {
 	return RudderConfig{}
 }
 
-func (ts *Service) sendDailyTelemetry(override bool) {
+// getRudderClient returns the telemetry client, creating it if needed,
+// or nil if the telemetry endpoint isn't configured.
+func (ts *Service) getRudderClient(override bool) rudder.Client {
+	ts.rudderMux.Lock()
+	defer ts.rudderMux.Unlock()
+
 	config := ts.getRudderConfig()
 	if (config.DataplaneURL != "" && config.RudderKey != "") || override {
 		ts.initRudder(config.DataplaneURL, config.RudderKey)
+	}
+	return ts.rudderClient
+}
+
+func (ts *Service) sendDailyTelemetry(override bool) {
+	client := ts.getRudderClient(override)
+	if client == nil {
+		return
+	}
+
+	for name, m := range ts.gatherTrackers() {
+		ts.sendTelemetry(client, name, m)
+	}
+}
 
-		for name, tracker := range ts.trackers {
-			m, err := tracker()
+// TrackEvent queues an event to be sent with the next batch. The events
+// are dropped if the telemetry job isn't running.
+func (ts *Service) TrackEvent(name string, properties map[string]interface{}) {
+	ts.eventsMux.Lock()
+	defer ts.eventsMux.Unlock()
+
+	if !ts.eventQueueRunning {
+		return
+	}
+
+	ts.events = append(ts.events, Event{Name: name, Properties: properties})
+	if len(ts.events) >= ts.eventBatchSize {
+		select {
+		case ts.flushEventsChan <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// startEventQueue starts sending the queued events every flush
+// interval, or as soon as a batch is full.
+func (ts *Service) startEventQueue() {
+	ts.eventsMux.Lock()
+	defer ts.eventsMux.Unlock()
+
+	if ts.eventQueueRunning {
+		return
+	}
+	ts.eventQueueRunning = true
+
+	go func() {
+		defer close(ts.eventQueueDone)
+
+		ticker := time.NewTicker(ts.eventFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ts.flushEvents()
+			case <-ts.flushEventsChan:
+				ts.flushEvents()
+			case <-ts.done:
+				return
+			}
+		}
+	}()
+}
+
+// flushEvents sends the queued events.
+func (ts *Service) flushEvents() {
+	ts.eventsMux.Lock()
+	events := ts.events
+	ts.events = nil
+	ts.eventsMux.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+
+	client := ts.getRudderClient(false)
+	if client == nil {
+		return
+	}
+
+	for _, event := range events {
+		ts.sendTelemetry(client, event.Name, event.Properties)
+	}
+	ts.logger.Debug("Telemetry events sent", mlog.Int("count", len(events)))
+}
+
+// gatherTrackers runs the trackers with bounded concurrency. The
+// trackers that fail or time out are skipped, so they don't hold the
+// rest of the report.
+func (ts *Service) gatherTrackers() map[string]Tracker {
+	results := map[string]Tracker{}
+	var resultsMux sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, ts.concurrency)
+
+	for name, tracker := range ts.trackers {
+		wg.Add(1)
+		go func(name string, tracker TrackerFunc) {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+			case <-ts.done:
+				return
+			}
+			defer func() { <-slots }()
+
+			m, err := ts.runTracker(tracker)
 			if err != nil {
 				ts.logger.Error("Error fetching telemetry data", mlog.String("name", name), mlog.Err(err))
-				continue
+				return
 			}
-			ts.sendTelemetry(name, m)
-		}
+
+			resultsMux.Lock()
+			defer resultsMux.Unlock()
+			results[name] = m
+		}(name, tracker)
 	}
+
+	wg.Wait()
+	return results
 }
 
-func (ts *Service) sendTelemetry(event string, properties map[string]interface{}) {
-	if ts.rudderClient != nil {
-		var context *rudder.Context
-		_ = ts.rudderClient.Enqueue(rudder.Track{
-			Event:      event,
-			UserId:     ts.telemetryID,
-			Properties: properties,
-			Context:    context,
-		})
+// runTracker waits for a tracker up to the tracker timeout or until the
+// service shuts down. The trackers can't be cancelled, so a tracker
+// that times out keeps running in the background and its result is
+// discarded.
+func (ts *Service) runTracker(tracker TrackerFunc) (Tracker, error) {
+	type trackerResult struct {
+		tracker Tracker
+		err     error
 	}
+
+	resultChan := make(chan trackerResult, 1)
+	go func() {
+		m, err := tracker()
+		resultChan <- trackerResult{m, err}
+	}()
+
+	timer := time.NewTimer(ts.trackerTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-resultChan:
+		return result.tracker, result.err
+	case <-timer.C:
+		return nil, ErrTrackerTimeout
+	case <-ts.done:
+		return nil, ErrShutdown
+	}
+}
+
+func (ts *Service) sendTelemetry(client rudder.Client, event string, properties map[string]interface{}) {
+	var context *rudder.Context
+	_ = client.Enqueue(rudder.Track{
+		Event:      event,
+		UserId:     ts.telemetryID,
+		Properties: properties,
+		Context:    context,
+	})
 }
 
 func (ts *Service) initRudder(endpoint, rudderKey string) {
@@ -136,6 +345,8 @@ func (ts *Service) doTelemetryIfNeeded(firstRun time.Time) {
 }
 
 func (ts *Service) RunTelemetryJob(firstRunMillis int64) {
+	ts.startEventQueue()
+
 	// Send on boot
 	ts.doTelemetry()
 	scheduler.CreateRecurringTask("Telemetry", func() {
@@ -148,8 +359,26 @@ func (ts *Service) doTelemetry() {
 	ts.sendDailyTelemetry(false)
 }
 
-// Shutdown closes the telemetry client.
+// Shutdown stops the trackers being gathered, sends the queued events
+// and closes the telemetry client.
 func (ts *Service) Shutdown() error {
+	ts.shutdownOnce.Do(func() {
+		close(ts.done)
+	})
+
+	ts.eventsMux.Lock()
+	queueRunning := ts.eventQueueRunning
+	ts.eventQueueRunning = false
+	ts.eventsMux.Unlock()
+
+	if queueRunning {
+		<-ts.eventQueueDone
+		ts.flushEvents()
+	}
+
+	ts.rudderMux.Lock()
+	defer ts.rudderMux.Unlock()
+
 	if ts.rudderClient != nil {
 		return ts.rudderClient.Close()
 	}
@@ -3,11 +3,13 @@ package telemetry
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -111,3 +113,124 @@ func TestTelemetry(t *testing.T) {
 		})
 	})
 }
+
+func TestGatherTrackers(t *testing.T) {
+	t.Run("slow and failing trackers are skipped", func(t *testing.T) {
+		service := New("mockTelemetryID", mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+		service.SetTrackerLimits(2, 50*time.Millisecond)
+
+		release := make(chan struct{})
+		defer close(release)
+
+		service.RegisterTracker("fast", func() (Tracker, error) {
+			return Tracker{"key": "value"}, nil
+		})
+		service.RegisterTracker("slow", func() (Tracker, error) {
+			<-release
+			return Tracker{"key": "value"}, nil
+		})
+		service.RegisterTracker("failing", func() (Tracker, error) {
+			return nil, errors.New("tracker error")
+		})
+
+		results := service.gatherTrackers()
+		require.Len(t, results, 1)
+		require.Equal(t, Tracker{"key": "value"}, results["fast"])
+	})
+
+	t.Run("concurrency is bounded", func(t *testing.T) {
+		service := New("mockTelemetryID", mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+		service.SetTrackerLimits(2, time.Second)
+
+		var mux sync.Mutex
+		running, maxRunning := 0, 0
+		for _, name := range []string{"t1", "t2", "t3", "t4", "t5"} {
+			service.RegisterTracker(name, func() (Tracker, error) {
+				mux.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mux.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mux.Lock()
+				running--
+				mux.Unlock()
+				return Tracker{}, nil
+			})
+		}
+
+		results := service.gatherTrackers()
+		require.Len(t, results, 5)
+		require.LessOrEqual(t, maxRunning, 2)
+	})
+
+	t.Run("shutdown stops the gathering", func(t *testing.T) {
+		service := New("mockTelemetryID", mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+		service.SetTrackerLimits(1, time.Hour)
+
+		release := make(chan struct{})
+		defer close(release)
+
+		service.RegisterTracker("blocked", func() (Tracker, error) {
+			<-release
+			return Tracker{}, nil
+		})
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = service.Shutdown()
+		}()
+
+		require.Empty(t, service.gatherTrackers())
+	})
+}
+
+func TestEventQueue(t *testing.T) {
+	receiveChan, server := mockServer()
+	defer server.Close()
+
+	os.Setenv("RUDDER_KEY", "mock-test-rudder-key")
+	os.Setenv("RUDDER_DATAPLANE_URL", server.URL)
+
+	t.Run("events are dropped if the queue isn't running", func(t *testing.T) {
+		service := New("mockTelemetryID", mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+		service.TrackEvent("mockEvent", map[string]interface{}{"key": "value"})
+
+		service.eventsMux.Lock()
+		defer service.eventsMux.Unlock()
+		require.Empty(t, service.events)
+	})
+
+	t.Run("a full batch is sent right away", func(t *testing.T) {
+		service := New("mockTelemetryID", mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+		service.SetEventQueueLimits(time.Hour, 2)
+		service.startEventQueue()
+		defer func() { _ = service.Shutdown() }()
+
+		service.TrackEvent("mockEvent1", map[string]interface{}{"key": "value"})
+		service.TrackEvent("mockEvent2", map[string]interface{}{"key": "value"})
+
+		require.Contains(t, string(<-receiveChan), "mockEvent1")
+		require.Contains(t, string(<-receiveChan), "mockEvent2")
+	})
+
+	t.Run("queued events are sent on shutdown", func(t *testing.T) {
+		service := New("mockTelemetryID", mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+		service.SetEventQueueLimits(time.Hour, 10)
+		service.startEventQueue()
+
+		service.TrackEvent("mockEvent3", map[string]interface{}{"key": "value"})
+		require.Equal(t, 0, len(receiveChan))
+
+		require.NoError(t, service.Shutdown())
+		require.Contains(t, string(<-receiveChan), "mockEvent3")
+
+		service.TrackEvent("mockEvent4", map[string]interface{}{"key": "value"})
+		service.eventsMux.Lock()
+		defer service.eventsMux.Unlock()
+		require.Empty(t, service.events)
+	})
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/config"
+)
+
+const (
+	dialTimeout    = 10 * time.Second
+	requestTimeout = 30 * time.Second
+)
+
+var (
+	ErrHostNotAllowed    = errors.New("webhook host is not allowed")
+	ErrAddressNotAllowed = errors.New("webhook address is not allowed")
+)
+
+// ValidateURL checks that a webhook URL can be called: it must be an
+// http or https URL, its host must be in the allowed hosts if any are
+// configured and, when the host is an IP address, it must not be a
+// private one unless they are allowed. Host names are resolved when
+// the webhook is called, see newHTTPClient.
+func ValidateURL(cfg *config.Configuration, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid webhook URL scheme %q", u.Scheme)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("webhook URL has no host")
+	}
+
+	if !isHostAllowed(host, cfg.WebhookAllowedHosts) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if err := checkAddress(cfg, ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isHostAllowed checks the host against the allowed hosts. An entry like
+// *.example.com allows the subdomains of example.com, and an empty list
+// allows every host.
+func isHostAllowed(host string, allowedHosts []string) bool {
+	if len(allowedHosts) == 0 {
+		return true
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAddress rejects the loopback, private, link-local and unspecified
+// addresses, so the webhooks can't be used to reach the internal
+// services of the server network, unless private addresses are allowed.
+func checkAddress(cfg *config.Configuration, ip net.IP) error {
+	if cfg.WebhookAllowPrivateAddresses {
+		return nil
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, ip)
+	}
+	return nil
+}
+
+// newHTTPClient returns the client used to call the webhooks. The address
+// is checked right before connecting, after the host name is resolved,
+// so a host that resolves to an internal address, even if it only does
+// so after the webhook was validated, can't be reached.
+func newHTTPClient(cfg *config.Configuration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("%w: %s", ErrAddressNotAllowed, host)
+			}
+			return checkAddress(cfg, ip)
+		},
+	}
+
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			// no proxy, as the checked address would be the proxy one
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: dialTimeout,
+		},
+		// the redirects are checked by the dialer as well, but they
+		// must also respect the allowed hosts
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return ValidateURL(cfg, req.URL.String())
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateURL(t *testing.T) {
+	for name, tc := range map[string]struct {
+		URL      string
+		Cfg      config.Configuration
+		Expected error
+	}{
+		"Public host": {
+			URL: "https://hooks.example.com/board",
+		},
+		"Loopback address": {
+			URL:      "http://127.0.0.1:8000/hook",
+			Expected: ErrAddressNotAllowed,
+		},
+		"Private address": {
+			URL:      "http://10.0.0.5/hook",
+			Expected: ErrAddressNotAllowed,
+		},
+		"Link-local address": {
+			URL:      "http://169.254.169.254/latest/meta-data",
+			Expected: ErrAddressNotAllowed,
+		},
+		"IPv6 loopback address": {
+			URL:      "http://[::1]/hook",
+			Expected: ErrAddressNotAllowed,
+		},
+		"Private address allowed": {
+			URL: "http://10.0.0.5/hook",
+			Cfg: config.Configuration{WebhookAllowPrivateAddresses: true},
+		},
+		"Allowed host": {
+			URL: "https://hooks.example.com/board",
+			Cfg: config.Configuration{WebhookAllowedHosts: []string{"hooks.example.com"}},
+		},
+		"Allowed subdomain": {
+			URL: "https://hooks.example.com/board",
+			Cfg: config.Configuration{WebhookAllowedHosts: []string{"*.example.com"}},
+		},
+		"Host not allowed": {
+			URL:      "https://hooks.example.org/board",
+			Cfg:      config.Configuration{WebhookAllowedHosts: []string{"*.example.com"}},
+			Expected: ErrHostNotAllowed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := tc.Cfg
+			err := ValidateURL(&cfg, tc.URL)
+			if tc.Expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tc.Expected), "unexpected error %v", err)
+			}
+		})
+	}
+
+	t.Run("Invalid scheme", func(t *testing.T) {
+		require.Error(t, ValidateURL(&config.Configuration{}, "file:///etc/passwd"))
+	})
+}
+
+func TestHTTPClientBlocksPrivateAddresses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	// the server URL is an IP literal, but a host name resolving to it
+	// is stopped the same way, as the address is checked when dialing
+	client := newHTTPClient(&config.Configuration{})
+	_, err := client.Get(ts.URL)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrAddressNotAllowed))
+
+	client = newHTTPClient(&config.Configuration{WebhookAllowPrivateAddresses: true})
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const (
+	EventCreate = model.WebhookEventCreate
+	EventUpdate = model.WebhookEventUpdate
+
+	// DefaultPayloadTemplate renders the block as JSON, which is the
+	// payload the webhooks have always received.
+	DefaultPayloadTemplate = "{{json .Block}}"
+)
+
+// EventData is the data the payload template is rendered with.
+type EventData struct {
+	// Event is the kind of change, create or update.
+	Event string
+
+	// Block is the created or updated block.
+	Block model.Block
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+}
+
+// ParsePayloadTemplate parses the template used to build the webhook
+// request body. An empty text uses the default template.
+func ParsePayloadTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultPayloadTemplate
+	}
+
+	tmpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook payload template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func renderPayload(tmpl *template.Template, data EventData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("cannot render the webhook payload: %w", err)
+	}
+	return buf.Bytes(), nil
+}
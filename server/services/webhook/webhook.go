@@ -2,9 +2,11 @@ package webhook
 
 import (
 	"bytes"
-	"encoding/json"
 	"io"
 	"net/http"
+	"sync"
+	"text/template"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
@@ -12,18 +14,96 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-// NotifyUpdate calls webhooks.
+// NotifyCreate calls webhooks for a new block. Creations are discrete
+// events, so they are never debounced.
+func (wh *Client) NotifyCreate(block model.Block) {
+	if !wh.isEnabled() {
+		return
+	}
+
+	wh.notify(EventCreate, block)
+}
+
+// NotifyUpdate calls webhooks. If debouncing is enabled, the updates of
+// a block received within the debounce window are collapsed into a
+// single call carrying the latest version of the block. The window
+// starts with the first update, so a block that keeps changing is still
+// notified once per window.
 func (wh *Client) NotifyUpdate(block model.Block) {
-	if len(wh.config.WebhookUpdate) < 1 {
+	if !wh.isEnabled() {
+		return
+	}
+
+	window := time.Duration(wh.config.WebhookUpdateDebounceMillis) * time.Millisecond
+	if window <= 0 {
+		wh.notify(EventUpdate, block)
+		return
+	}
+
+	key := block.BoardID + "/" + block.ID
+
+	wh.pendingMux.Lock()
+	defer wh.pendingMux.Unlock()
+
+	if pending, ok := wh.pending[key]; ok {
+		pending.block = block
+		return
+	}
+
+	pending := &pendingUpdate{block: block}
+	pending.timer = time.AfterFunc(window, func() {
+		wh.notifyPending(key)
+	})
+	wh.pending[key] = pending
+}
+
+// Flush sends the pending updates right away. It is called on shutdown
+// so no update is lost.
+func (wh *Client) Flush() {
+	wh.pendingMux.Lock()
+	pending := wh.pending
+	wh.pending = map[string]*pendingUpdate{}
+	wh.pendingMux.Unlock()
+
+	for _, update := range pending {
+		update.timer.Stop()
+		wh.notify(EventUpdate, update.block)
+	}
+}
+
+func (wh *Client) notifyPending(key string) {
+	wh.pendingMux.Lock()
+	pending, ok := wh.pending[key]
+	delete(wh.pending, key)
+	wh.pendingMux.Unlock()
+
+	if ok {
+		wh.notify(EventUpdate, pending.block)
+	}
+}
+
+func (wh *Client) notify(event string, block model.Block) {
+	urls := wh.urls(event, block)
+	if len(urls) == 0 {
 		return
 	}
 
-	json, err := json.Marshal(block)
+	payload, err := renderPayload(wh.template, EventData{Event: event, Block: block})
 	if err != nil {
-		wh.logger.Fatal("NotifyUpdate: json.Marshal", mlog.Err(err))
+		wh.logger.Error("webhook.NotifyUpdate", mlog.String("blockID", block.ID), mlog.Err(err))
+		return
 	}
-	for _, url := range wh.config.WebhookUpdate {
-		resp, _ := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
+	for _, url := range urls {
+		if err := ValidateURL(wh.config, url); err != nil {
+			wh.logger.Error("webhook.NotifyUpdate", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
+
+		resp, err := wh.httpClient.Post(url, "application/json", bytes.NewBuffer(payload))
+		if err != nil {
+			wh.logger.Error("webhook.NotifyUpdate", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
 		_, _ = io.ReadAll(resp.Body)
 		resp.Body.Close()
 
@@ -31,16 +111,85 @@ func (wh *Client) NotifyUpdate(block model.Block) {
 	}
 }
 
+func (wh *Client) isEnabled() bool {
+	return len(wh.config.WebhookUpdate) > 0 || wh.store != nil
+}
+
+// urls returns the configured webhooks, which are called for every
+// event, and the webhooks of the team of the block's board that are
+// called for the event.
+func (wh *Client) urls(event string, block model.Block) []string {
+	urls := append([]string{}, wh.config.WebhookUpdate...)
+	if wh.store == nil {
+		return urls
+	}
+
+	board, err := wh.store.GetBoard(block.BoardID)
+	if err != nil {
+		wh.logger.Error("webhook.NotifyUpdate cannot get the board", mlog.String("boardID", block.BoardID), mlog.Err(err))
+		return urls
+	}
+
+	webhooks, err := wh.store.GetTeamWebhooks(board.TeamID)
+	if err != nil {
+		wh.logger.Error("webhook.NotifyUpdate cannot get the team webhooks", mlog.String("teamID", board.TeamID), mlog.Err(err))
+		return urls
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.HasEventType(event) {
+			urls = append(urls, webhook.URL)
+		}
+	}
+	return urls
+}
+
+type pendingUpdate struct {
+	block model.Block
+	timer *time.Timer
+}
+
+// TeamWebhookStore is the part of the store the client uses to find
+// the webhooks of the team of a block.
+type TeamWebhookStore interface {
+	GetBoard(boardID string) (*model.Board, error)
+	GetTeamWebhooks(teamID string) ([]*model.TeamWebhook, error)
+}
+
 // Client is a webhook client.
 type Client struct {
-	config *config.Configuration
-	logger mlog.LoggerIFace
+	config     *config.Configuration
+	logger     mlog.LoggerIFace
+	httpClient *http.Client
+	template   *template.Template
+	store      TeamWebhookStore
+
+	pendingMux sync.Mutex
+	pending    map[string]*pendingUpdate
 }
 
-// NewClient creates a new Client.
+// NewClient creates a new Client. The payload template is expected to
+// have been validated with the rest of the configuration, if it's
+// invalid the default one is used.
 func NewClient(config *config.Configuration, logger mlog.LoggerIFace) *Client {
+	tmpl, err := ParsePayloadTemplate(config.WebhookUpdateTemplate)
+	if err != nil {
+		logger.Error("webhook.NewClient, using the default payload template", mlog.Err(err))
+		tmpl, _ = ParsePayloadTemplate("")
+	}
+
 	return &Client{
-		config: config,
-		logger: logger,
+		config:     config,
+		logger:     logger,
+		httpClient: newHTTPClient(config),
+		template:   tmpl,
+		pending:    map[string]*pendingUpdate{},
 	}
 }
+
+// SetTeamWebhookStore sets the store the team webhooks are read from.
+// Without it, only the configured webhooks are called. It must be called
+// before the client is used.
+func (wh *Client) SetTeamWebhookStore(store TeamWebhookStore) {
+	wh.store = store
+}
@@ -1,13 +1,18 @@
 package webhook
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -20,7 +25,8 @@ func TestClientUpdateNotify(t *testing.T) {
 	defer ts.Close()
 
 	cfg := &config.Configuration{
-		WebhookUpdate: []string{ts.URL},
+		WebhookUpdate:                []string{ts.URL},
+		WebhookAllowPrivateAddresses: true,
 	}
 
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
@@ -37,3 +43,200 @@ func TestClientUpdateNotify(t *testing.T) {
 		t.Error("webhook url not be notified")
 	}
 }
+
+func TestClientUpdateNotifyDebounce(t *testing.T) {
+	var mux sync.Mutex
+	bodies := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mux.Lock()
+		defer mux.Unlock()
+		bodies = append(bodies, string(body))
+	}))
+	defer ts.Close()
+
+	received := func() []string {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]string{}, bodies...)
+	}
+
+	cfg := &config.Configuration{
+		WebhookUpdate:                []string{ts.URL},
+		WebhookUpdateDebounceMillis:  100,
+		WebhookAllowPrivateAddresses: true,
+	}
+
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	t.Run("updates of the same block are collapsed", func(t *testing.T) {
+		mux.Lock()
+		bodies = []string{}
+		mux.Unlock()
+
+		client := NewClient(cfg, logger)
+		client.NotifyUpdate(model.Block{ID: "card-id", BoardID: "board-id", Title: "first"})
+		client.NotifyUpdate(model.Block{ID: "card-id", BoardID: "board-id", Title: "second"})
+		client.NotifyUpdate(model.Block{ID: "card-id", BoardID: "board-id", Title: "final"})
+		require.Empty(t, received())
+
+		require.Eventually(t, func() bool { return len(received()) == 1 }, time.Second, 10*time.Millisecond)
+		time.Sleep(200 * time.Millisecond)
+
+		notified := received()
+		require.Len(t, notified, 1)
+		require.Contains(t, notified[0], `"title":"final"`)
+	})
+
+	t.Run("creations are not debounced", func(t *testing.T) {
+		mux.Lock()
+		bodies = []string{}
+		mux.Unlock()
+
+		client := NewClient(cfg, logger)
+		client.NotifyCreate(model.Block{ID: "card-1", BoardID: "board-id"})
+		client.NotifyCreate(model.Block{ID: "card-2", BoardID: "board-id"})
+		require.Len(t, received(), 2)
+	})
+
+	t.Run("flush sends the pending updates", func(t *testing.T) {
+		mux.Lock()
+		bodies = []string{}
+		mux.Unlock()
+
+		client := NewClient(cfg, logger)
+		client.NotifyUpdate(model.Block{ID: "card-1", BoardID: "board-id"})
+		client.NotifyUpdate(model.Block{ID: "card-2", BoardID: "board-id"})
+		client.Flush()
+		require.Len(t, received(), 2)
+
+		time.Sleep(200 * time.Millisecond)
+		require.Len(t, received(), 2)
+	})
+}
+
+func TestClientNotifyPayloadTemplate(t *testing.T) {
+	bodies := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+	}))
+	defer ts.Close()
+
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	block := model.Block{ID: "block-id", Title: "Task"}
+
+	t.Run("default template", func(t *testing.T) {
+		cfg := &config.Configuration{
+			WebhookUpdate:                []string{ts.URL},
+			WebhookAllowPrivateAddresses: true,
+		}
+		client := NewClient(cfg, logger)
+		client.NotifyCreate(block)
+
+		expected, err := json.Marshal(block)
+		require.NoError(t, err)
+		require.Equal(t, string(expected), <-bodies)
+	})
+
+	t.Run("custom template", func(t *testing.T) {
+		cfg := &config.Configuration{
+			WebhookUpdate:                []string{ts.URL},
+			WebhookAllowPrivateAddresses: true,
+			WebhookUpdateTemplate:        `{"text": "{{.Event}}: {{.Block.Title}}", "id": {{json .Block.ID}}}`,
+		}
+		client := NewClient(cfg, logger)
+		client.NotifyUpdate(block)
+
+		require.Equal(t, `{"text": "update: Task", "id": "block-id"}`, <-bodies)
+	})
+}
+
+func TestParsePayloadTemplate(t *testing.T) {
+	_, err := ParsePayloadTemplate("")
+	require.NoError(t, err)
+
+	_, err = ParsePayloadTemplate(`{"title": {{json .Block.Title}}}`)
+	require.NoError(t, err)
+
+	_, err = ParsePayloadTemplate(`{"title": {{json .Block.Title}`)
+	require.Error(t, err)
+
+	_, err = ParsePayloadTemplate(`{{unknownFunc .Block}}`)
+	require.Error(t, err)
+}
+
+type testTeamWebhookStore struct {
+	webhooks []*model.TeamWebhook
+}
+
+func (s *testTeamWebhookStore) GetBoard(boardID string) (*model.Board, error) {
+	return &model.Board{ID: boardID, TeamID: "team-id"}, nil
+}
+
+func (s *testTeamWebhookStore) GetTeamWebhooks(teamID string) ([]*model.TeamWebhook, error) {
+	webhooks := []*model.TeamWebhook{}
+	for _, webhook := range s.webhooks {
+		if webhook.TeamID == teamID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func TestClientNotifyTeamWebhooks(t *testing.T) {
+	var mux sync.Mutex
+	paths := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		paths = append(paths, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	received := func() []string {
+		mux.Lock()
+		defer mux.Unlock()
+		result := append([]string{}, paths...)
+		paths = []string{}
+		return result
+	}
+
+	cfg := &config.Configuration{
+		WebhookAllowPrivateAddresses: true,
+	}
+
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	client := NewClient(cfg, logger)
+	client.SetTeamWebhookStore(&testTeamWebhookStore{
+		webhooks: []*model.TeamWebhook{
+			{ID: "all", TeamID: "team-id", URL: ts.URL + "/all"},
+			{ID: "create", TeamID: "team-id", URL: ts.URL + "/create", EventTypes: []string{EventCreate}},
+			{ID: "other", TeamID: "other-team-id", URL: ts.URL + "/other"},
+		},
+	})
+
+	t.Run("create event", func(t *testing.T) {
+		client.NotifyCreate(model.Block{ID: "card-id", BoardID: "board-id"})
+		require.ElementsMatch(t, []string{"/all", "/create"}, received())
+	})
+
+	t.Run("update event", func(t *testing.T) {
+		client.NotifyUpdate(model.Block{ID: "card-id", BoardID: "board-id"})
+		require.Equal(t, []string{"/all"}, received())
+	})
+}
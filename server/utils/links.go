@@ -13,3 +13,8 @@ func MakeCardLink(serverRoot string, teamID string, boardID string, cardID strin
 func MakeBoardLink(serverRoot string, teamID string, board string) string {
 	return fmt.Sprintf("%s/team/%s/%s", serverRoot, teamID, board)
 }
+
+// MakeFileLink creates fully qualified links to the files of a board.
+func MakeFileLink(serverRoot string, teamID string, boardID string, filename string) string {
+	return fmt.Sprintf("%s/api/v2/files/teams/%s/%s/%s", serverRoot, teamID, boardID, filename)
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter limits how many times an action can be performed for each
+// key, e.g. a user, within a sliding time window.
+type RateLimiter struct {
+	mux       sync.Mutex
+	limit     int
+	window    time.Duration
+	hits      map[string][]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing limit actions per key
+// in each window. A limit of zero or less disables the limiter.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		hits:   map[string][]time.Time{},
+		now:    time.Now,
+	}
+}
+
+// Allow records an action for the key, returning false if the key
+// already reached the limit.
+func (r *RateLimiter) Allow(key string) bool {
+	return r.AllowN(key, 1)
+}
+
+// AllowN records n actions for the key, returning false without
+// recording them if they would exceed the limit.
+func (r *RateLimiter) AllowN(key string, n int) bool {
+	if r.limit <= 0 || n <= 0 {
+		return true
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	now := r.now()
+	windowStart := now.Add(-r.window)
+
+	// the keys that weren't used in the last window are removed from
+	// time to time so the limiter doesn't grow forever
+	if now.Sub(r.lastSweep) > r.window {
+		for k, hits := range r.hits {
+			if len(hits) == 0 || !hits[len(hits)-1].After(windowStart) {
+				delete(r.hits, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	hits := r.hits[key]
+	first := 0
+	for first < len(hits) && !hits[first].After(windowStart) {
+		first++
+	}
+	hits = hits[first:]
+
+	if len(hits)+n > r.limit {
+		r.hits[key] = hits
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		hits = append(hits, now)
+	}
+	r.hits[key] = hits
+	return true
+}
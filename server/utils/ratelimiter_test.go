@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(3, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	t.Run("allows up to the limit per key", func(t *testing.T) {
+		require.True(t, limiter.Allow("user-1"))
+		require.True(t, limiter.AllowN("user-1", 2))
+		require.False(t, limiter.Allow("user-1"))
+
+		require.True(t, limiter.Allow("user-2"))
+	})
+
+	t.Run("rejected actions are not recorded", func(t *testing.T) {
+		require.False(t, limiter.AllowN("user-2", 3))
+		require.True(t, limiter.AllowN("user-2", 2))
+	})
+
+	t.Run("the window slides", func(t *testing.T) {
+		now = now.Add(30 * time.Second)
+		require.False(t, limiter.Allow("user-1"))
+
+		now = now.Add(31 * time.Second)
+		require.True(t, limiter.AllowN("user-1", 3))
+	})
+
+	t.Run("unused keys are removed", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		require.True(t, limiter.Allow("user-3"))
+		require.Len(t, limiter.hits, 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := NewRateLimiter(0, time.Minute)
+		for i := 0; i < 100; i++ {
+			require.True(t, disabled.Allow("user-1"))
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+type requestIDContextKey struct{}
+
+// goroutineContexts maps the ID of the goroutines serving a request to
+// the context of that request.
+var goroutineContexts sync.Map
+
+// ContextWithRequestID returns a copy of the context carrying the ID
+// assigned to the request.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the ID assigned to the request, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// RunWithGoroutineContext runs fn with the context registered for the
+// current goroutine. The store methods don't take a context, so this
+// is how the request a query runs for is known to the store. The
+// goroutines started by fn aren't registered.
+func RunWithGoroutineContext(ctx context.Context, fn func()) {
+	id := goroutineID()
+	if previous, ok := goroutineContexts.Load(id); ok {
+		defer goroutineContexts.Store(id, previous)
+	} else {
+		defer goroutineContexts.Delete(id)
+	}
+
+	goroutineContexts.Store(id, ctx)
+	fn()
+}
+
+// GoroutineContext returns the context registered for the current
+// goroutine, or nil if there is none.
+func GoroutineContext() context.Context {
+	ctx, ok := goroutineContexts.Load(goroutineID())
+	if !ok {
+		return nil
+	}
+	return ctx.(context.Context)
+}
+
+// goroutineID parses the ID of the current goroutine from the header
+// of its stack trace, "goroutine 18 [running]:".
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
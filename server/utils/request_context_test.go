@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutineContext(t *testing.T) {
+	require.Nil(t, GoroutineContext())
+
+	ctx := ContextWithRequestID(context.Background(), "request-id")
+	RunWithGoroutineContext(ctx, func() {
+		require.Equal(t, "request-id", RequestIDFromContext(GoroutineContext()))
+
+		done := make(chan context.Context)
+		go func() { done <- GoroutineContext() }()
+		require.Nil(t, <-done, "other goroutines shouldn't see the context")
+
+		nested := ContextWithRequestID(context.Background(), "nested-id")
+		RunWithGoroutineContext(nested, func() {
+			require.Equal(t, "nested-id", RequestIDFromContext(GoroutineContext()))
+		})
+		require.Equal(t, "request-id", RequestIDFromContext(GoroutineContext()))
+	})
+
+	require.Nil(t, GoroutineContext())
+	require.Empty(t, RequestIDFromContext(nil))
+}
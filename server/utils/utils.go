@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/sha1" //nolint:gosec
+	"encoding/base32"
 	"encoding/json"
 	"reflect"
 	"time"
@@ -30,6 +32,22 @@ func NewID(idType IDType) string {
 	return string(idType) + mmModel.NewId()
 }
 
+var idEncoding = base32.NewEncoding("ybndrfg8ejkmcpqxot1uwisza345h769").WithPadding(base32.NoPadding)
+
+// NewDeterministicID returns an identifier with the same format as NewID, but
+// derived from a namespace and a name instead of being random. It is a UUID
+// version 5 built from both values, so the same namespace and name always
+// produce the same ID.
+func NewDeterministicID(idType IDType, namespace, name string) string {
+	//nolint:gosec
+	// SHA1 is what UUID version 5 is defined with, it is not used for security
+	hash := sha1.Sum([]byte(namespace + ":" + name))
+	uuid := hash[:16]
+	uuid[6] = (uuid[6] & 0x0f) | 0x50 // version 5
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return string(idType) + idEncoding.EncodeToString(uuid)
+}
+
 // GetMillis is a convenience method to get milliseconds since epoch.
 func GetMillis() int64 {
 	return mmModel.GetMillis()
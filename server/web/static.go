@@ -0,0 +1,79 @@
+package web
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	cacheControlNoCache = "no-cache"
+)
+
+// hashedAssetRegexp matches the file names that contain a content hash,
+// like main.3f9a8c1d.js or vendor-3f9a8c1d0b.css. As the name changes
+// with every build, those files can be cached forever.
+var hashedAssetRegexp = regexp.MustCompile(`[.-][0-9a-f]{8,}\.[a-z0-9]+$`)
+
+// staticContentTypes lists the content types of the assets that are
+// not always present in the system mime tables.
+var staticContentTypes = map[string]string{
+	".js":          "application/javascript",
+	".mjs":         "application/javascript",
+	".map":         "application/json",
+	".svg":         "image/svg+xml",
+	".woff":        "font/woff",
+	".woff2":       "font/woff2",
+	".ttf":         "font/ttf",
+	".wasm":        "application/wasm",
+	".webmanifest": "application/manifest+json",
+}
+
+func isHashedAsset(name string) bool {
+	return hashedAssetRegexp.MatchString(strings.ToLower(path.Base(name)))
+}
+
+// staticCacheControl returns the Cache-Control header for a static
+// asset. Hashed assets are immutable, the rest need to be revalidated
+// so a deploy never leaves clients with stale files.
+func staticCacheControl(name string, maxAge int) string {
+	if maxAge > 0 && isHashedAsset(name) {
+		return fmt.Sprintf("public, max-age=%d, immutable", maxAge)
+	}
+	return cacheControlNoCache
+}
+
+func fileETag(info os.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+func contentETag(content []byte) string {
+	return fmt.Sprintf(`"%x"`, sha256.Sum256(content))
+}
+
+// staticHandler serves the files of dir with the caching headers
+// that correspond to each of them.
+func (ws *Server) staticHandler(dir string) http.Handler {
+	fileServer := http.FileServer(http.Dir(dir))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := path.Clean("/" + r.URL.Path)
+
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil && !info.IsDir() {
+			w.Header().Set("ETag", fileETag(info))
+			w.Header().Set("Cache-Control", staticCacheControl(name, ws.staticCacheMaxAge))
+
+			if contentType, ok := staticContentTypes[strings.ToLower(path.Ext(name))]; ok {
+				w.Header().Set("Content-Type", contentType)
+			}
+		}
+
+		fileServer.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,72 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticCacheControl(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		maxAge   int
+		expected string
+	}{
+		{"hashed bundle", "/main.3f9a8c1d.js", 3600, "public, max-age=3600, immutable"},
+		{"hashed bundle with dash", "/vendor-3f9a8c1d0b.css", 3600, "public, max-age=3600, immutable"},
+		{"not hashed bundle", "/main.js", 3600, cacheControlNoCache},
+		{"short suffix is not a hash", "/main.abc.js", 3600, cacheControlNoCache},
+		{"caching disabled", "/main.3f9a8c1d.js", 0, cacheControlNoCache},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, staticCacheControl(test.file, test.maxAge))
+		})
+	}
+}
+
+func TestStaticHandler(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.3f9a8c1d.js"), []byte("console.log(1)"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "font.woff2"), []byte("font"), 0600))
+
+	ws := &Server{staticCacheMaxAge: 3600}
+	handler := ws.staticHandler(dir)
+
+	t.Run("hashed asset", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/main.3f9a8c1d.js", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "public, max-age=3600, immutable", w.Header().Get("Cache-Control"))
+		require.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+		require.NotEmpty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("revalidation with etag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/font.woff2", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, cacheControlNoCache, w.Header().Get("Cache-Control"))
+		require.Equal(t, "font/woff2", w.Header().Get("Content-Type"))
+
+		r := httptest.NewRequest(http.MethodGet, "/font.woff2", nil)
+		r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing.js", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Empty(t, w.Header().Get("Cache-Control"))
+	})
+}
@@ -0,0 +1,53 @@
+package web
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// NewTLSConfig returns the TLS configuration of the web server for a
+// minimum TLS version, like "1.2", and an optional list of cipher suite
+// names. Versions older than 1.2 and insecure cipher suites are
+// rejected. An empty list keeps the Go default cipher suites.
+func NewTLSConfig(minVersion string, cipherSuites []string) (*tls.Config, error) {
+	version, ok := tlsVersions[minVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown TLS version %q", minVersion)
+	}
+	if version < tls.VersionTLS12 {
+		return nil, fmt.Errorf("TLS version %s is insecure, the minimum supported version is 1.2", minVersion)
+	}
+
+	config := &tls.Config{MinVersion: version}
+	if len(cipherSuites) == 0 {
+		return config, nil
+	}
+
+	// the TLS 1.3 cipher suites are not configurable
+	if version == tls.VersionTLS13 {
+		return nil, errors.New("cipher suites cannot be configured when the minimum TLS version is 1.3")
+	}
+
+	secureSuites := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		secureSuites[suite.Name] = suite.ID
+	}
+
+	for _, name := range cipherSuites {
+		id, ok := secureSuites[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+		}
+		config.CipherSuites = append(config.CipherSuites, id)
+	}
+
+	return config, nil
+}
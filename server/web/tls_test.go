@@ -0,0 +1,47 @@
+package web
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	t.Run("minimum version", func(t *testing.T) {
+		config, err := NewTLSConfig("1.2", nil)
+		require.NoError(t, err)
+		require.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+		require.Empty(t, config.CipherSuites)
+
+		config, err = NewTLSConfig("1.3", nil)
+		require.NoError(t, err)
+		require.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+	})
+
+	t.Run("insecure or unknown versions", func(t *testing.T) {
+		for _, version := range []string{"1.0", "1.1", "", "2.0"} {
+			_, err := NewTLSConfig(version, nil)
+			require.Error(t, err, version)
+		}
+	})
+
+	t.Run("cipher suites", func(t *testing.T) {
+		config, err := NewTLSConfig("1.2", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"})
+		require.NoError(t, err)
+		require.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}, config.CipherSuites)
+	})
+
+	t.Run("insecure or unknown cipher suites", func(t *testing.T) {
+		_, err := NewTLSConfig("1.2", []string{"TLS_RSA_WITH_RC4_128_SHA"})
+		require.Error(t, err)
+
+		_, err = NewTLSConfig("1.2", []string{"TLS_UNKNOWN"})
+		require.Error(t, err)
+	})
+
+	t.Run("cipher suites with TLS 1.3", func(t *testing.T) {
+		_, err := NewTLSConfig("1.3", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"})
+		require.Error(t, err)
+	})
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,7 +10,9 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"text/template"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -23,6 +26,15 @@ type RoutedService interface {
 	RegisterRoutes(*mux.Router)
 }
 
+// Timeouts configures the timeouts of the web server connections. A
+// zero value disables the corresponding timeout.
+type Timeouts struct {
+	Read       time.Duration
+	ReadHeader time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
 // Server is the structure responsible for managing our http web server.
 type Server struct {
 	http.Server
@@ -33,10 +45,16 @@ type Server struct {
 	port       int
 	ssl        bool
 	logger     mlog.LoggerIFace
+
+	staticCacheMaxAge int
+
+	// draining is set to 1 when the server is about to shut down and
+	// shouldn't receive new requests
+	draining int32
 }
 
 // NewServer creates a new instance of the webserver.
-func NewServer(rootPath string, serverRoot string, port int, ssl, localOnly bool, logger mlog.LoggerIFace) *Server {
+func NewServer(rootPath string, serverRoot string, port int, ssl, localOnly bool, staticCacheMaxAge int, timeouts Timeouts, logger mlog.LoggerIFace) *Server {
 	r := mux.NewRouter()
 
 	basePrefix := os.Getenv("FOCALBOARD_HTTP_SERVER_BASEPATH")
@@ -62,6 +80,14 @@ func NewServer(rootPath string, serverRoot string, port int, ssl, localOnly bool
 		Server: http.Server{
 			Addr:    addr,
 			Handler: r,
+
+			// the websocket connections are hijacked from the server,
+			// so these timeouts only apply to their initial handshake.
+			// The API also lifts them for its long-lived routes.
+			ReadTimeout:       timeouts.Read,
+			ReadHeaderTimeout: timeouts.ReadHeader,
+			WriteTimeout:      timeouts.Write,
+			IdleTimeout:       timeouts.Idle,
 		},
 		baseURL:    baseURL,
 		rootPath:   rootPath,
@@ -69,6 +95,8 @@ func NewServer(rootPath string, serverRoot string, port int, ssl, localOnly bool
 		ssl:        ssl,
 		logger:     logger,
 		basePrefix: basePrefix,
+
+		staticCacheMaxAge: staticCacheMaxAge,
 	}
 
 	return ws
@@ -84,24 +112,66 @@ func (ws *Server) AddRoutes(rs RoutedService) {
 }
 
 func (ws *Server) registerRoutes() {
-	ws.Router().PathPrefix("/static").Handler(http.StripPrefix(ws.basePrefix+"/static/", http.FileServer(http.Dir(filepath.Join(ws.rootPath, "static")))))
+	ws.Router().HandleFunc("/readyz", ws.handleReady).Methods("GET")
+	ws.Router().PathPrefix("/static").Handler(http.StripPrefix(ws.basePrefix+"/static/", ws.staticHandler(filepath.Join(ws.rootPath, "static"))))
 	ws.Router().PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		indexTemplate, err := template.New("index").ParseFiles(path.Join(ws.rootPath, "index.html"))
 		if err != nil {
 			ws.logger.Log(errorOrWarn(), "Unable to serve the index.html file", mlog.Err(err))
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		err = indexTemplate.ExecuteTemplate(w, "index.html", map[string]string{"BaseURL": ws.baseURL})
+
+		var index bytes.Buffer
+		err = indexTemplate.ExecuteTemplate(&index, "index.html", map[string]string{"BaseURL": ws.baseURL})
 		if err != nil {
 			ws.logger.Log(errorOrWarn(), "Unable to serve the index.html file", mlog.Err(err))
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+
+		// the index references the current assets, so it always needs
+		// to be revalidated to avoid serving a stale page after a deploy
+		etag := contentETag(index.Bytes())
+		w.Header().Set("Cache-Control", cacheControlNoCache)
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if _, err = w.Write(index.Bytes()); err != nil {
+			ws.logger.Log(errorOrWarn(), "Unable to serve the index.html file", mlog.Err(err))
+		}
 	})
 }
 
+// handleReady responds with 200 while the server accepts new requests,
+// and with 503 once it's draining, so the load balancers stop routing
+// requests to it.
+func (ws *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if ws.IsDraining() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("draining"))
+		return
+	}
+	_, _ = w.Write([]byte("OK"))
+}
+
+// SetDraining marks the server as about to shut down, making the
+// readiness endpoint fail.
+func (ws *Server) SetDraining() {
+	atomic.StoreInt32(&ws.draining, 1)
+}
+
+// IsDraining returns true if the server is about to shut down.
+func (ws *Server) IsDraining() bool {
+	return atomic.LoadInt32(&ws.draining) == 1
+}
+
 // Start runs the web server and start listening for connections.
 func (ws *Server) Start() {
 	ws.registerRoutes()
@@ -1,7 +1,10 @@
 package web
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -79,7 +82,7 @@ func Test_NewServer(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			ws := NewServer(test.rootPath, test.serverRoot, test.port, test.ssl, test.localOnly, test.logger)
+			ws := NewServer(test.rootPath, test.serverRoot, test.port, test.ssl, test.localOnly, 0, Timeouts{}, test.logger)
 
 			require.NotNil(t, ws, "The webserver object is nil!")
 
@@ -97,3 +100,37 @@ func Test_NewServer(t *testing.T) {
 		})
 	}
 }
+
+func TestNewServerTimeouts(t *testing.T) {
+	timeouts := Timeouts{
+		Read:       300 * time.Second,
+		ReadHeader: 10 * time.Second,
+		Write:      300 * time.Second,
+		Idle:       60 * time.Second,
+	}
+
+	ws := NewServer("", "http://localhost:8000", 9999, false, true, 0, timeouts, &mlog.Logger{})
+
+	require.Equal(t, timeouts.Read, ws.Server.ReadTimeout)
+	require.Equal(t, timeouts.ReadHeader, ws.Server.ReadHeaderTimeout)
+	require.Equal(t, timeouts.Write, ws.Server.WriteTimeout)
+	require.Equal(t, timeouts.Idle, ws.Server.IdleTimeout)
+}
+
+func TestReadyEndpoint(t *testing.T) {
+	ws := NewServer("", "http://localhost:8000", 9999, false, true, 0, Timeouts{}, &mlog.Logger{})
+	ws.registerRoutes()
+
+	ready := func() int {
+		rec := httptest.NewRecorder()
+		ws.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code
+	}
+
+	require.False(t, ws.IsDraining())
+	require.Equal(t, http.StatusOK, ready())
+
+	ws.SetDraining()
+	require.True(t, ws.IsDraining())
+	require.Equal(t, http.StatusServiceUnavailable, ready())
+}
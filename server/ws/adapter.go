@@ -15,21 +15,28 @@ const (
 	websocketActionUpdateMember             = "UPDATE_MEMBER"
 	websocketActionDeleteMember             = "DELETE_MEMBER"
 	websocketActionUpdateBlock              = "UPDATE_BLOCK"
+	websocketActionUpdateBlocks             = "UPDATE_BLOCKS"
 	websocketActionUpdateConfig             = "UPDATE_CLIENT_CONFIG"
 	websocketActionUpdateCategory           = "UPDATE_CATEGORY"
 	websocketActionUpdateCategoryBoard      = "UPDATE_BOARD_CATEGORY"
 	websocketActionUpdateSubscription       = "UPDATE_SUBSCRIPTION"
 	websocketActionUpdateCardLimitTimestamp = "UPDATE_CARD_LIMIT_TIMESTAMP"
+	websocketActionUpdateUserBoardView      = "UPDATE_USER_BOARD_VIEW"
+	websocketActionUpdateBoardFavorite      = "UPDATE_BOARD_FAVORITE"
+	websocketActionAdminNotice              = "ADMIN_NOTICE"
 )
 
 type Store interface {
 	GetBlock(blockID string) (*model.Block, error)
 	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
+	GetSharing(boardID string) (*model.Sharing, error)
 }
 
 type Adapter interface {
 	BroadcastBlockChange(teamID string, block model.Block)
 	BroadcastBlockDelete(teamID, blockID, boardID string)
+	BroadcastBlocksDelete(teamID, boardID string, blockIDs []string)
+	BroadcastBlocksChange(teamID, boardID string, blocks []model.Block)
 	BroadcastBoardChange(teamID string, board *model.Board)
 	BroadcastBoardDelete(teamID, boardID string)
 	BroadcastMemberChange(teamID, boardID string, member *model.BoardMember)
@@ -39,4 +46,8 @@ type Adapter interface {
 	BroadcastCategoryBoardChange(teamID, userID string, blockCategory model.BoardCategoryWebsocketData)
 	BroadcastCardLimitTimestampChange(cardLimitTimestamp int64)
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
+	BroadcastUserBoardViewChange(teamID string, view *model.UserBoardView)
+	BroadcastBoardFavoriteChange(teamID, userID, boardID string, isFavorite bool)
+	BroadcastAdminNotice(notice *model.AdminNotice)
+	SetUserDisconnectHandler(handler func(userID string))
 }
@@ -0,0 +1,84 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// boardACLCacheTTL is how long the users allowed to view a board are
+// cached, so a burst of changes to a board doesn't read its members
+// for every event.
+const boardACLCacheTTL = 5 * time.Second
+
+type boardACLEntry struct {
+	viewers  map[string]bool
+	expireAt time.Time
+}
+
+// boardACLCache caches the IDs of the users allowed to view each board.
+// The entries expire after the TTL and are invalidated when the members
+// of the board change.
+type boardACLCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]boardACLEntry
+	now     func() time.Time
+}
+
+func newBoardACLCache(ttl time.Duration) *boardACLCache {
+	return &boardACLCache{
+		ttl:     ttl,
+		entries: map[string]boardACLEntry{},
+		now:     time.Now,
+	}
+}
+
+// get returns the viewers of a board, or false if they aren't cached.
+func (c *boardACLCache) get(boardID string) (map[string]bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[boardID]
+	if !ok || !c.now().Before(entry.expireAt) {
+		return nil, false
+	}
+	return entry.viewers, true
+}
+
+// set caches the viewers of a board, removing the expired entries.
+func (c *boardACLCache) set(boardID string, viewers map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for id, entry := range c.entries {
+		if !now.Before(entry.expireAt) {
+			delete(c.entries, id)
+		}
+	}
+	c.entries[boardID] = boardACLEntry{viewers: viewers, expireAt: now.Add(c.ttl)}
+}
+
+// invalidate removes the cached viewers of a board.
+func (c *boardACLCache) invalidate(boardID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, boardID)
+}
+
+// canViewBoard returns true if the roles of the member, including the
+// minimum role of the board, allow them to view the board.
+func canViewBoard(member *model.BoardMember) bool {
+	if member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter || member.SchemeViewer {
+		return true
+	}
+
+	switch member.MinimumRole {
+	case string(model.BoardRoleAdmin), string(model.BoardRoleEditor), string(model.BoardRoleCommenter), string(model.BoardRoleViewer):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package ws
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
+	wsMocks "github.com/mattermost/focalboard/server/ws/mocks"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardACLCache(t *testing.T) {
+	now := time.Now()
+	cache := newBoardACLCache(time.Second)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.get("board-id")
+	require.False(t, ok)
+
+	cache.set("board-id", map[string]bool{"user-id": true})
+	viewers, ok := cache.get("board-id")
+	require.True(t, ok)
+	require.Equal(t, map[string]bool{"user-id": true}, viewers)
+
+	t.Run("expired entries are ignored and removed", func(t *testing.T) {
+		now = now.Add(time.Second)
+		_, ok := cache.get("board-id")
+		require.False(t, ok)
+
+		cache.set("other-board-id", map[string]bool{})
+		require.Len(t, cache.entries, 1)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		cache.set("board-id", map[string]bool{"user-id": true})
+		cache.invalidate("board-id")
+		_, ok := cache.get("board-id")
+		require.False(t, ok)
+	})
+}
+
+func TestCanViewBoard(t *testing.T) {
+	require.True(t, canViewBoard(&model.BoardMember{SchemeViewer: true}))
+	require.True(t, canViewBoard(&model.BoardMember{SchemeAdmin: true}))
+	require.True(t, canViewBoard(&model.BoardMember{MinimumRole: string(model.BoardRoleCommenter)}))
+	require.False(t, canViewBoard(&model.BoardMember{}))
+	require.False(t, canViewBoard(&model.BoardMember{MinimumRole: string(model.BoardRoleNone)}))
+}
+
+func TestGetListenersForTeamAndBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := wsMocks.NewMockStore(ctrl)
+
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, mockStore, 0, 0)
+	teamID := "team-id"
+	boardID := "board-id"
+
+	newListener := func(userID string) *websocketSession {
+		session := &websocketSession{
+			conn:   &websocket.Conn{},
+			mu:     sync.Mutex{},
+			userID: userID,
+			teams:  []string{},
+			blocks: []string{},
+		}
+		server.addListener(session)
+		server.subscribeListenerToTeam(session, teamID)
+		return session
+	}
+
+	viewer := newListener("viewer-id")
+	noRoles := newListener("no-roles-id")
+	outsider := newListener("outsider-id")
+
+	members := []*model.BoardMember{
+		{BoardID: boardID, UserID: "viewer-id", SchemeViewer: true},
+		{BoardID: boardID, UserID: "no-roles-id"},
+	}
+	mockStore.EXPECT().GetMembersForBoard(boardID).Return(members, nil).Times(2)
+
+	t.Run("only the listeners allowed to view the board", func(t *testing.T) {
+		listeners := server.getListenersForTeamAndBoard(teamID, boardID)
+		require.Equal(t, []*websocketSession{viewer}, listeners)
+	})
+
+	t.Run("the members are cached", func(t *testing.T) {
+		listeners := server.getListenersForTeamAndBoard(teamID, boardID, "outsider-id")
+		require.ElementsMatch(t, []*websocketSession{viewer, outsider}, listeners)
+		require.NotContains(t, listeners, noRoles)
+	})
+
+	t.Run("a member change invalidates the cache", func(t *testing.T) {
+		// the change is broadcast to a team without listeners, so
+		// nothing is sent but the members are read again
+		server.BroadcastMemberChange("other-team-id", boardID, &model.BoardMember{BoardID: boardID})
+
+		listeners := server.getListenersForTeamAndBoard(teamID, boardID)
+		require.Equal(t, []*websocketSession{viewer}, listeners)
+	})
+}
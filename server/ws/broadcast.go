@@ -0,0 +1,192 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// sendQueueSize is the number of messages a listener can have
+	// pending before it's disconnected.
+	sendQueueSize = 256
+
+	// writeTimeout bounds each write to a listener, so a client that
+	// stopped reading can't hold a broadcast worker indefinitely.
+	writeTimeout = 10 * time.Second
+)
+
+// sendQueue holds the messages pending to be sent to a listener. A
+// listener is handed to a single worker at a time, and only handed
+// again once that worker sent its next message, so its messages are
+// sent in order.
+type sendQueue struct {
+	mu        sync.Mutex
+	messages  []interface{}
+	scheduled bool
+}
+
+// broadcastPool is the fixed set of workers that send the queued
+// messages. The listeners with pending messages take turns, one message
+// at a time, so a slow client only delays the worker writing to it.
+type broadcastPool struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	ready    []*websocketSession
+	stopping bool
+}
+
+// startBroadcastWorkers starts the workers that send the broadcast
+// messages. Without workers the messages are sent by the broadcasting
+// goroutine.
+func (ws *Server) startBroadcastWorkers(workers int) {
+	if workers <= 0 {
+		return
+	}
+
+	ws.pool = &broadcastPool{}
+	ws.pool.cond = sync.NewCond(&ws.pool.mu)
+	for i := 0; i < workers; i++ {
+		go ws.runBroadcastWorker()
+	}
+}
+
+// stopBroadcastWorkers wakes up the idle workers so they return.
+func (ws *Server) stopBroadcastWorkers() {
+	if ws.pool == nil {
+		return
+	}
+
+	ws.pool.mu.Lock()
+	ws.pool.stopping = true
+	ws.pool.ready = nil
+	ws.pool.mu.Unlock()
+	ws.pool.cond.Broadcast()
+}
+
+func (ws *Server) runBroadcastWorker() {
+	for {
+		listener := ws.nextReadyListener()
+		if listener == nil {
+			return
+		}
+		ws.sendNextMessage(listener)
+	}
+}
+
+// nextReadyListener waits for a listener with pending messages. It
+// returns nil when the server shuts down.
+func (ws *Server) nextReadyListener() *websocketSession {
+	ws.pool.mu.Lock()
+	defer ws.pool.mu.Unlock()
+
+	for len(ws.pool.ready) == 0 && !ws.pool.stopping {
+		ws.pool.cond.Wait()
+	}
+	if ws.pool.stopping {
+		return nil
+	}
+
+	listener := ws.pool.ready[0]
+	ws.pool.ready[0] = nil
+	ws.pool.ready = ws.pool.ready[1:]
+	return listener
+}
+
+func (ws *Server) scheduleListener(listener *websocketSession) {
+	ws.pool.mu.Lock()
+	if !ws.pool.stopping {
+		ws.pool.ready = append(ws.pool.ready, listener)
+	}
+	ws.pool.mu.Unlock()
+	ws.pool.cond.Signal()
+}
+
+// sendNextMessage sends the oldest pending message of the listener, and
+// hands the listener back to the pool if it has more.
+func (ws *Server) sendNextMessage(listener *websocketSession) {
+	queue := &listener.queue
+
+	queue.mu.Lock()
+	if len(queue.messages) == 0 || listener.isClosed() {
+		queue.messages = nil
+		queue.scheduled = false
+		queue.mu.Unlock()
+		return
+	}
+	message := queue.messages[0]
+	queue.messages[0] = nil
+	queue.messages = queue.messages[1:]
+	queue.mu.Unlock()
+
+	ws.writeMessage(listener, message)
+
+	queue.mu.Lock()
+	more := len(queue.messages) > 0 && !listener.isClosed()
+	queue.scheduled = more
+	if !more {
+		queue.messages = nil
+	}
+	queue.mu.Unlock()
+
+	if more {
+		ws.scheduleListener(listener)
+	}
+}
+
+// queueMessage queues the message for the listener without blocking.
+// A listener whose queue is full can't keep up with the messages, so
+// it's disconnected instead.
+func (ws *Server) queueMessage(listener *websocketSession, message interface{}) {
+	queue := &listener.queue
+
+	queue.mu.Lock()
+	if len(queue.messages) >= sendQueueSize {
+		queue.mu.Unlock()
+		ws.disconnectSlowListener(listener)
+		return
+	}
+	queue.messages = append(queue.messages, message)
+	schedule := !queue.scheduled
+	queue.scheduled = true
+	queue.mu.Unlock()
+
+	if schedule {
+		ws.scheduleListener(listener)
+	}
+}
+
+// disconnectSlowListener closes the connection of a listener that fell
+// behind. The close frame tells the client to try again later, and the
+// client reloads the board it shows when it reconnects, so it resyncs
+// the changes it missed.
+func (ws *Server) disconnectSlowListener(listener *websocketSession) {
+	ws.logger.Warn("Websocket send queue full, disconnecting the client so it resyncs",
+		mlog.String("userID", listener.userID),
+		mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		mlog.Int("queueSize", sendQueueSize),
+	)
+
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "send queue full")
+	_ = listener.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second))
+	listener.close()
+}
+
+// writeMessage writes the message to the listener, closing it if the
+// write fails.
+func (ws *Server) writeMessage(listener *websocketSession, message interface{}) {
+	if listener.isClosed() {
+		return
+	}
+
+	if err := listener.WriteJSON(message); err != nil {
+		ws.logger.Error("broadcast error",
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+			mlog.Err(err),
+		)
+		listener.close()
+	}
+}
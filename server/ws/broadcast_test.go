@@ -0,0 +1,125 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/auth"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConnPair returns the server side of a websocket connection
+// and the client side that receives its messages.
+func newTestConnPair(t *testing.T) (*websocket.Conn, *websocket.Conn) {
+	conns := make(chan *websocket.Conn, 1)
+	upgrader := websocket.Upgrader{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		conns <- conn
+	}))
+	t.Cleanup(ts.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return <-conns, client
+}
+
+func TestBroadcastWorkers(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+
+	newSession := func(server *Server, conn *websocket.Conn) *websocketSession {
+		ctx, cancel := context.WithCancel(server.ctx)
+		return &websocketSession{
+			conn:   conn,
+			ctx:    ctx,
+			cancel: cancel,
+			teams:  []string{},
+			blocks: []string{},
+		}
+	}
+
+	t.Run("the messages of each listener are sent in order", func(t *testing.T) {
+		server := NewServer(&auth.Auth{}, "token", false, logger, nil, 2, 0)
+		defer server.Shutdown()
+
+		const sessionCount = 3
+		const messageCount = 50
+
+		clients := []*websocket.Conn{}
+		sessions := []*websocketSession{}
+		for i := 0; i < sessionCount; i++ {
+			conn, client := newTestConnPair(t)
+			session := newSession(server, conn)
+			server.addListener(session)
+			sessions = append(sessions, session)
+			clients = append(clients, client)
+		}
+
+		var wg sync.WaitGroup
+		for _, client := range clients {
+			wg.Add(1)
+			go func(client *websocket.Conn) {
+				defer wg.Done()
+				for i := 0; i < messageCount; i++ {
+					var message map[string]int
+					if err := client.ReadJSON(&message); err != nil {
+						t.Error(err)
+						return
+					}
+					if message["seq"] != i {
+						t.Errorf("expected message %d, got %d", i, message["seq"])
+					}
+				}
+			}(client)
+		}
+
+		for i := 0; i < messageCount; i++ {
+			for _, session := range sessions {
+				require.True(t, server.sendMessage(session, map[string]int{"seq": i}))
+			}
+		}
+		wg.Wait()
+	})
+
+	t.Run("a listener with a full queue is disconnected", func(t *testing.T) {
+		server := NewServer(&auth.Auth{}, "token", false, logger, nil, 0, 0)
+		defer server.Shutdown()
+		// a pool without workers never drains the queues
+		server.pool = &broadcastPool{}
+		server.pool.cond = sync.NewCond(&server.pool.mu)
+
+		conn, client := newTestConnPair(t)
+		session := newSession(server, conn)
+		server.addListener(session)
+
+		for i := 0; i < sendQueueSize; i++ {
+			require.True(t, server.sendMessage(session, map[string]int{"seq": i}))
+		}
+		require.False(t, session.isClosed())
+
+		require.True(t, server.sendMessage(session, map[string]int{"seq": sendQueueSize}))
+		require.True(t, session.isClosed())
+
+		// the client is told why, so it reconnects and resyncs
+		_, _, err := client.ReadMessage()
+		require.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater))
+	})
+
+	t.Run("the workers stop on shutdown", func(t *testing.T) {
+		server := NewServer(&auth.Auth{}, "token", false, logger, nil, 2, 0)
+		server.Shutdown()
+
+		require.Nil(t, server.nextReadyListener())
+	})
+}
@@ -19,6 +19,31 @@ type UpdateBlockMsg struct {
 	Block  model.Block `json:"block"`
 }
 
+// UpdateBlocksMsg is sent when several blocks of a board change at
+// once.
+type UpdateBlocksMsg struct {
+	Action  string        `json:"action"`
+	TeamID  string        `json:"teamId"`
+	BoardID string        `json:"boardId"`
+	Blocks  []model.Block `json:"blocks"`
+}
+
+// deletedBlocks builds the blocks sent to the clients for deleted
+// blocks, which only carry their IDs and deletion time.
+func deletedBlocks(boardID string, blockIDs []string) []model.Block {
+	now := model.GetMillis()
+	blocks := make([]model.Block, 0, len(blockIDs))
+	for _, blockID := range blockIDs {
+		blocks = append(blocks, model.Block{
+			ID:       blockID,
+			BoardID:  boardID,
+			UpdateAt: now,
+			DeleteAt: now,
+		})
+	}
+	return blocks
+}
+
 // UpdateBoardMsg is sent on block updates.
 type UpdateBoardMsg struct {
 	Action string       `json:"action"`
@@ -33,6 +58,32 @@ type UpdateMemberMsg struct {
 	Member *model.BoardMember `json:"member"`
 }
 
+// UpdateUserBoardViewMsg is sent to a user when their view state of a
+// board changes.
+type UpdateUserBoardViewMsg struct {
+	Action        string               `json:"action"`
+	TeamID        string               `json:"teamId"`
+	UserBoardView *model.UserBoardView `json:"userBoardView"`
+}
+
+// UpdateBoardFavoriteMsg is sent to a user when they add a board to
+// their favorites or remove it.
+type UpdateBoardFavoriteMsg struct {
+	Action     string `json:"action"`
+	TeamID     string `json:"teamId"`
+	BoardID    string `json:"boardId"`
+	IsFavorite bool   `json:"isFavorite"`
+}
+
+// AdminNoticeMsg is sent to the clients of a team, or to all of them,
+// when an admin sends a notice.
+type AdminNoticeMsg struct {
+	Action   string `json:"action"`
+	TeamID   string `json:"teamId,omitempty"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
 // UpdateSubscription is sent on subscription updates.
 type UpdateSubscription struct {
 	Action       string              `json:"action"`
@@ -58,4 +109,5 @@ type WebsocketCommand struct {
 	Token     string   `json:"token"`
 	ReadToken string   `json:"readToken"`
 	BlockIDs  []string `json:"blockIds"`
+	ClientID  string   `json:"clientId"`
 }
@@ -47,6 +47,9 @@ type PluginAdapter struct {
 	subscriptionsMU  sync.RWMutex
 	listenersByTeam  map[string][]*PluginAdapterClient
 	listenersByBlock map[string][]*PluginAdapterClient
+
+	disconnectHandlerMU sync.RWMutex
+	disconnectHandler   func(userID string)
 }
 
 // servicesAPI is the interface required by the PluginAdapter to interact with
@@ -303,6 +306,22 @@ func (pa *PluginAdapter) OnWebSocketDisconnect(webConnID, userID string) {
 	}
 
 	atomic.StoreInt64(&pac.inactiveAt, mmModel.GetMillis())
+
+	pa.disconnectHandlerMU.RLock()
+	handler := pa.disconnectHandler
+	pa.disconnectHandlerMU.RUnlock()
+
+	if handler != nil && userID != "" {
+		handler(userID)
+	}
+}
+
+// SetUserDisconnectHandler sets the function called with the user ID
+// when a websocket connection of a user closes.
+func (pa *PluginAdapter) SetUserDisconnectHandler(handler func(userID string)) {
+	pa.disconnectHandlerMU.Lock()
+	defer pa.disconnectHandlerMU.Unlock()
+	pa.disconnectHandler = handler
 }
 
 func commandFromRequest(req *mmModel.WebSocketRequest) (*WebsocketCommand, error) {
@@ -536,6 +555,40 @@ func (pa *PluginAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
 	pa.BroadcastBlockChange(teamID, block)
 }
 
+func (pa *PluginAdapter) BroadcastBlocksDelete(teamID, boardID string, blockIDs []string) {
+	pa.logger.Debug("BroadcastBlocksDelete",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+		mlog.Int("block_count", len(blockIDs)),
+	)
+
+	message := UpdateBlocksMsg{
+		Action:  websocketActionUpdateBlocks,
+		TeamID:  teamID,
+		BoardID: boardID,
+		Blocks:  deletedBlocks(boardID, blockIDs),
+	}
+
+	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+}
+
+func (pa *PluginAdapter) BroadcastBlocksChange(teamID, boardID string, blocks []model.Block) {
+	pa.logger.Debug("BroadcastBlocksChange",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+		mlog.Int("block_count", len(blocks)),
+	)
+
+	message := UpdateBlocksMsg{
+		Action:  websocketActionUpdateBlocks,
+		TeamID:  teamID,
+		BoardID: boardID,
+		Blocks:  blocks,
+	}
+
+	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+}
+
 func (pa *PluginAdapter) BroadcastBoardChange(teamID string, board *model.Board) {
 	pa.logger.Debug("BroadcastingBoardChange",
 		mlog.String("teamID", teamID),
@@ -624,3 +677,78 @@ func (pa *PluginAdapter) BroadcastCardLimitTimestampChange(cardLimitTimestamp in
 
 	pa.sendMessageToAll(websocketActionUpdateCardLimitTimestamp, utils.StructToMap(message))
 }
+
+func (pa *PluginAdapter) BroadcastUserBoardViewChange(teamID string, view *model.UserBoardView) {
+	pa.logger.Debug("BroadcastUserBoardViewChange",
+		mlog.String("userID", view.UserID),
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", view.BoardID),
+	)
+
+	message := UpdateUserBoardViewMsg{
+		Action:        websocketActionUpdateUserBoardView,
+		TeamID:        teamID,
+		UserBoardView: view,
+	}
+
+	payload := utils.StructToMap(message)
+
+	go func() {
+		clusterMessage := &ClusterMessage{
+			Payload: payload,
+			UserID:  view.UserID,
+		}
+
+		pa.sendMessageToCluster("websocket_message", clusterMessage)
+	}()
+
+	pa.sendUserMessageSkipCluster(websocketActionUpdateUserBoardView, payload, view.UserID)
+}
+
+func (pa *PluginAdapter) BroadcastBoardFavoriteChange(teamID, userID, boardID string, isFavorite bool) {
+	pa.logger.Debug("BroadcastBoardFavoriteChange",
+		mlog.String("userID", userID),
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+	)
+
+	message := UpdateBoardFavoriteMsg{
+		Action:     websocketActionUpdateBoardFavorite,
+		TeamID:     teamID,
+		BoardID:    boardID,
+		IsFavorite: isFavorite,
+	}
+
+	payload := utils.StructToMap(message)
+
+	go func() {
+		clusterMessage := &ClusterMessage{
+			Payload: payload,
+			UserID:  userID,
+		}
+
+		pa.sendMessageToCluster("websocket_message", clusterMessage)
+	}()
+
+	pa.sendUserMessageSkipCluster(websocketActionUpdateBoardFavorite, payload, userID)
+}
+
+func (pa *PluginAdapter) BroadcastAdminNotice(notice *model.AdminNotice) {
+	pa.logger.Debug("BroadcastAdminNotice",
+		mlog.String("teamID", notice.TeamID),
+		mlog.String("severity", notice.Severity),
+	)
+
+	message := AdminNoticeMsg{
+		Action:   websocketActionAdminNotice,
+		TeamID:   notice.TeamID,
+		Message:  notice.Message,
+		Severity: notice.Severity,
+	}
+
+	if notice.TeamID == "" {
+		pa.sendMessageToAll(websocketActionAdminNotice, utils.StructToMap(message))
+		return
+	}
+	pa.sendTeamMessage(websocketActionAdminNotice, notice.TeamID, utils.StructToMap(message))
+}
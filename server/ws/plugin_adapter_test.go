@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -551,3 +553,46 @@ func TestParallelSubscriptionsOnMultipleConnections(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestPluginAdapterBroadcastAdminNotice(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	t.Run("Should send a notice without a team to all the users", func(t *testing.T) {
+		notice := &model.AdminNotice{Message: "Maintenance in 10 minutes", Severity: model.AdminNoticeWarning}
+		payload := utils.StructToMap(AdminNoticeMsg{
+			Action:   websocketActionAdminNotice,
+			Message:  notice.Message,
+			Severity: notice.Severity,
+		})
+
+		th.api.EXPECT().PublishWebSocketEvent(websocketActionAdminNotice, payload, &mmModel.WebsocketBroadcast{})
+
+		th.pa.BroadcastAdminNotice(notice)
+	})
+
+	t.Run("Should send a notice with a team to the users subscribed to it", func(t *testing.T) {
+		webConnID := mmModel.NewId()
+		userID := mmModel.NewId()
+		teamID := mmModel.NewId()
+
+		th.pa.OnWebSocketConnect(webConnID, userID)
+		th.SubscribeWebConnToTeam(webConnID, userID, teamID)
+
+		notice := &model.AdminNotice{Message: "Maintenance in 10 minutes", Severity: model.AdminNoticeInfo, TeamID: teamID}
+		payload := utils.StructToMap(AdminNoticeMsg{
+			Action:   websocketActionAdminNotice,
+			TeamID:   teamID,
+			Message:  notice.Message,
+			Severity: notice.Severity,
+		})
+
+		clusterDone := make(chan struct{})
+		th.api.EXPECT().PublishPluginClusterEvent(gomock.Any(), gomock.Any()).
+			Do(func(mmModel.PluginClusterEvent, mmModel.PluginClusterEventSendOptions) { close(clusterDone) }).
+			Return(nil)
+		th.api.EXPECT().PublishWebSocketEvent(websocketActionAdminNotice, payload, &mmModel.WebsocketBroadcast{UserId: userID})
+
+		th.pa.BroadcastAdminNotice(notice)
+		<-clusterDone
+	})
+}
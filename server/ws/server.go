@@ -1,9 +1,11 @@
 package ws
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
@@ -17,10 +19,23 @@ import (
 func (wss *websocketSession) WriteJSON(v interface{}) error {
 	wss.mu.Lock()
 	defer wss.mu.Unlock()
+	_ = wss.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
 	err := wss.conn.WriteJSON(v)
 	return err
 }
 
+// isClosed returns true if the connection of the session is gone or
+// the server is shutting down.
+func (wss *websocketSession) isClosed() bool {
+	return wss.ctx.Err() != nil
+}
+
+// close cancels the context of the session and closes its connection.
+func (wss *websocketSession) close() {
+	wss.cancel()
+	wss.conn.Close()
+}
+
 func (wss *websocketSession) isSubscribedToTeam(teamID string) bool {
 	for _, id := range wss.teams {
 		if id == teamID {
@@ -53,23 +68,61 @@ type Server struct {
 	isMattermostAuth bool
 	logger           mlog.LoggerIFace
 	store            Store
+	boardACL         *boardACLCache
+	subscriptions    *subscriptionCache
+
+	// ctx is canceled when the server shuts down, which stops the
+	// in-flight broadcasts
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// pool sends the broadcast messages, or is nil if they're sent by
+	// the broadcasting goroutine
+	pool *broadcastPool
+
+	// disconnectHandler is called when a connection of an
+	// authenticated user closes
+	disconnectHandler func(userID string)
+
+	// requestAuthenticator returns the user authenticated by the
+	// upgrade request itself, e.g. through a trusted proxy header
+	requestAuthenticator func(r *http.Request) string
 }
 
 type websocketSession struct {
 	conn   *websocket.Conn
+	ctx    context.Context
+	cancel context.CancelFunc
 	userID string
 	mu     sync.Mutex
 	teams  []string
 	blocks []string
+
+	// clientID is the stable ID the client authenticated with, which
+	// its subscriptions are remembered by when the connection closes
+	clientID string
+
+	// blockTokens holds the read token each block subscription was
+	// made with, so it can be checked again when it's restored
+	blockTokens map[string]blockReadToken
+
+	// queue holds the messages pending to be sent to the session by
+	// the broadcast workers
+	queue sendQueue
 }
 
 func (wss *websocketSession) isAuthenticated() bool {
 	return wss.userID != ""
 }
 
-// NewServer creates a new Server.
-func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, logger mlog.LoggerIFace, store Store) *Server {
-	return &Server{
+// NewServer creates a new Server. The broadcast messages are sent by
+// broadcastWorkers workers, or by the broadcasting goroutine if it's 0.
+// The subscriptions of a closed connection are restored if its client
+// reconnects within subscriptionTTL, and never if it's 0.
+func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, logger mlog.LoggerIFace, store Store, broadcastWorkers int, subscriptionTTL time.Duration) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ws := &Server{
 		listeners:        make(map[*websocketSession]bool),
 		listenersByTeam:  make(map[string][]*websocketSession),
 		listenersByBlock: make(map[string][]*websocketSession),
@@ -83,6 +136,26 @@ func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, l
 		isMattermostAuth: isMattermostAuth,
 		logger:           logger,
 		store:            store,
+		boardACL:         newBoardACLCache(boardACLCacheTTL),
+		subscriptions:    newSubscriptionCache(subscriptionTTL),
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+	ws.startBroadcastWorkers(broadcastWorkers)
+	return ws
+}
+
+// Shutdown cancels the in-flight broadcasts and closes the connections
+// of all the listeners.
+func (ws *Server) Shutdown() {
+	ws.cancel()
+	ws.stopBroadcastWorkers()
+
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	for listener := range ws.listeners {
+		listener.close()
 	}
 }
 
@@ -99,9 +172,18 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// create an empty session with websocket client
+	// websocket connections are long-lived, so they are exempt from
+	// the deadlines set by the web server timeouts
+	_ = client.SetReadDeadline(time.Time{})
+	_ = client.SetWriteDeadline(time.Time{})
+
+	// create an empty session with websocket client, whose context is
+	// canceled when the connection ends or the server shuts down
+	ctx, cancel := context.WithCancel(ws.ctx)
 	wsSession := &websocketSession{
 		conn:   client,
+		ctx:    ctx,
+		cancel: cancel,
 		userID: "",
 		mu:     sync.Mutex{},
 		teams:  []string{},
@@ -110,6 +192,8 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	if ws.isMattermostAuth {
 		wsSession.userID = r.Header.Get("Mattermost-User-Id")
+	} else {
+		wsSession.userID = ws.authenticateRequest(r)
 	}
 
 	ws.addListener(wsSession)
@@ -120,7 +204,9 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 		// Remove session from listeners
 		ws.removeListener(wsSession)
-		wsSession.conn.Close()
+		wsSession.close()
+
+		ws.notifyUserDisconnect(wsSession.userID)
 	}()
 
 	// Simple message handling loop
@@ -131,6 +217,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 				mlog.Err(err),
 			)
+			ws.rememberSubscriptions(wsSession)
 			ws.removeListener(wsSession)
 			break
 		}
@@ -148,6 +235,9 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		if command.Action == websocketActionAuth {
 			ws.logger.Debug(`Command: AUTH`, mlog.Stringer("client", wsSession.conn.RemoteAddr()))
 			ws.authenticateListener(wsSession, command.Token)
+			if command.ClientID != "" {
+				ws.restoreSubscriptions(wsSession, command.ClientID)
+			}
 
 			continue
 		}
@@ -171,7 +261,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 
-			ws.subscribeListenerToBlocks(wsSession, command.BlockIDs)
+			ws.subscribeListenerToBlocks(wsSession, blockReadToken{teamID: command.TeamID, readToken: command.ReadToken}, command.BlockIDs)
 			continue
 		}
 
@@ -213,21 +303,8 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 			)
 
-			// if single user mode, check that the userID is valid and
-			// assume that the user has permission if so
-			if len(ws.singleUserToken) != 0 {
-				if wsSession.userID != model.SingleUser {
-					continue
-				}
-
-				// if not in single user mode validate that the session
-				// has permissions to the team
-			} else {
-				ws.logger.Debug("Not single user mode")
-				if !ws.auth.DoesUserHaveTeamAccess(wsSession.userID, command.TeamID) {
-					ws.logger.Error("WS user doesn't have team access", mlog.String("teamID", command.TeamID), mlog.String("userID", wsSession.userID))
-					continue
-				}
+			if !ws.canSubscribeToTeam(wsSession, command.TeamID) {
+				continue
 			}
 
 			ws.subscribeListenerToTeam(wsSession, command.TeamID)
@@ -244,6 +321,81 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// canSubscribeToTeam checks that the user of a session can subscribe to
+// a team changes.
+func (ws *Server) canSubscribeToTeam(wsSession *websocketSession, teamID string) bool {
+	// if single user mode, check that the userID is valid and
+	// assume that the user has permission if so
+	if len(ws.singleUserToken) != 0 {
+		return wsSession.userID == model.SingleUser
+	}
+
+	// if not in single user mode validate that the session
+	// has permissions to the team
+	ws.logger.Debug("Not single user mode")
+	if !ws.auth.DoesUserHaveTeamAccess(wsSession.userID, teamID) {
+		ws.logger.Error("WS user doesn't have team access", mlog.String("teamID", teamID), mlog.String("userID", wsSession.userID))
+		return false
+	}
+	return true
+}
+
+// rememberSubscriptions keeps the subscriptions of a closing session
+// if its client authenticated with a client ID.
+func (ws *Server) rememberSubscriptions(wsSession *websocketSession) {
+	if wsSession.clientID == "" || ws.ctx.Err() != nil {
+		return
+	}
+
+	ws.mu.RLock()
+	teams := append([]string{}, wsSession.teams...)
+	blocks := make(map[string]blockReadToken, len(wsSession.blocks))
+	for _, blockID := range wsSession.blocks {
+		blocks[blockID] = wsSession.blockTokens[blockID]
+	}
+	ws.mu.RUnlock()
+
+	ws.subscriptions.remember(wsSession.clientID, wsSession.userID, teams, blocks)
+}
+
+// restoreSubscriptions subscribes an authenticated session to the teams
+// and blocks its client was subscribed to before reconnecting. The team
+// access and the read tokens of the blocks are checked again like for a
+// new subscription, as they may have changed in the meantime.
+func (ws *Server) restoreSubscriptions(wsSession *websocketSession, clientID string) {
+	if !wsSession.isAuthenticated() || wsSession.clientID != "" {
+		return
+	}
+	wsSession.clientID = clientID
+
+	teams, blocks, ok := ws.subscriptions.take(clientID, wsSession.userID)
+	if !ok {
+		return
+	}
+
+	for _, teamID := range teams {
+		if ws.canSubscribeToTeam(wsSession, teamID) {
+			ws.subscribeListenerToTeam(wsSession, teamID)
+		}
+	}
+	blocksByToken := map[blockReadToken][]string{}
+	for blockID, token := range blocks {
+		blocksByToken[token] = append(blocksByToken[token], blockID)
+	}
+	for token, blockIDs := range blocksByToken {
+		command := WebsocketCommand{TeamID: token.teamID, ReadToken: token.readToken, BlockIDs: blockIDs}
+		if ws.isCommandReadTokenValid(command) {
+			ws.subscribeListenerToBlocks(wsSession, token, blockIDs)
+		}
+	}
+
+	ws.logger.Debug("Restored the subscriptions of a reconnected client",
+		mlog.String("userID", wsSession.userID),
+		mlog.Int("team_count", len(teams)),
+		mlog.Int("block_count", len(blocks)),
+	)
+}
+
 // isCommandReadTokenValid ensures that a command contains a read
 // token and a set of block ids that said token is valid for.
 func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
@@ -282,6 +434,48 @@ func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
 	return isValid
 }
 
+// SetUserDisconnectHandler sets the function called with the user ID
+// when a connection of an authenticated user closes.
+func (ws *Server) SetUserDisconnectHandler(handler func(userID string)) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.disconnectHandler = handler
+}
+
+// SetRequestAuthenticator sets the function that authenticates the
+// connections from their upgrade request. The connections it doesn't
+// authenticate still need to send an AUTH command with a session token.
+func (ws *Server) SetRequestAuthenticator(authenticator func(r *http.Request) string) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.requestAuthenticator = authenticator
+}
+
+func (ws *Server) authenticateRequest(r *http.Request) string {
+	ws.mu.RLock()
+	authenticator := ws.requestAuthenticator
+	ws.mu.RUnlock()
+
+	if authenticator == nil {
+		return ""
+	}
+	return authenticator(r)
+}
+
+func (ws *Server) notifyUserDisconnect(userID string) {
+	if userID == "" {
+		return
+	}
+
+	ws.mu.RLock()
+	handler := ws.disconnectHandler
+	ws.mu.RUnlock()
+
+	if handler != nil {
+		handler(userID)
+	}
+}
+
 // addListener adds a listener to the websocket server. The listener
 // should not receive any update from the server until it subscribes
 // itself to some entity changes. Adding a listener to the server
@@ -342,12 +536,18 @@ func (ws *Server) unsubscribeListenerFromTeam(listener *websocketSession, teamID
 }
 
 // subscribeListenerToBlocks safely modifies the listener and the
-// server to subscribe the listener to a given set of block updates.
-func (ws *Server) subscribeListenerToBlocks(listener *websocketSession, blockIDs []string) {
+// server to subscribe the listener to a given set of block updates,
+// remembering the read token the subscription was made with.
+func (ws *Server) subscribeListenerToBlocks(listener *websocketSession, token blockReadToken, blockIDs []string) {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
+	if listener.blockTokens == nil {
+		listener.blockTokens = map[string]blockReadToken{}
+	}
+
 	for _, blockID := range blockIDs {
+		listener.blockTokens[blockID] = token
 		if listener.isSubscribedToBlock(blockID) {
 			continue
 		}
@@ -413,6 +613,7 @@ func (ws *Server) removeListenerFromBlock(listener *websocketSession, blockID st
 		}
 	}
 	listener.blocks = newListenerBlocks
+	delete(listener.blockTokens, blockID)
 }
 
 func (ws *Server) getUserIDForToken(token string) string {
@@ -450,7 +651,7 @@ func (ws *Server) authenticateListener(wsSession *websocketSession, token string
 	// Authenticate session
 	userID := ws.getUserIDForToken(token)
 	if userID == "" {
-		wsSession.conn.Close()
+		wsSession.close()
 		return
 	}
 
@@ -471,38 +672,63 @@ func (ws *Server) getListenersForTeam(teamID string) []*websocketSession {
 	return ws.listenersByTeam[teamID]
 }
 
+// getListenersForTeamAndUser returns the listeners of a user subscribed
+// to a team changes.
+func (ws *Server) getListenersForTeamAndUser(teamID, userID string) []*websocketSession {
+	listeners := []*websocketSession{}
+	for _, listener := range ws.listenersByTeam[teamID] {
+		if listener.userID == userID {
+			listeners = append(listeners, listener)
+		}
+	}
+	return listeners
+}
+
+// getBoardViewers returns the IDs of the users allowed to view a
+// board, reading its members only if they aren't cached.
+func (ws *Server) getBoardViewers(boardID string) (map[string]bool, error) {
+	if viewers, ok := ws.boardACL.get(boardID); ok {
+		return viewers, nil
+	}
+
+	members, err := ws.store.GetMembersForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	viewers := map[string]bool{}
+	for _, member := range members {
+		if canViewBoard(member) {
+			viewers[member.UserID] = true
+		}
+	}
+	ws.boardACL.set(boardID, viewers)
+	return viewers, nil
+}
+
 // getListenersForTeamAndBoard returns the listeners subscribed to a
-// team changes and members of a given board.
+// team changes that are allowed to view a given board.
 func (ws *Server) getListenersForTeamAndBoard(teamID, boardID string, ensureUsers ...string) []*websocketSession {
-	members, err := ws.store.GetMembersForBoard(boardID)
+	viewers, err := ws.getBoardViewers(boardID)
 	if err != nil {
 		ws.logger.Error("error getting members for board",
 			mlog.String("method", "getListenersForTeamAndBoard"),
 			mlog.String("teamID", teamID),
 			mlog.String("boardID", boardID),
+			mlog.Err(err),
 		)
 		return nil
 	}
 
-	memberMap := map[string]bool{}
-	for _, member := range members {
-		memberMap[member.UserID] = true
-	}
+	ensured := map[string]bool{}
 	for _, id := range ensureUsers {
-		memberMap[id] = true
-	}
-
-	memberIDs := []string{}
-	for id := range memberMap {
-		memberIDs = append(memberIDs, id)
+		ensured[id] = true
 	}
 
 	listeners := []*websocketSession{}
-	for _, memberID := range memberIDs {
-		for _, listener := range ws.listenersByTeam[teamID] {
-			if listener.userID == memberID {
-				listeners = append(listeners, listener)
-			}
+	for _, listener := range ws.listenersByTeam[teamID] {
+		if listener.isAuthenticated() && (viewers[listener.userID] || ensured[listener.userID]) {
+			listeners = append(listeners, listener)
 		}
 	}
 	return listeners
@@ -520,6 +746,101 @@ func (ws *Server) BroadcastBlockDelete(teamID, blockID, boardID string) {
 	ws.BroadcastBlockChange(teamID, block)
 }
 
+// BroadcastBlocksDelete broadcasts a single message for several deleted
+// blocks of a board.
+func (ws *Server) BroadcastBlocksDelete(teamID, boardID string, blockIDs []string) {
+	message := UpdateBlocksMsg{
+		Action:  websocketActionUpdateBlocks,
+		TeamID:  teamID,
+		BoardID: boardID,
+		Blocks:  deletedBlocks(boardID, blockIDs),
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	for _, blockID := range blockIDs {
+		listeners = append(listeners, ws.getListenersForBlock(blockID)...)
+	}
+
+	notified := map[*websocketSession]bool{}
+	for _, listener := range listeners {
+		if notified[listener] {
+			continue
+		}
+		notified[listener] = true
+
+		ws.logger.Debug("Broadcast blocks delete",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.Int("block_count", len(blockIDs)),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		if !ws.sendMessage(listener, message) {
+			return
+		}
+	}
+}
+
+// BroadcastBlocksChange broadcasts a single message for several
+// changed blocks of a board.
+func (ws *Server) BroadcastBlocksChange(teamID, boardID string, blocks []model.Block) {
+	message := UpdateBlocksMsg{
+		Action:  websocketActionUpdateBlocks,
+		TeamID:  teamID,
+		BoardID: boardID,
+		Blocks:  blocks,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast blocks change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.Int("block_count", len(blocks)),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		if !ws.sendMessage(listener, message) {
+			return
+		}
+	}
+
+	// the block listeners subscribed through the shared link of the
+	// board, so they only receive the card properties visible through it
+	blockListeners := []*websocketSession{}
+	notified := map[*websocketSession]bool{}
+	for _, block := range blocks {
+		for _, blockID := range []string{block.ID, block.ParentID} {
+			for _, listener := range ws.getListenersForBlock(blockID) {
+				if !notified[listener] {
+					notified[listener] = true
+					blockListeners = append(blockListeners, listener)
+				}
+			}
+		}
+	}
+
+	if len(blockListeners) == 0 || ws.ctx.Err() != nil {
+		return
+	}
+
+	sharing, err := ws.store.GetSharing(boardID)
+	if err != nil && !model.IsErrNotFound(err) {
+		ws.logger.Error("broadcast error, cannot get the board sharing",
+			mlog.String("boardID", boardID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	message.Blocks = sharing.FilterBlocks(blocks)
+	for _, listener := range blockListeners {
+		if !ws.sendMessage(listener, message) {
+			return
+		}
+	}
+}
+
 // BroadcastBlockChange broadcasts update messages to clients.
 func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
 	blockIDsToNotify := []string{block.ID, block.ParentID}
@@ -537,25 +858,46 @@ func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
 		mlog.String("boardID", block.BoardID),
 	)
 
+	ws.sendBlockMessage(listeners, message)
+
+	// the block listeners subscribed through the shared link of the
+	// board, so they only receive the card properties visible through it
+	blockListeners := []*websocketSession{}
 	for _, blockID := range blockIDsToNotify {
-		listeners = append(listeners, ws.getListenersForBlock(blockID)...)
+		blockListeners = append(blockListeners, ws.getListenersForBlock(blockID)...)
 		ws.logger.Trace("listener(s) for blockID",
-			mlog.Int("listener_count", len(listeners)),
+			mlog.Int("listener_count", len(blockListeners)),
 			mlog.String("blockID", blockID),
 		)
 	}
 
+	if len(blockListeners) == 0 || ws.ctx.Err() != nil {
+		return
+	}
+
+	sharing, err := ws.store.GetSharing(block.BoardID)
+	if err != nil && !model.IsErrNotFound(err) {
+		ws.logger.Error("broadcast error, cannot get the board sharing",
+			mlog.String("boardID", block.BoardID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	message.Block = sharing.FilterBlock(block)
+	ws.sendBlockMessage(blockListeners, message)
+}
+
+func (ws *Server) sendBlockMessage(listeners []*websocketSession, message UpdateBlockMsg) {
 	for _, listener := range listeners {
 		ws.logger.Debug("Broadcast block change",
-			mlog.String("teamID", teamID),
-			mlog.String("blockID", block.ID),
+			mlog.String("teamID", message.TeamID),
+			mlog.String("blockID", message.Block.ID),
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
-		err := listener.WriteJSON(message)
-		if err != nil {
-			ws.logger.Error("broadcast error", mlog.Err(err))
-			listener.conn.Close()
+		if !ws.sendMessage(listener, message) {
+			return
 		}
 	}
 }
@@ -567,7 +909,7 @@ func (ws *Server) BroadcastCategoryChange(category model.Category) {
 		Category: &category,
 	}
 
-	listeners := ws.getListenersForTeam(category.TeamID)
+	listeners := ws.getListenersForTeamAndUser(category.TeamID, category.UserID)
 	ws.logger.Debug("listener(s) for teamID",
 		mlog.Int("listener_count", len(listeners)),
 		mlog.String("teamID", category.TeamID),
@@ -582,9 +924,8 @@ func (ws *Server) BroadcastCategoryChange(category model.Category) {
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
-		if err := listener.WriteJSON(message); err != nil {
-			ws.logger.Error("broadcast category change error", mlog.Err(err))
-			listener.conn.Close()
+		if !ws.sendMessage(listener, message) {
+			return
 		}
 	}
 }
@@ -596,7 +937,7 @@ func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCateg
 		BoardCategories: &boardCategory,
 	}
 
-	listeners := ws.getListenersForTeam(teamID)
+	listeners := ws.getListenersForTeamAndUser(teamID, userID)
 	ws.logger.Debug("listener(s) for teamID",
 		mlog.Int("listener_count", len(listeners)),
 		mlog.String("teamID", teamID),
@@ -613,9 +954,8 @@ func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCateg
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
-		if err := listener.WriteJSON(message); err != nil {
-			ws.logger.Error("broadcast category change error", mlog.Err(err))
-			listener.conn.Close()
+		if !ws.sendMessage(listener, message) {
+			return
 		}
 	}
 }
@@ -636,15 +976,16 @@ func (ws *Server) BroadcastConfigChange(clientConfig model.ClientConfig) {
 		ws.logger.Debug("Broadcast Config change",
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
-		err := listener.WriteJSON(message)
-		if err != nil {
-			ws.logger.Error("broadcast error", mlog.Err(err))
-			listener.conn.Close()
+		if !ws.sendMessage(listener, message) {
+			return
 		}
 	}
 }
 
 func (ws *Server) BroadcastBoardChange(teamID string, board *model.Board) {
+	// the minimum role of the board may have changed
+	ws.boardACL.invalidate(board.ID)
+
 	message := UpdateBoardMsg{
 		Action: websocketActionUpdateBoard,
 		TeamID: teamID,
@@ -665,10 +1006,8 @@ func (ws *Server) BroadcastBoardChange(teamID string, board *model.Board) {
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
-		err := listener.WriteJSON(message)
-		if err != nil {
-			ws.logger.Error("broadcast error", mlog.Err(err))
-			listener.conn.Close()
+		if !ws.sendMessage(listener, message) {
+			return
 		}
 	}
 }
@@ -685,6 +1024,8 @@ func (ws *Server) BroadcastBoardDelete(teamID, boardID string) {
 }
 
 func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
+	ws.boardACL.invalidate(boardID)
+
 	message := UpdateMemberMsg{
 		Action: websocketActionUpdateMember,
 		TeamID: teamID,
@@ -705,15 +1046,15 @@ func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.Bo
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
-		err := listener.WriteJSON(message)
-		if err != nil {
-			ws.logger.Error("broadcast error", mlog.Err(err))
-			listener.conn.Close()
+		if !ws.sendMessage(listener, message) {
+			return
 		}
 	}
 }
 
 func (ws *Server) BroadcastMemberDelete(teamID, boardID, userID string) {
+	ws.boardACL.invalidate(boardID)
+
 	message := UpdateMemberMsg{
 		Action: websocketActionDeleteMember,
 		TeamID: teamID,
@@ -737,14 +1078,122 @@ func (ws *Server) BroadcastMemberDelete(teamID, boardID, userID string) {
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
-		err := listener.WriteJSON(message)
-		if err != nil {
-			ws.logger.Error("broadcast error", mlog.Err(err))
-			listener.conn.Close()
+		if !ws.sendMessage(listener, message) {
+			return
+		}
+	}
+}
+
+// BroadcastUserBoardViewChange sends the view state of a board only to
+// the connections of the user it belongs to.
+func (ws *Server) BroadcastUserBoardViewChange(teamID string, view *model.UserBoardView) {
+	message := UpdateUserBoardViewMsg{
+		Action:        websocketActionUpdateUserBoardView,
+		TeamID:        teamID,
+		UserBoardView: view,
+	}
+
+	for _, listener := range ws.getListenersForTeam(teamID) {
+		if listener.userID != view.UserID {
+			continue
+		}
+
+		ws.logger.Debug("Broadcast user board view change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", view.BoardID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		if !ws.sendMessage(listener, message) {
+			return
 		}
 	}
 }
 
+// BroadcastBoardFavoriteChange sends the favorite state of a board only
+// to the connections of the user it belongs to.
+func (ws *Server) BroadcastBoardFavoriteChange(teamID, userID, boardID string, isFavorite bool) {
+	message := UpdateBoardFavoriteMsg{
+		Action:     websocketActionUpdateBoardFavorite,
+		TeamID:     teamID,
+		BoardID:    boardID,
+		IsFavorite: isFavorite,
+	}
+
+	for _, listener := range ws.getListenersForTeam(teamID) {
+		if listener.userID != userID {
+			continue
+		}
+
+		ws.logger.Debug("Broadcast board favorite change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		if !ws.sendMessage(listener, message) {
+			return
+		}
+	}
+}
+
+// BroadcastAdminNotice sends an admin notice to the listeners subscribed
+// to its team, or to all the listeners if it has no team.
+func (ws *Server) BroadcastAdminNotice(notice *model.AdminNotice) {
+	message := AdminNoticeMsg{
+		Action:   websocketActionAdminNotice,
+		TeamID:   notice.TeamID,
+		Message:  notice.Message,
+		Severity: notice.Severity,
+	}
+
+	ws.mu.RLock()
+	var listeners []*websocketSession
+	if notice.TeamID != "" {
+		listeners = append(listeners, ws.getListenersForTeam(notice.TeamID)...)
+	} else {
+		for listener := range ws.listeners {
+			listeners = append(listeners, listener)
+		}
+	}
+	ws.mu.RUnlock()
+
+	ws.logger.Debug("broadcasting admin notice to listener(s)",
+		mlog.String("teamID", notice.TeamID),
+		mlog.String("severity", notice.Severity),
+		mlog.Int("listener_count", len(listeners)),
+	)
+
+	for _, listener := range listeners {
+		if !ws.sendMessage(listener, message) {
+			return
+		}
+	}
+}
+
+// sendMessage sends the message to the listener, skipping it if its
+// connection is already gone. With broadcast workers the message is
+// queued to the listener. It returns false if the server is shutting down, in
+// which case the caller should stop the broadcast.
+func (ws *Server) sendMessage(listener *websocketSession, message interface{}) bool {
+	if ws.ctx.Err() != nil {
+		ws.logger.Debug("Broadcast canceled, the server is shutting down")
+		return false
+	}
+
+	if listener.isClosed() {
+		return true
+	}
+
+	if ws.pool != nil {
+		ws.queueMessage(listener, message)
+		return true
+	}
+
+	ws.writeMessage(listener, message)
+	return true
+}
+
 func (ws *Server) BroadcastSubscriptionChange(workspaceID string, subscription *model.Subscription) {
 	// not implemented for standalone server.
 }
@@ -1,20 +1,26 @@
 package ws
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
+	wsMocks "github.com/mattermost/focalboard/server/ws/mocks"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 
+	"github.com/golang/mock/gomock"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTeamSubscription(t *testing.T) {
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil, 0, 0)
 	session := &websocketSession{
 		conn:   &websocket.Conn{},
 		mu:     sync.Mutex{},
@@ -104,7 +110,7 @@ func TestTeamSubscription(t *testing.T) {
 }
 
 func TestBlocksSubscription(t *testing.T) {
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil, 0, 0)
 	session := &websocketSession{
 		conn:   &websocket.Conn{},
 		mu:     sync.Mutex{},
@@ -128,7 +134,7 @@ func TestBlocksSubscription(t *testing.T) {
 		require.False(t, session.isSubscribedToBlock(blockID2))
 		require.False(t, session.isSubscribedToBlock(blockID3))
 
-		server.subscribeListenerToBlocks(session, blockIDs)
+		server.subscribeListenerToBlocks(session, blockReadToken{}, blockIDs)
 
 		require.Len(t, server.listenersByBlock[blockID1], 1)
 		require.Contains(t, server.listenersByBlock[blockID1], session)
@@ -148,7 +154,7 @@ func TestBlocksSubscription(t *testing.T) {
 			require.True(t, session.isSubscribedToBlock(blockID2))
 			require.True(t, session.isSubscribedToBlock(blockID3))
 
-			server.subscribeListenerToBlocks(session, blockIDs)
+			server.subscribeListenerToBlocks(session, blockReadToken{}, blockIDs)
 
 			require.Len(t, server.listenersByBlock[blockID1], 1)
 			require.Contains(t, server.listenersByBlock[blockID1], session)
@@ -201,7 +207,7 @@ func TestBlocksSubscription(t *testing.T) {
 
 	t.Run("If subscribed to blocks and removed, should be removed from the blocks subscription list", func(t *testing.T) {
 		server.addListener(session)
-		server.subscribeListenerToBlocks(session, blockIDs)
+		server.subscribeListenerToBlocks(session, blockReadToken{}, blockIDs)
 
 		require.Len(t, server.listeners, 1)
 		require.Len(t, server.listenersByBlock[blockID1], 1)
@@ -222,9 +228,91 @@ func TestBlocksSubscription(t *testing.T) {
 	})
 }
 
+func TestRestoreSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	wsStore := wsMocks.NewMockStore(ctrl)
+	authStore := mockstore.NewMockStore(ctrl)
+
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	server := NewServer(auth.New(&config.Configuration{}, authStore, nil), "token", false, logger, wsStore, 0, time.Minute)
+	newSession := func() *websocketSession {
+		ctx, cancel := context.WithCancel(server.ctx)
+		return &websocketSession{
+			conn:   &websocket.Conn{},
+			ctx:    ctx,
+			cancel: cancel,
+			userID: model.SingleUser,
+			mu:     sync.Mutex{},
+			teams:  []string{},
+			blocks: []string{},
+		}
+	}
+	teamID := "fake-team-id"
+	boardID := "fake-board-id"
+	blockID := "fake-block-id"
+	token := blockReadToken{teamID: teamID, readToken: "read-token"}
+
+	subscribeAndDisconnect := func(clientID string) {
+		session := newSession()
+		server.addListener(session)
+		server.restoreSubscriptions(session, clientID)
+		server.subscribeListenerToTeam(session, teamID)
+		server.subscribeListenerToBlocks(session, token, []string{blockID})
+
+		server.rememberSubscriptions(session)
+		server.removeListener(session)
+		require.Empty(t, server.listenersByTeam[teamID])
+		require.Empty(t, server.listenersByBlock[blockID])
+	}
+
+	t.Run("Should restore the subscriptions of a reconnected client", func(t *testing.T) {
+		subscribeAndDisconnect("client-id")
+		wsStore.EXPECT().GetBlock(blockID).Return(&model.Block{ID: blockID, BoardID: boardID}, nil)
+		authStore.EXPECT().GetSharing(boardID).Return(&model.Sharing{ID: boardID, Enabled: true, Token: "read-token"}, nil)
+
+		reconnected := newSession()
+		server.addListener(reconnected)
+		server.restoreSubscriptions(reconnected, "client-id")
+
+		require.True(t, reconnected.isSubscribedToTeam(teamID))
+		require.True(t, reconnected.isSubscribedToBlock(blockID))
+		require.Contains(t, server.listenersByTeam[teamID], reconnected)
+		require.Contains(t, server.listenersByBlock[blockID], reconnected)
+		server.removeListener(reconnected)
+	})
+
+	t.Run("Should not restore the block subscriptions whose read token is no longer valid", func(t *testing.T) {
+		subscribeAndDisconnect("client-id")
+		wsStore.EXPECT().GetBlock(blockID).Return(&model.Block{ID: blockID, BoardID: boardID}, nil)
+		authStore.EXPECT().GetSharing(boardID).Return(&model.Sharing{ID: boardID, Enabled: false, Token: "read-token"}, nil)
+
+		reconnected := newSession()
+		server.addListener(reconnected)
+		server.restoreSubscriptions(reconnected, "client-id")
+
+		require.True(t, reconnected.isSubscribedToTeam(teamID))
+		require.False(t, reconnected.isSubscribedToBlock(blockID))
+		require.Empty(t, server.listenersByBlock[blockID])
+		server.removeListener(reconnected)
+	})
+
+	t.Run("Should not restore the subscriptions of another client", func(t *testing.T) {
+		subscribeAndDisconnect("client-id")
+
+		reconnected := newSession()
+		server.addListener(reconnected)
+		server.restoreSubscriptions(reconnected, "other-client-id")
+
+		require.False(t, reconnected.isSubscribedToTeam(teamID))
+		require.False(t, reconnected.isSubscribedToBlock(blockID))
+		server.removeListener(reconnected)
+	})
+}
+
 func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
 	singleUserToken := "single-user-token"
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
+	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil, 0, 0)
 	server.singleUserToken = singleUserToken
 
 	t.Run("Should return nothing if the token is empty", func(t *testing.T) {
@@ -239,3 +327,38 @@ func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
 		require.Equal(t, model.SingleUser, server.getUserIDForToken(singleUserToken))
 	})
 }
+
+func TestSendMessageCancellation(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	server := NewServer(&auth.Auth{}, "token", false, logger, nil, 0, 0)
+
+	newSession := func() *websocketSession {
+		ctx, cancel := context.WithCancel(server.ctx)
+		return &websocketSession{
+			conn:   &websocket.Conn{},
+			ctx:    ctx,
+			cancel: cancel,
+			mu:     sync.Mutex{},
+			teams:  []string{},
+			blocks: []string{},
+		}
+	}
+
+	t.Run("Should skip a session whose connection is gone", func(t *testing.T) {
+		session := newSession()
+		session.cancel()
+
+		require.True(t, session.isClosed())
+		require.True(t, server.sendMessage(session, "message"))
+	})
+
+	t.Run("Should stop the broadcast when the server shuts down", func(t *testing.T) {
+		session := newSession()
+		require.False(t, session.isClosed())
+
+		server.Shutdown()
+
+		require.True(t, session.isClosed())
+		require.False(t, server.sendMessage(session, "message"))
+	})
+}
@@ -0,0 +1,84 @@
+package ws
+
+import (
+	"sync"
+	"time"
+)
+
+// blockReadToken is the read token a block subscription was made with,
+// along with the team it was sent for.
+type blockReadToken struct {
+	teamID    string
+	readToken string
+}
+
+type subscriptionEntry struct {
+	userID   string
+	teams    []string
+	blocks   map[string]blockReadToken
+	expireAt time.Time
+}
+
+// subscriptionCache keeps the subscriptions of the closed connections by
+// the client ID they authenticated with, so a client reconnecting within
+// the TTL gets them back without subscribing again. The entries expire
+// after the TTL and are removed once taken.
+type subscriptionCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]subscriptionEntry
+	now     func() time.Time
+}
+
+func newSubscriptionCache(ttl time.Duration) *subscriptionCache {
+	return &subscriptionCache{
+		ttl:     ttl,
+		entries: map[string]subscriptionEntry{},
+		now:     time.Now,
+	}
+}
+
+// remember keeps the subscriptions of a client, removing the expired
+// entries. Nothing is kept with a TTL of 0 or without subscriptions.
+func (c *subscriptionCache) remember(clientID, userID string, teams []string, blocks map[string]blockReadToken) {
+	if c.ttl <= 0 || (len(teams) == 0 && len(blocks) == 0) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for id, entry := range c.entries {
+		if !now.Before(entry.expireAt) {
+			delete(c.entries, id)
+		}
+	}
+	c.entries[clientID] = subscriptionEntry{
+		userID:   userID,
+		teams:    teams,
+		blocks:   blocks,
+		expireAt: now.Add(c.ttl),
+	}
+}
+
+// take returns and removes the subscriptions of a client, or false if
+// there are none, they expired or they belong to another user.
+func (c *subscriptionCache) take(clientID, userID string) ([]string, map[string]blockReadToken, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[clientID]
+	if !ok {
+		return nil, nil, false
+	}
+	if entry.userID != userID {
+		return nil, nil, false
+	}
+
+	delete(c.entries, clientID)
+	if !c.now().Before(entry.expireAt) {
+		return nil, nil, false
+	}
+	return entry.teams, entry.blocks, true
+}
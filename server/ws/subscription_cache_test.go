@@ -0,0 +1,60 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionCache(t *testing.T) {
+	now := time.Now()
+	cache := newSubscriptionCache(time.Second)
+	cache.now = func() time.Time { return now }
+
+	t.Run("remembered subscriptions are taken once", func(t *testing.T) {
+		blocks := map[string]blockReadToken{"block-id": {teamID: "team-id", readToken: "read-token"}}
+		cache.remember("client-id", "user-id", []string{"team-id"}, blocks)
+
+		teams, takenBlocks, ok := cache.take("client-id", "user-id")
+		require.True(t, ok)
+		require.Equal(t, []string{"team-id"}, teams)
+		require.Equal(t, blocks, takenBlocks)
+
+		_, _, ok = cache.take("client-id", "user-id")
+		require.False(t, ok)
+	})
+
+	t.Run("subscriptions of another user are not taken", func(t *testing.T) {
+		cache.remember("client-id", "user-id", []string{"team-id"}, nil)
+
+		_, _, ok := cache.take("client-id", "other-user-id")
+		require.False(t, ok)
+
+		_, _, ok = cache.take("client-id", "user-id")
+		require.True(t, ok)
+	})
+
+	t.Run("expired entries are ignored and removed", func(t *testing.T) {
+		cache.remember("client-id", "user-id", []string{"team-id"}, nil)
+		now = now.Add(time.Second)
+
+		_, _, ok := cache.take("client-id", "user-id")
+		require.False(t, ok)
+
+		cache.remember("expired-client-id", "user-id", []string{"team-id"}, nil)
+		now = now.Add(time.Second)
+		cache.remember("other-client-id", "user-id", []string{"team-id"}, nil)
+		require.Len(t, cache.entries, 1)
+	})
+
+	t.Run("nothing is remembered without subscriptions or TTL", func(t *testing.T) {
+		cache.remember("empty-client-id", "user-id", nil, nil)
+		_, _, ok := cache.take("empty-client-id", "user-id")
+		require.False(t, ok)
+
+		disabled := newSubscriptionCache(0)
+		disabled.remember("client-id", "user-id", []string{"team-id"}, nil)
+		require.Empty(t, disabled.entries)
+	})
+}
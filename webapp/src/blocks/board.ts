@@ -36,6 +36,7 @@ type Board = {
     createAt: number
     updateAt: number
     deleteAt: number
+    isFavorite?: boolean
 }
 
 type BoardPatch = {
@@ -85,12 +86,20 @@ interface IPropertyOption {
     color: string
 }
 
+// Shows a property only when another property of the card has one of
+// the values, which are option IDs for the properties with options
+interface IPropertyVisibilityRule {
+    dependsOn: string
+    values: string[]
+}
+
 // A template for card properties attached to a board
 interface IPropertyTemplate {
     id: string
     name: string
     type: PropertyTypeEnum
     options: IPropertyOption[]
+    visibilityRule?: IPropertyVisibilityRule
 }
 
 function createBoard(board?: Board): Board {
@@ -115,6 +124,7 @@ function createBoard(board?: Board): Board {
                 name: o.name,
                 type: o.type,
                 options: o.options ? o.options.map((option) => ({...option})) : [],
+                ...(o.visibilityRule ? {visibilityRule: {dependsOn: o.visibilityRule.dependsOn, values: [...o.visibilityRule.values]}} : {}),
             }
         })
     }
@@ -316,6 +326,7 @@ export {
     PropertyTypeEnum,
     IPropertyOption,
     IPropertyTemplate,
+    IPropertyVisibilityRule,
     BoardGroup,
     createBoard,
     BoardTypes,
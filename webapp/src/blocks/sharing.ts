@@ -7,6 +7,7 @@ interface ISharing {
     token: string
     modifiedBy?: string
     updateAt?: number
+    visibleProperties?: string[] | null
 }
 
 export {ISharing}
@@ -371,6 +371,10 @@ class OctoClient {
         })
     }
 
+    async getDeletedBlocks(boardId: string): Promise<Block[]> {
+        return this.getBlocksWithPath(`/api/v2/boards/${encodeURIComponent(boardId)}/trash`)
+    }
+
     async undeleteBoard(boardId: string): Promise<Response> {
         Utils.log(`undeleteBoard: ${boardId}`)
         return fetch(`${this.getBaseURL()}/api/v2/boards/${boardId}/undelete`, {
@@ -720,6 +724,17 @@ class OctoClient {
         return this.getJson<Block[]>(response, [] as Block[])
     }
 
+    async getRecentBoards(): Promise<Board[]> {
+        return this.getBoardsWithPath('/api/v2/users/me/recent-boards')
+    }
+
+    async addRecentBoard(boardId: string): Promise<Response> {
+        return fetch(`${this.getBaseURL()}/api/v2/users/me/recent-boards/${encodeURIComponent(boardId)}`, {
+            method: 'POST',
+            headers: this.headers(),
+        })
+    }
+
     async getBlocksForBoard(teamId: string, boardId: string): Promise<Board[]> {
         const path = this.teamPath(teamId) + `/boards/${boardId}`
         return this.getBoardsWithPath(path)
@@ -185,6 +185,10 @@ const BoardPage = (props: Props): JSX.Element => {
             teamId: boardTeamId,
             boardId,
         }))
+
+        if (userId) {
+            octoClient.addRecentBoard(boardId)
+        }
     }, [])
 
     useEffect(() => {
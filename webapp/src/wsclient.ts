@@ -3,7 +3,7 @@
 
 import {ClientConfig} from './config/clientConfig'
 
-import {Utils, WSMessagePayloads} from './utils'
+import {IDType, Utils, WSMessagePayloads} from './utils'
 import {Block} from './blocks/block'
 import {Board, BoardMember} from './blocks/board'
 import {OctoUtils} from './octoUtils'
@@ -21,6 +21,7 @@ type WSCommand = {
 export type WSMessage = {
     action?: string
     block?: Block
+    blocks?: Block[]
     board?: Board
     category?: Category
     blockCategories?: BoardCategoryWebsocketData
@@ -34,6 +35,7 @@ export const ACTION_UPDATE_BOARD = 'UPDATE_BOARD'
 export const ACTION_UPDATE_MEMBER = 'UPDATE_MEMBER'
 export const ACTION_DELETE_MEMBER = 'DELETE_MEMBER'
 export const ACTION_UPDATE_BLOCK = 'UPDATE_BLOCK'
+export const ACTION_UPDATE_BLOCKS = 'UPDATE_BLOCKS'
 export const ACTION_AUTH = 'AUTH'
 export const ACTION_SUBSCRIBE_BLOCKS = 'SUBSCRIBE_BLOCKS'
 export const ACTION_SUBSCRIBE_TEAM = 'SUBSCRIBE_TEAM'
@@ -130,6 +132,10 @@ class WSClient {
 
     private logged = false
 
+    // clientId identifies this client across reconnects, so the server
+    // can restore its subscriptions
+    private clientId = Utils.createGuid(IDType.None)
+
     // this need to be a function rather than a const because
     // one of the global variable (`window.baseURL`) is set at runtime
     // after the first instance of OctoClient is created.
@@ -186,7 +192,7 @@ class WSClient {
     }
 
     sendAuthenticationCommand(token: string): void {
-        const command = {action: ACTION_AUTH, token}
+        const command = {action: ACTION_AUTH, token, clientId: this.clientId}
 
         this.sendCommand(command)
     }
@@ -458,6 +464,9 @@ class WSClient {
                 case ACTION_UPDATE_BLOCK:
                     this.updateHandler(message)
                     break
+                case ACTION_UPDATE_BLOCKS:
+                    this.updateBlocksHandler(message)
+                    break
                 case ACTION_UPDATE_CATEGORY:
                     this.updateHandler(message)
                     break
@@ -493,6 +502,16 @@ class WSClient {
         }
     }
 
+    updateBlocksHandler(message: WSMessage): void {
+        if (message.teamId && message.teamId !== this.teamId) {
+            return
+        }
+
+        for (const block of message.blocks || []) {
+            this.queueUpdateNotification(Utils.fixBlock(block), 'block')
+        }
+    }
+
     setOnFollowBlock(handler: FollowChangeHandler): void {
         this.onFollowBlock = handler
     }